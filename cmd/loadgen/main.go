@@ -0,0 +1,87 @@
+// Command loadgen drives a mix of deposit, withdraw, transfer and read traffic against a running server and reports
+// throughput, latency percentiles and errors, then checks that the wallets hold what the responses say they should.
+//
+// Usage:
+//
+//	loadgen -wallets wallets.csv [-url http://localhost:8080] [-mix deposit=30,withdraw=20,transfer=40,read=10]
+//	        [-concurrency 16] [-duration 30s] [-requests 0] [-rate 0] [-hot 0] [-hot-share 0.9] [-max-amount 50] [-seed 1]
+//
+// The wallets are read from the wallets.csv that seed -out csv writes. With -hot N the first N wallets take
+// -hot-share of the traffic, so their rows are locked by many requests at once. It exits with status 1 when the
+// balance-conservation check fails.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/loadgen"
+)
+
+func main() {
+	logger := zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+	var opts loadgen.Options
+	var walletsPath, mix, maxAmount string
+	flag.StringVar(&opts.BaseURL, "url", "http://localhost:8080", "base URL of the server")
+	flag.StringVar(&walletsPath, "wallets", "", "wallets.csv written by seed -out csv")
+	flag.StringVar(&mix, "mix", "deposit=30,withdraw=20,transfer=40,read=10", "relative weight of each op")
+	flag.IntVar(&opts.Concurrency, "concurrency", 16, "requests in flight at once")
+	flag.DurationVar(&opts.Duration, "duration", 30*time.Second, "how long to send traffic")
+	flag.IntVar(&opts.Requests, "requests", 0, "stop after this many requests instead, if set")
+	flag.Float64Var(&opts.Rate, "rate", 0, "requests per second across all workers, 0 for as fast as possible")
+	flag.IntVar(&opts.HotWallets, "hot", 0, "number of hot wallets")
+	flag.Float64Var(&opts.HotShare, "hot-share", 0.9, "share of the wallet picks that go to the hot wallets")
+	flag.StringVar(&maxAmount, "max-amount", "50", "largest amount moved by a request")
+	flag.Uint64Var(&opts.Seed, "seed", 1, "seed of the generated traffic")
+	flag.Parse()
+
+	if walletsPath == "" {
+		logger.Fatal().Msg("-wallets is required")
+	}
+	f, err := os.Open(walletsPath)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to open wallets file")
+	}
+	opts.Wallets, err = loadgen.ReadWallets(f)
+	f.Close()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to read wallets")
+	}
+	if opts.Mix, err = loadgen.ParseMix(mix); err != nil {
+		logger.Fatal().Err(err).Msg("Invalid -mix")
+	}
+	if opts.MaxAmount, err = decimal.NewFromString(maxAmount); err != nil {
+		logger.Fatal().Err(err).Msg("-max-amount must be a decimal amount")
+	}
+	if opts.Requests > 0 {
+		opts.Duration = 0
+	}
+
+	// Interrupting stops the run early; the report and the conservation check still follow.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info().
+		Int("wallets", len(opts.Wallets)).
+		Int("concurrency", opts.Concurrency).
+		Int("hot", opts.HotWallets).
+		Msgf("Sending traffic to %s", opts.BaseURL)
+	report, err := loadgen.Run(ctx, opts)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Load run failed")
+	}
+	report.Write(os.Stdout)
+
+	if !report.Conservation.Holds() {
+		stop()
+		os.Exit(1)
+	}
+}
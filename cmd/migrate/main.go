@@ -0,0 +1,105 @@
+// Command migrate applies, reverts and reports the database schema migrations.
+//
+// Usage:
+//
+//	migrate up                apply every pending migration
+//	migrate down [steps]      revert the last steps applied migrations (default 1)
+//	migrate status            list every migration and when it was applied
+//	migrate baseline VERSION  record migrations up to VERSION as applied without running them
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/kylenguyen/wallet-app/internal/config"
+	"github.com/kylenguyen/wallet-app/internal/db"
+	"github.com/kylenguyen/wallet-app/migrations"
+	"github.com/kylenguyen/wallet-app/pkg/migrate"
+)
+
+const usage = "usage: migrate up | down [steps] | status | baseline VERSION"
+
+func main() {
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load config")
+	}
+	conn, err := db.Connect(cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to connect to database")
+	}
+	defer conn.Close()
+
+	all, err := migrations.Load()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to load migrations")
+	}
+	m := migrate.New(conn, all)
+	ctx := context.Background()
+
+	var done []migrate.Migration
+	switch cmd := os.Args[1]; cmd {
+	case "up":
+		done, err = m.Up(ctx)
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			if steps, err = strconv.Atoi(os.Args[2]); err != nil || steps < 1 {
+				logger.Fatal().Str("steps", os.Args[2]).Msg("steps must be a positive number")
+			}
+		}
+		done, err = m.Down(ctx, steps)
+	case "baseline":
+		if len(os.Args) < 3 {
+			fmt.Fprintln(os.Stderr, usage)
+			os.Exit(2)
+		}
+		version, perr := strconv.ParseInt(os.Args[2], 10, 64)
+		if perr != nil {
+			logger.Fatal().Str("version", os.Args[2]).Msg("VERSION must be a number")
+		}
+		done, err = m.Baseline(ctx, version)
+	case "status":
+		printStatus(ctx, m)
+		return
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	for _, mig := range done {
+		logger.Info().Int64("version", mig.Version).Str("name", mig.Name).Msgf("Migration %s done", os.Args[1])
+	}
+	if err != nil {
+		logger.Fatal().Err(err).Msgf("Migration %s failed", os.Args[1])
+	}
+	logger.Info().Int("count", len(done)).Msgf("Migration %s complete", os.Args[1])
+}
+
+func printStatus(ctx context.Context, m *migrate.Migrator) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for _, s := range statuses {
+		applied := "pending"
+		if s.AppliedAt != nil {
+			applied = s.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Printf("%03d  %-25s  %s\n", s.Version, s.Name, applied)
+	}
+}
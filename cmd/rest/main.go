@@ -1,13 +1,17 @@
 package main
 
 import (
-	"errors"
-	"net/http"
+	"context"
+	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/kylenguyen/wallet-app/internal/config"
 	"github.com/kylenguyen/wallet-app/internal/db"
 	"github.com/kylenguyen/wallet-app/internal/server"
+	"github.com/kylenguyen/wallet-app/migrations"
+	"github.com/kylenguyen/wallet-app/pkg/migrate"
 	"github.com/rs/zerolog"
 )
 
@@ -15,26 +19,82 @@ func main() {
 	// Initialize Zerolog
 	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
 
+	if err := run(&logger); err != nil {
+		logger.Fatal().Err(err).Msg("Server stopped")
+	}
+	logger.Info().Msg("Server stopped")
+}
+
+// run serves until SIGTERM or SIGINT, then shuts the server down and closes the database pool last.
+func run(logger *zerolog.Logger) error {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Connect to the database
-	db, err := db.Connect(cfg)
+	conn, err := db.Connect(cfg)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logger.Error().Err(err).Msg("Failed to close database pool")
+			return
+		}
+		logger.Info().Msg("Database pool closed")
+	}()
+
+	if cfg.AutoMigrate {
+		all, err := migrations.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load migrations: %w", err)
+		}
+		applied, err := migrate.New(conn, all).Up(context.Background())
+		for _, m := range applied {
+			logger.Info().Int64("version", m.Version).Str("name", m.Name).Msg("Applied migration")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to migrate database: %w", err)
+		}
 	}
 
 	// Initialize HTTP server
-	srv := server.New(db, &logger, cfg)
+	srv := server.New(conn, logger, cfg)
 
 	srv.UseMiddleware()
 
+	if err := srv.SyncFeeSchedules(context.Background()); err != nil {
+		return fmt.Errorf("failed to sync fee schedules: %w", err)
+	}
+
 	srv.RegisterRoutes()
 
-	if err := srv.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		logger.Panic().Err(err).Msg("Failed to start HTTP server")
+	srv.StartWorkers(context.Background())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	served := make(chan error, 1)
+	go func() { served <- srv.Start() }()
+
+	var serveErr error
+	select {
+	case serveErr = <-served:
+	case <-ctx.Done():
+		logger.Info().Dur("timeout", cfg.HTTPVar.ShutdownTimeout).Msg("Shutdown signal received")
+	}
+	// A second signal kills the process instead of waiting for the shutdown to finish.
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTPVar.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if serveErr != nil {
+		return serveErr
 	}
+	return <-served
 }
@@ -0,0 +1,93 @@
+// Command seed generates users, wallets and transaction histories for load and demo environments and writes them
+// to the database, a SQL script or CSV files. The same flags always generate the same data.
+//
+// Usage:
+//
+//	seed [-seed 1] [-users 100] [-wallets 2] [-transactions 20] [-start 2025-01-01] [-days 90] [-out db|sql|csv] [-path PATH]
+//
+// With -out db the data is inserted into the database configured in deployments/*.env. With -out sql the script
+// is written to -path, or standard output when it is empty; with -out csv the files go into the directory -path.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/kylenguyen/wallet-app/internal/config"
+	"github.com/kylenguyen/wallet-app/internal/db"
+	"github.com/kylenguyen/wallet-app/internal/seed"
+)
+
+func main() {
+	logger := zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+	var opts seed.Options
+	var start, out, path string
+	flag.Uint64Var(&opts.Seed, "seed", 1, "seed of the generated data")
+	flag.IntVar(&opts.Users, "users", 100, "number of users")
+	flag.IntVar(&opts.WalletsPerUser, "wallets", 2, "wallets per user")
+	flag.IntVar(&opts.TransactionsPerWallet, "transactions", 20, "transactions made by each wallet")
+	flag.StringVar(&start, "start", "2025-01-01", "day the first user signs up, YYYY-MM-DD")
+	flag.IntVar(&opts.Days, "days", 90, "length of the history in days")
+	flag.StringVar(&out, "out", "sql", "where to write the data: db, sql or csv")
+	flag.StringVar(&path, "path", "", "SQL file (standard output if empty) or CSV directory")
+	flag.Parse()
+
+	var err error
+	if opts.Start, err = time.Parse(time.DateOnly, start); err != nil {
+		logger.Fatal().Err(err).Msg("-start must be a date in YYYY-MM-DD format")
+	}
+
+	data, err := seed.Generate(opts)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to generate seed data")
+	}
+
+	switch out {
+	case "db":
+		cfg, err := config.Load()
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to load config")
+		}
+		conn, err := db.Connect(cfg)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to connect to database")
+		}
+		defer conn.Close()
+		err = seed.Insert(context.Background(), conn, data)
+	case "sql":
+		var w io.Writer = os.Stdout
+		if path != "" {
+			f, ferr := os.Create(path)
+			if ferr != nil {
+				logger.Fatal().Err(ferr).Msg("Failed to create SQL file")
+			}
+			defer f.Close()
+			w = f
+		}
+		err = seed.WriteSQL(w, data)
+	case "csv":
+		if path == "" {
+			logger.Fatal().Msg("-path is required with -out csv")
+		}
+		err = seed.WriteCSV(path, data)
+	default:
+		fmt.Fprintln(os.Stderr, "-out must be db, sql or csv")
+		os.Exit(2)
+	}
+	if err != nil {
+		logger.Fatal().Err(err).Msgf("Failed to write seed data to %s", out)
+	}
+
+	logger.Info().
+		Int("users", len(data.Users)).
+		Int("wallets", len(data.Wallets)).
+		Int("transactions", len(data.Transactions)).
+		Msgf("Seed data written to %s", out)
+}
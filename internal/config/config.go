@@ -3,8 +3,11 @@ package config
 import (
 	"errors"
 	"fmt"
+	"github.com/google/uuid"
+	"github.com/kylenguyen/wallet-app/internal/model"
 	"github.com/spf13/viper"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -14,8 +17,34 @@ type Config struct {
 	ServiceName string
 	Env         string
 	ServicePort int
+	// Currency is the ISO 4217 code wallet balances are held in.
+	Currency string
+	// AutoMigrate applies pending schema migrations when the server starts.
+	AutoMigrate bool
 
-	DatabaseVar DatabaseVar
+	HTTPVar       HTTPVar
+	DatabaseVar   DatabaseVar
+	SchedulerVar  SchedulerVar
+	FeeVar        FeeVar
+	InterestVar   InterestVar
+	ApprovalVar   ApprovalVar
+	EscrowVar     EscrowVar
+	AdminVar      AdminVar
+	AuditTrailVar AuditTrailVar
+}
+
+type HTTPVar struct {
+	// ReadTimeout bounds reading a whole request, body included.
+	ReadTimeout time.Duration
+	// WriteTimeout bounds handling a request and writing its response; it must outlast the slowest transfer.
+	WriteTimeout time.Duration
+	// IdleTimeout is how long a keep-alive connection waits for its next request.
+	IdleTimeout time.Duration
+	// ShutdownTimeout is how long in-flight requests and background jobs get to finish after SIGTERM or SIGINT.
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long readiness fails before the server stops accepting connections, so load balancers stop
+	// routing to it first. It counts towards ShutdownTimeout.
+	DrainDelay time.Duration
 }
 
 type DatabaseVar struct {
@@ -29,6 +58,57 @@ type DatabaseVar struct {
 	ConnMaxLifetime time.Duration
 }
 
+type FeeVar struct {
+	// WalletID is the house wallet fees are paid into.
+	WalletID string
+	// SchedulesFile optionally names a JSON file of fee schedule versions to add to the database at startup.
+	SchedulesFile string
+}
+
+type InterestVar struct {
+	// DayCount is the day-count convention daily interest is accrued with: ACT/365, ACT/360 or ACT/ACT.
+	DayCount string
+	// Interval is how often the interest job looks for days to accrue and months to pay out.
+	Interval time.Duration
+}
+
+type ApprovalVar struct {
+	// ExpiryInterval is how often pending transfer proposals past their expiry are expired and their holds released.
+	ExpiryInterval time.Duration
+}
+
+type EscrowVar struct {
+	// WalletID is the system account escrowed funds are held in.
+	WalletID string
+	// Interval is how often held escrows past their release time are released to their sellers.
+	Interval time.Duration
+}
+
+type AdminVar struct {
+	// Tokens maps each administrator's bearer token to their name. It is read from ADMIN_API_TOKENS as
+	// comma-separated name:token pairs; with no tokens the admin API rejects every request.
+	Tokens map[string]string
+	// StatusExpiryInterval is how often wallet restrictions past their expiry are lifted.
+	StatusExpiryInterval time.Duration
+}
+
+type AuditTrailVar struct {
+	// ChainInterval is how often the entries of finished requests are chained onto the audit trail.
+	ChainInterval time.Duration
+}
+
+type SchedulerVar struct {
+	// Interval is how often the worker looks for due scheduled transfers.
+	Interval time.Duration
+	// MaxAttempts is how many times a run is attempted while the source wallet lacks funds.
+	MaxAttempts int
+	// RetryBackoff is the wait before the first retry; it doubles with every further attempt.
+	RetryBackoff time.Duration
+	// RunLease is how long a run may stay running before it is taken to be abandoned by a dead worker.
+	// It must be well above the time one transfer takes.
+	RunLease time.Duration
+}
+
 // Load reads the configuration from environment variables and returns a Config struct.
 // It uses Viper to automatically load environment variables.
 // It also validates the loaded configuration to ensure all required values are present and valid.
@@ -52,6 +132,16 @@ func Load() (Config, error) {
 		ServiceName: viper.GetString("SERVICE_NAME"),
 		Env:         viper.GetString("ENV"),
 		ServicePort: viper.GetInt("SERVICE_PORT"),
+		Currency:    viper.GetString("CURRENCY"),
+		AutoMigrate: viper.GetBool("DB_AUTO_MIGRATE"),
+
+		HTTPVar: HTTPVar{
+			ReadTimeout:     viper.GetDuration("HTTP_READ_TIMEOUT"),
+			WriteTimeout:    viper.GetDuration("HTTP_WRITE_TIMEOUT"),
+			IdleTimeout:     viper.GetDuration("HTTP_IDLE_TIMEOUT"),
+			ShutdownTimeout: viper.GetDuration("HTTP_SHUTDOWN_TIMEOUT"),
+			DrainDelay:      viper.GetDuration("HTTP_DRAIN_DELAY"),
+		},
 
 		DatabaseVar: DatabaseVar{
 			Name:            viper.GetString("DB_NAME"),
@@ -63,7 +153,44 @@ func Load() (Config, error) {
 			MaxIdleConns:    viper.GetInt("MAXIDLECONNS"),
 			ConnMaxLifetime: viper.GetDuration("CONNMAXLIFETIME"),
 		},
+
+		SchedulerVar: SchedulerVar{
+			Interval:     viper.GetDuration("SCHEDULER_INTERVAL"),
+			MaxAttempts:  viper.GetInt("SCHEDULED_TRANSFER_MAX_ATTEMPTS"),
+			RetryBackoff: viper.GetDuration("SCHEDULED_TRANSFER_RETRY_BACKOFF"),
+			RunLease:     viper.GetDuration("SCHEDULED_TRANSFER_RUN_LEASE"),
+		},
+
+		FeeVar: FeeVar{
+			WalletID:      viper.GetString("FEE_WALLET_ID"),
+			SchedulesFile: viper.GetString("FEE_SCHEDULES_FILE"),
+		},
+
+		InterestVar: InterestVar{
+			DayCount: viper.GetString("INTEREST_DAY_COUNT"),
+			Interval: viper.GetDuration("INTEREST_ACCRUAL_INTERVAL"),
+		},
+
+		ApprovalVar: ApprovalVar{
+			ExpiryInterval: viper.GetDuration("TRANSFER_PROPOSAL_EXPIRY_INTERVAL"),
+		},
+
+		EscrowVar: EscrowVar{
+			WalletID: viper.GetString("ESCROW_WALLET_ID"),
+			Interval: viper.GetDuration("ESCROW_RELEASE_INTERVAL"),
+		},
+
+		AuditTrailVar: AuditTrailVar{
+			ChainInterval: viper.GetDuration("AUDIT_TRAIL_CHAIN_INTERVAL"),
+		},
+	}
+	tokens, err := parseAdminTokens(viper.GetString("ADMIN_API_TOKENS"))
+	if err != nil {
+		return config, err
 	}
+	config.AdminVar.Tokens = tokens
+	config.AdminVar.StatusExpiryInterval = viper.GetDuration("WALLET_STATUS_EXPIRY_INTERVAL")
+
 	if err := config.validate(); err != nil {
 		return config, err
 	}
@@ -80,6 +207,30 @@ func (config Config) validate() error {
 		return fmt.Errorf("SERVICE_PORT: %w", ErrEnvVarsNotSet)
 	}
 
+	if len(config.Currency) != 3 {
+		return fmt.Errorf("CURRENCY: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.HTTPVar.ReadTimeout <= 0 {
+		return fmt.Errorf("HTTP_READ_TIMEOUT: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.HTTPVar.WriteTimeout <= 0 {
+		return fmt.Errorf("HTTP_WRITE_TIMEOUT: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.HTTPVar.IdleTimeout <= 0 {
+		return fmt.Errorf("HTTP_IDLE_TIMEOUT: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.HTTPVar.ShutdownTimeout <= 0 {
+		return fmt.Errorf("HTTP_SHUTDOWN_TIMEOUT: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.HTTPVar.DrainDelay < 0 || config.HTTPVar.DrainDelay >= config.HTTPVar.ShutdownTimeout {
+		return fmt.Errorf("HTTP_DRAIN_DELAY must be shorter than HTTP_SHUTDOWN_TIMEOUT: %w", ErrEnvVarsNotSet)
+	}
+
 	if config.DatabaseVar.Name == "" {
 		return fmt.Errorf("DB_NAME: %w", ErrEnvVarsNotSet)
 	}
@@ -100,5 +251,74 @@ func (config Config) validate() error {
 		return fmt.Errorf("DB_PORT: %w", ErrEnvVarsNotSet)
 	}
 
+	if config.SchedulerVar.Interval <= 0 {
+		return fmt.Errorf("SCHEDULER_INTERVAL: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.SchedulerVar.MaxAttempts <= 0 {
+		return fmt.Errorf("SCHEDULED_TRANSFER_MAX_ATTEMPTS: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.SchedulerVar.RetryBackoff <= 0 {
+		return fmt.Errorf("SCHEDULED_TRANSFER_RETRY_BACKOFF: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.SchedulerVar.RunLease <= 0 {
+		return fmt.Errorf("SCHEDULED_TRANSFER_RUN_LEASE: %w", ErrEnvVarsNotSet)
+	}
+
+	if _, err := uuid.Parse(config.FeeVar.WalletID); err != nil {
+		return fmt.Errorf("FEE_WALLET_ID: %w", ErrEnvVarsNotSet)
+	}
+
+	if !model.DayCountConvention(config.InterestVar.DayCount).IsValid() {
+		return fmt.Errorf("INTEREST_DAY_COUNT: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.InterestVar.Interval <= 0 {
+		return fmt.Errorf("INTEREST_ACCRUAL_INTERVAL: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.ApprovalVar.ExpiryInterval <= 0 {
+		return fmt.Errorf("TRANSFER_PROPOSAL_EXPIRY_INTERVAL: %w", ErrEnvVarsNotSet)
+	}
+
+	if _, err := uuid.Parse(config.EscrowVar.WalletID); err != nil {
+		return fmt.Errorf("ESCROW_WALLET_ID: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.EscrowVar.Interval <= 0 {
+		return fmt.Errorf("ESCROW_RELEASE_INTERVAL: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.AdminVar.StatusExpiryInterval <= 0 {
+		return fmt.Errorf("WALLET_STATUS_EXPIRY_INTERVAL: %w", ErrEnvVarsNotSet)
+	}
+
+	if config.AuditTrailVar.ChainInterval <= 0 {
+		return fmt.Errorf("AUDIT_TRAIL_CHAIN_INTERVAL: %w", ErrEnvVarsNotSet)
+	}
+
 	return nil
 }
+
+// parseAdminTokens reads comma-separated name:token pairs into a map from token to name.
+func parseAdminTokens(s string) (map[string]string, error) {
+	tokens := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, token, ok := strings.Cut(pair, ":")
+		name, token = strings.TrimSpace(name), strings.TrimSpace(token)
+		if !ok || name == "" || token == "" {
+			return nil, fmt.Errorf("ADMIN_API_TOKENS: entries must be name:token pairs")
+		}
+		if _, dup := tokens[token]; dup {
+			return nil, fmt.Errorf("ADMIN_API_TOKENS: token of %s is already used", name)
+		}
+		tokens[token] = name
+	}
+	return tokens, nil
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+// AdminActorKey is the gin context key the admin authentication middleware stores the administrator's name under.
+const AdminActorKey = "admin-actor"
+
+// adminActor returns the name of the administrator making the request.
+func adminActor(c *gin.Context) string {
+	return c.GetString(AdminActorKey)
+}
+
+type AdminService interface {
+	SearchUsers(ctx context.Context, admin, query string) ([]model.User, error)
+	SearchWallets(ctx context.Context, admin, query, userId string) ([]model.Wallet, error)
+	SetWalletStatus(ctx context.Context, admin, walletId string, req model.WalletStatusRequest) (*model.Wallet, error)
+	FreezeWallet(ctx context.Context, admin, walletId string, req model.WalletFreezeRequest) (*model.Wallet, error)
+	UnfreezeWallet(ctx context.Context, admin, walletId string, req model.WalletFreezeRequest) (*model.Wallet, error)
+	AdjustWallet(ctx context.Context, admin, walletId string, req model.AdjustmentRequest) (*model.Transaction, error)
+	ListAuditLog(ctx context.Context, admin, walletId string) ([]model.AdminAuditEntry, error)
+}
+
+func NewAdminImpl(aService AdminService) *AdminHandler {
+	return &AdminHandler{aService}
+}
+
+type AdminHandler struct {
+	aService AdminService
+}
+
+// SearchUsers finds users by ?q=, an ID or part of a name, email or handle.
+// GET /v1/admin/users
+func (h *AdminHandler) SearchUsers(c *gin.Context) {
+	users, err := h.aService.SearchUsers(c.Request.Context(), adminActor(c), c.Query("q"))
+	if err != nil {
+		respondAdminError(c, err, "failed to search users")
+		return
+	}
+	restjson.ResponseData(c, users)
+}
+
+// SearchWallets finds wallets by ?q=, an ID or part of a name, and/or by ?user_id= of an owner or member.
+// GET /v1/admin/wallets
+func (h *AdminHandler) SearchWallets(c *gin.Context) {
+	wallets, err := h.aService.SearchWallets(c.Request.Context(), adminActor(c), c.Query("q"), c.Query("user_id"))
+	if err != nil {
+		respondAdminError(c, err, "failed to search wallets")
+		return
+	}
+	restjson.ResponseData(c, wallets)
+}
+
+// SetWalletStatus puts a wallet in a status, optionally until an expires_at.
+// PUT /v1/admin/wallets/{walletId}/status
+func (h *AdminHandler) SetWalletStatus(c *gin.Context) {
+	walletId := c.Param("walletId")
+
+	if walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("walletId is invalid in path"))
+		return
+	}
+
+	var req model.WalletStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	wallet, err := h.aService.SetWalletStatus(c.Request.Context(), adminActor(c), walletId, req)
+	if err != nil {
+		respondAdminError(c, err, "failed to set wallet status")
+		return
+	}
+	restjson.ResponseData(c, wallet)
+}
+
+// FreezeWallet stops a wallet from paying or being paid.
+// POST /v1/admin/wallets/{walletId}/freeze
+func (h *AdminHandler) FreezeWallet(c *gin.Context) {
+	h.setWalletStatus(c, h.aService.FreezeWallet, "failed to freeze wallet")
+}
+
+// UnfreezeWallet lifts any restriction on a wallet.
+// POST /v1/admin/wallets/{walletId}/unfreeze
+func (h *AdminHandler) UnfreezeWallet(c *gin.Context) {
+	h.setWalletStatus(c, h.aService.UnfreezeWallet, "failed to unfreeze wallet")
+}
+
+func (h *AdminHandler) setWalletStatus(c *gin.Context,
+	set func(ctx context.Context, admin, walletId string, req model.WalletFreezeRequest) (*model.Wallet, error), fallback string) {
+	walletId := c.Param("walletId")
+
+	if walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("walletId is invalid in path"))
+		return
+	}
+
+	var req model.WalletFreezeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	wallet, err := set(c.Request.Context(), adminActor(c), walletId, req)
+	if err != nil {
+		respondAdminError(c, err, fallback)
+		return
+	}
+	restjson.ResponseData(c, wallet)
+}
+
+// AdjustWallet posts a manual credit or debit to a wallet under a reason code.
+// POST /v1/admin/wallets/{walletId}/adjustments
+func (h *AdminHandler) AdjustWallet(c *gin.Context) {
+	walletId := c.Param("walletId")
+
+	if walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("walletId is invalid in path"))
+		return
+	}
+
+	var req model.AdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	transaction, err := h.aService.AdjustWallet(c.Request.Context(), adminActor(c), walletId, req)
+	if err != nil {
+		respondAdminError(c, err, "failed to adjust wallet")
+		return
+	}
+	c.JSON(http.StatusCreated, restjson.Response{Code: http.StatusCreated, Data: transaction})
+}
+
+// ListAuditLog lists the most recent back-office actions, optionally of one ?admin= or about one ?wallet_id=.
+// GET /v1/admin/audit-log
+func (h *AdminHandler) ListAuditLog(c *gin.Context) {
+	entries, err := h.aService.ListAuditLog(c.Request.Context(), c.Query("admin"), c.Query("wallet_id"))
+	if err != nil {
+		respondAdminError(c, err, "failed to retrieve audit log")
+		return
+	}
+	restjson.ResponseData(c, entries)
+}
+
+// respondAdminError maps back-office errors to responses. A debit larger than the money available is a conflict
+// with the wallet's state rather than a malformed request.
+func respondAdminError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repo.ErrWalletNotFound):
+		restjson.ResponseError(c, http.StatusNotFound, err)
+	case errors.Is(err, repo.ErrWalletStatusUnchanged), errors.Is(err, repo.ErrInsufficientFunds):
+		restjson.ResponseError(c, http.StatusConflict, err)
+	case errors.Is(err, service.ErrInvalidAdminRequest):
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+	default:
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New(fallback))
+	}
+}
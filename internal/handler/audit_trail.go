@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type AuditTrailService interface {
+	ListAuditTrail(ctx context.Context, afterSeq int64, limit int) ([]model.AuditTrailEntry, error)
+	VerifyAuditTrail(ctx context.Context) (*model.AuditChainReport, error)
+}
+
+func NewAuditTrailImpl(atService AuditTrailService) *AuditTrailHandler {
+	return &AuditTrailHandler{atService}
+}
+
+type AuditTrailHandler struct {
+	atService AuditTrailService
+}
+
+// ListAuditTrail lists up to ?limit= audit trail entries numbered after ?after_seq=, in order.
+// GET /v1/admin/audit-trail
+func (h *AuditTrailHandler) ListAuditTrail(c *gin.Context) {
+	afterSeq, err := strconv.ParseInt(c.DefaultQuery("after_seq", "0"), 10, 64)
+	if err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("after_seq must be a number"))
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("limit must be a number"))
+		return
+	}
+
+	entries, err := h.atService.ListAuditTrail(c.Request.Context(), afterSeq, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAuditTrailQuery) {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve audit trail"))
+		}
+		return
+	}
+	restjson.ResponseData(c, entries)
+}
+
+// VerifyAuditTrail checks the audit trail's hash chain and reports where it breaks.
+// GET /v1/admin/audit-trail/verify
+func (h *AuditTrailHandler) VerifyAuditTrail(c *gin.Context) {
+	report, err := h.atService.VerifyAuditTrail(c.Request.Context())
+	if err != nil {
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to verify audit trail"))
+		return
+	}
+	restjson.ResponseData(c, report)
+}
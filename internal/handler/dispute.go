@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type DisputeService interface {
+	OpenDispute(ctx context.Context, userId, walletId string, req model.DisputeRequest) (*model.Dispute, error)
+	ListDisputes(ctx context.Context, userId, walletId string) ([]model.Dispute, error)
+	GetDispute(ctx context.Context, userId, walletId, disputeId string) (*model.Dispute, error)
+	AddDisputeNote(ctx context.Context, userId, walletId, disputeId string, req model.DisputeNoteRequest) (*model.DisputeNote, error)
+	WithdrawDispute(ctx context.Context, userId, walletId, disputeId string) (*model.Dispute, error)
+	ListDisputeQueue(ctx context.Context, status model.DisputeStatus) ([]model.Dispute, error)
+	GetDisputeCase(ctx context.Context, disputeId string) (*model.Dispute, error)
+	AddAdminDisputeNote(ctx context.Context, admin, disputeId string, req model.DisputeNoteRequest) (*model.DisputeNote, error)
+	ResolveDispute(ctx context.Context, admin, disputeId string, req model.DisputeResolutionRequest) (*model.Dispute, error)
+}
+
+func NewDisputeImpl(dService DisputeService) *DisputeHandler {
+	return &DisputeHandler{dService}
+}
+
+type DisputeHandler struct {
+	dService DisputeService
+}
+
+// OpenDispute disputes a transfer made from the wallet.
+// POST /v1/user/{userId}/wallet/{walletId}/disputes
+func (h *DisputeHandler) OpenDispute(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	var req model.DisputeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	dispute, err := h.dService.OpenDispute(c.Request.Context(), userId, walletId, req)
+	if err != nil {
+		respondDisputeError(c, err, "failed to open dispute")
+		return
+	}
+	c.JSON(http.StatusCreated, restjson.Response{Code: http.StatusCreated, Data: dispute})
+}
+
+// ListDisputes lists the disputes the wallet is the payer or the recipient in.
+// GET /v1/user/{userId}/wallet/{walletId}/disputes
+func (h *DisputeHandler) ListDisputes(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	disputes, err := h.dService.ListDisputes(c.Request.Context(), userId, walletId)
+	if err != nil {
+		respondDisputeError(c, err, "failed to retrieve disputes")
+		return
+	}
+	restjson.ResponseData(c, disputes)
+}
+
+// GetDispute returns a dispute the wallet is the payer or the recipient in, with its notes.
+// GET /v1/user/{userId}/wallet/{walletId}/disputes/{disputeId}
+func (h *DisputeHandler) GetDispute(c *gin.Context) {
+	userId, walletId, disputeId, ok := disputePathParams(c)
+	if !ok {
+		return
+	}
+
+	dispute, err := h.dService.GetDispute(c.Request.Context(), userId, walletId, disputeId)
+	if err != nil {
+		respondDisputeError(c, err, "failed to retrieve dispute")
+		return
+	}
+	restjson.ResponseData(c, dispute)
+}
+
+// AddDisputeNote attaches evidence to an open dispute.
+// POST /v1/user/{userId}/wallet/{walletId}/disputes/{disputeId}/notes
+func (h *DisputeHandler) AddDisputeNote(c *gin.Context) {
+	userId, walletId, disputeId, ok := disputePathParams(c)
+	if !ok {
+		return
+	}
+
+	var req model.DisputeNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	note, err := h.dService.AddDisputeNote(c.Request.Context(), userId, walletId, disputeId, req)
+	if err != nil {
+		respondDisputeError(c, err, "failed to add dispute note")
+		return
+	}
+	c.JSON(http.StatusCreated, restjson.Response{Code: http.StatusCreated, Data: note})
+}
+
+// WithdrawDispute withdraws an open dispute the wallet raised.
+// POST /v1/user/{userId}/wallet/{walletId}/disputes/{disputeId}/withdraw
+func (h *DisputeHandler) WithdrawDispute(c *gin.Context) {
+	userId, walletId, disputeId, ok := disputePathParams(c)
+	if !ok {
+		return
+	}
+
+	dispute, err := h.dService.WithdrawDispute(c.Request.Context(), userId, walletId, disputeId)
+	if err != nil {
+		respondDisputeError(c, err, "failed to withdraw dispute")
+		return
+	}
+	restjson.ResponseData(c, dispute)
+}
+
+// ListDisputeQueue lists every dispute in ?status= (open by default), oldest first, for administrators.
+// GET /v1/admin/disputes
+func (h *DisputeHandler) ListDisputeQueue(c *gin.Context) {
+	status := model.DisputeStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		restjson.ResponseError(c, http.StatusBadRequest, fmt.Errorf("unknown status %q", status))
+		return
+	}
+
+	disputes, err := h.dService.ListDisputeQueue(c.Request.Context(), status)
+	if err != nil {
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve disputes"))
+		return
+	}
+	restjson.ResponseData(c, disputes)
+}
+
+// GetDisputeCase returns any dispute with its notes, for administrators.
+// GET /v1/admin/disputes/{disputeId}
+func (h *DisputeHandler) GetDisputeCase(c *gin.Context) {
+	disputeId, ok := adminDisputePathParam(c)
+	if !ok {
+		return
+	}
+
+	dispute, err := h.dService.GetDisputeCase(c.Request.Context(), disputeId)
+	if err != nil {
+		respondDisputeError(c, err, "failed to retrieve dispute")
+		return
+	}
+	restjson.ResponseData(c, dispute)
+}
+
+// AddAdminDisputeNote attaches an administrator's note to an open dispute.
+// POST /v1/admin/disputes/{disputeId}/notes
+func (h *DisputeHandler) AddAdminDisputeNote(c *gin.Context) {
+	disputeId, ok := adminDisputePathParam(c)
+	if !ok {
+		return
+	}
+
+	var req model.DisputeNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	note, err := h.dService.AddAdminDisputeNote(c.Request.Context(), adminActor(c), disputeId, req)
+	if err != nil {
+		respondDisputeError(c, err, "failed to add dispute note")
+		return
+	}
+	c.JSON(http.StatusCreated, restjson.Response{Code: http.StatusCreated, Data: note})
+}
+
+// ResolveDispute resolves an open dispute in favour of the payer, reversing the transfer, or of the recipient.
+// POST /v1/admin/disputes/{disputeId}/resolution
+func (h *DisputeHandler) ResolveDispute(c *gin.Context) {
+	disputeId, ok := adminDisputePathParam(c)
+	if !ok {
+		return
+	}
+
+	var req model.DisputeResolutionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	dispute, err := h.dService.ResolveDispute(c.Request.Context(), adminActor(c), disputeId, req)
+	if err != nil {
+		respondDisputeError(c, err, "failed to resolve dispute")
+		return
+	}
+	restjson.ResponseData(c, dispute)
+}
+
+func disputePathParams(c *gin.Context) (string, string, string, bool) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+	disputeId := c.Param("disputeId")
+
+	if userId == "" || walletId == "" || disputeId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId, walletId or disputeId is invalid in path"))
+		return "", "", "", false
+	}
+	return userId, walletId, disputeId, true
+}
+
+func adminDisputePathParam(c *gin.Context) (string, bool) {
+	disputeId := c.Param("disputeId")
+
+	if disputeId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("disputeId is invalid in path"))
+		return "", false
+	}
+	return disputeId, true
+}
+
+// respondDisputeError maps dispute errors to responses. Insufficient funds can only come from reversing a
+// transfer the recipient no longer has the money for, which is a conflict with the wallet's state.
+func respondDisputeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repo.ErrWalletNotFound), errors.Is(err, repo.ErrDisputeNotFound), errors.Is(err, repo.ErrTransactionNotDisputable):
+		restjson.ResponseError(c, http.StatusNotFound, err)
+	case errors.Is(err, repo.ErrWalletForbidden):
+		restjson.ResponseError(c, http.StatusForbidden, err)
+	case errors.Is(err, repo.ErrDisputeExists), errors.Is(err, repo.ErrDisputeNotOpen), errors.Is(err, repo.ErrDisputeWindowClosed),
+		errors.Is(err, repo.ErrInsufficientFunds):
+		restjson.ResponseError(c, http.StatusConflict, err)
+	case errors.Is(err, service.ErrInvalidDispute):
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+	default:
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New(fallback))
+	}
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type EscrowService interface {
+	CreateEscrow(ctx context.Context, userId, walletId string, req model.EscrowRequest) (*model.Escrow, error)
+	ListEscrows(ctx context.Context, userId, walletId string) ([]model.Escrow, error)
+	GetEscrow(ctx context.Context, userId, walletId, escrowId string) (*model.Escrow, error)
+	ConfirmEscrow(ctx context.Context, userId, walletId, escrowId string) (*model.Escrow, error)
+	DecideEscrow(ctx context.Context, admin, escrowId string, req model.EscrowDecisionRequest) (*model.Escrow, error)
+	ListEscrowsByStatus(ctx context.Context, status model.EscrowStatus) ([]model.Escrow, error)
+}
+
+func NewEscrowImpl(eService EscrowService) *EscrowHandler {
+	return &EscrowHandler{eService}
+}
+
+type EscrowHandler struct {
+	eService EscrowService
+}
+
+// CreateEscrow moves funds from the wallet into escrow for a seller's wallet.
+// POST /v1/user/{userId}/wallet/{walletId}/escrows
+func (h *EscrowHandler) CreateEscrow(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	var req model.EscrowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	escrow, err := h.eService.CreateEscrow(c.Request.Context(), userId, walletId, req)
+	if err != nil {
+		respondEscrowError(c, err, "failed to create escrow")
+		return
+	}
+	c.JSON(http.StatusCreated, restjson.Response{Code: http.StatusCreated, Data: escrow})
+}
+
+// ListEscrows lists the escrows the wallet buys or sells in.
+// GET /v1/user/{userId}/wallet/{walletId}/escrows
+func (h *EscrowHandler) ListEscrows(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	escrows, err := h.eService.ListEscrows(c.Request.Context(), userId, walletId)
+	if err != nil {
+		respondEscrowError(c, err, "failed to retrieve escrows")
+		return
+	}
+	restjson.ResponseData(c, escrows)
+}
+
+// GetEscrow returns an escrow the wallet buys or sells in.
+// GET /v1/user/{userId}/wallet/{walletId}/escrows/{escrowId}
+func (h *EscrowHandler) GetEscrow(c *gin.Context) {
+	userId, walletId, escrowId, ok := escrowPathParams(c)
+	if !ok {
+		return
+	}
+
+	escrow, err := h.eService.GetEscrow(c.Request.Context(), userId, walletId, escrowId)
+	if err != nil {
+		respondEscrowError(c, err, "failed to retrieve escrow")
+		return
+	}
+	restjson.ResponseData(c, escrow)
+}
+
+// ConfirmEscrow releases the escrow to the seller. Only the buyer's wallet can confirm.
+// POST /v1/user/{userId}/wallet/{walletId}/escrows/{escrowId}/confirm
+func (h *EscrowHandler) ConfirmEscrow(c *gin.Context) {
+	userId, walletId, escrowId, ok := escrowPathParams(c)
+	if !ok {
+		return
+	}
+
+	escrow, err := h.eService.ConfirmEscrow(c.Request.Context(), userId, walletId, escrowId)
+	if err != nil {
+		respondEscrowError(c, err, "failed to confirm escrow")
+		return
+	}
+	restjson.ResponseData(c, escrow)
+}
+
+// ListEscrowsByStatus lists every escrow in ?status= (held by default) for administrators.
+// GET /v1/admin/escrows
+func (h *EscrowHandler) ListEscrowsByStatus(c *gin.Context) {
+	status := model.EscrowStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		restjson.ResponseError(c, http.StatusBadRequest, fmt.Errorf("unknown status %q", status))
+		return
+	}
+
+	escrows, err := h.eService.ListEscrowsByStatus(c.Request.Context(), status)
+	if err != nil {
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve escrows"))
+		return
+	}
+	restjson.ResponseData(c, escrows)
+}
+
+// DecideEscrow releases a held escrow to the seller or refunds it to the buyer on an administrator's decision.
+// POST /v1/admin/escrows/{escrowId}/decision
+func (h *EscrowHandler) DecideEscrow(c *gin.Context) {
+	escrowId := c.Param("escrowId")
+
+	if escrowId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("escrowId is invalid in path"))
+		return
+	}
+
+	var req model.EscrowDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	escrow, err := h.eService.DecideEscrow(c.Request.Context(), adminActor(c), escrowId, req)
+	if err != nil {
+		respondEscrowError(c, err, "failed to decide escrow")
+		return
+	}
+	restjson.ResponseData(c, escrow)
+}
+
+func escrowPathParams(c *gin.Context) (string, string, string, bool) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+	escrowId := c.Param("escrowId")
+
+	if userId == "" || walletId == "" || escrowId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId, walletId or escrowId is invalid in path"))
+		return "", "", "", false
+	}
+	return userId, walletId, escrowId, true
+}
+
+func respondEscrowError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repo.ErrWalletNotFound), errors.Is(err, repo.ErrEscrowNotFound):
+		restjson.ResponseError(c, http.StatusNotFound, err)
+	case errors.Is(err, repo.ErrWalletForbidden), errors.Is(err, repo.ErrEscrowNotBuyer):
+		restjson.ResponseError(c, http.StatusForbidden, err)
+	case errors.Is(err, repo.ErrEscrowNotHeld), errors.Is(err, repo.ErrApprovalRequired):
+		restjson.ResponseError(c, http.StatusConflict, err)
+	case errors.Is(err, repo.ErrWalletFrozen):
+		restjson.ResponseError(c, http.StatusLocked, err)
+	case errors.Is(err, repo.ErrWalletDebitBlocked), errors.Is(err, repo.ErrWalletCreditBlocked):
+		restjson.ResponseError(c, http.StatusConflict, err)
+	case errors.Is(err, service.ErrInvalidEscrow), errors.Is(err, repo.ErrInsufficientFunds):
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+	default:
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New(fallback))
+	}
+}
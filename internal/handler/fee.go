@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type FeeService interface {
+	Quote(ctx context.Context, operation model.FeeOperation, amount decimal.Decimal) (*model.FeeQuote, error)
+	ListFeeSchedules(ctx context.Context) ([]model.FeeSchedule, error)
+}
+
+func NewFeeImpl(fService FeeService) *FeeHandler {
+	return &FeeHandler{fService}
+}
+
+type FeeHandler struct {
+	fService FeeService
+}
+
+// QuoteFee tells what a withdrawal or transfer of ?amount= would cost, so the user can see it before committing.
+// GET /v1/fees/quote?operation={withdrawal|transfer}&amount={amount}
+func (h *FeeHandler) QuoteFee(c *gin.Context) {
+	amount, err := decimal.NewFromString(c.Query("amount"))
+	if err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("amount is invalid"))
+		return
+	}
+
+	quote, err := h.fService.Quote(c.Request.Context(), model.FeeOperation(c.Query("operation")), amount)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidFeeQuote) {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to quote fee"))
+		}
+		return
+	}
+	restjson.ResponseData(c, quote)
+}
+
+// ListFeeSchedules returns every version of the fee schedules, so past fees can be explained.
+// GET /v1/fees/schedules
+func (h *FeeHandler) ListFeeSchedules(c *gin.Context) {
+	schedules, err := h.fService.ListFeeSchedules(c.Request.Context())
+	if err != nil {
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve fee schedules"))
+		return
+	}
+	restjson.ResponseData(c, schedules)
+}
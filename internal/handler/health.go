@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type HealthService interface {
+	Live(ctx context.Context) model.HealthReport
+	Ready(ctx context.Context) model.HealthReport
+}
+
+func NewHealthImpl(hService HealthService) *HealthHandler {
+	return &HealthHandler{hService}
+}
+
+type HealthHandler struct {
+	hService HealthService
+}
+
+// Live answers the liveness probe: 200 while the process can serve requests.
+// GET /healthz
+func (h *HealthHandler) Live(c *gin.Context) {
+	respondHealth(c, h.hService.Live(c.Request.Context()))
+}
+
+// Ready answers the readiness probe: 200 while the server should receive traffic, 503 with the failing checks
+// otherwise, e.g. while the database is down or the server is shutting down.
+// GET /readyz
+func (h *HealthHandler) Ready(c *gin.Context) {
+	respondHealth(c, h.hService.Ready(c.Request.Context()))
+}
+
+func respondHealth(c *gin.Context, report model.HealthReport) {
+	code := http.StatusOK
+	if report.Status != model.HealthStatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	restjson.ResponseDataWithCode(c, code, report)
+}
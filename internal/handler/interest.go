@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type InterestService interface {
+	ListWalletProducts(ctx context.Context) ([]model.WalletProduct, error)
+	SetWalletProduct(ctx context.Context, userID string, walletID string, productID *uuid.UUID) (*model.Wallet, error)
+	GetInterestSummary(ctx context.Context, userID string, walletID string) (*model.InterestSummary, error)
+}
+
+func NewInterestImpl(iService InterestService) *InterestHandler {
+	return &InterestHandler{iService}
+}
+
+type InterestHandler struct {
+	iService InterestService
+}
+
+// ListWalletProducts returns the savings products a wallet can be put on.
+// GET /v1/products
+func (h *InterestHandler) ListWalletProducts(c *gin.Context) {
+	products, err := h.iService.ListWalletProducts(c.Request.Context())
+	if err != nil {
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve wallet products"))
+		return
+	}
+	restjson.ResponseData(c, products)
+}
+
+// SetWalletProduct puts a wallet on a savings product, or takes it off with a null product_id.
+// PUT /v1/user/{userId}/wallet/{walletId}/product
+func (h *InterestHandler) SetWalletProduct(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	var req model.WalletProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	wallet, err := h.iService.SetWalletProduct(c.Request.Context(), userId, walletId, req.ProductID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrWalletNotFound), errors.Is(err, repo.ErrWalletProductNotFound):
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		case errors.Is(err, repo.ErrWalletForbidden):
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		default:
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to set wallet product"))
+		}
+		return
+	}
+	restjson.ResponseData(c, wallet)
+}
+
+// GetInterestSummary returns the product of a wallet and the interest accrued but not paid out yet.
+// GET /v1/user/{userId}/wallet/{walletId}/interest
+func (h *InterestHandler) GetInterestSummary(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	summary, err := h.iService.GetInterestSummary(c.Request.Context(), userId, walletId)
+	if err != nil {
+		if errors.Is(err, repo.ErrWalletNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve interest"))
+		}
+		return
+	}
+	restjson.ResponseData(c, summary)
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type PaymentRequestService interface {
+	CreatePaymentRequest(ctx context.Context, userId, walletId string, req model.PaymentRequestRequest) (*model.PaymentRequest, error)
+	ListIncomingPaymentRequests(ctx context.Context, userId string, status model.PaymentRequestStatus) ([]model.PaymentRequest, error)
+	ListOutgoingPaymentRequests(ctx context.Context, userId string, status model.PaymentRequestStatus) ([]model.PaymentRequest, error)
+	GetPaymentRequest(ctx context.Context, userId, requestId string) (*model.PaymentRequest, error)
+	AcceptPaymentRequest(ctx context.Context, userId, requestId, walletId string) (*model.PaymentRequest, error)
+	DeclinePaymentRequest(ctx context.Context, userId, requestId string) (*model.PaymentRequest, error)
+	CancelPaymentRequest(ctx context.Context, userId, requestId string) (*model.PaymentRequest, error)
+}
+
+func NewPaymentRequestImpl(pService PaymentRequestService) *PaymentRequestHandler {
+	return &PaymentRequestHandler{pService}
+}
+
+type PaymentRequestHandler struct {
+	pService PaymentRequestService
+}
+
+// CreatePaymentRequest asks another user to pay into the wallet.
+// POST /v1/user/{userId}/wallet/{walletId}/payment-requests
+func (h *PaymentRequestHandler) CreatePaymentRequest(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	var req model.PaymentRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	pr, err := h.pService.CreatePaymentRequest(c.Request.Context(), userId, walletId, req)
+	if err != nil {
+		if errors.Is(err, repo.ErrWalletNotFound) || errors.Is(err, repo.ErrPayerNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repo.ErrWalletForbidden) {
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		} else if errors.Is(err, service.ErrInvalidPaymentRequest) {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to create payment request"))
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, restjson.Response{Code: http.StatusCreated, Data: pr})
+}
+
+// ListIncomingPaymentRequests lists the requests the user has been asked to pay, optionally filtered by ?status=.
+// GET /v1/user/{userId}/payment-requests/incoming
+func (h *PaymentRequestHandler) ListIncomingPaymentRequests(c *gin.Context) {
+	h.listPaymentRequests(c, h.pService.ListIncomingPaymentRequests)
+}
+
+// ListOutgoingPaymentRequests lists the requests the user has made, optionally filtered by ?status=.
+// GET /v1/user/{userId}/payment-requests/outgoing
+func (h *PaymentRequestHandler) ListOutgoingPaymentRequests(c *gin.Context) {
+	h.listPaymentRequests(c, h.pService.ListOutgoingPaymentRequests)
+}
+
+func (h *PaymentRequestHandler) listPaymentRequests(c *gin.Context, list func(ctx context.Context, userId string, status model.PaymentRequestStatus) ([]model.PaymentRequest, error)) {
+	userId := c.Param("userId")
+	if userId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId is invalid in path"))
+		return
+	}
+
+	status := model.PaymentRequestStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		restjson.ResponseError(c, http.StatusBadRequest, fmt.Errorf("unknown status %q", status))
+		return
+	}
+
+	requests, err := list(c.Request.Context(), userId, status)
+	if err != nil {
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve payment requests"))
+		return
+	}
+	restjson.ResponseData(c, requests)
+}
+
+// GetPaymentRequest returns a request the user made or was asked to pay.
+// GET /v1/user/{userId}/payment-requests/{requestId}
+func (h *PaymentRequestHandler) GetPaymentRequest(c *gin.Context) {
+	userId := c.Param("userId")
+	requestId := c.Param("requestId")
+
+	if userId == "" || requestId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or requestId is invalid in path"))
+		return
+	}
+
+	pr, err := h.pService.GetPaymentRequest(c.Request.Context(), userId, requestId)
+	if err != nil {
+		if errors.Is(err, repo.ErrPaymentRequestNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve payment request"))
+		}
+		return
+	}
+	restjson.ResponseData(c, pr)
+}
+
+// AcceptPaymentRequest pays a pending request from the wallet given in the body.
+// POST /v1/user/{userId}/payment-requests/{requestId}/accept
+func (h *PaymentRequestHandler) AcceptPaymentRequest(c *gin.Context) {
+	userId := c.Param("userId")
+	requestId := c.Param("requestId")
+
+	if userId == "" || requestId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or requestId is invalid in path"))
+		return
+	}
+
+	var req model.AcceptPaymentRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	pr, err := h.pService.AcceptPaymentRequest(c.Request.Context(), userId, requestId, req.WalletID)
+	if err != nil {
+		respondPaymentRequestError(c, err, "failed to accept payment request")
+		return
+	}
+	restjson.ResponseData(c, pr)
+}
+
+// DeclinePaymentRequest refuses a pending request.
+// POST /v1/user/{userId}/payment-requests/{requestId}/decline
+func (h *PaymentRequestHandler) DeclinePaymentRequest(c *gin.Context) {
+	userId := c.Param("userId")
+	requestId := c.Param("requestId")
+
+	if userId == "" || requestId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or requestId is invalid in path"))
+		return
+	}
+
+	pr, err := h.pService.DeclinePaymentRequest(c.Request.Context(), userId, requestId)
+	if err != nil {
+		respondPaymentRequestError(c, err, "failed to decline payment request")
+		return
+	}
+	restjson.ResponseData(c, pr)
+}
+
+// CancelPaymentRequest withdraws a pending request the user made.
+// POST /v1/user/{userId}/payment-requests/{requestId}/cancel
+func (h *PaymentRequestHandler) CancelPaymentRequest(c *gin.Context) {
+	userId := c.Param("userId")
+	requestId := c.Param("requestId")
+
+	if userId == "" || requestId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or requestId is invalid in path"))
+		return
+	}
+
+	pr, err := h.pService.CancelPaymentRequest(c.Request.Context(), userId, requestId)
+	if err != nil {
+		respondPaymentRequestError(c, err, "failed to cancel payment request")
+		return
+	}
+	restjson.ResponseData(c, pr)
+}
+
+// respondPaymentRequestError maps the errors of a payment request state transition to a response.
+func respondPaymentRequestError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repo.ErrPaymentRequestNotFound), errors.Is(err, repo.ErrWalletNotFound):
+		restjson.ResponseError(c, http.StatusNotFound, err)
+	case errors.Is(err, repo.ErrWalletForbidden):
+		restjson.ResponseError(c, http.StatusForbidden, err)
+	case errors.Is(err, repo.ErrPaymentRequestNotPending), errors.Is(err, repo.ErrApprovalRequired):
+		restjson.ResponseError(c, http.StatusConflict, err)
+	case errors.Is(err, repo.ErrWalletFrozen):
+		restjson.ResponseError(c, http.StatusLocked, err)
+	case errors.Is(err, repo.ErrWalletDebitBlocked), errors.Is(err, repo.ErrWalletCreditBlocked):
+		restjson.ResponseError(c, http.StatusConflict, err)
+	case errors.Is(err, repo.ErrPaymentRequestExpired):
+		restjson.ResponseError(c, http.StatusGone, err)
+	case errors.Is(err, repo.ErrInsufficientFunds):
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+	default:
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New(fallback))
+	}
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type PotService interface {
+	CreatePot(ctx context.Context, userId, walletId string, req model.PotRequest) (*model.Pot, error)
+	ListPots(ctx context.Context, userId, walletId string) ([]model.Pot, error)
+	GetPot(ctx context.Context, userId, walletId, potId string) (*model.Pot, error)
+	UpdatePot(ctx context.Context, userId, walletId, potId string, req model.PotRequest) (*model.Pot, error)
+	MoveIntoPot(ctx context.Context, userId, walletId, potId string, amount decimal.Decimal) (*model.Pot, error)
+	MoveOutOfPot(ctx context.Context, userId, walletId, potId string, amount decimal.Decimal) (*model.Pot, error)
+	ClosePot(ctx context.Context, userId, walletId, potId string) (*model.Pot, error)
+}
+
+func NewPotImpl(pService PotService) *PotHandler {
+	return &PotHandler{pService}
+}
+
+type PotHandler struct {
+	pService PotService
+}
+
+// CreatePot adds a pot to the wallet, optionally with a target amount and date.
+// POST /v1/user/{userId}/wallet/{walletId}/pots
+func (h *PotHandler) CreatePot(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	var req model.PotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	pot, err := h.pService.CreatePot(c.Request.Context(), userId, walletId, req)
+	if err != nil {
+		respondPotError(c, err, "failed to create pot")
+		return
+	}
+	c.JSON(http.StatusCreated, restjson.Response{Code: http.StatusCreated, Data: pot})
+}
+
+// ListPots lists the pots of the wallet with their progress.
+// GET /v1/user/{userId}/wallet/{walletId}/pots
+func (h *PotHandler) ListPots(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	pots, err := h.pService.ListPots(c.Request.Context(), userId, walletId)
+	if err != nil {
+		respondPotError(c, err, "failed to retrieve pots")
+		return
+	}
+	restjson.ResponseData(c, pots)
+}
+
+// GetPot returns one pot of the wallet with its progress.
+// GET /v1/user/{userId}/wallet/{walletId}/pots/{potId}
+func (h *PotHandler) GetPot(c *gin.Context) {
+	userId, walletId, potId, ok := potPathParams(c)
+	if !ok {
+		return
+	}
+
+	pot, err := h.pService.GetPot(c.Request.Context(), userId, walletId, potId)
+	if err != nil {
+		respondPotError(c, err, "failed to retrieve pot")
+		return
+	}
+	restjson.ResponseData(c, pot)
+}
+
+// UpdatePot replaces the name and target of a pot.
+// PUT /v1/user/{userId}/wallet/{walletId}/pots/{potId}
+func (h *PotHandler) UpdatePot(c *gin.Context) {
+	userId, walletId, potId, ok := potPathParams(c)
+	if !ok {
+		return
+	}
+
+	var req model.PotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	pot, err := h.pService.UpdatePot(c.Request.Context(), userId, walletId, potId, req)
+	if err != nil {
+		respondPotError(c, err, "failed to update pot")
+		return
+	}
+	restjson.ResponseData(c, pot)
+}
+
+// MoveIntoPot sets money of the wallet aside in the pot.
+// POST /v1/user/{userId}/wallet/{walletId}/pots/{potId}/move-in
+func (h *PotHandler) MoveIntoPot(c *gin.Context) {
+	h.move(c, h.pService.MoveIntoPot)
+}
+
+// MoveOutOfPot moves money from the pot back to the wallet.
+// POST /v1/user/{userId}/wallet/{walletId}/pots/{potId}/move-out
+func (h *PotHandler) MoveOutOfPot(c *gin.Context) {
+	h.move(c, h.pService.MoveOutOfPot)
+}
+
+func (h *PotHandler) move(c *gin.Context, move func(ctx context.Context, userId, walletId, potId string, amount decimal.Decimal) (*model.Pot, error)) {
+	userId, walletId, potId, ok := potPathParams(c)
+	if !ok {
+		return
+	}
+
+	var req model.AmountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	pot, err := move(c.Request.Context(), userId, walletId, potId, req.Amount)
+	if err != nil {
+		respondPotError(c, err, "failed to move money")
+		return
+	}
+	restjson.ResponseData(c, pot)
+}
+
+// ClosePot returns the money left in the pot to the wallet and closes the pot.
+// DELETE /v1/user/{userId}/wallet/{walletId}/pots/{potId}
+func (h *PotHandler) ClosePot(c *gin.Context) {
+	userId, walletId, potId, ok := potPathParams(c)
+	if !ok {
+		return
+	}
+
+	pot, err := h.pService.ClosePot(c.Request.Context(), userId, walletId, potId)
+	if err != nil {
+		respondPotError(c, err, "failed to close pot")
+		return
+	}
+	restjson.ResponseData(c, pot)
+}
+
+func potPathParams(c *gin.Context) (string, string, string, bool) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+	potId := c.Param("potId")
+
+	if userId == "" || walletId == "" || potId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId, walletId or potId is invalid in path"))
+		return "", "", "", false
+	}
+	return userId, walletId, potId, true
+}
+
+func respondPotError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repo.ErrWalletNotFound), errors.Is(err, repo.ErrPotNotFound):
+		restjson.ResponseError(c, http.StatusNotFound, err)
+	case errors.Is(err, repo.ErrWalletForbidden):
+		restjson.ResponseError(c, http.StatusForbidden, err)
+	case errors.Is(err, repo.ErrPotNameTaken), errors.Is(err, repo.ErrPotClosed):
+		restjson.ResponseError(c, http.StatusConflict, err)
+	case errors.Is(err, service.ErrInvalidPot), errors.Is(err, repo.ErrInsufficientFunds):
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+	default:
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New(fallback))
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type ScheduledTransferService interface {
+	CreateScheduledTransfer(ctx context.Context, userId, walletId string, req model.ScheduledTransferRequest) (*model.ScheduledTransfer, error)
+	ListScheduledTransfers(ctx context.Context, userId, walletId string) ([]model.ScheduledTransfer, error)
+	GetScheduledTransfer(ctx context.Context, userId, walletId, scheduleId string) (*model.ScheduledTransfer, error)
+	CancelScheduledTransfer(ctx context.Context, userId, walletId, scheduleId string) error
+}
+
+func NewScheduledTransferImpl(sService ScheduledTransferService) *ScheduledTransferHandler {
+	return &ScheduledTransferHandler{sService}
+}
+
+type ScheduledTransferHandler struct {
+	sService ScheduledTransferService
+}
+
+// CreateScheduledTransfer creates a standing order from the wallet.
+// POST /v1/user/{userId}/wallet/{walletId}/schedules
+func (h *ScheduledTransferHandler) CreateScheduledTransfer(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	var req model.ScheduledTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	s, err := h.sService.CreateScheduledTransfer(c.Request.Context(), userId, walletId, req)
+	if err != nil {
+		if errors.Is(err, repo.ErrWalletNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repo.ErrWalletForbidden) {
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		} else if errors.Is(err, service.ErrInvalidScheduledTransfer) {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to create scheduled transfer"))
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, restjson.Response{Code: http.StatusCreated, Data: s})
+}
+
+// ListScheduledTransfers lists the standing orders of the wallet.
+// GET /v1/user/{userId}/wallet/{walletId}/schedules
+func (h *ScheduledTransferHandler) ListScheduledTransfers(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	schedules, err := h.sService.ListScheduledTransfers(c.Request.Context(), userId, walletId)
+	if err != nil {
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve scheduled transfers"))
+		return
+	}
+	restjson.ResponseData(c, schedules)
+}
+
+// GetScheduledTransfer returns a standing order with the outcome of each of its runs.
+// GET /v1/user/{userId}/wallet/{walletId}/schedules/{scheduleId}
+func (h *ScheduledTransferHandler) GetScheduledTransfer(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+	scheduleId := c.Param("scheduleId")
+
+	if userId == "" || walletId == "" || scheduleId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId, walletId or scheduleId is invalid in path"))
+		return
+	}
+
+	s, err := h.sService.GetScheduledTransfer(c.Request.Context(), userId, walletId, scheduleId)
+	if err != nil {
+		if errors.Is(err, repo.ErrScheduledTransferNotFound) || errors.Is(err, repo.ErrWalletNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repo.ErrWalletForbidden) {
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve scheduled transfer"))
+		}
+		return
+	}
+	restjson.ResponseData(c, s)
+}
+
+// CancelScheduledTransfer stops an active standing order.
+// DELETE /v1/user/{userId}/wallet/{walletId}/schedules/{scheduleId}
+func (h *ScheduledTransferHandler) CancelScheduledTransfer(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+	scheduleId := c.Param("scheduleId")
+
+	if userId == "" || walletId == "" || scheduleId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId, walletId or scheduleId is invalid in path"))
+		return
+	}
+
+	if err := h.sService.CancelScheduledTransfer(c.Request.Context(), userId, walletId, scheduleId); err != nil {
+		if errors.Is(err, repo.ErrScheduledTransferNotFound) || errors.Is(err, repo.ErrWalletNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repo.ErrWalletForbidden) {
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to cancel scheduled transfer"))
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
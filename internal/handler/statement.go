@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type StatementService interface {
+	GenerateCamt053(ctx context.Context, userId, walletId string, from, to time.Time) ([]byte, error)
+}
+
+func NewStatementImpl(sService StatementService) *StatementHandler {
+	return &StatementHandler{sService}
+}
+
+type StatementHandler struct {
+	sService StatementService
+}
+
+// GetCamt053Statement returns an ISO 20022 camt.053 statement of a wallet as XML.
+// Both dates are inclusive calendar days in UTC.
+// GET /v1/user/{userId}/wallet/{walletId}/statement/camt053?from=YYYY-MM-DD&to=YYYY-MM-DD
+func (h *StatementHandler) GetCamt053Statement(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	from, err := time.Parse(time.DateOnly, c.Query("from"))
+	if err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, fmt.Errorf("from must be a date formatted as YYYY-MM-DD"))
+		return
+	}
+	to, err := time.Parse(time.DateOnly, c.Query("to"))
+	if err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, fmt.Errorf("to must be a date formatted as YYYY-MM-DD"))
+		return
+	}
+
+	doc, err := h.sService.GenerateCamt053(c.Request.Context(), userId, walletId, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		if errors.Is(err, repo.ErrWalletNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repo.ErrWalletForbidden) {
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		} else if errors.Is(err, service.ErrInvalidStatementPeriod) {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to generate statement"))
+		}
+		return
+	}
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", doc)
+}
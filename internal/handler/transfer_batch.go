@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type TransferBatchService interface {
+	CreateTransferBatch(ctx context.Context, userId, walletId string, mode model.TransferBatchMode, rows []model.TransferBatchRow) (*model.TransferBatch, error)
+	GetTransferBatch(ctx context.Context, userId, walletId, batchId string) (*model.TransferBatch, error)
+}
+
+func NewTransferBatchImpl(bService TransferBatchService) *TransferBatchHandler {
+	return &TransferBatchHandler{bService}
+}
+
+type TransferBatchHandler struct {
+	bService TransferBatchService
+}
+
+// CreateTransferBatch accepts a batch of transfers from one source wallet and starts executing it.
+// The body is either a model.TransferBatchRequest in JSON, or a CSV file with the columns
+// destination_wallet_id,amount,reference sent as text/csv or as the "file" field of a multipart form.
+// For CSV uploads the mode is taken from the "mode" query or form parameter.
+// The batch runs asynchronously: the response is 202 Accepted and the batch can be polled.
+// POST /v1/user/{userId}/wallet/{walletId}/transfer-batches
+func (h *TransferBatchHandler) CreateTransferBatch(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	var req model.TransferBatchRequest
+	switch c.ContentType() {
+	case "text/csv":
+		rows, err := parseTransferBatchCSV(c.Request.Body)
+		if err != nil {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+			return
+		}
+		req = model.TransferBatchRequest{Mode: model.TransferBatchMode(c.Query("mode")), Items: rows}
+	case "multipart/form-data":
+		file, err := c.FormFile("file")
+		if err != nil {
+			restjson.ResponseError(c, http.StatusBadRequest, errors.New("multipart upload must contain a CSV \"file\" field"))
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+			return
+		}
+		defer f.Close()
+		rows, err := parseTransferBatchCSV(f)
+		if err != nil {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+			return
+		}
+		req = model.TransferBatchRequest{Mode: model.TransferBatchMode(c.DefaultPostForm("mode", c.Query("mode"))), Items: rows}
+	default:
+		if err := c.ShouldBindJSON(&req); err != nil {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	batch, err := h.bService.CreateTransferBatch(c.Request.Context(), userId, walletId, req.Mode, req.Items)
+	if err != nil {
+		if errors.Is(err, repo.ErrWalletNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repo.ErrWalletForbidden) {
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		} else if errors.Is(err, service.ErrInvalidTransferBatch) {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to create transfer batch"))
+		}
+		return
+	}
+	c.JSON(http.StatusAccepted, restjson.Response{Code: http.StatusAccepted, Data: batch})
+}
+
+// GetTransferBatch returns a batch with the per-row results so far.
+// GET /v1/user/{userId}/wallet/{walletId}/transfer-batches/{batchId}
+func (h *TransferBatchHandler) GetTransferBatch(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+	batchId := c.Param("batchId")
+
+	if userId == "" || walletId == "" || batchId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId, walletId or batchId is invalid in path"))
+		return
+	}
+
+	batch, err := h.bService.GetTransferBatch(c.Request.Context(), userId, walletId, batchId)
+	if err != nil {
+		if errors.Is(err, repo.ErrTransferBatchNotFound) || errors.Is(err, repo.ErrWalletNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repo.ErrWalletForbidden) {
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve transfer batch"))
+		}
+		return
+	}
+	restjson.ResponseData(c, batch)
+}
+
+// parseTransferBatchCSV reads destination_wallet_id,amount,reference rows. A header row is optional.
+func parseTransferBatchCSV(r io.Reader) ([]model.TransferBatchRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "destination_wallet_id") {
+		records = records[1:]
+	}
+
+	rows := make([]model.TransferBatchRow, 0, len(records))
+	for i, record := range records {
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("CSV row %d: expected destination_wallet_id,amount[,reference]", i+1)
+		}
+		amount, err := decimal.NewFromString(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("CSV row %d: invalid amount", i+1)
+		}
+		row := model.TransferBatchRow{DestinationWalletID: strings.TrimSpace(record[0]), Amount: amount}
+		if len(record) == 3 {
+			row.Reference = strings.TrimSpace(record[2])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
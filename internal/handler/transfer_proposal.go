@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type TransferProposalService interface {
+	SetApprovalPolicy(ctx context.Context, userId, walletId string, req model.ApprovalPolicyRequest) (*model.ApprovalPolicy, error)
+	GetApprovalPolicy(ctx context.Context, userId, walletId string) (*model.ApprovalPolicy, error)
+	DeleteApprovalPolicy(ctx context.Context, userId, walletId string) error
+	ListTransferProposals(ctx context.Context, userId, walletId string, status model.TransferProposalStatus) ([]model.TransferProposal, error)
+	GetTransferProposal(ctx context.Context, userId, walletId, proposalId string) (*model.TransferProposal, error)
+	ApproveTransferProposal(ctx context.Context, userId, walletId, proposalId string) (*model.TransferProposal, error)
+	CancelTransferProposal(ctx context.Context, userId, walletId, proposalId string) (*model.TransferProposal, error)
+}
+
+func NewTransferProposalImpl(pService TransferProposalService) *TransferProposalHandler {
+	return &TransferProposalHandler{pService}
+}
+
+type TransferProposalHandler struct {
+	pService TransferProposalService
+}
+
+// SetApprovalPolicy sets the threshold above which transfers from the wallet need approvals, and how many.
+// PUT /v1/user/{userId}/wallet/{walletId}/approval-policy
+func (h *TransferProposalHandler) SetApprovalPolicy(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	var req model.ApprovalPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	policy, err := h.pService.SetApprovalPolicy(c.Request.Context(), userId, walletId, req)
+	if err != nil {
+		respondTransferProposalError(c, err, "failed to set approval policy")
+		return
+	}
+	restjson.ResponseData(c, policy)
+}
+
+// GetApprovalPolicy returns the approval policy of the wallet.
+// GET /v1/user/{userId}/wallet/{walletId}/approval-policy
+func (h *TransferProposalHandler) GetApprovalPolicy(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	policy, err := h.pService.GetApprovalPolicy(c.Request.Context(), userId, walletId)
+	if err != nil {
+		respondTransferProposalError(c, err, "failed to retrieve approval policy")
+		return
+	}
+	restjson.ResponseData(c, policy)
+}
+
+// DeleteApprovalPolicy lets transfers from the wallet through without approvals again.
+// DELETE /v1/user/{userId}/wallet/{walletId}/approval-policy
+func (h *TransferProposalHandler) DeleteApprovalPolicy(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	if err := h.pService.DeleteApprovalPolicy(c.Request.Context(), userId, walletId); err != nil {
+		respondTransferProposalError(c, err, "failed to delete approval policy")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListTransferProposals lists the transfer proposals of the wallet, optionally filtered by ?status=.
+// GET /v1/user/{userId}/wallet/{walletId}/transfer-proposals
+func (h *TransferProposalHandler) ListTransferProposals(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	status := model.TransferProposalStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		restjson.ResponseError(c, http.StatusBadRequest, fmt.Errorf("unknown status %q", status))
+		return
+	}
+
+	proposals, err := h.pService.ListTransferProposals(c.Request.Context(), userId, walletId, status)
+	if err != nil {
+		respondTransferProposalError(c, err, "failed to retrieve transfer proposals")
+		return
+	}
+	restjson.ResponseData(c, proposals)
+}
+
+// GetTransferProposal returns a transfer proposal with its approvals.
+// GET /v1/user/{userId}/wallet/{walletId}/transfer-proposals/{proposalId}
+func (h *TransferProposalHandler) GetTransferProposal(c *gin.Context) {
+	userId, walletId, proposalId, ok := transferProposalPathParams(c)
+	if !ok {
+		return
+	}
+
+	proposal, err := h.pService.GetTransferProposal(c.Request.Context(), userId, walletId, proposalId)
+	if err != nil {
+		respondTransferProposalError(c, err, "failed to retrieve transfer proposal")
+		return
+	}
+	restjson.ResponseData(c, proposal)
+}
+
+// ApproveTransferProposal signs off on a transfer proposal. The approval that reaches the quorum executes the transfer.
+// POST /v1/user/{userId}/wallet/{walletId}/transfer-proposals/{proposalId}/approve
+func (h *TransferProposalHandler) ApproveTransferProposal(c *gin.Context) {
+	userId, walletId, proposalId, ok := transferProposalPathParams(c)
+	if !ok {
+		return
+	}
+
+	proposal, err := h.pService.ApproveTransferProposal(c.Request.Context(), userId, walletId, proposalId)
+	if err != nil {
+		respondTransferProposalError(c, err, "failed to approve transfer proposal")
+		return
+	}
+	restjson.ResponseData(c, proposal)
+}
+
+// CancelTransferProposal withdraws a pending transfer proposal and releases the funds it holds.
+// POST /v1/user/{userId}/wallet/{walletId}/transfer-proposals/{proposalId}/cancel
+func (h *TransferProposalHandler) CancelTransferProposal(c *gin.Context) {
+	userId, walletId, proposalId, ok := transferProposalPathParams(c)
+	if !ok {
+		return
+	}
+
+	proposal, err := h.pService.CancelTransferProposal(c.Request.Context(), userId, walletId, proposalId)
+	if err != nil {
+		respondTransferProposalError(c, err, "failed to cancel transfer proposal")
+		return
+	}
+	restjson.ResponseData(c, proposal)
+}
+
+func transferProposalPathParams(c *gin.Context) (string, string, string, bool) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+	proposalId := c.Param("proposalId")
+
+	if userId == "" || walletId == "" || proposalId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId, walletId or proposalId is invalid in path"))
+		return "", "", "", false
+	}
+	return userId, walletId, proposalId, true
+}
+
+// respondTransferProposalError maps the errors of approval policies and transfer proposals to a response.
+func respondTransferProposalError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repo.ErrWalletNotFound), errors.Is(err, repo.ErrApprovalPolicyNotFound),
+		errors.Is(err, repo.ErrTransferProposalNotFound):
+		restjson.ResponseError(c, http.StatusNotFound, err)
+	case errors.Is(err, repo.ErrWalletForbidden), errors.Is(err, repo.ErrSelfApproval):
+		restjson.ResponseError(c, http.StatusForbidden, err)
+	case errors.Is(err, repo.ErrTransferProposalNotPending), errors.Is(err, repo.ErrAlreadyApproved),
+		errors.Is(err, repo.ErrTooFewApprovers):
+		restjson.ResponseError(c, http.StatusConflict, err)
+	case errors.Is(err, repo.ErrTransferProposalExpired):
+		restjson.ResponseError(c, http.StatusGone, err)
+	case errors.Is(err, repo.ErrWalletFrozen):
+		restjson.ResponseError(c, http.StatusLocked, err)
+	case errors.Is(err, repo.ErrWalletDebitBlocked), errors.Is(err, repo.ErrWalletCreditBlocked):
+		restjson.ResponseError(c, http.StatusConflict, err)
+	case errors.Is(err, service.ErrInvalidTransferProposal), errors.Is(err, repo.ErrInsufficientFunds):
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+	default:
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New(fallback))
+	}
+}
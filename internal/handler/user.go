@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type UserService interface {
+	LookupRecipient(ctx context.Context, email, handle string) (*model.RecipientPreview, error)
+	SetHandle(ctx context.Context, userId, handle string) (*model.User, error)
+	SetDefaultWallet(ctx context.Context, userId, walletId string) error
+}
+
+func NewUserImpl(uService UserService) *UserHandler {
+	return &UserHandler{uService}
+}
+
+type UserHandler struct {
+	uService UserService
+}
+
+// LookupRecipient returns the masked name of the user behind ?email= or ?handle=,
+// so the sender can confirm who they are paying before sending.
+// GET /v1/recipients/lookup
+func (h *UserHandler) LookupRecipient(c *gin.Context) {
+	preview, err := h.uService.LookupRecipient(c.Request.Context(), c.Query("email"), c.Query("handle"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRecipient) {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+		} else if errors.Is(err, repo.ErrRecipientNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to look up recipient"))
+		}
+		return
+	}
+	restjson.ResponseData(c, preview)
+}
+
+// SetHandle claims the handle other users can pay the user by.
+// PUT /v1/user/{userId}/handle
+func (h *UserHandler) SetHandle(c *gin.Context) {
+	userId := c.Param("userId")
+	if userId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId is invalid in path"))
+		return
+	}
+
+	var req model.HandleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.uService.SetHandle(c.Request.Context(), userId, req.Handle)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidHandle) {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+		} else if errors.Is(err, repo.ErrUserNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repo.ErrHandleTaken) {
+			restjson.ResponseError(c, http.StatusConflict, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to set handle"))
+		}
+		return
+	}
+	restjson.ResponseData(c, user)
+}
+
+// SetDefaultWallet makes the wallet the one that transfers addressed to the user by email or handle are paid into.
+// PUT /v1/user/{userId}/wallet/{walletId}/default
+func (h *UserHandler) SetDefaultWallet(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	if err := h.uService.SetDefaultWallet(c.Request.Context(), userId, walletId); err != nil {
+		if errors.Is(err, repo.ErrWalletNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repo.ErrWalletForbidden) {
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to set default wallet"))
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
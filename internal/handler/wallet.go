@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/kylenguyen/wallet-app/internal/model"
 	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
 	"github.com/kylenguyen/wallet-app/pkg/restjson"
 	"github.com/shopspring/decimal"
 	"net/http"
@@ -19,14 +20,28 @@ type WalletService interface {
 	Transfer(ctx context.Context, sourceUserId, sourceWalletId, destinationWalletId string, amount decimal.Decimal) (*model.Transaction, error)
 }
 
-func NewWalletImpl(wService WalletService) *WalletHandler {
-	return &WalletHandler{wService}
+// RecipientResolver finds the wallet a transfer addressed to an email or handle is paid into.
+type RecipientResolver interface {
+	ResolveRecipientWallet(ctx context.Context, email, handle string) (string, error)
+}
+
+// TransferProposer turns a transfer that needs approval into a pending transfer proposal.
+type TransferProposer interface {
+	ProposeTransfer(ctx context.Context, userId, walletId, destinationWalletId string, amount decimal.Decimal) (*model.TransferProposal, error)
+}
+
+func NewWalletImpl(wService WalletService, rResolver RecipientResolver, tProposer TransferProposer) *WalletHandler {
+	return &WalletHandler{wService, rResolver, tProposer}
 }
 
 type WalletHandler struct {
-	wService WalletService
+	wService  WalletService
+	rResolver RecipientResolver
+	tProposer TransferProposer
 }
 
+// GetWalletInfo handles fetching a wallet the user is a member of.
+// GET /api/v1/users/{user_id}/wallets/{wallet_id}
 func (h *WalletHandler) GetWalletInfo(c *gin.Context) {
 	userId := c.Param("userId")
 	walletId := c.Param("walletId")
@@ -39,7 +54,11 @@ func (h *WalletHandler) GetWalletInfo(c *gin.Context) {
 	result, err := h.wService.GetWalletInfo(c.Request.Context(), userId, walletId)
 
 	if err != nil {
-		restjson.ResponseError(c, http.StatusInternalServerError, err)
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve wallet"))
+		return
+	}
+	if result == nil {
+		restjson.ResponseError(c, http.StatusNotFound, repo.ErrWalletNotFound)
 		return
 	}
 	restjson.ResponseData(c, result)
@@ -58,7 +77,11 @@ func (h *WalletHandler) GetWalletTransactionsByWalletID(c *gin.Context) {
 
 	transactions, err := h.wService.GetWalletTransactionsByWalletID(c.Request.Context(), userId, walletId)
 	if err != nil {
-		restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve wallet transactions"))
+		if errors.Is(err, repo.ErrWalletNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve wallet transactions"))
+		}
 		return
 	}
 
@@ -91,8 +114,16 @@ func (h *WalletHandler) Deposit(c *gin.Context) {
 
 	transaction, err := h.wService.Deposit(c.Request.Context(), userId, walletId, req.Amount)
 	if err != nil {
-		if errors.Is(err, repo.ErrWalletNotFound) {
+		if errors.Is(err, service.ErrInvalidAmount) {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+		} else if errors.Is(err, repo.ErrWalletNotFound) {
 			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repo.ErrWalletForbidden) {
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		} else if errors.Is(err, repo.ErrWalletFrozen) {
+			restjson.ResponseError(c, http.StatusLocked, err)
+		} else if errors.Is(err, repo.ErrWalletDebitBlocked) || errors.Is(err, repo.ErrWalletCreditBlocked) {
+			restjson.ResponseError(c, http.StatusConflict, err)
 		} else {
 			// log.Printf("Error in Deposit: %v", err)
 			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to process deposit"))
@@ -121,8 +152,16 @@ func (h *WalletHandler) Withdraw(c *gin.Context) {
 
 	transaction, err := h.wService.Withdraw(c.Request.Context(), userId, walletId, req.Amount)
 	if err != nil {
-		if errors.Is(err, repo.ErrWalletNotFound) {
+		if errors.Is(err, service.ErrInvalidAmount) {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+		} else if errors.Is(err, repo.ErrWalletNotFound) {
 			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else if errors.Is(err, repo.ErrWalletForbidden) {
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		} else if errors.Is(err, repo.ErrWalletFrozen) {
+			restjson.ResponseError(c, http.StatusLocked, err)
+		} else if errors.Is(err, repo.ErrWalletDebitBlocked) || errors.Is(err, repo.ErrWalletCreditBlocked) {
+			restjson.ResponseError(c, http.StatusConflict, err)
 		} else if errors.Is(err, repo.ErrInsufficientFunds) {
 			restjson.ResponseError(c, http.StatusBadRequest, err) // Or http.StatusUnprocessableEntity
 		} else {
@@ -134,7 +173,8 @@ func (h *WalletHandler) Withdraw(c *gin.Context) {
 	restjson.ResponseData(c, transaction)
 }
 
-// Transfer handles transferring funds between wallets.
+// Transfer handles transferring funds between wallets. A transfer the source wallet's approval policy holds back
+// becomes a pending transfer proposal and is answered with 202 Accepted.
 // POST /api/v1/users/{user_id}/wallets/{from_wallet_id}/transfer
 func (h *WalletHandler) Transfer(c *gin.Context) {
 	sourceUserId := c.Param("userId") // Renamed from user_id in path to sourceUserId for clarity
@@ -151,15 +191,32 @@ func (h *WalletHandler) Transfer(c *gin.Context) {
 		return
 	}
 
-	if sourceWalletId == req.DestinationWalletID {
+	destinationWalletId, ok := h.resolveDestination(c, req)
+	if !ok {
+		return
+	}
+
+	if sourceWalletId == destinationWalletId {
 		restjson.ResponseError(c, http.StatusBadRequest, errors.New("source and destination wallets cannot be the same"))
 		return
 	}
 
-	transaction, err := h.wService.Transfer(c.Request.Context(), sourceUserId, sourceWalletId, req.DestinationWalletID, req.Amount)
+	transaction, err := h.wService.Transfer(c.Request.Context(), sourceUserId, sourceWalletId, destinationWalletId, req.Amount)
+	if errors.Is(err, repo.ErrApprovalRequired) {
+		h.proposeTransfer(c, sourceUserId, sourceWalletId, destinationWalletId, req.Amount)
+		return
+	}
 	if err != nil {
-		if errors.Is(err, repo.ErrWalletNotFound) { // This could be source or destination
+		if errors.Is(err, service.ErrInvalidAmount) {
+			restjson.ResponseError(c, http.StatusBadRequest, err)
+		} else if errors.Is(err, repo.ErrWalletNotFound) { // This could be source or destination
 			restjson.ResponseError(c, http.StatusNotFound, err) // Consider more specific error messages if needed
+		} else if errors.Is(err, repo.ErrWalletForbidden) {
+			restjson.ResponseError(c, http.StatusForbidden, err)
+		} else if errors.Is(err, repo.ErrWalletFrozen) {
+			restjson.ResponseError(c, http.StatusLocked, err)
+		} else if errors.Is(err, repo.ErrWalletDebitBlocked) || errors.Is(err, repo.ErrWalletCreditBlocked) {
+			restjson.ResponseError(c, http.StatusConflict, err)
 		} else if errors.Is(err, repo.ErrInsufficientFunds) {
 			restjson.ResponseError(c, http.StatusBadRequest, err)
 		} else {
@@ -170,3 +227,42 @@ func (h *WalletHandler) Transfer(c *gin.Context) {
 	}
 	restjson.ResponseData(c, transaction)
 }
+
+// proposeTransfer answers a transfer that needs approval with the pending proposal it became.
+func (h *WalletHandler) proposeTransfer(c *gin.Context, userId, walletId, destinationWalletId string, amount decimal.Decimal) {
+	proposal, err := h.tProposer.ProposeTransfer(c.Request.Context(), userId, walletId, destinationWalletId, amount)
+	if err != nil {
+		respondTransferProposalError(c, err, "failed to propose transfer")
+		return
+	}
+	c.JSON(http.StatusAccepted, restjson.Response{Code: http.StatusAccepted, Data: proposal})
+}
+
+// resolveDestination returns the destination wallet of a transfer, looking up the recipient's default wallet
+// when the transfer is addressed by email or handle. It writes the error response itself when it fails.
+func (h *WalletHandler) resolveDestination(c *gin.Context, req model.TransferRequest) (string, bool) {
+	set := 0
+	for _, v := range []string{req.DestinationWalletID, req.DestinationEmail, req.DestinationHandle} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("exactly one of destination_wallet_id, destination_email or destination_handle is required"))
+		return "", false
+	}
+	if req.DestinationWalletID != "" {
+		return req.DestinationWalletID, true
+	}
+
+	walletId, err := h.rResolver.ResolveRecipientWallet(c.Request.Context(), req.DestinationEmail, req.DestinationHandle)
+	if err != nil {
+		if errors.Is(err, repo.ErrRecipientNotFound) || errors.Is(err, repo.ErrWalletNotFound) {
+			restjson.ResponseError(c, http.StatusNotFound, err)
+		} else {
+			restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to resolve recipient"))
+		}
+		return "", false
+	}
+	return walletId, true
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
+)
+
+type WalletMemberService interface {
+	ListWalletMembers(ctx context.Context, userId, walletId string) ([]model.WalletMember, error)
+	InviteWalletMember(ctx context.Context, userId, walletId string, req model.WalletMemberRequest) (*model.WalletMember, error)
+	UpdateWalletMemberRole(ctx context.Context, userId, walletId, memberId string, req model.WalletRoleRequest) (*model.WalletMember, error)
+	RemoveWalletMember(ctx context.Context, userId, walletId, memberId string) error
+	ListWalletInvitations(ctx context.Context, userId string) ([]model.WalletMember, error)
+	AcceptWalletInvitation(ctx context.Context, userId, walletId string) (*model.WalletMember, error)
+}
+
+func NewWalletMemberImpl(mService WalletMemberService) *WalletMemberHandler {
+	return &WalletMemberHandler{mService}
+}
+
+type WalletMemberHandler struct {
+	mService WalletMemberService
+}
+
+// ListWalletMembers lists the members of the wallet and the users invited to it.
+// GET /v1/user/{userId}/wallet/{walletId}/members
+func (h *WalletMemberHandler) ListWalletMembers(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	members, err := h.mService.ListWalletMembers(c.Request.Context(), userId, walletId)
+	if err != nil {
+		respondWalletMemberError(c, err, "failed to retrieve wallet members")
+		return
+	}
+	restjson.ResponseData(c, members)
+}
+
+// InviteWalletMember invites another user to the wallet with a role. Only owners may invite.
+// POST /v1/user/{userId}/wallet/{walletId}/members
+func (h *WalletMemberHandler) InviteWalletMember(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	var req model.WalletMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	member, err := h.mService.InviteWalletMember(c.Request.Context(), userId, walletId, req)
+	if err != nil {
+		respondWalletMemberError(c, err, "failed to invite wallet member")
+		return
+	}
+	c.JSON(http.StatusCreated, restjson.Response{Code: http.StatusCreated, Data: member})
+}
+
+// UpdateWalletMemberRole changes the role of a member. Only owners may do it.
+// PUT /v1/user/{userId}/wallet/{walletId}/members/{memberId}
+func (h *WalletMemberHandler) UpdateWalletMemberRole(c *gin.Context) {
+	userId, walletId, memberId, ok := walletMemberPathParams(c)
+	if !ok {
+		return
+	}
+
+	var req model.WalletRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	member, err := h.mService.UpdateWalletMemberRole(c.Request.Context(), userId, walletId, memberId, req)
+	if err != nil {
+		respondWalletMemberError(c, err, "failed to update wallet member role")
+		return
+	}
+	restjson.ResponseData(c, member)
+}
+
+// RemoveWalletMember removes a member or withdraws an invitation. Members may also remove themselves.
+// DELETE /v1/user/{userId}/wallet/{walletId}/members/{memberId}
+func (h *WalletMemberHandler) RemoveWalletMember(c *gin.Context) {
+	userId, walletId, memberId, ok := walletMemberPathParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.mService.RemoveWalletMember(c.Request.Context(), userId, walletId, memberId); err != nil {
+		respondWalletMemberError(c, err, "failed to remove wallet member")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListWalletInvitations lists the wallets the user has been invited to.
+// GET /v1/user/{userId}/wallet-invitations
+func (h *WalletMemberHandler) ListWalletInvitations(c *gin.Context) {
+	userId := c.Param("userId")
+
+	if userId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId is invalid in path"))
+		return
+	}
+
+	invitations, err := h.mService.ListWalletInvitations(c.Request.Context(), userId)
+	if err != nil {
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New("failed to retrieve wallet invitations"))
+		return
+	}
+	restjson.ResponseData(c, invitations)
+}
+
+// AcceptWalletInvitation makes the user an active member of the wallet they were invited to.
+// POST /v1/user/{userId}/wallet-invitations/{walletId}/accept
+func (h *WalletMemberHandler) AcceptWalletInvitation(c *gin.Context) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+
+	if userId == "" || walletId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId or walletId is invalid in path"))
+		return
+	}
+
+	member, err := h.mService.AcceptWalletInvitation(c.Request.Context(), userId, walletId)
+	if err != nil {
+		respondWalletMemberError(c, err, "failed to accept wallet invitation")
+		return
+	}
+	restjson.ResponseData(c, member)
+}
+
+func walletMemberPathParams(c *gin.Context) (string, string, string, bool) {
+	userId := c.Param("userId")
+	walletId := c.Param("walletId")
+	memberId := c.Param("memberId")
+
+	if userId == "" || walletId == "" || memberId == "" {
+		restjson.ResponseError(c, http.StatusBadRequest, errors.New("userId, walletId or memberId is invalid in path"))
+		return "", "", "", false
+	}
+	return userId, walletId, memberId, true
+}
+
+func respondWalletMemberError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repo.ErrWalletNotFound), errors.Is(err, repo.ErrWalletMemberNotFound), errors.Is(err, repo.ErrUserNotFound):
+		restjson.ResponseError(c, http.StatusNotFound, err)
+	case errors.Is(err, repo.ErrWalletForbidden):
+		restjson.ResponseError(c, http.StatusForbidden, err)
+	case errors.Is(err, repo.ErrWalletMemberExists), errors.Is(err, repo.ErrLastWalletOwner):
+		restjson.ResponseError(c, http.StatusConflict, err)
+	case errors.Is(err, service.ErrInvalidWalletMember):
+		restjson.ResponseError(c, http.StatusBadRequest, err)
+	default:
+		restjson.ResponseError(c, http.StatusInternalServerError, errors.New(fallback))
+	}
+}
@@ -0,0 +1,423 @@
+// Package loadgen drives a mix of deposit, withdraw, transfer and read traffic against a running wallet API and
+// reports throughput, latency percentiles and errors, then checks that no money was created or lost.
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Op is a kind of request the generator sends.
+type Op string
+
+const (
+	OpDeposit  Op = "deposit"
+	OpWithdraw Op = "withdraw"
+	OpTransfer Op = "transfer"
+	OpRead     Op = "read"
+)
+
+// Ops lists every Op in the order reports show them.
+var Ops = []Op{OpDeposit, OpWithdraw, OpTransfer, OpRead}
+
+// ErrInvalidOptions indicates options Run cannot generate traffic with.
+var ErrInvalidOptions = errors.New("invalid load options")
+
+// Mix is the relative weight of each Op in the traffic.
+type Mix map[Op]int
+
+// ParseMix parses weights written as "deposit=30,withdraw=20,transfer=40,read=10". Ops left out get no traffic.
+func ParseMix(s string) (Mix, error) {
+	mix := Mix{}
+	total := 0
+	for _, part := range strings.Split(s, ",") {
+		name, weight, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: mix entry %q must be op=weight", ErrInvalidOptions, part)
+		}
+		op := Op(strings.TrimSpace(name))
+		if !op.isValid() {
+			return nil, fmt.Errorf("%w: unknown op %q in mix", ErrInvalidOptions, name)
+		}
+		w, err := strconv.Atoi(strings.TrimSpace(weight))
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("%w: weight of %s must be a non-negative integer", ErrInvalidOptions, op)
+		}
+		mix[op] += w
+		total += w
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w: mix has no traffic", ErrInvalidOptions)
+	}
+	return mix, nil
+}
+
+func (op Op) isValid() bool {
+	for _, o := range Ops {
+		if op == o {
+			return true
+		}
+	}
+	return false
+}
+
+// pick returns an Op with probability proportional to its weight.
+func (m Mix) pick(rng *rand.Rand) Op {
+	total := 0
+	for _, op := range Ops {
+		total += m[op]
+	}
+	n := rng.IntN(total)
+	for _, op := range Ops {
+		if n < m[op] {
+			return op
+		}
+		n -= m[op]
+	}
+	return OpRead
+}
+
+// Wallet is a wallet the traffic uses, with the user it is sent as.
+type Wallet struct {
+	UserID   string
+	WalletID string
+}
+
+// ReadWallets reads wallets from CSV with a header row naming an "id" and a "user_id" column, such as the
+// wallets.csv cmd/seed writes.
+func ReadWallets(r io.Reader) ([]Wallet, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallets: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: wallets file is empty", ErrInvalidOptions)
+	}
+	idCol, userCol := -1, -1
+	for i, name := range rows[0] {
+		switch strings.TrimSpace(name) {
+		case "id":
+			idCol = i
+		case "user_id":
+			userCol = i
+		}
+	}
+	if idCol < 0 || userCol < 0 {
+		return nil, fmt.Errorf("%w: wallets file needs id and user_id columns", ErrInvalidOptions)
+	}
+	wallets := make([]Wallet, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		wallets = append(wallets, Wallet{UserID: row[userCol], WalletID: row[idCol]})
+	}
+	return wallets, nil
+}
+
+// Options configures a run.
+type Options struct {
+	// BaseURL is where the API is served, e.g. http://localhost:8080.
+	BaseURL string
+	Wallets []Wallet
+	Mix     Mix
+	// Concurrency is how many requests are in flight at once.
+	Concurrency int
+	// The run stops after Duration, or after Requests requests if that is set.
+	Duration time.Duration
+	Requests int
+	// Rate caps the requests per second across all workers; zero sends them as fast as the server answers.
+	Rate float64
+	// The first HotWallets wallets are hot: HotShare of the requests pick each wallet they use among them, so they
+	// contend for the same rows.
+	HotWallets int
+	HotShare   float64
+	// MaxAmount is the largest amount moved by a request; amounts are drawn in whole cents up to it.
+	MaxAmount decimal.Decimal
+	Seed      uint64
+	Client    *http.Client
+}
+
+func (o *Options) validate() error {
+	switch {
+	case o.BaseURL == "":
+		return fmt.Errorf("%w: a base URL is required", ErrInvalidOptions)
+	case len(o.Wallets) < 2:
+		return fmt.Errorf("%w: at least two wallets are required", ErrInvalidOptions)
+	case o.Concurrency < 1:
+		return fmt.Errorf("%w: concurrency must be at least 1", ErrInvalidOptions)
+	case o.Duration <= 0 && o.Requests <= 0:
+		return fmt.Errorf("%w: a duration or a number of requests is required", ErrInvalidOptions)
+	case o.Rate < 0:
+		return fmt.Errorf("%w: rate must not be negative", ErrInvalidOptions)
+	case o.HotWallets < 0 || o.HotWallets > len(o.Wallets):
+		return fmt.Errorf("%w: hot wallets must be between 0 and the number of wallets", ErrInvalidOptions)
+	case o.HotShare < 0 || o.HotShare > 1:
+		return fmt.Errorf("%w: hot share must be between 0 and 1", ErrInvalidOptions)
+	case o.MaxAmount.LessThan(decimal.New(1, -2)):
+		return fmt.Errorf("%w: max amount must be at least 0.01", ErrInvalidOptions)
+	case len(o.Mix) == 0:
+		return fmt.Errorf("%w: a mix is required", ErrInvalidOptions)
+	}
+	return nil
+}
+
+// Run checks the balances of the wallets, sends traffic until the options or ctx say to stop, checks the balances
+// again and reports. An error is only returned if the run could not start or the balances could not be read.
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+	opts.BaseURL = strings.TrimSuffix(opts.BaseURL, "/")
+	r := &runner{opts: opts, report: newReport()}
+
+	before, err := r.totalBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read balances before the run: %w", err)
+	}
+
+	runCtx := ctx
+	if opts.Duration > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+	var tick <-chan time.Time
+	if opts.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.Rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewPCG(opts.Seed, uint64(i)))
+			for r.next() {
+				if tick != nil {
+					select {
+					case <-tick:
+					case <-runCtx.Done():
+						return
+					}
+				}
+				if runCtx.Err() != nil {
+					return
+				}
+				// A request in flight when the run ends completes, so its outcome is known.
+				r.send(ctx, rng)
+			}
+		}(i)
+	}
+	wg.Wait()
+	r.report.Elapsed = time.Since(start)
+
+	// Read the balances even if ctx was cancelled to stop the run early.
+	after, err := r.totalBalance(context.WithoutCancel(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read balances after the run: %w", err)
+	}
+	r.report.Conservation.Before = before
+	r.report.Conservation.After = after
+	r.report.Conservation.Expected = before.Add(r.report.Conservation.Deposited).
+		Sub(r.report.Conservation.Withdrawn).Sub(r.report.Conservation.Fees)
+	return r.report, nil
+}
+
+type runner struct {
+	opts   Options
+	sent   atomic.Int64
+	report *Report
+}
+
+// next reports whether another request may be sent.
+func (r *runner) next() bool {
+	if r.opts.Requests <= 0 {
+		return true
+	}
+	return r.sent.Add(1) <= int64(r.opts.Requests)
+}
+
+// wallet picks a wallet, among the hot ones with probability HotShare.
+func (r *runner) wallet(rng *rand.Rand) Wallet {
+	hot := r.opts.HotWallets
+	if hot > 0 && (hot == len(r.opts.Wallets) || rng.Float64() < r.opts.HotShare) {
+		return r.opts.Wallets[rng.IntN(hot)]
+	}
+	return r.opts.Wallets[hot+rng.IntN(len(r.opts.Wallets)-hot)]
+}
+
+func (r *runner) amount(rng *rand.Rand) decimal.Decimal {
+	maxCents := r.opts.MaxAmount.Shift(2).IntPart()
+	return decimal.New(1+rng.Int64N(maxCents), -2)
+}
+
+// send sends one request of an Op the mix picks and records how it went.
+func (r *runner) send(ctx context.Context, rng *rand.Rand) {
+	op := r.opts.Mix.pick(rng)
+	w := r.wallet(rng)
+	path := fmt.Sprintf("/v1/user/%s/wallet/%s", w.UserID, w.WalletID)
+
+	var (
+		method = http.MethodPost
+		body   any
+		amount = r.amount(rng)
+	)
+	switch op {
+	case OpDeposit:
+		path += "/deposit"
+		body = map[string]string{"amount": amount.String()}
+	case OpWithdraw:
+		path += "/withdraw"
+		body = map[string]string{"amount": amount.String()}
+	case OpTransfer:
+		// With a single hot wallet taking every pick the destination would never differ; fall back to any wallet.
+		destination := r.wallet(rng)
+		for tries := 1; destination.WalletID == w.WalletID; tries++ {
+			if tries < 8 {
+				destination = r.wallet(rng)
+			} else {
+				destination = r.opts.Wallets[rng.IntN(len(r.opts.Wallets))]
+			}
+		}
+		path += "/transfer"
+		body = map[string]string{"amount": amount.String(), "destination_wallet_id": destination.WalletID}
+	case OpRead:
+		method = http.MethodGet
+	}
+
+	start := time.Now()
+	status, resp, err := r.do(ctx, method, path, body)
+	latency := time.Since(start)
+	if err != nil && ctx.Err() != nil {
+		// Cut off by the caller, not by the server; the outcome is unknown.
+		r.report.record(op, latency, 0, "cancelled", false)
+		if op != OpRead {
+			r.report.uncertain()
+		}
+		return
+	}
+	if err != nil {
+		r.report.record(op, latency, 0, err.Error(), false)
+		if op != OpRead {
+			r.report.uncertain()
+		}
+		return
+	}
+
+	ok := status == http.StatusOK || (op == OpTransfer && status == http.StatusAccepted)
+	r.report.record(op, latency, status, resp.Message, ok)
+	switch {
+	case status >= http.StatusInternalServerError:
+		// A server error may have come after the money moved.
+		if op != OpRead {
+			r.report.uncertain()
+		}
+	case status == http.StatusOK:
+		var fee decimal.Decimal
+		if resp.Data.Fee != nil {
+			fee = resp.Data.Fee.Amount
+		}
+		switch op {
+		case OpDeposit:
+			r.report.moved(amount, decimal.Zero, decimal.Zero)
+		case OpWithdraw:
+			r.report.moved(decimal.Zero, amount, fee)
+		case OpTransfer:
+			r.report.moved(decimal.Zero, decimal.Zero, fee)
+		}
+	}
+}
+
+// response is the part of a restjson response the generator reads.
+type response struct {
+	Message string `json:"message"`
+	Data    struct {
+		Balance decimal.Decimal `json:"balance"`
+		Fee     *struct {
+			Amount decimal.Decimal `json:"amount"`
+		} `json:"fee"`
+	} `json:"data"`
+}
+
+func (r *runner) do(ctx context.Context, method, path string, body any) (int, response, error) {
+	var resp response
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return 0, resp, err
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, r.opts.BaseURL+path, reader)
+	if err != nil {
+		return 0, resp, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := r.opts.Client.Do(req)
+	if err != nil {
+		return 0, resp, err
+	}
+	defer res.Body.Close()
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res.StatusCode, resp, err
+	}
+	if json.Unmarshal(raw, &resp) != nil && res.StatusCode != http.StatusOK {
+		resp.Message = strings.TrimSpace(string(raw))
+	}
+	return res.StatusCode, resp, nil
+}
+
+// totalBalance reads the balance of every wallet, Concurrency at a time, and returns their sum.
+func (r *runner) totalBalance(ctx context.Context) (decimal.Decimal, error) {
+	var (
+		mu       sync.Mutex
+		total    decimal.Decimal
+		firstErr error
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, r.opts.Concurrency)
+	)
+	for _, w := range r.opts.Wallets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(w Wallet) {
+			defer func() { <-sem; wg.Done() }()
+			status, resp, err := r.do(ctx, http.MethodGet, fmt.Sprintf("/v1/user/%s/wallet/%s", w.UserID, w.WalletID), nil)
+			if err == nil && status != http.StatusOK {
+				err = fmt.Errorf("wallet %s: %d %s", w.WalletID, status, resp.Message)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			total = total.Add(resp.Data.Balance)
+		}(w)
+	}
+	wg.Wait()
+	return total, firstErr
+}
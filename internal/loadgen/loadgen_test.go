@@ -0,0 +1,169 @@
+package loadgen_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/config"
+	"github.com/kylenguyen/wallet-app/internal/loadgen"
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/server"
+	"github.com/kylenguyen/wallet-app/internal/service/mocks"
+)
+
+func TestParseMix(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    loadgen.Mix
+		wantErr bool
+	}{
+		{"all ops", "deposit=30,withdraw=20,transfer=40,read=10", loadgen.Mix{loadgen.OpDeposit: 30, loadgen.OpWithdraw: 20, loadgen.OpTransfer: 40, loadgen.OpRead: 10}, false},
+		{"spaces and repeats", " transfer = 1, transfer=2 ", loadgen.Mix{loadgen.OpTransfer: 3}, false},
+		{"unknown op", "deposit=1,refund=1", nil, true},
+		{"missing weight", "deposit", nil, true},
+		{"negative weight", "deposit=-1", nil, true},
+		{"no traffic", "deposit=0,read=0", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadgen.ParseMix(tt.in)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, loadgen.ErrInvalidOptions)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadWallets(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []loadgen.Wallet
+		wantErr bool
+	}{
+		{
+			name: "seed wallets.csv",
+			in:   "id,user_id,name,balance,created_at,updated_at\nw1,u1,Main,10.00,2025-01-01,2025-01-01\nw2,u2,Savings,0.00,2025-01-01,2025-01-01\n",
+			want: []loadgen.Wallet{{UserID: "u1", WalletID: "w1"}, {UserID: "u2", WalletID: "w2"}},
+		},
+		{"columns in any order", "user_id,id\nu1,w1\n", []loadgen.Wallet{{UserID: "u1", WalletID: "w1"}}, false},
+		{"no user_id column", "id,name\nw1,Main\n", nil, true},
+		{"empty", "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadgen.ReadWallets(strings.NewReader(tt.in))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// newAPI serves the wallet API over wallets in memory, each owned by its own user and holding 100.
+func newAPI(t *testing.T, n int) (*httptest.Server, []loadgen.Wallet) {
+	gin.SetMode(gin.TestMode)
+	wr := repo.NewWalletMemory()
+	wallets := make([]loadgen.Wallet, n)
+	for i := range wallets {
+		w := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "load", Balance: decimal.NewFromInt(100)}
+		require.NoError(t, wr.AddWallet(context.Background(), w))
+		wallets[i] = loadgen.Wallet{UserID: w.UserID.String(), WalletID: w.ID.String()}
+	}
+
+	feeRepo := mocks.NewFeeScheduleRepoMock(t)
+	feeRepo.On("GetActiveFeeSchedule", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, repo.ErrFeeScheduleNotFound).Maybe()
+	auditRepo := mocks.NewAuditTrailRepoMock(t)
+	auditRepo.On("RecordAuditTrailEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	logger := zerolog.Nop()
+	cfg := config.Config{
+		ServiceName: "wallet-app-loadgen",
+		Currency:    "USD",
+		FeeVar:      config.FeeVar{WalletID: uuid.NewString()},
+		EscrowVar:   config.EscrowVar{WalletID: uuid.NewString()},
+	}
+	s := server.New(nil, &logger, cfg,
+		server.WithWalletRepo(wr), server.WithFeeScheduleRepo(feeRepo), server.WithAuditTrailRepo(auditRepo))
+	s.UseMiddleware()
+	s.RegisterRoutes()
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return ts, wallets
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name string
+		opts loadgen.Options
+	}{
+		{"uniform", loadgen.Options{Concurrency: 8, Requests: 400}},
+		{"single hot wallet", loadgen.Options{Concurrency: 8, Requests: 400, HotWallets: 1, HotShare: 1}},
+		{"rate limited", loadgen.Options{Concurrency: 4, Duration: 200 * time.Millisecond, Rate: 200}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, wallets := newAPI(t, 5)
+			opts := tt.opts
+			opts.BaseURL = ts.URL
+			opts.Wallets = wallets
+			opts.Mix = loadgen.Mix{loadgen.OpDeposit: 3, loadgen.OpWithdraw: 3, loadgen.OpTransfer: 3, loadgen.OpRead: 1}
+			opts.MaxAmount = decimal.NewFromInt(60)
+			opts.Seed = 7
+
+			report, err := loadgen.Run(context.Background(), opts)
+			require.NoError(t, err)
+
+			total := 0
+			for _, s := range report.Ops {
+				total += s.Requests
+				if s.Requests > 0 {
+					assert.LessOrEqual(t, s.Percentile(50), s.Percentile(99))
+				}
+			}
+			if opts.Requests > 0 {
+				assert.Equal(t, opts.Requests, total)
+			} else {
+				assert.Positive(t, total)
+			}
+			// Overdrawing is the only way a request can fail here
+			for k := range report.Errors {
+				assert.Equal(t, 400, k.Status, "%+v", k)
+				assert.Equal(t, repo.ErrInsufficientFunds.Error(), k.Message[strings.LastIndex(k.Message, ": ")+2:], "%+v", k)
+			}
+			assert.Zero(t, report.Conservation.Uncertain)
+			assert.True(t, report.Conservation.Before.Equal(decimal.NewFromInt(500)))
+			assert.True(t, report.Conservation.Holds(), "after %s, expected %s", report.Conservation.After, report.Conservation.Expected)
+
+			var out strings.Builder
+			report.Write(&out)
+			assert.Contains(t, out.String(), "Balance conservation")
+			assert.Contains(t, out.String(), "OK")
+		})
+	}
+}
+
+func TestRun_InvalidOptions(t *testing.T) {
+	_, err := loadgen.Run(context.Background(), loadgen.Options{BaseURL: "http://localhost", Concurrency: 1, Requests: 1})
+	assert.ErrorIs(t, err, loadgen.ErrInvalidOptions)
+}
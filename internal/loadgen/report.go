@@ -0,0 +1,170 @@
+package loadgen
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Report is what a run measured.
+type Report struct {
+	Elapsed time.Duration
+	Ops     map[Op]*OpStats
+	// Errors counts the failed requests by Op, status and message. A status of zero is a request that got no
+	// response.
+	Errors       map[ErrorKey]int
+	Conservation Conservation
+
+	mu sync.Mutex
+}
+
+// OpStats is how the requests of one Op went.
+type OpStats struct {
+	Requests  int
+	Succeeded int
+	latencies []time.Duration
+}
+
+// ErrorKey groups failed requests.
+type ErrorKey struct {
+	Op      Op
+	Status  int
+	Message string
+}
+
+// Conservation compares the money in the wallets after the run with what the responses say should be there.
+type Conservation struct {
+	Before    decimal.Decimal
+	After     decimal.Decimal
+	Expected  decimal.Decimal
+	Deposited decimal.Decimal
+	Withdrawn decimal.Decimal
+	// Fees is what withdrawals and transfers paid into the fee wallet, which is outside the run.
+	Fees decimal.Decimal
+	// Uncertain counts requests that moved money, or may have, without the generator knowing: server errors and
+	// requests that got no response.
+	Uncertain int
+}
+
+// Holds reports whether the wallets hold what the responses say they should.
+func (c Conservation) Holds() bool {
+	return c.After.Equal(c.Expected)
+}
+
+func newReport() *Report {
+	r := &Report{Ops: make(map[Op]*OpStats, len(Ops)), Errors: make(map[ErrorKey]int)}
+	for _, op := range Ops {
+		r.Ops[op] = &OpStats{}
+	}
+	return r
+}
+
+func (r *Report) record(op Op, latency time.Duration, status int, message string, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.Ops[op]
+	s.Requests++
+	s.latencies = append(s.latencies, latency)
+	if ok {
+		s.Succeeded++
+		return
+	}
+	r.Errors[ErrorKey{Op: op, Status: status, Message: message}]++
+}
+
+func (r *Report) moved(deposited, withdrawn, fee decimal.Decimal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Conservation.Deposited = r.Conservation.Deposited.Add(deposited)
+	r.Conservation.Withdrawn = r.Conservation.Withdrawn.Add(withdrawn)
+	r.Conservation.Fees = r.Conservation.Fees.Add(fee)
+}
+
+func (r *Report) uncertain() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Conservation.Uncertain++
+}
+
+// Percentile returns the latency under which p percent of the requests of the Op completed, by nearest rank.
+func (s *OpStats) Percentile(p float64) time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), s.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(p/100*float64(len(sorted))+0.999999) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
+
+// Write prints the report as tables.
+func (r *Report) Write(w io.Writer) {
+	fmt.Fprintf(w, "Ran for %s\n\n", r.Elapsed.Round(time.Millisecond))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "op\trequests\tok\treq/s\tp50\tp90\tp99\tmax\t")
+	var total, ok int
+	for _, op := range Ops {
+		s := r.Ops[op]
+		if s.Requests == 0 {
+			continue
+		}
+		total += s.Requests
+		ok += s.Succeeded
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t\n", op, s.Requests, s.Succeeded, rate(s.Requests, r.Elapsed),
+			ms(s.Percentile(50)), ms(s.Percentile(90)), ms(s.Percentile(99)), ms(s.Percentile(100)))
+	}
+	fmt.Fprintf(tw, "total\t%d\t%d\t%.1f\t\t\t\t\t\n", total, ok, rate(total, r.Elapsed))
+	tw.Flush()
+
+	if len(r.Errors) > 0 {
+		fmt.Fprintln(w, "\nErrors")
+		keys := make([]ErrorKey, 0, len(r.Errors))
+		for k := range r.Errors {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if r.Errors[keys[i]] != r.Errors[keys[j]] {
+				return r.Errors[keys[i]] > r.Errors[keys[j]]
+			}
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "count\top\tstatus\tmessage")
+		for _, k := range keys {
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%s\n", r.Errors[k], k.Op, k.Status, k.Message)
+		}
+		tw.Flush()
+	}
+
+	c := r.Conservation
+	fmt.Fprintln(w, "\nBalance conservation")
+	fmt.Fprintf(w, "  before %s + deposited %s - withdrawn %s - fees %s = expected %s\n",
+		c.Before, c.Deposited, c.Withdrawn, c.Fees, c.Expected)
+	fmt.Fprintf(w, "  after  %s\n", c.After)
+	switch {
+	case c.Holds():
+		fmt.Fprintln(w, "  OK")
+	case c.Uncertain > 0:
+		fmt.Fprintf(w, "  MISMATCH of %s, with %d requests of unknown outcome that may explain it\n", c.After.Sub(c.Expected), c.Uncertain)
+	default:
+		fmt.Fprintf(w, "  MISMATCH of %s\n", c.After.Sub(c.Expected))
+	}
+}
+
+func rate(n int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(n) / elapsed.Seconds()
+}
+
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// WalletFreezeRequest is the request body for freezing or unfreezing a wallet.
+type WalletFreezeRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// WalletStatusRequest is the request body for putting a wallet in a status.
+type WalletStatusRequest struct {
+	Status WalletStatus `json:"status" binding:"required"`
+	Reason string       `json:"reason" binding:"required"`
+	// ExpiresAt optionally lifts a restriction automatically; it cannot be given for the active status.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// AdjustmentRequest is the request body for a manual credit or debit of a wallet.
+type AdjustmentRequest struct {
+	Direction  AdjustmentDirection `json:"direction" binding:"required"`
+	Amount     decimal.Decimal     `json:"amount" binding:"required"`
+	ReasonCode AdjustmentReason    `json:"reason_code" binding:"required"`
+	// Note explains the adjustment; it is required for the reason code other.
+	Note string `json:"note"`
+}
@@ -0,0 +1,11 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+// ApprovalPolicyRequest is the request body for setting the approval policy of a wallet.
+type ApprovalPolicyRequest struct {
+	Threshold         decimal.Decimal `json:"threshold" binding:"required"`
+	RequiredApprovals int             `json:"required_approvals" binding:"required"`
+	// ProposalTTLHours is optional; proposals wait 72 hours when it is omitted.
+	ProposalTTLHours int `json:"proposal_ttl_hours"`
+}
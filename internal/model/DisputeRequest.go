@@ -0,0 +1,21 @@
+package model
+
+// DisputeRequest is the request body for disputing a transfer made from the wallet.
+type DisputeRequest struct {
+	TransactionID string `json:"transaction_id" binding:"required"`
+	Reason        string `json:"reason" binding:"required"`
+	// HoldFunds asks for the amount to be held on the recipient's wallet while the dispute is open, as far as
+	// the money it has available covers it.
+	HoldFunds bool `json:"hold_funds"`
+}
+
+// DisputeNoteRequest is the request body for attaching evidence to a dispute.
+type DisputeNoteRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// DisputeResolutionRequest is the request body for an administrator resolving a dispute.
+type DisputeResolutionRequest struct {
+	// InFavorOf is payer (reverse the transfer) or recipient (keep it and release the hold).
+	InFavorOf DisputeParty `json:"in_favor_of" binding:"required"`
+}
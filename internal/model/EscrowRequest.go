@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// EscrowRequest is the request body for moving funds into escrow for a seller.
+type EscrowRequest struct {
+	SellerWalletID string          `json:"seller_wallet_id" binding:"required"`
+	Amount         decimal.Decimal `json:"amount" binding:"required"`
+	Memo           string          `json:"memo"`
+	// ReleaseAfter is when the funds are released to the seller unless the escrow was settled before.
+	// It defaults to 14 days from now.
+	ReleaseAfter *time.Time `json:"release_after"`
+}
+
+// EscrowDecisionRequest is the request body for an administrator settling an escrow.
+type EscrowDecisionRequest struct {
+	// Outcome is released (pay the seller) or refunded (pay the buyer back).
+	Outcome EscrowStatus `json:"outcome" binding:"required"`
+}
@@ -0,0 +1,6 @@
+package model
+
+// HandleRequest is the request body for claiming a handle.
+type HandleRequest struct {
+	Handle string `json:"handle" binding:"required"`
+}
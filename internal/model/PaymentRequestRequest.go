@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PaymentRequestRequest is the request body for asking another user for money.
+// ExpiresAt defaults to seven days from now.
+type PaymentRequestRequest struct {
+	Amount      decimal.Decimal `json:"amount" binding:"required"`
+	PayerUserID string          `json:"payer_user_id" binding:"required"`
+	Memo        string          `json:"memo"`
+	ExpiresAt   *time.Time      `json:"expires_at"`
+}
+
+// AcceptPaymentRequestRequest is the request body for paying a payment request.
+type AcceptPaymentRequestRequest struct {
+	WalletID string `json:"wallet_id" binding:"required"`
+}
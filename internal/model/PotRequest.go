@@ -0,0 +1,11 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+// PotRequest is the request body for creating a pot or replacing its name and target.
+// TargetDate is a calendar date, e.g. "2025-12-24".
+type PotRequest struct {
+	Name         string           `json:"name" binding:"required"`
+	TargetAmount *decimal.Decimal `json:"target_amount"`
+	TargetDate   *string          `json:"target_date"`
+}
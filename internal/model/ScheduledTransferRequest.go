@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ScheduledTransferRequest is the request body for creating a standing order.
+// Schedule is a 5-field cron expression (e.g. "0 9 1 * *") or an RRULE (e.g. "FREQ=MONTHLY;BYMONTHDAY=1").
+// StartAt defaults to now; RRULE occurrences take their default time of day from it.
+type ScheduledTransferRequest struct {
+	Amount              decimal.Decimal `json:"amount" binding:"required"`
+	DestinationWalletID string          `json:"destination_wallet_id" binding:"required"`
+	Schedule            string          `json:"schedule" binding:"required"`
+	StartAt             *time.Time      `json:"start_at"`
+	EndAt               *time.Time      `json:"end_at"`
+}
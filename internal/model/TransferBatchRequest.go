@@ -0,0 +1,16 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+// TransferBatchRequest is the JSON request body for creating a transfer batch.
+type TransferBatchRequest struct {
+	Mode  TransferBatchMode  `json:"mode" binding:"required"`
+	Items []TransferBatchRow `json:"items" binding:"required"`
+}
+
+// TransferBatchRow is one requested payment of a batch, as uploaded by the client.
+type TransferBatchRow struct {
+	DestinationWalletID string          `json:"destination_wallet_id"`
+	Amount              decimal.Decimal `json:"amount"`
+	Reference           string          `json:"reference"`
+}
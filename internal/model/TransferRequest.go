@@ -3,7 +3,11 @@ package model
 import "github.com/shopspring/decimal"
 
 // TransferRequest is the request body for transferring funds.
+// Exactly one of DestinationWalletID, DestinationEmail or DestinationHandle must be set;
+// an email or handle is resolved to the recipient's default wallet.
 type TransferRequest struct {
 	Amount              decimal.Decimal `json:"amount" binding:"required"`
-	DestinationWalletID string          `json:"destination_wallet_id" binding:"required"`
+	DestinationWalletID string          `json:"destination_wallet_id"`
+	DestinationEmail    string          `json:"destination_email"`
+	DestinationHandle   string          `json:"destination_handle"`
 }
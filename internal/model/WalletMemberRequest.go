@@ -0,0 +1,12 @@
+package model
+
+// WalletMemberRequest is the request body for inviting a user to a wallet.
+type WalletMemberRequest struct {
+	UserID string     `json:"user_id" binding:"required"`
+	Role   WalletRole `json:"role" binding:"required"`
+}
+
+// WalletRoleRequest is the request body for changing the role of a wallet member.
+type WalletRoleRequest struct {
+	Role WalletRole `json:"role" binding:"required"`
+}
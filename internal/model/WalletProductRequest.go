@@ -0,0 +1,8 @@
+package model
+
+import "github.com/google/uuid"
+
+// WalletProductRequest is the request body for putting a wallet on a product; a null product_id takes it off.
+type WalletProductRequest struct {
+	ProductID *uuid.UUID `json:"product_id"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdjustmentDirection is whether a manual adjustment credits or debits the wallet.
+type AdjustmentDirection string
+
+const (
+	AdjustmentDirectionCredit AdjustmentDirection = "credit"
+	AdjustmentDirectionDebit  AdjustmentDirection = "debit"
+)
+
+// AdjustmentReason is the code every manual adjustment must be booked under.
+type AdjustmentReason string
+
+const (
+	// AdjustmentReasonErrorCorrection corrects a booking the system got wrong.
+	AdjustmentReasonErrorCorrection AdjustmentReason = "error_correction"
+	// AdjustmentReasonGoodwill is a credit given to the customer as a gesture of goodwill.
+	AdjustmentReasonGoodwill AdjustmentReason = "goodwill"
+	// AdjustmentReasonFeeRefund refunds fees charged to the customer.
+	AdjustmentReasonFeeRefund AdjustmentReason = "fee_refund"
+	// AdjustmentReasonChargeback books a chargeback settled outside the dispute workflow.
+	AdjustmentReasonChargeback AdjustmentReason = "chargeback"
+	// AdjustmentReasonCompliance moves money on a legal or regulatory instruction.
+	AdjustmentReasonCompliance AdjustmentReason = "compliance"
+	// AdjustmentReasonOther is any other reason, which the note must explain.
+	AdjustmentReasonOther AdjustmentReason = "other"
+)
+
+// IsValid reports whether r is one of the known reason codes.
+func (r AdjustmentReason) IsValid() bool {
+	switch r {
+	case AdjustmentReasonErrorCorrection, AdjustmentReasonGoodwill, AdjustmentReasonFeeRefund, AdjustmentReasonChargeback,
+		AdjustmentReasonCompliance, AdjustmentReasonOther:
+		return true
+	}
+	return false
+}
+
+// AdminAction names a back-office action in the audit log.
+type AdminAction string
+
+const (
+	AdminActionSearchUsers     AdminAction = "search_users"
+	AdminActionSearchWallets   AdminAction = "search_wallets"
+	AdminActionSetWalletStatus AdminAction = "set_wallet_status"
+	AdminActionAdjustWallet    AdminAction = "adjust_wallet"
+)
+
+// AdminAuditEntry represents the structure of the 'admin_audit_log' table: one action an administrator took.
+type AdminAuditEntry struct {
+	ID            uuid.UUID   `json:"id" db:"id"`
+	Admin         string      `json:"admin" db:"admin"`
+	Action        AdminAction `json:"action" db:"action"`
+	WalletID      *uuid.UUID  `json:"wallet_id,omitempty" db:"wallet_id"`
+	TransactionID *uuid.UUID  `json:"transaction_id,omitempty" db:"transaction_id"`
+	ReasonCode    *string     `json:"reason_code,omitempty" db:"reason_code"`
+	// Details is the search query, the status set with its reason or the note the administrator gave.
+	Details   string    `json:"details" db:"details"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// AuditTrailEntry represents the structure of the 'audit_trail' table: one state-changing API call.
+type AuditTrailEntry struct {
+	Seq       int64  `json:"seq" db:"seq"`
+	RequestID string `json:"request_id" db:"request_id"`
+	// Actor is "admin:<name>" for an administrator, "user:<id>" for a call on a user's resources, or "anonymous".
+	Actor  string      `json:"actor" db:"actor"`
+	Method string      `json:"method" db:"method"`
+	Route  string      `json:"route" db:"route"`
+	Params AuditParams `json:"params" db:"params"`
+	// Status is the HTTP status code of the response, or 0 if the server stopped before recording it.
+	Status int `json:"status" db:"status"`
+	// WalletID is the wallet in the route, and BalanceBefore and BalanceAfter its balance before and after the call,
+	// as the call's own transactions saw it; a call that did not change it records its balance at the end.
+	WalletID      *uuid.UUID       `json:"wallet_id,omitempty" db:"wallet_id"`
+	BalanceBefore *decimal.Decimal `json:"balance_before,omitempty" db:"balance_before"`
+	BalanceAfter  *decimal.Decimal `json:"balance_after,omitempty" db:"balance_after"`
+	CreatedAt     time.Time        `json:"created_at" db:"created_at"`
+	PrevHash      string           `json:"prev_hash" db:"prev_hash"`
+	Hash          string           `json:"hash" db:"hash"`
+}
+
+// AuditParams are the parameters of an audited call. The values of the body's fields are redacted.
+type AuditParams struct {
+	Path  map[string]string   `json:"path,omitempty"`
+	Query map[string][]string `json:"query,omitempty"`
+	// Body names the fields of the JSON request body.
+	Body []string `json:"body,omitempty"`
+}
+
+// String renders the parameters as JSON. Map keys are sorted, so equal parameters always render the same.
+func (p AuditParams) String() string {
+	data, _ := json.Marshal(p)
+	return string(data)
+}
+
+// Value stores the parameters as JSON.
+func (p AuditParams) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan reads the parameters from a JSON column.
+func (p *AuditParams) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	}
+	return fmt.Errorf("cannot scan %T into AuditParams", src)
+}
+
+// ComputeHash returns the hex SHA-256 of every field of the entry but Hash itself. CreatedAt is hashed at
+// microsecond precision, the precision the database keeps.
+func (e AuditTrailEntry) ComputeHash() string {
+	balance := func(d *decimal.Decimal) string {
+		if d == nil {
+			return ""
+		}
+		return d.String()
+	}
+	walletID := ""
+	if e.WalletID != nil {
+		walletID = e.WalletID.String()
+	}
+	fields := []string{
+		e.PrevHash,
+		e.RequestID,
+		e.Actor,
+		e.Method,
+		e.Route,
+		e.Params.String(),
+		walletID,
+		balance(e.BalanceBefore),
+		balance(e.BalanceAfter),
+		e.CreatedAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+	}
+	// Encoding the fields as a JSON array keeps their boundaries unambiguous.
+	data, _ := json.Marshal(struct {
+		Seq    int64    `json:"seq"`
+		Status int      `json:"status"`
+		Fields []string `json:"fields"`
+	}{e.Seq, e.Status, fields})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditTrailHead represents the structure of the 'audit_trail_head' table: the seq and hash of the last entry
+// appended to the audit trail, kept apart from the entries so that deleting the newest of them shows.
+type AuditTrailHead struct {
+	Seq  int64  `json:"seq" db:"seq"`
+	Hash string `json:"hash" db:"hash"`
+}
+
+// AuditChainBreak is a point where the audit trail's hash chain does not hold.
+type AuditChainBreak struct {
+	Seq    int64  `json:"seq"`
+	Reason string `json:"reason"`
+}
+
+// AuditChainReport is the result of verifying the audit trail's hash chain.
+type AuditChainReport struct {
+	Checked int64             `json:"checked"`
+	LastSeq int64             `json:"last_seq"`
+	Intact  bool              `json:"intact"`
+	Breaks  []AuditChainBreak `json:"breaks"`
+	// Head is the checkpoint the chain was verified against, nil while the audit trail is empty. Keeping a copy
+	// outside the database lets a later report be checked against it.
+	Head *AuditTrailHead `json:"head,omitempty"`
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// DisputeStatus is the lifecycle state of a dispute.
+// Only open disputes can change state; resolved and withdrawn are final.
+type DisputeStatus string
+
+const (
+	DisputeStatusOpen      DisputeStatus = "open"
+	DisputeStatusResolved  DisputeStatus = "resolved"
+	DisputeStatusWithdrawn DisputeStatus = "withdrawn"
+)
+
+// IsValid reports whether s is one of the known dispute states.
+func (s DisputeStatus) IsValid() bool {
+	switch s {
+	case DisputeStatusOpen, DisputeStatusResolved, DisputeStatusWithdrawn:
+		return true
+	}
+	return false
+}
+
+// DisputeParty is a side of a disputed transfer.
+type DisputeParty string
+
+const (
+	// DisputePartyPayer is the wallet that made the transfer and raised the dispute.
+	DisputePartyPayer DisputeParty = "payer"
+	// DisputePartyRecipient is the wallet that received the transfer.
+	DisputePartyRecipient DisputeParty = "recipient"
+)
+
+// IsValid reports whether p is one of the known dispute parties.
+func (p DisputeParty) IsValid() bool {
+	return p == DisputePartyPayer || p == DisputePartyRecipient
+}
+
+// Dispute represents the structure of the 'disputes' table: a payer's claim against a transfer. While it is open,
+// HeldAmount is held on the recipient's wallet. Resolving it for the payer reverses the transfer; resolving it
+// for the recipient, or withdrawing it, only releases the hold.
+type Dispute struct {
+	ID                    uuid.UUID       `json:"id" db:"id"`
+	TransactionID         uuid.UUID       `json:"transaction_id" db:"transaction_id"`
+	PayerWalletID         uuid.UUID       `json:"payer_wallet_id" db:"payer_wallet_id"`
+	RecipientWalletID     uuid.UUID       `json:"recipient_wallet_id" db:"recipient_wallet_id"`
+	Amount                decimal.Decimal `json:"amount" db:"amount"`
+	HeldAmount            decimal.Decimal `json:"held_amount" db:"held_amount"`
+	Reason                string          `json:"reason" db:"reason"`
+	Status                DisputeStatus   `json:"status" db:"status"`
+	OpenedBy              uuid.UUID       `json:"opened_by" db:"opened_by"`
+	ResolvedInFavorOf     *DisputeParty   `json:"resolved_in_favor_of,omitempty" db:"resolved_in_favor_of"`
+	ReversalTransactionID *uuid.UUID      `json:"reversal_transaction_id,omitempty" db:"reversal_transaction_id"`
+	// ResolvedBy names the administrator who resolved the dispute.
+	ResolvedBy *string   `json:"resolved_by,omitempty" db:"resolved_by"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+
+	// Notes is the evidence attached to the dispute, oldest first. It is only filled in for a single dispute.
+	Notes []DisputeNote `json:"notes,omitempty" db:"-"`
+}
+
+// DisputeNote represents the structure of the 'dispute_notes' table. Exactly one of AuthorUserID and
+// AuthorAdmin is set.
+type DisputeNote struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	DisputeID    uuid.UUID  `json:"dispute_id" db:"dispute_id"`
+	AuthorUserID *uuid.UUID `json:"author_user_id,omitempty" db:"author_user_id"`
+	AuthorAdmin  *string    `json:"author_admin,omitempty" db:"author_admin"`
+	Body         string     `json:"body" db:"body"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
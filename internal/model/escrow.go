@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// EscrowStatus is the lifecycle state of an escrow.
+// Only held escrows can change state; released and refunded are final.
+type EscrowStatus string
+
+const (
+	EscrowStatusHeld     EscrowStatus = "held"
+	EscrowStatusReleased EscrowStatus = "released"
+	EscrowStatusRefunded EscrowStatus = "refunded"
+)
+
+// IsValid reports whether s is one of the known escrow states.
+func (s EscrowStatus) IsValid() bool {
+	switch s {
+	case EscrowStatusHeld, EscrowStatusReleased, EscrowStatusRefunded:
+		return true
+	}
+	return false
+}
+
+// EscrowResolution records why an escrow was released or refunded.
+type EscrowResolution string
+
+const (
+	// EscrowResolutionBuyerConfirmed is a release by the buyer confirming the deal.
+	EscrowResolutionBuyerConfirmed EscrowResolution = "buyer_confirmed"
+	// EscrowResolutionTimeout is a release because the buyer did not object before release_after.
+	EscrowResolutionTimeout EscrowResolution = "timeout"
+	// EscrowResolutionAdmin is a release or refund decided by an administrator.
+	EscrowResolutionAdmin EscrowResolution = "admin"
+)
+
+// Escrow represents the structure of the 'escrows' table: funds of a buyer held in the escrow account until
+// they are released to the seller or refunded to the buyer.
+type Escrow struct {
+	ID                      uuid.UUID         `json:"id" db:"id"`
+	BuyerWalletID           uuid.UUID         `json:"buyer_wallet_id" db:"buyer_wallet_id"`
+	SellerWalletID          uuid.UUID         `json:"seller_wallet_id" db:"seller_wallet_id"`
+	EscrowWalletID          uuid.UUID         `json:"-" db:"escrow_wallet_id"`
+	Amount                  decimal.Decimal   `json:"amount" db:"amount"`
+	Memo                    string            `json:"memo" db:"memo"`
+	Status                  EscrowStatus      `json:"status" db:"status"`
+	ReleaseAfter            time.Time         `json:"release_after" db:"release_after"`
+	CreatedBy               uuid.UUID         `json:"created_by" db:"created_by"`
+	FundingTransactionID    uuid.UUID         `json:"funding_transaction_id" db:"funding_transaction_id"`
+	SettlementTransactionID *uuid.UUID        `json:"settlement_transaction_id,omitempty" db:"settlement_transaction_id"`
+	Resolution              *EscrowResolution `json:"resolution,omitempty" db:"resolution"`
+	// ResolvedBy names the administrator who decided the escrow.
+	ResolvedBy *string   `json:"resolved_by,omitempty" db:"resolved_by"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
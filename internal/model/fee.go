@@ -0,0 +1,160 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// FeeOperation is an operation that can be charged a fee.
+type FeeOperation string
+
+const (
+	FeeOperationWithdrawal FeeOperation = "withdrawal"
+	// FeeOperationTransfer covers every payment from one wallet to another: transfers, batch rows and
+	// accepted payment requests.
+	FeeOperationTransfer FeeOperation = "transfer"
+	// FeeOperationEscrow is charged to the buyer when an escrow is funded.
+	FeeOperationEscrow FeeOperation = "escrow"
+)
+
+// IsValid checks if the fee operation is valid.
+func (o FeeOperation) IsValid() bool {
+	switch o {
+	case FeeOperationWithdrawal, FeeOperationTransfer, FeeOperationEscrow:
+		return true
+	}
+	return false
+}
+
+// FeeSchedule represents the structure of the 'fee_schedules' table.
+// Schedules are never modified: a change is a new row with a higher Version, so the schedule behind
+// any fee ever charged can still be looked up through Transaction.FeeScheduleID.
+type FeeSchedule struct {
+	ID            uuid.UUID    `json:"id" db:"id"`
+	Operation     FeeOperation `json:"operation" db:"operation"`
+	Currency      string       `json:"currency" db:"currency"`
+	Version       int          `json:"version" db:"version"`
+	Rule          FeeRule      `json:"rule" db:"rule"`
+	EffectiveFrom time.Time    `json:"effective_from" db:"effective_from"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+}
+
+// FeeRule describes how a fee is computed from the amount of an operation.
+//
+// Without Tiers the fee is Flat plus Percent percent of the amount. With Tiers, the first tier whose
+// UpTo is at least the amount (or which has no UpTo) supplies Flat and Percent instead.
+// The result is then raised to Min and lowered to Max when those are set, and rounded to cents.
+type FeeRule struct {
+	Flat    decimal.Decimal  `json:"flat"`
+	Percent decimal.Decimal  `json:"percent"`
+	Tiers   []FeeTier        `json:"tiers,omitempty"`
+	Min     *decimal.Decimal `json:"min,omitempty"`
+	Max     *decimal.Decimal `json:"max,omitempty"`
+}
+
+// FeeTier is one band of a tiered FeeRule.
+type FeeTier struct {
+	UpTo    *decimal.Decimal `json:"up_to,omitempty"`
+	Flat    decimal.Decimal  `json:"flat"`
+	Percent decimal.Decimal  `json:"percent"`
+}
+
+var hundred = decimal.NewFromInt(100)
+
+// Calculate returns the fee charged on amount.
+func (r FeeRule) Calculate(amount decimal.Decimal) decimal.Decimal {
+	flat, percent := r.Flat, r.Percent
+	for _, tier := range r.Tiers {
+		if tier.UpTo == nil || amount.LessThanOrEqual(*tier.UpTo) {
+			flat, percent = tier.Flat, tier.Percent
+			break
+		}
+	}
+
+	fee := flat.Add(amount.Mul(percent).Div(hundred))
+	if r.Min != nil && fee.LessThan(*r.Min) {
+		fee = *r.Min
+	}
+	if r.Max != nil && fee.GreaterThan(*r.Max) {
+		fee = *r.Max
+	}
+	return fee.Round(2)
+}
+
+// Validate checks that the rule cannot produce a negative fee and that its tiers are ordered.
+func (r FeeRule) Validate() error {
+	check := func(name string, d decimal.Decimal) error {
+		if d.IsNegative() {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+		return nil
+	}
+	if err := errors.Join(check("flat", r.Flat), check("percent", r.Percent)); err != nil {
+		return err
+	}
+	for i, tier := range r.Tiers {
+		if err := errors.Join(check(fmt.Sprintf("tiers[%d].flat", i), tier.Flat), check(fmt.Sprintf("tiers[%d].percent", i), tier.Percent)); err != nil {
+			return err
+		}
+		if tier.UpTo == nil && i != len(r.Tiers)-1 {
+			return fmt.Errorf("only the last tier may omit up_to")
+		}
+		if i > 0 && tier.UpTo != nil && !tier.UpTo.GreaterThan(*r.Tiers[i-1].UpTo) {
+			return fmt.Errorf("tiers must be in increasing up_to order")
+		}
+	}
+	if r.Min != nil {
+		if err := check("min", *r.Min); err != nil {
+			return err
+		}
+	}
+	if r.Max != nil {
+		if err := check("max", *r.Max); err != nil {
+			return err
+		}
+		if r.Min != nil && r.Max.LessThan(*r.Min) {
+			return fmt.Errorf("max must not be less than min")
+		}
+	}
+	return nil
+}
+
+// Value stores the rule as JSON.
+func (r FeeRule) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan reads the rule from a JSON column.
+func (r *FeeRule) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	}
+	return fmt.Errorf("cannot scan %T into FeeRule", src)
+}
+
+// Fee is a fee to be charged together with an operation and paid into WalletID.
+type Fee struct {
+	Amount     decimal.Decimal
+	WalletID   uuid.UUID
+	ScheduleID *uuid.UUID
+}
+
+// FeeQuote tells a user what an operation will cost before they commit to it.
+type FeeQuote struct {
+	Operation       FeeOperation    `json:"operation"`
+	Currency        string          `json:"currency"`
+	Amount          decimal.Decimal `json:"amount"`
+	Fee             decimal.Decimal `json:"fee"`
+	Total           decimal.Decimal `json:"total"`
+	ScheduleID      *uuid.UUID      `json:"schedule_id,omitempty"`
+	ScheduleVersion int             `json:"schedule_version,omitempty"`
+}
@@ -0,0 +1,45 @@
+package model
+
+// HealthStatus is the outcome of a health check, or of all of them.
+type HealthStatus string
+
+const (
+	HealthStatusOK   HealthStatus = "ok"
+	HealthStatusFail HealthStatus = "fail"
+)
+
+// HealthReport is the outcome of a liveness or readiness probe. It is ok only if every check is.
+type HealthReport struct {
+	Status HealthStatus           `json:"status"`
+	Checks map[string]HealthCheck `json:"checks"`
+}
+
+// HealthCheck is the outcome of one check of a probe, with how long it took.
+type HealthCheck struct {
+	Status    HealthStatus `json:"status"`
+	LatencyMs float64      `json:"latency_ms"`
+	// Error tells why the check failed.
+	Error   string `json:"error,omitempty"`
+	Details any    `json:"details,omitempty"`
+}
+
+// PoolStats describes the database connection pool, to tell whether MAXOPENCONNS is too small.
+type PoolStats struct {
+	MaxOpenConnections int `json:"max_open_connections"`
+	OpenConnections    int `json:"open_connections"`
+	InUse              int `json:"in_use"`
+	Idle               int `json:"idle"`
+	// WaitCount and WaitDurationMs add up the waits for a free connection since the pool was opened.
+	WaitCount         int64   `json:"wait_count"`
+	WaitDurationMs    float64 `json:"wait_duration_ms"`
+	MaxIdleClosed     int64   `json:"max_idle_closed"`
+	MaxLifetimeClosed int64   `json:"max_lifetime_closed"`
+}
+
+// MigrationState tells whether the database schema is what this build expects.
+type MigrationState struct {
+	// Latest is the version of the newest migration this build has.
+	Latest int64 `json:"latest_version"`
+	// Pending lists the versions this build has that are not applied.
+	Pending []int64 `json:"pending_versions"`
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// DayCountConvention decides how many days a year has when turning an APR into a daily rate.
+type DayCountConvention string
+
+const (
+	// DayCountActual365 always divides by 365 days (ACT/365 Fixed).
+	DayCountActual365 DayCountConvention = "ACT/365"
+	// DayCountActual360 always divides by 360 days.
+	DayCountActual360 DayCountConvention = "ACT/360"
+	// DayCountActualActual divides by the number of days in the calendar year of the accrual day.
+	DayCountActualActual DayCountConvention = "ACT/ACT"
+)
+
+// IsValid checks if the day-count convention is valid.
+func (d DayCountConvention) IsValid() bool {
+	switch d {
+	case DayCountActual365, DayCountActual360, DayCountActualActual:
+		return true
+	}
+	return false
+}
+
+// DaysInYear returns the year length the convention uses for the given day.
+func (d DayCountConvention) DaysInYear(day time.Time) int {
+	switch d {
+	case DayCountActual360:
+		return 360
+	case DayCountActualActual:
+		return time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	}
+	return 365
+}
+
+// InterestAccrualScale is the number of decimal places a daily accrual is kept to.
+// Accruals are only rounded to cents when they are paid out.
+const InterestAccrualScale = 10
+
+// DailyInterest returns the interest earned in one day by balance at apr percent a year.
+func DailyInterest(balance decimal.Decimal, apr decimal.Decimal, convention DayCountConvention, day time.Time) decimal.Decimal {
+	days := decimal.NewFromInt(int64(convention.DaysInYear(day)))
+	return balance.Mul(apr).Div(hundred).Div(days).Round(InterestAccrualScale)
+}
+
+// WalletProduct represents the structure of the 'wallet_products' table.
+// A wallet with a product is a savings wallet and earns the product's APR.
+type WalletProduct struct {
+	ID        uuid.UUID       `json:"id" db:"id"`
+	Code      string          `json:"code" db:"code"`
+	Name      string          `json:"name" db:"name"`
+	APR       decimal.Decimal `json:"apr" db:"apr"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// SavingsWallet is a wallet the interest job accrues for.
+type SavingsWallet struct {
+	WalletID uuid.UUID       `db:"wallet_id"`
+	APR      decimal.Decimal `db:"apr"`
+	// ProductSince is when the wallet was given its product; interest accrues from that day on.
+	ProductSince time.Time `db:"product_since"`
+	// LastAccrualDate is the latest day interest has been accrued for, if any.
+	LastAccrualDate *time.Time `db:"last_accrual_date"`
+}
+
+// InterestAccrual represents the structure of the 'interest_accruals' table: the interest earned by a
+// wallet on one day, at full precision. TransactionID is set once it has been paid out.
+type InterestAccrual struct {
+	ID            uuid.UUID          `json:"id" db:"id"`
+	WalletID      uuid.UUID          `json:"wallet_id" db:"wallet_id"`
+	AccrualDate   time.Time          `json:"accrual_date" db:"accrual_date"`
+	Balance       decimal.Decimal    `json:"balance" db:"balance"`
+	APR           decimal.Decimal    `json:"apr" db:"apr"`
+	DayCount      DayCountConvention `json:"day_count" db:"day_count"`
+	Amount        decimal.Decimal    `json:"amount" db:"amount"`
+	TransactionID *uuid.UUID         `json:"transaction_id,omitempty" db:"transaction_id"`
+	CreatedAt     time.Time          `json:"created_at" db:"created_at"`
+}
+
+// InterestSummary tells a user what their wallet earns and what it has earned but not been paid yet.
+type InterestSummary struct {
+	WalletID        uuid.UUID          `json:"wallet_id" db:"wallet_id"`
+	Product         *WalletProduct     `json:"product,omitempty" db:"-"`
+	DayCount        DayCountConvention `json:"day_count" db:"-"`
+	AccruedUnpaid   decimal.Decimal    `json:"accrued_unpaid" db:"accrued_unpaid"`
+	LastAccrualDate *time.Time         `json:"last_accrual_date,omitempty" db:"last_accrual_date"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PaymentRequestStatus is the lifecycle state of a payment request.
+// Only pending requests can change state; every other state is final.
+type PaymentRequestStatus string
+
+const (
+	PaymentRequestStatusPending   PaymentRequestStatus = "pending"
+	PaymentRequestStatusAccepted  PaymentRequestStatus = "accepted"
+	PaymentRequestStatusDeclined  PaymentRequestStatus = "declined"
+	PaymentRequestStatusCancelled PaymentRequestStatus = "cancelled"
+	PaymentRequestStatusExpired   PaymentRequestStatus = "expired"
+)
+
+// IsValid reports whether s is one of the known payment request states.
+func (s PaymentRequestStatus) IsValid() bool {
+	switch s {
+	case PaymentRequestStatusPending, PaymentRequestStatusAccepted, PaymentRequestStatusDeclined,
+		PaymentRequestStatusCancelled, PaymentRequestStatusExpired:
+		return true
+	}
+	return false
+}
+
+// PaymentRequest represents the structure of the 'payment_requests' table.
+type PaymentRequest struct {
+	ID                uuid.UUID            `json:"id" db:"id"`
+	RequesterUserID   uuid.UUID            `json:"requester_user_id" db:"requester_user_id"`
+	RequesterWalletID uuid.UUID            `json:"requester_wallet_id" db:"requester_wallet_id"`
+	PayerUserID       uuid.UUID            `json:"payer_user_id" db:"payer_user_id"`
+	PayerWalletID     *uuid.UUID           `json:"payer_wallet_id,omitempty" db:"payer_wallet_id"`
+	Amount            decimal.Decimal      `json:"amount" db:"amount"`
+	Memo              string               `json:"memo" db:"memo"`
+	Status            PaymentRequestStatus `json:"status" db:"status"`
+	ExpiresAt         time.Time            `json:"expires_at" db:"expires_at"`
+	TransactionID     *uuid.UUID           `json:"transaction_id,omitempty" db:"transaction_id"`
+	CreatedAt         time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PotStatus defines the allowed statuses of a pot.
+type PotStatus string
+
+const (
+	PotStatusOpen   PotStatus = "open"
+	PotStatusClosed PotStatus = "closed"
+)
+
+// PotMoveDirection tells which way money moves between a wallet and one of its pots.
+type PotMoveDirection string
+
+const (
+	// PotMoveIn moves money from the wallet into the pot.
+	PotMoveIn PotMoveDirection = "in"
+	// PotMoveOut moves money from the pot back to the wallet.
+	PotMoveOut PotMoveDirection = "out"
+)
+
+// Pot represents the structure of the 'pots' table: money ring-fenced inside a wallet.
+// The pot balance is part of the wallet balance but cannot be withdrawn or transferred until it
+// is moved back out of the pot.
+type Pot struct {
+	ID           uuid.UUID        `json:"id" db:"id"`
+	WalletID     uuid.UUID        `json:"wallet_id" db:"wallet_id"`
+	Name         string           `json:"name" db:"name"`
+	Balance      decimal.Decimal  `json:"balance" db:"balance"`
+	TargetAmount *decimal.Decimal `json:"target_amount,omitempty" db:"target_amount"`
+	TargetDate   *time.Time       `json:"target_date,omitempty" db:"target_date"`
+	Status       PotStatus        `json:"status" db:"status"`
+	CreatedAt    time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at" db:"updated_at"`
+
+	// Progress reports how far the pot is towards its target, when it has one.
+	Progress *PotProgress `json:"progress,omitempty" db:"-"`
+}
+
+// PotProgress describes how far a pot is towards its target amount and date.
+type PotProgress struct {
+	// Percent is the balance as a percentage of the target amount, capped at 100.
+	Percent   decimal.Decimal `json:"percent"`
+	Remaining decimal.Decimal `json:"remaining"`
+	Reached   bool            `json:"reached"`
+	// DaysLeft is the number of days until the target date; it is negative once the date has passed.
+	DaysLeft *int `json:"days_left,omitempty"`
+	// MonthlyNeeded is what has to go into the pot every month from now on to reach the target on time.
+	MonthlyNeeded *decimal.Decimal `json:"monthly_needed,omitempty"`
+}
+
+// PotMovement represents the structure of the 'pot_movements' table: one move between a wallet and a pot.
+// Moves are internal to the wallet, so they do not change its balance and are not transactions.
+type PotMovement struct {
+	ID        uuid.UUID        `json:"id" db:"id"`
+	PotID     uuid.UUID        `json:"pot_id" db:"pot_id"`
+	Direction PotMoveDirection `json:"direction" db:"direction"`
+	Amount    decimal.Decimal  `json:"amount" db:"amount"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+}
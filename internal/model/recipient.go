@@ -0,0 +1,18 @@
+package model
+
+import "github.com/google/uuid"
+
+// Recipient is a user resolved from an email or handle, together with the wallet they are paid into.
+type Recipient struct {
+	UserID   uuid.UUID  `db:"id"`
+	Name     string     `db:"name"`
+	Handle   *string    `db:"handle"`
+	WalletID *uuid.UUID `db:"wallet_id"`
+}
+
+// RecipientPreview is what a sender is shown to confirm who they are about to pay.
+// It deliberately carries neither the recipient's full name nor any identifier.
+type RecipientPreview struct {
+	DisplayName string  `json:"display_name"`
+	Handle      *string `json:"handle,omitempty"`
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ScheduledTransferStatus is the lifecycle state of a standing order.
+type ScheduledTransferStatus string
+
+const (
+	ScheduledTransferStatusActive    ScheduledTransferStatus = "active"
+	ScheduledTransferStatusCancelled ScheduledTransferStatus = "cancelled"
+	// ScheduledTransferStatusCompleted means the schedule has no further occurrences.
+	ScheduledTransferStatusCompleted ScheduledTransferStatus = "completed"
+)
+
+// ScheduledTransferRunStatus is the outcome of one occurrence of a schedule.
+type ScheduledTransferRunStatus string
+
+const (
+	ScheduledTransferRunStatusRunning   ScheduledTransferRunStatus = "running"
+	ScheduledTransferRunStatusRetrying  ScheduledTransferRunStatus = "retrying"
+	ScheduledTransferRunStatusSucceeded ScheduledTransferRunStatus = "succeeded"
+	ScheduledTransferRunStatusFailed    ScheduledTransferRunStatus = "failed"
+)
+
+// ScheduledTransfer represents the structure of the 'scheduled_transfers' table.
+type ScheduledTransfer struct {
+	ID                  uuid.UUID               `json:"id" db:"id"`
+	UserID              uuid.UUID               `json:"user_id" db:"user_id"`
+	SourceWalletID      uuid.UUID               `json:"source_wallet_id" db:"source_wallet_id"`
+	DestinationWalletID uuid.UUID               `json:"destination_wallet_id" db:"destination_wallet_id"`
+	Amount              decimal.Decimal         `json:"amount" db:"amount"`
+	Expression          string                  `json:"expression" db:"expression"`
+	StartAt             time.Time               `json:"start_at" db:"start_at"`
+	EndAt               *time.Time              `json:"end_at,omitempty" db:"end_at"`
+	NextRunAt           *time.Time              `json:"next_run_at,omitempty" db:"next_run_at"`
+	Status              ScheduledTransferStatus `json:"status" db:"status"`
+	CreatedAt           time.Time               `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time               `json:"updated_at" db:"updated_at"`
+
+	Runs []ScheduledTransferRun `json:"runs,omitempty" db:"-"`
+}
+
+// ScheduledTransferRun represents the structure of the 'scheduled_transfer_runs' table.
+type ScheduledTransferRun struct {
+	ID            uuid.UUID                  `json:"id" db:"id"`
+	ScheduleID    uuid.UUID                  `json:"schedule_id" db:"schedule_id"`
+	ScheduledFor  time.Time                  `json:"scheduled_for" db:"scheduled_for"`
+	Status        ScheduledTransferRunStatus `json:"status" db:"status"`
+	Attempts      int                        `json:"attempts" db:"attempts"`
+	NextAttemptAt *time.Time                 `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	TransactionID *uuid.UUID                 `json:"transaction_id,omitempty" db:"transaction_id"`
+	Error         *string                    `json:"error,omitempty" db:"error"`
+	ClaimedAt     *time.Time                 `json:"claimed_at,omitempty" db:"claimed_at"`
+	CreatedAt     time.Time                  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at" db:"updated_at"`
+}
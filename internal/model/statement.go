@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// WalletActivity is a consistent snapshot of a wallet, its owner and every transaction
+// that touched the wallet since a point in time, oldest first.
+type WalletActivity struct {
+	Wallet       Wallet
+	Owner        User
+	Since        time.Time
+	Transactions []Transaction
+}
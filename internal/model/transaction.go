@@ -15,4 +15,40 @@ type Transaction struct {
 	Amount          decimal.Decimal `json:"amount" db:"amount"`
 	RelatedWalletID *uuid.UUID      `json:"related_wallet_id,omitempty" db:"related_wallet_id"`
 	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
+
+	// InitiatedBy is the wallet member who initiated the transaction; it is nil when the system booked it.
+	InitiatedBy *uuid.UUID `json:"initiated_by,omitempty" db:"initiated_by"`
+
+	// FeeScheduleID is the schedule a fee transaction was computed with.
+	FeeScheduleID *uuid.UUID `json:"fee_schedule_id,omitempty" db:"fee_schedule_id"`
+	// ParentTransactionID links a fee transaction to the operation it was charged on, and a reversal to the
+	// transfer it pays back.
+	ParentTransactionID *uuid.UUID `json:"parent_transaction_id,omitempty" db:"parent_transaction_id"`
+
+	// Fee is the fee charged together with this transaction, if any.
+	Fee *Transaction `json:"fee,omitempty" db:"-"`
+}
+
+// NetAmountFor returns the signed effect of the transaction on the balance of walletID:
+// positive when the wallet was credited and negative when it was debited.
+//
+// A transfer, a fee or a reversal is recorded once, on the paying wallet, with related_wallet_id pointing at the
+// receiving one; the same row is therefore a debit for the payer and a credit for the payee.
+// An adjustment carries its own sign.
+func (t Transaction) NetAmountFor(walletID uuid.UUID) decimal.Decimal {
+	amount := t.Amount.Abs()
+	switch t.Type {
+	case TransactionTypeDeposit, TransactionTypeInterest:
+		return amount
+	case TransactionTypeWithdrawal:
+		return amount.Neg()
+	case TransactionTypeAdjustment:
+		return t.Amount
+	case TransactionTypeTransfer, TransactionTypeFee, TransactionTypeReversal:
+		if t.WalletID == walletID {
+			return amount.Neg()
+		}
+		return amount
+	}
+	return decimal.Zero
 }
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TransferBatchMode defines how failures of individual rows affect the rest of a batch.
+type TransferBatchMode string
+
+const (
+	// TransferBatchModeAllOrNothing commits every row in one database transaction, or none of them.
+	TransferBatchModeAllOrNothing TransferBatchMode = "all_or_nothing"
+	// TransferBatchModeBestEffort commits each row on its own and carries on past failures.
+	TransferBatchModeBestEffort TransferBatchMode = "best_effort"
+)
+
+// IsValid checks if the batch mode is valid.
+func (m TransferBatchMode) IsValid() bool {
+	switch m {
+	case TransferBatchModeAllOrNothing, TransferBatchModeBestEffort:
+		return true
+	}
+	return false
+}
+
+// TransferBatchStatus is the lifecycle state of a batch.
+type TransferBatchStatus string
+
+const (
+	TransferBatchStatusPending   TransferBatchStatus = "pending"
+	TransferBatchStatusRunning   TransferBatchStatus = "running"
+	TransferBatchStatusCompleted TransferBatchStatus = "completed"
+	TransferBatchStatusFailed    TransferBatchStatus = "failed"
+)
+
+// TransferBatchItemStatus is the outcome of a single batch row.
+type TransferBatchItemStatus string
+
+const (
+	TransferBatchItemStatusPending   TransferBatchItemStatus = "pending"
+	TransferBatchItemStatusSucceeded TransferBatchItemStatus = "succeeded"
+	TransferBatchItemStatusFailed    TransferBatchItemStatus = "failed"
+	// TransferBatchItemStatusSkipped marks rows rolled back because another row of an all-or-nothing batch failed.
+	TransferBatchItemStatusSkipped TransferBatchItemStatus = "skipped"
+)
+
+// TransferBatch represents the structure of the 'transfer_batches' table, together with its rows.
+type TransferBatch struct {
+	ID             uuid.UUID           `json:"id" db:"id"`
+	UserID         uuid.UUID           `json:"user_id" db:"user_id"`
+	SourceWalletID uuid.UUID           `json:"source_wallet_id" db:"source_wallet_id"`
+	Mode           TransferBatchMode   `json:"mode" db:"mode"`
+	Status         TransferBatchStatus `json:"status" db:"status"`
+	CreatedAt      time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at" db:"updated_at"`
+
+	Summary TransferBatchSummary `json:"summary" db:"-"`
+	Items   []TransferBatchItem  `json:"items" db:"-"`
+}
+
+// TransferBatchSummary counts the rows of a batch by outcome.
+type TransferBatchSummary struct {
+	Total     int             `json:"total"`
+	Pending   int             `json:"pending"`
+	Succeeded int             `json:"succeeded"`
+	Failed    int             `json:"failed"`
+	Skipped   int             `json:"skipped"`
+	Amount    decimal.Decimal `json:"amount"`
+	Moved     decimal.Decimal `json:"moved"`
+}
+
+// Summarize recomputes Summary from Items.
+func (b *TransferBatch) Summarize() {
+	s := TransferBatchSummary{Total: len(b.Items), Amount: decimal.Zero, Moved: decimal.Zero}
+	for _, item := range b.Items {
+		s.Amount = s.Amount.Add(item.Amount)
+		switch item.Status {
+		case TransferBatchItemStatusPending:
+			s.Pending++
+		case TransferBatchItemStatusSucceeded:
+			s.Succeeded++
+			s.Moved = s.Moved.Add(item.Amount)
+		case TransferBatchItemStatusFailed:
+			s.Failed++
+		case TransferBatchItemStatusSkipped:
+			s.Skipped++
+		}
+	}
+	b.Summary = s
+}
+
+// TransferBatchItem represents the structure of the 'transfer_batch_items' table.
+type TransferBatchItem struct {
+	ID                  uuid.UUID       `json:"id" db:"id"`
+	BatchID             uuid.UUID       `json:"batch_id" db:"batch_id"`
+	RowNumber           int             `json:"row_number" db:"row_number"`
+	DestinationWalletID uuid.UUID       `json:"destination_wallet_id" db:"destination_wallet_id"`
+	Amount              decimal.Decimal `json:"amount" db:"amount"`
+	Reference           string          `json:"reference" db:"reference"`
+	// Fee is the fee quoted when the batch was created; it is the fee charged with the row.
+	Fee           decimal.Decimal         `json:"fee" db:"fee"`
+	FeeWalletID   *uuid.UUID              `json:"-" db:"fee_wallet_id"`
+	FeeScheduleID *uuid.UUID              `json:"fee_schedule_id,omitempty" db:"fee_schedule_id"`
+	Status        TransferBatchItemStatus `json:"status" db:"status"`
+	Error         *string                 `json:"error,omitempty" db:"error"`
+	TransactionID *uuid.UUID              `json:"transaction_id,omitempty" db:"transaction_id"`
+	UpdatedAt     time.Time               `json:"updated_at" db:"updated_at"`
+}
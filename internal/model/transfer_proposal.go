@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ApprovalPolicy makes transfers from a wallet above Threshold need RequiredApprovals approvals from the
+// wallet's owners before they are executed.
+type ApprovalPolicy struct {
+	WalletID          uuid.UUID       `json:"wallet_id" db:"wallet_id"`
+	Threshold         decimal.Decimal `json:"threshold" db:"threshold"`
+	RequiredApprovals int             `json:"required_approvals" db:"required_approvals"`
+	// ProposalTTLHours is how long a proposal waits for approvals before it expires.
+	ProposalTTLHours int       `json:"proposal_ttl_hours" db:"proposal_ttl_hours"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// RequiresApproval reports whether a transfer of amount needs approval under the policy.
+func (p ApprovalPolicy) RequiresApproval(amount decimal.Decimal) bool {
+	return amount.GreaterThan(p.Threshold)
+}
+
+// TransferProposalStatus is the lifecycle state of a transfer proposal.
+// Only pending proposals can change state; every other state is final.
+type TransferProposalStatus string
+
+const (
+	TransferProposalStatusPending   TransferProposalStatus = "pending"
+	TransferProposalStatusExecuted  TransferProposalStatus = "executed"
+	TransferProposalStatusCancelled TransferProposalStatus = "cancelled"
+	TransferProposalStatusExpired   TransferProposalStatus = "expired"
+)
+
+// IsValid reports whether s is one of the known transfer proposal states.
+func (s TransferProposalStatus) IsValid() bool {
+	switch s {
+	case TransferProposalStatusPending, TransferProposalStatusExecuted, TransferProposalStatusCancelled,
+		TransferProposalStatusExpired:
+		return true
+	}
+	return false
+}
+
+// TransferProposal represents the structure of the 'transfer_proposals' table: a transfer waiting for approval.
+type TransferProposal struct {
+	ID                  uuid.UUID       `json:"id" db:"id"`
+	WalletID            uuid.UUID       `json:"wallet_id" db:"wallet_id"`
+	DestinationWalletID uuid.UUID       `json:"destination_wallet_id" db:"destination_wallet_id"`
+	Amount              decimal.Decimal `json:"amount" db:"amount"`
+	// Fee is the fee quoted when the transfer was proposed; it is the fee charged when it is executed.
+	Fee           decimal.Decimal `json:"fee" db:"fee"`
+	FeeWalletID   *uuid.UUID      `json:"-" db:"fee_wallet_id"`
+	FeeScheduleID *uuid.UUID      `json:"fee_schedule_id,omitempty" db:"fee_schedule_id"`
+	// HeldAmount is held in the wallet while the proposal is pending.
+	HeldAmount        decimal.Decimal        `json:"held_amount" db:"held_amount"`
+	ProposedBy        uuid.UUID              `json:"proposed_by" db:"proposed_by"`
+	RequiredApprovals int                    `json:"required_approvals" db:"required_approvals"`
+	Status            TransferProposalStatus `json:"status" db:"status"`
+	ExpiresAt         time.Time              `json:"expires_at" db:"expires_at"`
+	TransactionID     *uuid.UUID             `json:"transaction_id,omitempty" db:"transaction_id"`
+	CreatedAt         time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at" db:"updated_at"`
+
+	Approvals []TransferApproval `json:"approvals" db:"-"`
+}
+
+// TransferApproval represents the structure of the 'transfer_approvals' table.
+type TransferApproval struct {
+	ProposalID uuid.UUID `json:"-" db:"proposal_id"`
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
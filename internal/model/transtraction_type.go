@@ -7,12 +7,21 @@ const (
 	TransactionTypeDeposit    TransactionType = "deposit"
 	TransactionTypeWithdrawal TransactionType = "withdrawal"
 	TransactionTypeTransfer   TransactionType = "transfer"
+	// TransactionTypeFee is a fee paid from wallet_id into the fee wallet in related_wallet_id.
+	TransactionTypeFee TransactionType = "fee"
+	// TransactionTypeInterest is a monthly interest payout credited to a savings wallet.
+	TransactionTypeInterest TransactionType = "interest"
+	// TransactionTypeReversal pays a disputed transfer back from wallet_id to the original payer in related_wallet_id.
+	TransactionTypeReversal TransactionType = "reversal"
+	// TransactionTypeAdjustment is a manual credit (positive amount) or debit (negative amount) posted by an administrator.
+	TransactionTypeAdjustment TransactionType = "adjustment"
 )
 
 // IsValid checks if the transaction type is valid.
 func (tt TransactionType) IsValid() bool {
 	switch tt {
-	case TransactionTypeDeposit, TransactionTypeWithdrawal, TransactionTypeTransfer:
+	case TransactionTypeDeposit, TransactionTypeWithdrawal, TransactionTypeTransfer, TransactionTypeFee, TransactionTypeInterest,
+		TransactionTypeReversal, TransactionTypeAdjustment:
 		return true
 	}
 	return false
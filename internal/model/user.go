@@ -12,4 +12,9 @@ type User struct {
 	Name      string    `json:"name" db:"name"`
 	Email     string    `json:"email" db:"email"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// Handle is the optional public name the user can be paid by, in lower case.
+	Handle *string `json:"handle,omitempty" db:"handle"`
+	// DefaultWalletID is the wallet that transfers addressed to the user by email or handle are paid into.
+	DefaultWalletID *uuid.UUID `json:"default_wallet_id,omitempty" db:"default_wallet_id"`
 }
@@ -7,6 +7,38 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// WalletStatus is which movements of money a wallet allows.
+type WalletStatus string
+
+const (
+	WalletStatusActive WalletStatus = "active"
+	// WalletStatusDebitBlocked is a wallet that can be paid but cannot pay.
+	WalletStatusDebitBlocked WalletStatus = "debit_blocked"
+	// WalletStatusCreditBlocked is a wallet that can pay but cannot be paid.
+	WalletStatusCreditBlocked WalletStatus = "credit_blocked"
+	// WalletStatusFrozen is a wallet that can neither pay nor be paid.
+	WalletStatusFrozen WalletStatus = "frozen"
+)
+
+// IsValid reports whether s is one of the known wallet states.
+func (s WalletStatus) IsValid() bool {
+	switch s {
+	case WalletStatusActive, WalletStatusDebitBlocked, WalletStatusCreditBlocked, WalletStatusFrozen:
+		return true
+	}
+	return false
+}
+
+// AllowsDebit reports whether a wallet in status s can pay.
+func (s WalletStatus) AllowsDebit() bool {
+	return s == WalletStatusActive || s == WalletStatusCreditBlocked
+}
+
+// AllowsCredit reports whether a wallet in status s can be paid.
+func (s WalletStatus) AllowsCredit() bool {
+	return s == WalletStatusActive || s == WalletStatusDebitBlocked
+}
+
 // Wallet represents the structure of the 'wallets' table.
 type Wallet struct {
 	ID        uuid.UUID       `json:"id" db:"id"`
@@ -15,4 +47,32 @@ type Wallet struct {
 	Balance   decimal.Decimal `json:"balance" db:"balance"`
 	CreatedAt time.Time       `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+
+	// ProductID is the savings product of the wallet, if any.
+	ProductID *uuid.UUID `json:"product_id,omitempty" db:"product_id"`
+	// PotsBalance is the part of Balance set aside in the wallet's pots.
+	PotsBalance decimal.Decimal `json:"pots_balance" db:"pots_balance"`
+	// HeldBalance is the part of Balance held for transfer proposals waiting for approval.
+	HeldBalance decimal.Decimal `json:"held_balance" db:"held_balance"`
+	// Status is which movements of money the wallet allows. A restriction is set by an administrator, who is
+	// recorded with the reason, and lifts by itself at StatusExpiresAt if that is set.
+	Status          WalletStatus `json:"status" db:"status"`
+	StatusReason    *string      `json:"status_reason,omitempty" db:"status_reason"`
+	StatusChangedBy *string      `json:"status_changed_by,omitempty" db:"status_changed_by"`
+	StatusChangedAt *time.Time   `json:"status_changed_at,omitempty" db:"status_changed_at"`
+	StatusExpiresAt *time.Time   `json:"status_expires_at,omitempty" db:"status_expires_at"`
+}
+
+// Available returns the part of the balance that is neither set aside in pots nor held, and can be spent.
+func (w Wallet) Available() decimal.Decimal {
+	return w.Balance.Sub(w.PotsBalance).Sub(w.HeldBalance)
+}
+
+// EffectiveStatus returns the status of the wallet at now: active once a restriction has expired, even if that has
+// not been written back yet.
+func (w Wallet) EffectiveStatus(now time.Time) WalletStatus {
+	if w.StatusExpiresAt != nil && !w.StatusExpiresAt.After(now) {
+		return WalletStatusActive
+	}
+	return w.Status
 }
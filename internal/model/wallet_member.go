@@ -0,0 +1,70 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WalletRole defines what a member may do with a shared wallet.
+type WalletRole string
+
+const (
+	// WalletRoleOwner may do everything, including managing members.
+	WalletRoleOwner WalletRole = "owner"
+	// WalletRoleSpender may move money in and out of the wallet.
+	WalletRoleSpender WalletRole = "spender"
+	// WalletRoleViewer may only see the wallet and its history.
+	WalletRoleViewer WalletRole = "viewer"
+)
+
+// IsValid checks if the wallet role is valid.
+func (r WalletRole) IsValid() bool {
+	switch r {
+	case WalletRoleOwner, WalletRoleSpender, WalletRoleViewer:
+		return true
+	}
+	return false
+}
+
+// Allows reports whether a member with role r may do what needs at least role min.
+func (r WalletRole) Allows(min WalletRole) bool {
+	return r.rank() >= min.rank()
+}
+
+func (r WalletRole) rank() int {
+	switch r {
+	case WalletRoleOwner:
+		return 3
+	case WalletRoleSpender:
+		return 2
+	case WalletRoleViewer:
+		return 1
+	}
+	return 0
+}
+
+// WalletMemberStatus defines the allowed statuses of a wallet membership.
+type WalletMemberStatus string
+
+const (
+	// WalletMemberStatusInvited is a membership the invited user has not accepted yet; it grants nothing.
+	WalletMemberStatusInvited WalletMemberStatus = "invited"
+	WalletMemberStatusActive  WalletMemberStatus = "active"
+)
+
+// WalletMember represents the structure of the 'wallet_members' table.
+type WalletMember struct {
+	WalletID  uuid.UUID          `json:"wallet_id" db:"wallet_id"`
+	UserID    uuid.UUID          `json:"user_id" db:"user_id"`
+	Role      WalletRole         `json:"role" db:"role"`
+	Status    WalletMemberStatus `json:"status" db:"status"`
+	InvitedBy *uuid.UUID         `json:"invited_by,omitempty" db:"invited_by"`
+	CreatedAt time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" db:"updated_at"`
+
+	// UserName is the member's name, for display.
+	UserName string `json:"user_name,omitempty" db:"user_name"`
+	// WalletName is the wallet's name, for display in invitations.
+	WalletName string `json:"wallet_name,omitempty" db:"wallet_name"`
+}
@@ -0,0 +1,302 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+// ErrWalletStatusUnchanged indicates an attempt to put a wallet in the status it already has.
+var ErrWalletStatusUnchanged = errors.New("wallet is already in this status")
+
+// SystemActor is the name audit entries of actions the system took by itself are recorded under.
+const SystemActor = "system"
+
+const adminWalletColumns = `w.id, w.user_id, w.name, w.balance, w.pots_balance, w.held_balance, w.status, w.status_reason,
+                            w.status_changed_by, w.status_changed_at, w.status_expires_at, w.created_at, w.updated_at, w.product_id`
+
+// likeEscaper escapes the LIKE wildcards in a search query, so it only matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type AdminRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewAdminImpl(db *sqlx.DB) *AdminRepoImpl {
+	return &AdminRepoImpl{db}
+}
+
+// SearchUsers returns up to limit users whose ID is query or whose name, email or handle contains it, ignoring case.
+// The search is recorded in the audit log under admin.
+func (ar *AdminRepoImpl) SearchUsers(ctx context.Context, admin string, query string, limit int, at time.Time) ([]model.User, error) {
+	entry := &model.AdminAuditEntry{Admin: admin, Action: model.AdminActionSearchUsers, Details: query, CreatedAt: at}
+	if err := insertAdminAuditEntry(ctx, ar.db, entry); err != nil {
+		return nil, err
+	}
+
+	users := []model.User{}
+	q := strings.ToLower(query)
+	searchQuery := `SELECT id, name, email, created_at, handle, default_wallet_id
+                    FROM users
+                    WHERE id::text = $1 OR lower(name) LIKE $2 OR lower(email) LIKE $2 OR lower(handle) LIKE $2
+                    ORDER BY created_at, id
+                    LIMIT $3`
+	if err := ar.db.SelectContext(ctx, &users, searchQuery, q, "%"+likeEscaper.Replace(q)+"%", limit); err != nil {
+		return nil, fmt.Errorf("database error searching users: %w", err)
+	}
+	return users, nil
+}
+
+// SearchWallets returns up to limit wallets whose ID is query or whose name contains it, ignoring case, and that
+// userIDStr owns or is a member of. Either filter may be empty. The search is recorded in the audit log under admin.
+func (ar *AdminRepoImpl) SearchWallets(ctx context.Context, admin string, query string, userIDStr string, limit int, at time.Time) ([]model.Wallet, error) {
+	var userID *uuid.UUID
+	if userIDStr != "" {
+		id, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID format: %w", err)
+		}
+		userID = &id
+	}
+
+	details := query
+	if userID != nil {
+		details = strings.TrimSpace(query + " user_id=" + userID.String())
+	}
+	entry := &model.AdminAuditEntry{Admin: admin, Action: model.AdminActionSearchWallets, Details: details, CreatedAt: at}
+	if err := insertAdminAuditEntry(ctx, ar.db, entry); err != nil {
+		return nil, err
+	}
+
+	wallets := []model.Wallet{}
+	q := strings.ToLower(query)
+	searchQuery := `SELECT ` + adminWalletColumns + `
+                    FROM wallets w
+                    WHERE ($1 = '' OR w.id::text = $1 OR lower(w.name) LIKE $2)
+                      AND ($3::uuid IS NULL OR w.user_id = $3
+                           OR EXISTS (SELECT 1 FROM wallet_members m WHERE m.wallet_id = w.id AND m.user_id = $3))
+                    ORDER BY w.created_at, w.id
+                    LIMIT $4`
+	if err := ar.db.SelectContext(ctx, &wallets, searchQuery, q, "%"+likeEscaper.Replace(q)+"%", userID, limit); err != nil {
+		return nil, fmt.Errorf("database error searching wallets: %w", err)
+	}
+	return wallets, nil
+}
+
+// SetWalletStatus puts a wallet in status, recording admin and the reason on the wallet and in the audit log.
+// A restriction with expiresAt lifts by itself at that time. Setting the status and expiry the wallet already has
+// fails with ErrWalletStatusUnchanged.
+func (ar *AdminRepoImpl) SetWalletStatus(ctx context.Context, admin string, walletIDStr string, status model.WalletStatus, reason string,
+	expiresAt *time.Time, at time.Time) (*model.Wallet, error) {
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	tx, err := beginTx(ctx, ar.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current model.Wallet
+	lockQuery := `SELECT id, status, status_expires_at FROM wallets WHERE id = $1 FOR UPDATE`
+	if err = tx.GetContext(ctx, &current, lockQuery, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve wallet: %w", err)
+	}
+	currentStatus := current.EffectiveStatus(at)
+	sameExpiry := current.StatusExpiresAt == nil && expiresAt == nil ||
+		current.StatusExpiresAt != nil && expiresAt != nil && current.StatusExpiresAt.Equal(*expiresAt)
+	if currentStatus == status && (status == model.WalletStatusActive || sameExpiry) {
+		return nil, fmt.Errorf("wallet is already %s: %w", status, ErrWalletStatusUnchanged)
+	}
+
+	var wallet model.Wallet
+	updateQuery := `UPDATE wallets w
+                    SET status = $1, status_reason = $2, status_changed_by = $3, status_changed_at = $4, status_expires_at = $5, updated_at = $4
+                    WHERE w.id = $6
+                    RETURNING ` + adminWalletColumns
+	if err = tx.GetContext(ctx, &wallet, updateQuery, status, reason, admin, at, expiresAt, walletID); err != nil {
+		return nil, fmt.Errorf("failed to update wallet status: %w", err)
+	}
+
+	details := fmt.Sprintf("%s -> %s: %s", currentStatus, status, reason)
+	if expiresAt != nil {
+		details = fmt.Sprintf("%s -> %s until %s: %s", currentStatus, status, expiresAt.UTC().Format(time.RFC3339), reason)
+	}
+	entry := &model.AdminAuditEntry{Admin: admin, Action: model.AdminActionSetWalletStatus, WalletID: &walletID, Details: details, CreatedAt: at}
+	if err = insertAdminAuditEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit wallet status: %w", err)
+	}
+	return &wallet, nil
+}
+
+// LiftExpiredWalletStatuses makes every wallet whose restriction expired at or before now active again, and records
+// each in the audit log under SystemActor. It returns the IDs of the wallets lifted.
+func (ar *AdminRepoImpl) LiftExpiredWalletStatuses(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	tx, err := beginTx(ctx, ar.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var lifted []struct {
+		ID     uuid.UUID          `db:"id"`
+		Status model.WalletStatus `db:"status"`
+	}
+	query := `WITH expired AS (
+                  SELECT id, status FROM wallets
+                  WHERE status_expires_at <= $1
+                  FOR UPDATE
+              )
+              UPDATE wallets w
+              SET status = $2, status_reason = 'restriction expired', status_changed_by = NULL,
+                  status_changed_at = w.status_expires_at, status_expires_at = NULL, updated_at = $1
+              FROM expired e
+              WHERE w.id = e.id
+              RETURNING w.id, e.status`
+	if err = tx.SelectContext(ctx, &lifted, query, now, model.WalletStatusActive); err != nil {
+		return nil, fmt.Errorf("failed to lift expired wallet statuses: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(lifted))
+	for _, l := range lifted {
+		entry := &model.AdminAuditEntry{
+			Admin:     SystemActor,
+			Action:    model.AdminActionSetWalletStatus,
+			WalletID:  &l.ID,
+			Details:   fmt.Sprintf("%s -> %s: restriction expired", l.Status, model.WalletStatusActive),
+			CreatedAt: now,
+		}
+		if err = insertAdminAuditEntry(ctx, tx, entry); err != nil {
+			return nil, err
+		}
+		ids = append(ids, l.ID)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit lifted wallet statuses: %w", err)
+	}
+	return ids, nil
+}
+
+// AdjustWallet posts a manual adjustment of amount, a credit when positive and a debit when negative, and records it
+// with its reason code and note in the audit log under admin. A debit cannot take money set aside in pots or held,
+// and fails with ErrInsufficientFunds instead. Adjustments are allowed whatever the wallet's status.
+func (ar *AdminRepoImpl) AdjustWallet(ctx context.Context, admin string, walletIDStr string, amount decimal.Decimal, reason model.AdjustmentReason,
+	note string, at time.Time) (*model.Transaction, error) {
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	tx, err := beginTx(ctx, ar.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Debits must leave pots and holds covered; the condition is always true for credits.
+	adjustQuery := `UPDATE wallets SET balance = balance + $1, updated_at = $2
+                    WHERE id = $3 AND balance - pots_balance - held_balance + $1 >= 0`
+	res, err := tx.ExecContext(ctx, adjustQuery, amount, at, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust wallet balance: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		var exists bool
+		if err = tx.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM wallets WHERE id = $1)`, walletID); err != nil {
+			return nil, fmt.Errorf("failed to check wallet: %w", err)
+		}
+		if !exists {
+			return nil, ErrWalletNotFound
+		}
+		return nil, ErrInsufficientFunds
+	}
+
+	transaction := &model.Transaction{
+		ID:        uuid.New(),
+		WalletID:  walletID,
+		Type:      model.TransactionTypeAdjustment,
+		Amount:    amount,
+		CreatedAt: at,
+	}
+	insertTxQuery := `INSERT INTO transactions (id, wallet_id, type, amount, created_at)
+                      VALUES ($1, $2, $3, $4, $5)`
+	_, err = tx.ExecContext(ctx, insertTxQuery, transaction.ID, transaction.WalletID, transaction.Type, transaction.Amount, transaction.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create adjustment transaction record: %w", err)
+	}
+
+	reasonCode := string(reason)
+	entry := &model.AdminAuditEntry{
+		Admin:         admin,
+		Action:        model.AdminActionAdjustWallet,
+		WalletID:      &walletID,
+		TransactionID: &transaction.ID,
+		ReasonCode:    &reasonCode,
+		Details:       note,
+		CreatedAt:     at,
+	}
+	if err = insertAdminAuditEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit adjustment: %w", err)
+	}
+	return transaction, nil
+}
+
+// ListAdminAuditLog returns up to limit audit entries, newest first, optionally only those of one administrator
+// or about one wallet.
+func (ar *AdminRepoImpl) ListAdminAuditLog(ctx context.Context, admin string, walletIDStr string, limit int) ([]model.AdminAuditEntry, error) {
+	var walletID *uuid.UUID
+	if walletIDStr != "" {
+		id, err := uuid.Parse(walletIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+		}
+		walletID = &id
+	}
+
+	entries := []model.AdminAuditEntry{}
+	query := `SELECT id, admin, action, wallet_id, transaction_id, reason_code, details, created_at
+              FROM admin_audit_log
+              WHERE ($1 = '' OR admin = $1) AND ($2::uuid IS NULL OR wallet_id = $2)
+              ORDER BY created_at DESC
+              LIMIT $3`
+	if err := ar.db.SelectContext(ctx, &entries, query, admin, walletID, limit); err != nil {
+		return nil, fmt.Errorf("database error retrieving audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// insertAdminAuditEntry appends entry to the audit log, in the database transaction of the action when there is one.
+func insertAdminAuditEntry(ctx context.Context, e sqlx.ExtContext, entry *model.AdminAuditEntry) error {
+	entry.ID = uuid.New()
+	query := `INSERT INTO admin_audit_log (id, admin, action, wallet_id, transaction_id, reason_code, details, created_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := e.ExecContext(ctx, query, entry.ID, entry.Admin, entry.Action, entry.WalletID, entry.TransactionID, entry.ReasonCode,
+		entry.Details, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
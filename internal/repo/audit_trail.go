@@ -0,0 +1,210 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+// auditTrailLockKey is the transaction-level advisory lock that serialises appends to the audit trail, so every
+// entry is chained to the one before it. Only the audit trail chainer takes it; requests write to the outbox.
+const auditTrailLockKey = 0x61756474 // "audt"
+
+type auditTrailEntryKey struct{}
+
+// stagedAuditTrailEntry is the entry a transaction stages in the audit trail outbox, as the JSON the database
+// trigger reads from the wallet_app.audit_trail_entry setting.
+type stagedAuditTrailEntry struct {
+	EntryID   uuid.UUID         `json:"entry_id"`
+	RequestID string            `json:"request_id"`
+	Actor     string            `json:"actor"`
+	Method    string            `json:"method"`
+	Route     string            `json:"route"`
+	Params    model.AuditParams `json:"params"`
+	WalletID  *uuid.UUID        `json:"wallet_id"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// pendingAuditTrailEntry is the entry of a request in progress. Once closed, transactions no longer stage it.
+type pendingAuditTrailEntry struct {
+	staged []byte
+	closed atomic.Bool
+}
+
+// WithAuditTrailEntry returns a copy of ctx under which every transaction of the repositories that changes the
+// balance of entry's wallet stages entry in the audit trail outbox under entryID, with the balance before its
+// first change and after its last. The row is written in that transaction, so it commits or rolls back with the
+// money it records, and a failure to write it fails the transaction. The returned function stops the staging;
+// call it once the request is done, before RecordAuditTrailEntry, so work outliving the request is not recorded.
+func WithAuditTrailEntry(ctx context.Context, entryID uuid.UUID, entry *model.AuditTrailEntry) (context.Context, func()) {
+	staged, _ := json.Marshal(stagedAuditTrailEntry{
+		EntryID:   entryID,
+		RequestID: entry.RequestID,
+		Actor:     entry.Actor,
+		Method:    entry.Method,
+		Route:     entry.Route,
+		Params:    entry.Params,
+		WalletID:  entry.WalletID,
+		CreatedAt: entry.CreatedAt.UTC().Truncate(time.Microsecond),
+	})
+	pending := &pendingAuditTrailEntry{staged: staged}
+	return context.WithValue(ctx, auditTrailEntryKey{}, pending), func() { pending.closed.Store(true) }
+}
+
+// beginTx begins a transaction on db which stages the audit trail entry of ctx, if any, as WithAuditTrailEntry
+// describes.
+func beginTx(ctx context.Context, db *sqlx.DB) (*sqlx.Tx, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	pending, ok := ctx.Value(auditTrailEntryKey{}).(*pendingAuditTrailEntry)
+	if !ok || pending.closed.Load() {
+		return tx, nil
+	}
+	if _, err = tx.ExecContext(ctx, `SELECT set_config('wallet_app.audit_trail_entry', $1, TRUE)`, string(pending.staged)); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to stage audit trail entry: %w", err)
+	}
+	return tx, nil
+}
+
+type AuditTrailRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewAuditTrailImpl(db *sqlx.DB) *AuditTrailRepoImpl {
+	return &AuditTrailRepoImpl{db}
+}
+
+// RecordAuditTrailEntry completes the entry entryID in the audit trail outbox with the status and actor of entry.
+// If no transaction of the request staged it, it is written whole, with the wallet's current balance as its
+// balance both before and after, since the request did not change it; a wallet that does not exist is left out.
+func (ar *AuditTrailRepoImpl) RecordAuditTrailEntry(ctx context.Context, entryID uuid.UUID, entry *model.AuditTrailEntry) error {
+	query := `INSERT INTO audit_trail_outbox (entry_id, request_id, actor, method, route, params, status, wallet_id,
+                                             balance_before, balance_after, created_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7,
+                      (SELECT id FROM wallets WHERE id = $8),
+                      (SELECT balance FROM wallets WHERE id = $8),
+                      (SELECT balance FROM wallets WHERE id = $8), $9)
+              ON CONFLICT (entry_id) DO UPDATE SET actor = EXCLUDED.actor, status = EXCLUDED.status`
+	_, err := ar.db.ExecContext(ctx, query, entryID, entry.RequestID, entry.Actor, entry.Method, entry.Route,
+		entry.Params, entry.Status, entry.WalletID, entry.CreatedAt.UTC().Truncate(time.Microsecond))
+	if err != nil {
+		return fmt.Errorf("failed to record audit trail entry: %w", err)
+	}
+	return nil
+}
+
+// ChainAuditTrail appends up to limit entries of the audit trail outbox to the audit trail, in the order they were
+// staged, and moves the audit trail's head on to the last of them. It takes the entries whose request is done and
+// those staged before abandonedBefore, whose request the server never finished recording; they are appended with
+// status 0. It returns how many entries it appended.
+func (ar *AuditTrailRepoImpl) ChainAuditTrail(ctx context.Context, abandonedBefore time.Time, limit int) (int, error) {
+	tx, err := ar.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, auditTrailLockKey); err != nil {
+		return 0, fmt.Errorf("failed to lock audit trail: %w", err)
+	}
+
+	var queued []struct {
+		ID int64 `db:"id"`
+		model.AuditTrailEntry
+	}
+	query := `SELECT id, request_id, actor, method, route, params, COALESCE(status, 0) AS status, wallet_id,
+                     balance_before, balance_after, created_at
+              FROM audit_trail_outbox
+              WHERE status IS NOT NULL OR created_at < $1
+              ORDER BY id
+              LIMIT $2`
+	if err = tx.SelectContext(ctx, &queued, query, abandonedBefore, limit); err != nil {
+		return 0, fmt.Errorf("failed to retrieve audit trail outbox: %w", err)
+	}
+	if len(queued) == 0 {
+		return 0, nil
+	}
+
+	var last struct {
+		Seq  int64  `db:"seq"`
+		Hash string `db:"hash"`
+	}
+	err = tx.GetContext(ctx, &last, `SELECT seq, hash FROM audit_trail ORDER BY seq DESC LIMIT 1`)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to retrieve last audit trail entry: %w", err)
+	}
+
+	insertQuery := `INSERT INTO audit_trail (seq, request_id, actor, method, route, params, status, wallet_id,
+                                            balance_before, balance_after, created_at, prev_hash, hash)
+                    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	var entry model.AuditTrailEntry
+	for _, q := range queued {
+		entry = q.AuditTrailEntry
+		entry.Seq = last.Seq + 1
+		entry.PrevHash = last.Hash
+		entry.CreatedAt = entry.CreatedAt.UTC()
+		entry.Hash = entry.ComputeHash()
+
+		_, err = tx.ExecContext(ctx, insertQuery, entry.Seq, entry.RequestID, entry.Actor, entry.Method, entry.Route,
+			entry.Params, entry.Status, entry.WalletID, entry.BalanceBefore, entry.BalanceAfter, entry.CreatedAt,
+			entry.PrevHash, entry.Hash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to write audit trail entry: %w", err)
+		}
+		if _, err = tx.ExecContext(ctx, `DELETE FROM audit_trail_outbox WHERE id = $1`, q.ID); err != nil {
+			return 0, fmt.Errorf("failed to remove audit trail entry from outbox: %w", err)
+		}
+		last.Seq, last.Hash = entry.Seq, entry.Hash
+	}
+
+	headQuery := `INSERT INTO audit_trail_head (id, seq, hash, updated_at)
+                  VALUES (TRUE, $1, $2, $3)
+                  ON CONFLICT (id) DO UPDATE SET seq = EXCLUDED.seq, hash = EXCLUDED.hash, updated_at = EXCLUDED.updated_at`
+	if _, err = tx.ExecContext(ctx, headQuery, entry.Seq, entry.Hash, entry.CreatedAt); err != nil {
+		return 0, fmt.Errorf("failed to move audit trail head: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit audit trail entries: %w", err)
+	}
+	return len(queued), nil
+}
+
+// ListAuditTrail returns up to limit entries numbered after afterSeq, in order.
+func (ar *AuditTrailRepoImpl) ListAuditTrail(ctx context.Context, afterSeq int64, limit int) ([]model.AuditTrailEntry, error) {
+	entries := []model.AuditTrailEntry{}
+	query := `SELECT seq, request_id, actor, method, route, params, status, wallet_id, balance_before, balance_after,
+                     created_at, prev_hash, hash
+              FROM audit_trail
+              WHERE seq > $1
+              ORDER BY seq
+              LIMIT $2`
+	if err := ar.db.SelectContext(ctx, &entries, query, afterSeq, limit); err != nil {
+		return nil, fmt.Errorf("database error retrieving audit trail: %w", err)
+	}
+	return entries, nil
+}
+
+// GetAuditTrailHead returns the seq and hash of the last entry appended to the audit trail, or nil if none was.
+func (ar *AuditTrailRepoImpl) GetAuditTrailHead(ctx context.Context) (*model.AuditTrailHead, error) {
+	var head model.AuditTrailHead
+	if err := ar.db.GetContext(ctx, &head, `SELECT seq, hash FROM audit_trail_head`); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error retrieving audit trail head: %w", err)
+	}
+	return &head, nil
+}
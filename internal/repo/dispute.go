@@ -0,0 +1,459 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+var (
+	// ErrDisputeNotFound indicates that the requested dispute was not found for the wallet.
+	ErrDisputeNotFound = errors.New("dispute not found")
+	// ErrDisputeNotOpen indicates an attempt to change a dispute that was already resolved or withdrawn.
+	ErrDisputeNotOpen = errors.New("dispute is not open")
+	// ErrDisputeExists indicates that the transaction has already been disputed.
+	ErrDisputeExists = errors.New("transaction has already been disputed")
+	// ErrTransactionNotDisputable indicates that the transaction is not a transfer made from the wallet, or is
+	// the funding of an escrow, which is settled through the escrow instead.
+	ErrTransactionNotDisputable = errors.New("transaction cannot be disputed from this wallet")
+	// ErrDisputeWindowClosed indicates that the transaction is too old to be disputed.
+	ErrDisputeWindowClosed = errors.New("transaction is too old to be disputed")
+)
+
+const disputeColumns = `id, transaction_id, payer_wallet_id, recipient_wallet_id, amount, held_amount, reason, status, opened_by,
+                        resolved_in_favor_of, reversal_transaction_id, resolved_by, created_at, updated_at`
+
+type DisputeRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewDisputeImpl(db *sqlx.DB) *DisputeRepoImpl {
+	return &DisputeRepoImpl{db}
+}
+
+// OpenDispute opens a dispute against dispute.TransactionID, a transfer made from dispute.PayerWalletID no earlier
+// than notBefore. The user must be allowed to spend from the payer's wallet. With hold, the amount is held on the
+// recipient's wallet as far as the money available there covers it; dispute.HeldAmount reports what was held.
+func (dr *DisputeRepoImpl) OpenDispute(ctx context.Context, userIDStr string, dispute *model.Dispute, hold bool, notBefore time.Time) error {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	tx, err := beginTx(ctx, dr.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkWalletAccess(ctx, tx, userID, dispute.PayerWalletID, model.WalletRoleSpender); err != nil {
+		return err
+	}
+
+	var transfer model.Transaction
+	transferQuery := `SELECT t.id, t.wallet_id, t.type, t.amount, t.related_wallet_id, t.created_at
+                      FROM transactions t
+                      WHERE t.id = $1 AND t.wallet_id = $2 AND t.type = $3 AND t.related_wallet_id IS NOT NULL
+                        AND NOT EXISTS (SELECT 1 FROM escrows e WHERE e.funding_transaction_id = t.id)`
+	err = tx.GetContext(ctx, &transfer, transferQuery, dispute.TransactionID, dispute.PayerWalletID, model.TransactionTypeTransfer)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTransactionNotDisputable
+		}
+		return fmt.Errorf("failed to retrieve disputed transaction: %w", err)
+	}
+	if transfer.CreatedAt.Before(notBefore) {
+		return ErrDisputeWindowClosed
+	}
+
+	dispute.RecipientWalletID = *transfer.RelatedWalletID
+	dispute.Amount = transfer.Amount.Abs()
+	dispute.HeldAmount = decimal.Zero
+	dispute.Status = model.DisputeStatusOpen
+	dispute.OpenedBy = userID
+	dispute.UpdatedAt = dispute.CreatedAt
+
+	if hold {
+		var recipient model.Wallet
+		lockQuery := `SELECT id, user_id, name, balance, pots_balance, held_balance, created_at, updated_at
+                      FROM wallets
+                      WHERE id = $1 FOR UPDATE`
+		if err = tx.GetContext(ctx, &recipient, lockQuery, dispute.RecipientWalletID); err != nil {
+			return fmt.Errorf("failed to retrieve recipient wallet: %w", err)
+		}
+		dispute.HeldAmount = decimal.Min(dispute.Amount, decimal.Max(recipient.Available(), decimal.Zero))
+		holdQuery := `UPDATE wallets SET held_balance = held_balance + $1, updated_at = $2 WHERE id = $3`
+		if _, err = tx.ExecContext(ctx, holdQuery, dispute.HeldAmount, dispute.CreatedAt, dispute.RecipientWalletID); err != nil {
+			return fmt.Errorf("failed to hold disputed funds: %w", err)
+		}
+	}
+
+	insertQuery := `INSERT INTO disputes (id, transaction_id, payer_wallet_id, recipient_wallet_id, amount, held_amount, reason,
+                                          status, opened_by, created_at, updated_at)
+                    VALUES (:id, :transaction_id, :payer_wallet_id, :recipient_wallet_id, :amount, :held_amount, :reason,
+                            :status, :opened_by, :created_at, :updated_at)`
+	if _, err = tx.NamedExecContext(ctx, insertQuery, dispute); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return ErrDisputeExists
+		}
+		return fmt.Errorf("failed to open dispute: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dispute: %w", err)
+	}
+	return nil
+}
+
+// ListDisputes returns the disputes a wallet the user is a member of is the payer or the recipient in, newest first.
+func (dr *DisputeRepoImpl) ListDisputes(ctx context.Context, userIDStr string, walletIDStr string) ([]model.Dispute, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	if err = checkWalletAccess(ctx, dr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	disputes := []model.Dispute{}
+	query := `SELECT ` + disputeColumns + `
+              FROM disputes
+              WHERE payer_wallet_id = $1 OR recipient_wallet_id = $1
+              ORDER BY created_at DESC`
+	if err = dr.db.SelectContext(ctx, &disputes, query, walletID); err != nil {
+		return nil, fmt.Errorf("database error retrieving disputes: %w", err)
+	}
+	return disputes, nil
+}
+
+// GetDispute returns a dispute the wallet is the payer or the recipient in, with its notes, checking that the
+// user is a member of the wallet.
+func (dr *DisputeRepoImpl) GetDispute(ctx context.Context, userIDStr string, walletIDStr string, disputeIDStr string) (*model.Dispute, error) {
+	userID, walletID, disputeID, err := parseDisputeIDs(userIDStr, walletIDStr, disputeIDStr)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkWalletAccess(ctx, dr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	var dispute model.Dispute
+	query := `SELECT ` + disputeColumns + `
+              FROM disputes
+              WHERE id = $1 AND (payer_wallet_id = $2 OR recipient_wallet_id = $2)`
+	if err = dr.db.GetContext(ctx, &dispute, query, disputeID, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDisputeNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve dispute: %w", err)
+	}
+	if dispute.Notes, err = disputeNotes(ctx, dr.db, dispute.ID); err != nil {
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// AddDisputeNote attaches evidence written by the user to an open dispute the wallet is the payer or the
+// recipient in. The user must be allowed to spend from the wallet.
+func (dr *DisputeRepoImpl) AddDisputeNote(ctx context.Context, userIDStr string, walletIDStr string, note *model.DisputeNote) error {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	tx, err := beginTx(ctx, dr.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkWalletAccess(ctx, tx, userID, walletID, model.WalletRoleSpender); err != nil {
+		return err
+	}
+	dispute, err := lockDisputeTx(ctx, tx, note.DisputeID)
+	if err != nil {
+		return err
+	}
+	if dispute.PayerWalletID != walletID && dispute.RecipientWalletID != walletID {
+		return ErrDisputeNotFound
+	}
+
+	note.AuthorUserID = &userID
+	note.AuthorAdmin = nil
+	if err = insertDisputeNoteTx(ctx, tx, note); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dispute note: %w", err)
+	}
+	return nil
+}
+
+// WithdrawDispute withdraws an open dispute the wallet raised and releases the funds held for it. The user must
+// be allowed to spend from the payer's wallet.
+func (dr *DisputeRepoImpl) WithdrawDispute(ctx context.Context, userIDStr string, walletIDStr string, disputeIDStr string, at time.Time) (*model.Dispute, error) {
+	userID, walletID, disputeID, err := parseDisputeIDs(userIDStr, walletIDStr, disputeIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := beginTx(ctx, dr.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkWalletAccess(ctx, tx, userID, walletID, model.WalletRoleSpender); err != nil {
+		return nil, err
+	}
+	dispute, err := lockDisputeTx(ctx, tx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if dispute.PayerWalletID != walletID {
+		return nil, ErrDisputeNotFound
+	}
+	if err = closeDisputeTx(ctx, tx, dispute, model.DisputeStatusWithdrawn, nil, nil, at); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit dispute withdrawal: %w", err)
+	}
+	return dispute, nil
+}
+
+// ListDisputesByStatus returns all disputes in status, oldest first, for administrators.
+func (dr *DisputeRepoImpl) ListDisputesByStatus(ctx context.Context, status model.DisputeStatus) ([]model.Dispute, error) {
+	disputes := []model.Dispute{}
+	query := `SELECT ` + disputeColumns + `
+              FROM disputes
+              WHERE status = $1
+              ORDER BY created_at`
+	if err := dr.db.SelectContext(ctx, &disputes, query, status); err != nil {
+		return nil, fmt.Errorf("database error retrieving disputes: %w", err)
+	}
+	return disputes, nil
+}
+
+// GetDisputeByID returns any dispute with its notes, for administrators.
+func (dr *DisputeRepoImpl) GetDisputeByID(ctx context.Context, disputeIDStr string) (*model.Dispute, error) {
+	disputeID, err := uuid.Parse(disputeIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dispute ID format: %w", err)
+	}
+
+	var dispute model.Dispute
+	query := `SELECT ` + disputeColumns + `
+              FROM disputes
+              WHERE id = $1`
+	if err = dr.db.GetContext(ctx, &dispute, query, disputeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDisputeNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve dispute: %w", err)
+	}
+	if dispute.Notes, err = disputeNotes(ctx, dr.db, dispute.ID); err != nil {
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// AddAdminDisputeNote attaches a note written by the administrator note.AuthorAdmin to an open dispute.
+func (dr *DisputeRepoImpl) AddAdminDisputeNote(ctx context.Context, note *model.DisputeNote) error {
+	tx, err := beginTx(ctx, dr.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = lockDisputeTx(ctx, tx, note.DisputeID); err != nil {
+		return err
+	}
+	note.AuthorUserID = nil
+	if err = insertDisputeNoteTx(ctx, tx, note); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dispute note: %w", err)
+	}
+	return nil
+}
+
+// ResolveDispute resolves an open dispute on the decision of the administrator resolvedBy and releases the funds
+// held for it. In favour of the payer, the transfer is reversed: its amount is paid back from the recipient's wallet,
+// which fails with ErrInsufficientFunds if the money available there, once the hold is released, cannot cover it.
+func (dr *DisputeRepoImpl) ResolveDispute(ctx context.Context, disputeIDStr string, inFavorOf model.DisputeParty, resolvedBy string, at time.Time) (*model.Dispute, error) {
+	disputeID, err := uuid.Parse(disputeIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dispute ID format: %w", err)
+	}
+
+	tx, err := beginTx(ctx, dr.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	dispute, err := lockDisputeTx(ctx, tx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if err = closeDisputeTx(ctx, tx, dispute, model.DisputeStatusResolved, &inFavorOf, &resolvedBy, at); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit dispute resolution: %w", err)
+	}
+	return dispute, nil
+}
+
+func parseDisputeIDs(userIDStr string, walletIDStr string, disputeIDStr string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	disputeID, err := uuid.Parse(disputeIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid dispute ID format: %w", err)
+	}
+	return userID, walletID, disputeID, nil
+}
+
+// lockDisputeTx locks an open dispute FOR UPDATE.
+func lockDisputeTx(ctx context.Context, tx *sqlx.Tx, disputeID uuid.UUID) (*model.Dispute, error) {
+	var dispute model.Dispute
+	query := `SELECT ` + disputeColumns + `
+              FROM disputes
+              WHERE id = $1 FOR UPDATE`
+	if err := tx.GetContext(ctx, &dispute, query, disputeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDisputeNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve dispute: %w", err)
+	}
+	if dispute.Status != model.DisputeStatusOpen {
+		return nil, fmt.Errorf("dispute is %s: %w", dispute.Status, ErrDisputeNotOpen)
+	}
+	return &dispute, nil
+}
+
+// closeDisputeTx moves a locked, open dispute to status and releases the funds held for it. A dispute resolved
+// in favour of the payer also has its transfer reversed.
+func closeDisputeTx(ctx context.Context, tx *sqlx.Tx, dispute *model.Dispute, status model.DisputeStatus, inFavorOf *model.DisputeParty,
+	resolvedBy *string, at time.Time) error {
+	if err := lockWalletsTx(ctx, tx, dispute.PayerWalletID, dispute.RecipientWalletID); err != nil {
+		return err
+	}
+	if dispute.HeldAmount.IsPositive() {
+		releaseQuery := `UPDATE wallets SET held_balance = held_balance - $1, updated_at = $2 WHERE id = $3`
+		if _, err := tx.ExecContext(ctx, releaseQuery, dispute.HeldAmount, at, dispute.RecipientWalletID); err != nil {
+			return fmt.Errorf("failed to release disputed funds: %w", err)
+		}
+	}
+
+	var reversalID *uuid.UUID
+	if inFavorOf != nil && *inFavorOf == model.DisputePartyPayer {
+		reversal, err := reverseTransferTx(ctx, tx, dispute, at)
+		if err != nil {
+			return err
+		}
+		reversalID = &reversal.ID
+	}
+
+	query := `UPDATE disputes
+              SET status = $1, resolved_in_favor_of = $2, reversal_transaction_id = $3, resolved_by = $4, updated_at = $5
+              WHERE id = $6`
+	if _, err := tx.ExecContext(ctx, query, status, inFavorOf, reversalID, resolvedBy, at, dispute.ID); err != nil {
+		return fmt.Errorf("failed to update dispute: %w", err)
+	}
+	dispute.Status = status
+	dispute.ResolvedInFavorOf = inFavorOf
+	dispute.ReversalTransactionID = reversalID
+	dispute.ResolvedBy = resolvedBy
+	dispute.UpdatedAt = at
+	return nil
+}
+
+// reverseTransferTx pays the disputed amount back from the recipient's wallet to the payer's and records it as a
+// reversal of the disputed transfer. Both wallets must already be locked by the caller.
+func reverseTransferTx(ctx context.Context, tx *sqlx.Tx, dispute *model.Dispute, at time.Time) (*model.Transaction, error) {
+	debitQuery := `UPDATE wallets SET balance = balance - $1, updated_at = $2 WHERE id = $3 AND balance - pots_balance - held_balance >= $1`
+	res, err := tx.ExecContext(ctx, debitQuery, dispute.Amount, at, dispute.RecipientWalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to debit reversal: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrInsufficientFunds
+	}
+
+	creditQuery := `UPDATE wallets SET balance = balance + $1, updated_at = $2 WHERE id = $3`
+	if _, err = tx.ExecContext(ctx, creditQuery, dispute.Amount, at, dispute.PayerWalletID); err != nil {
+		return nil, fmt.Errorf("failed to credit reversal: %w", err)
+	}
+
+	transaction := &model.Transaction{
+		ID:                  uuid.New(),
+		WalletID:            dispute.RecipientWalletID,
+		Type:                model.TransactionTypeReversal,
+		Amount:              dispute.Amount,
+		RelatedWalletID:     &dispute.PayerWalletID,
+		CreatedAt:           at,
+		ParentTransactionID: &dispute.TransactionID,
+	}
+	insertTxQuery := `INSERT INTO transactions (id, wallet_id, type, amount, related_wallet_id, created_at, parent_transaction_id)
+                      VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err = tx.ExecContext(ctx, insertTxQuery, transaction.ID, transaction.WalletID, transaction.Type, transaction.Amount,
+		transaction.RelatedWalletID, transaction.CreatedAt, transaction.ParentTransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reversal transaction record: %w", err)
+	}
+	return transaction, nil
+}
+
+func insertDisputeNoteTx(ctx context.Context, tx *sqlx.Tx, note *model.DisputeNote) error {
+	query := `INSERT INTO dispute_notes (id, dispute_id, author_user_id, author_admin, body, created_at)
+              VALUES (:id, :dispute_id, :author_user_id, :author_admin, :body, :created_at)`
+	if _, err := tx.NamedExecContext(ctx, query, note); err != nil {
+		return fmt.Errorf("failed to add dispute note: %w", err)
+	}
+	return nil
+}
+
+// disputeNotes returns the notes of a dispute, oldest first.
+func disputeNotes(ctx context.Context, q sqlx.QueryerContext, disputeID uuid.UUID) ([]model.DisputeNote, error) {
+	notes := []model.DisputeNote{}
+	query := `SELECT id, dispute_id, author_user_id, author_admin, body, created_at
+              FROM dispute_notes
+              WHERE dispute_id = $1
+              ORDER BY created_at, id`
+	if err := sqlx.SelectContext(ctx, q, &notes, query, disputeID); err != nil {
+		return nil, fmt.Errorf("database error retrieving dispute notes: %w", err)
+	}
+	return notes, nil
+}
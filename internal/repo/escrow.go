@@ -0,0 +1,321 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+var (
+	// ErrEscrowNotFound indicates that the requested escrow was not found for the wallet.
+	ErrEscrowNotFound = errors.New("escrow not found")
+	// ErrEscrowNotHeld indicates an attempt to release or refund an escrow that was already settled.
+	ErrEscrowNotHeld = errors.New("escrow is not held")
+	// ErrEscrowNotDue indicates an attempt to release an escrow on timeout before its release_after.
+	ErrEscrowNotDue = errors.New("escrow is not due for release")
+	// ErrEscrowNotBuyer indicates that only the buyer's wallet may confirm an escrow.
+	ErrEscrowNotBuyer = errors.New("only the buyer can confirm an escrow")
+)
+
+const escrowColumns = `id, buyer_wallet_id, seller_wallet_id, escrow_wallet_id, amount, memo, status, release_after, created_by,
+                       funding_transaction_id, settlement_transaction_id, resolution, resolved_by, created_at, updated_at`
+
+type EscrowRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewEscrowImpl(db *sqlx.DB) *EscrowRepoImpl {
+	return &EscrowRepoImpl{db}
+}
+
+// CreateEscrow moves the escrow's amount from the buyer's wallet into the escrow account, charges the buyer's
+// wallet fee and stores the escrow, all in one database transaction.
+// The user must be allowed to spend from the buyer's wallet, and the transfer is subject to its approval policy.
+func (er *EscrowRepoImpl) CreateEscrow(ctx context.Context, userIDStr string, escrow *model.Escrow, fee model.Fee) error {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	tx, err := beginTx(ctx, er.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkWalletAccess(ctx, tx, userID, escrow.BuyerWalletID, model.WalletRoleSpender); err != nil {
+		return err
+	}
+	if err = checkApprovalPolicyTx(ctx, tx, escrow.BuyerWalletID, escrow.Amount); err != nil {
+		return err
+	}
+
+	var sellerExists bool
+	checkSellerQuery := `SELECT EXISTS(SELECT 1 FROM wallets WHERE id = $1)`
+	if err = tx.GetContext(ctx, &sellerExists, checkSellerQuery, escrow.SellerWalletID); err != nil {
+		return fmt.Errorf("failed to check seller wallet: %w", err)
+	}
+	if !sellerExists {
+		return fmt.Errorf("seller wallet not found: %w", ErrWalletNotFound)
+	}
+
+	if err = lockWalletsTx(ctx, tx, escrow.BuyerWalletID, escrow.EscrowWalletID); err != nil {
+		return err
+	}
+	funding, err := moveFundsTx(ctx, tx, &userID, escrow.BuyerWalletID, escrow.EscrowWalletID, escrow.Amount)
+	if err != nil {
+		return err
+	}
+	if _, err = chargeFeeTx(ctx, tx, escrow.BuyerWalletID, funding.ID, userID, fee); err != nil {
+		return err
+	}
+
+	escrow.Status = model.EscrowStatusHeld
+	escrow.CreatedBy = userID
+	escrow.FundingTransactionID = funding.ID
+	escrow.UpdatedAt = escrow.CreatedAt
+	insertQuery := `INSERT INTO escrows (id, buyer_wallet_id, seller_wallet_id, escrow_wallet_id, amount, memo, status, release_after,
+                                         created_by, funding_transaction_id, created_at, updated_at)
+                    VALUES (:id, :buyer_wallet_id, :seller_wallet_id, :escrow_wallet_id, :amount, :memo, :status, :release_after,
+                            :created_by, :funding_transaction_id, :created_at, :updated_at)`
+	if _, err = tx.NamedExecContext(ctx, insertQuery, escrow); err != nil {
+		return fmt.Errorf("failed to create escrow: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit escrow: %w", err)
+	}
+	return nil
+}
+
+// ListEscrows returns the escrows a wallet the user is a member of buys or sells in, newest first.
+func (er *EscrowRepoImpl) ListEscrows(ctx context.Context, userIDStr string, walletIDStr string) ([]model.Escrow, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	if err = checkWalletAccess(ctx, er.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	escrows := []model.Escrow{}
+	query := `SELECT ` + escrowColumns + `
+              FROM escrows
+              WHERE buyer_wallet_id = $1 OR seller_wallet_id = $1
+              ORDER BY created_at DESC`
+	if err = er.db.SelectContext(ctx, &escrows, query, walletID); err != nil {
+		return nil, fmt.Errorf("database error retrieving escrows: %w", err)
+	}
+	return escrows, nil
+}
+
+// GetEscrow returns an escrow the wallet buys or sells in, checking that the user is a member of the wallet.
+func (er *EscrowRepoImpl) GetEscrow(ctx context.Context, userIDStr string, walletIDStr string, escrowIDStr string) (*model.Escrow, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	escrowID, err := uuid.Parse(escrowIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid escrow ID format: %w", err)
+	}
+	if err = checkWalletAccess(ctx, er.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	var escrow model.Escrow
+	query := `SELECT ` + escrowColumns + `
+              FROM escrows
+              WHERE id = $1 AND (buyer_wallet_id = $2 OR seller_wallet_id = $2)`
+	if err = er.db.GetContext(ctx, &escrow, query, escrowID, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEscrowNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve escrow: %w", err)
+	}
+	return &escrow, nil
+}
+
+// ConfirmEscrow releases a held escrow to the seller on the buyer's confirmation. The user must be allowed to
+// spend from the buyer's wallet.
+func (er *EscrowRepoImpl) ConfirmEscrow(ctx context.Context, userIDStr string, walletIDStr string, escrowIDStr string, at time.Time) (*model.Escrow, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	escrowID, err := uuid.Parse(escrowIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid escrow ID format: %w", err)
+	}
+
+	tx, err := beginTx(ctx, er.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkWalletAccess(ctx, tx, userID, walletID, model.WalletRoleSpender); err != nil {
+		return nil, err
+	}
+	escrow, err := lockEscrowTx(ctx, tx, escrowID)
+	if err != nil {
+		return nil, err
+	}
+	if escrow.BuyerWalletID != walletID {
+		if escrow.SellerWalletID == walletID {
+			return nil, ErrEscrowNotBuyer
+		}
+		return nil, ErrEscrowNotFound
+	}
+	if err = settleEscrowTx(ctx, tx, escrow, model.EscrowStatusReleased, model.EscrowResolutionBuyerConfirmed, &userID, nil, at); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit escrow release: %w", err)
+	}
+	return escrow, nil
+}
+
+// SettleEscrow releases a held escrow to the seller or refunds it to the buyer, without checking who may do so.
+// It is used for administrators' decisions, named by resolvedBy, and for releases on timeout, where resolvedBy is
+// nil and the escrow must be past its release_after.
+func (er *EscrowRepoImpl) SettleEscrow(ctx context.Context, escrowIDStr string, outcome model.EscrowStatus, resolution model.EscrowResolution, resolvedBy *string, at time.Time) (*model.Escrow, error) {
+	escrowID, err := uuid.Parse(escrowIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid escrow ID format: %w", err)
+	}
+
+	tx, err := beginTx(ctx, er.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	escrow, err := lockEscrowTx(ctx, tx, escrowID)
+	if err != nil {
+		return nil, err
+	}
+	if resolution == model.EscrowResolutionTimeout && escrow.ReleaseAfter.After(at) {
+		return nil, ErrEscrowNotDue
+	}
+	if err = settleEscrowTx(ctx, tx, escrow, outcome, resolution, nil, resolvedBy, at); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit escrow settlement: %w", err)
+	}
+	return escrow, nil
+}
+
+// ListEscrowsByStatus returns all escrows in status, oldest first, for administrators.
+func (er *EscrowRepoImpl) ListEscrowsByStatus(ctx context.Context, status model.EscrowStatus) ([]model.Escrow, error) {
+	escrows := []model.Escrow{}
+	query := `SELECT ` + escrowColumns + `
+              FROM escrows
+              WHERE status = $1
+              ORDER BY created_at`
+	if err := er.db.SelectContext(ctx, &escrows, query, status); err != nil {
+		return nil, fmt.Errorf("database error retrieving escrows: %w", err)
+	}
+	return escrows, nil
+}
+
+// ListEscrowsDue returns the IDs of held escrows whose release_after is not after before.
+func (er *EscrowRepoImpl) ListEscrowsDue(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	query := `SELECT id FROM escrows WHERE status = $1 AND release_after <= $2 ORDER BY release_after`
+	if err := er.db.SelectContext(ctx, &ids, query, model.EscrowStatusHeld, before); err != nil {
+		return nil, fmt.Errorf("database error retrieving escrows due: %w", err)
+	}
+	return ids, nil
+}
+
+// lockEscrowTx locks a held escrow FOR UPDATE.
+func lockEscrowTx(ctx context.Context, tx *sqlx.Tx, escrowID uuid.UUID) (*model.Escrow, error) {
+	var escrow model.Escrow
+	query := `SELECT ` + escrowColumns + `
+              FROM escrows
+              WHERE id = $1 FOR UPDATE`
+	if err := tx.GetContext(ctx, &escrow, query, escrowID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrEscrowNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve escrow: %w", err)
+	}
+	if escrow.Status != model.EscrowStatusHeld {
+		return nil, fmt.Errorf("escrow is %s: %w", escrow.Status, ErrEscrowNotHeld)
+	}
+	return &escrow, nil
+}
+
+// settleEscrowTx pays a locked, held escrow out of the escrow account, to the seller for EscrowStatusReleased or
+// back to the buyer for EscrowStatusRefunded, and records the outcome on the escrow.
+func settleEscrowTx(ctx context.Context, tx *sqlx.Tx, escrow *model.Escrow, outcome model.EscrowStatus, resolution model.EscrowResolution,
+	initiatedBy *uuid.UUID, resolvedBy *string, at time.Time) error {
+	var payee uuid.UUID
+	switch outcome {
+	case model.EscrowStatusReleased:
+		payee = escrow.SellerWalletID
+	case model.EscrowStatusRefunded:
+		payee = escrow.BuyerWalletID
+	default:
+		return fmt.Errorf("unknown escrow outcome %q", outcome)
+	}
+
+	if err := lockWalletsTx(ctx, tx, escrow.EscrowWalletID, payee); err != nil {
+		return err
+	}
+	settlement, err := moveFundsTx(ctx, tx, initiatedBy, escrow.EscrowWalletID, payee, escrow.Amount)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE escrows
+              SET status = $1, settlement_transaction_id = $2, resolution = $3, resolved_by = $4, updated_at = $5
+              WHERE id = $6`
+	if _, err = tx.ExecContext(ctx, query, outcome, settlement.ID, resolution, resolvedBy, at, escrow.ID); err != nil {
+		return fmt.Errorf("failed to update escrow: %w", err)
+	}
+	escrow.Status = outcome
+	escrow.SettlementTransactionID = &settlement.ID
+	escrow.Resolution = &resolution
+	escrow.ResolvedBy = resolvedBy
+	escrow.UpdatedAt = at
+	return nil
+}
+
+// lockWalletsTx locks the wallet rows FOR UPDATE in ID order. The escrow account takes part in every escrow
+// movement, in either direction, so locking in a fixed order keeps concurrent movements from deadlocking.
+func lockWalletsTx(ctx context.Context, tx *sqlx.Tx, walletIDs ...uuid.UUID) error {
+	var locked []uuid.UUID
+	query := `SELECT id FROM wallets WHERE id = ANY($1) ORDER BY id FOR UPDATE`
+	if err := tx.SelectContext(ctx, &locked, query, pq.Array(walletIDs)); err != nil {
+		return fmt.Errorf("failed to lock wallets: %w", err)
+	}
+	if len(locked) != len(walletIDs) {
+		return ErrWalletNotFound
+	}
+	return nil
+}
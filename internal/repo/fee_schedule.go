@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+var (
+	// ErrFeeScheduleNotFound indicates that no fee schedule is in effect for the operation and currency.
+	ErrFeeScheduleNotFound = errors.New("fee schedule not found")
+	// ErrFeeScheduleConflict indicates an attempt to change a fee schedule version that already exists.
+	ErrFeeScheduleConflict = errors.New("fee schedule version already exists with a different rule")
+)
+
+const feeScheduleColumns = `id, operation, currency, version, rule, effective_from, created_at`
+
+type FeeScheduleRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewFeeScheduleImpl(db *sqlx.DB) *FeeScheduleRepoImpl {
+	return &FeeScheduleRepoImpl{db}
+}
+
+// GetActiveFeeSchedule returns the highest version of the schedule for the operation and currency
+// that is in effect at the given time.
+func (fr *FeeScheduleRepoImpl) GetActiveFeeSchedule(ctx context.Context, operation model.FeeOperation, currency string, at time.Time) (*model.FeeSchedule, error) {
+	var schedule model.FeeSchedule
+	query := `SELECT ` + feeScheduleColumns + `
+              FROM fee_schedules
+              WHERE operation = $1 AND currency = $2 AND effective_from <= $3
+              ORDER BY version DESC
+              LIMIT 1`
+	if err := fr.db.GetContext(ctx, &schedule, query, operation, currency, at); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFeeScheduleNotFound
+		}
+		return nil, fmt.Errorf("database error retrieving fee schedule: %w", err)
+	}
+	return &schedule, nil
+}
+
+// ListFeeSchedules returns every version of every schedule for the currency, newest version first.
+func (fr *FeeScheduleRepoImpl) ListFeeSchedules(ctx context.Context, currency string) ([]model.FeeSchedule, error) {
+	schedules := []model.FeeSchedule{}
+	query := `SELECT ` + feeScheduleColumns + `
+              FROM fee_schedules
+              WHERE currency = $1
+              ORDER BY operation, version DESC`
+	if err := fr.db.SelectContext(ctx, &schedules, query, currency); err != nil {
+		return nil, fmt.Errorf("database error retrieving fee schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// SyncFeeSchedules stores schedule versions loaded from configuration. Versions already stored are left alone
+// as long as their rule is unchanged; a changed rule for an existing version fails with ErrFeeScheduleConflict,
+// because the fees charged under it could otherwise no longer be explained.
+func (fr *FeeScheduleRepoImpl) SyncFeeSchedules(ctx context.Context, schedules []model.FeeSchedule) error {
+	tx, err := fr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, s := range schedules {
+		var stored model.FeeSchedule
+		query := `SELECT ` + feeScheduleColumns + `
+                  FROM fee_schedules
+                  WHERE operation = $1 AND currency = $2 AND version = $3`
+		err = tx.GetContext(ctx, &stored, query, s.Operation, s.Currency, s.Version)
+		switch {
+		case err == nil:
+			if !sameFeeRule(stored.Rule, s.Rule) || !stored.EffectiveFrom.Equal(s.EffectiveFrom) {
+				return fmt.Errorf("%s %s version %d: %w", s.Operation, s.Currency, s.Version, ErrFeeScheduleConflict)
+			}
+			continue
+		case !errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("database error retrieving fee schedule: %w", err)
+		}
+
+		insertQuery := `INSERT INTO fee_schedules (id, operation, currency, version, rule, effective_from, created_at)
+                        VALUES (:id, :operation, :currency, :version, :rule, :effective_from, :created_at)`
+		if _, err = tx.NamedExecContext(ctx, insertQuery, s); err != nil {
+			return fmt.Errorf("failed to store fee schedule: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit fee schedules: %w", err)
+	}
+	return nil
+}
+
+// sameFeeRule compares rules by value. Decimals marshal without trailing zeros, so "1.0" and "1" are equal.
+func sameFeeRule(a, b model.FeeRule) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
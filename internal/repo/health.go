@@ -0,0 +1,61 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/pkg/migrate"
+)
+
+type HealthRepoImpl struct {
+	db         *sqlx.DB
+	migrations []migrate.Migration
+}
+
+// NewHealthImpl checks db, and that it has every one of migrations applied.
+func NewHealthImpl(db *sqlx.DB, migrations []migrate.Migration) *HealthRepoImpl {
+	return &HealthRepoImpl{db, migrations}
+}
+
+// Ping checks that a connection to the database can be made and used.
+func (hr *HealthRepoImpl) Ping(ctx context.Context) error {
+	if err := hr.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
+// PoolStats returns the state of the connection pool.
+func (hr *HealthRepoImpl) PoolStats() model.PoolStats {
+	s := hr.db.Stats()
+	return model.PoolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDurationMs:     float64(s.WaitDuration) / float64(time.Millisecond),
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
+
+// GetMigrationState returns which migrations of this build are not applied to the database.
+func (hr *HealthRepoImpl) GetMigrationState(ctx context.Context) (*model.MigrationState, error) {
+	statuses, err := migrate.New(hr.db, hr.migrations).Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
+	state := &model.MigrationState{Pending: []int64{}}
+	for _, s := range statuses {
+		state.Latest = max(state.Latest, s.Version)
+		if s.AppliedAt == nil {
+			state.Pending = append(state.Pending, s.Version)
+		}
+	}
+	return state, nil
+}
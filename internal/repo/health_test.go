@@ -0,0 +1,44 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+	"github.com/kylenguyen/wallet-app/migrations"
+	"github.com/kylenguyen/wallet-app/pkg/migrate"
+)
+
+func TestHealthRepoPostgres(t *testing.T) {
+	db := repotest.Postgres(t)
+	ctx := context.Background()
+	schema, err := migrations.Load()
+	require.NoError(t, err)
+	latest := schema[len(schema)-1].Version
+
+	tests := []struct {
+		name        string
+		migrations  []migrate.Migration
+		wantLatest  int64
+		wantPending []int64
+	}{
+		{"current", schema, latest, []int64{}},
+		{"newer build", append(schema[:len(schema):len(schema)], migrate.Migration{Version: latest + 1, Name: "next", Up: "SELECT 1"}), latest + 1, []int64{latest + 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hr := repo.NewHealthImpl(db, tt.migrations)
+			require.NoError(t, hr.Ping(ctx))
+			assert.Positive(t, hr.PoolStats().OpenConnections)
+
+			state, err := hr.GetMigrationState(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLatest, state.Latest)
+			assert.Equal(t, tt.wantPending, state.Pending)
+		})
+	}
+}
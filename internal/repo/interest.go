@@ -0,0 +1,260 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+// ErrWalletProductNotFound indicates that the requested wallet product does not exist.
+var ErrWalletProductNotFound = errors.New("wallet product not found")
+
+// foreignKeyViolation is the Postgres error code for a foreign key constraint violation.
+const foreignKeyViolation = "23503"
+
+type InterestRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewInterestImpl(db *sqlx.DB) *InterestRepoImpl {
+	return &InterestRepoImpl{db}
+}
+
+// ListWalletProducts returns every product a wallet can be put on.
+func (ir *InterestRepoImpl) ListWalletProducts(ctx context.Context) ([]model.WalletProduct, error) {
+	products := []model.WalletProduct{}
+	query := `SELECT id, code, name, apr, created_at, updated_at FROM wallet_products ORDER BY code`
+	if err := ir.db.SelectContext(ctx, &products, query); err != nil {
+		return nil, fmt.Errorf("database error retrieving wallet products: %w", err)
+	}
+	return products, nil
+}
+
+// SetWalletProduct puts the wallet on a product, or takes it off when productID is nil. Only owners may do it.
+// Moving between products keeps the date interest accrues from; only joining afresh resets it to at.
+func (ir *InterestRepoImpl) SetWalletProduct(ctx context.Context, userIDStr string, walletIDStr string, productID *uuid.UUID, at time.Time) (*model.Wallet, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	if err = checkWalletAccess(ctx, ir.db, userID, walletID, model.WalletRoleOwner); err != nil {
+		return nil, err
+	}
+
+	var wallet model.Wallet
+	query := `UPDATE wallets
+              SET product_id = $2,
+                  product_since = CASE WHEN $2::uuid IS NULL THEN NULL ELSE COALESCE(product_since, $3) END,
+                  updated_at = $3
+              WHERE id = $1
+              RETURNING id, user_id, name, balance, pots_balance, held_balance, status, status_reason, status_changed_by,
+                        status_changed_at, status_expires_at, created_at, updated_at, product_id`
+	err = ir.db.GetContext(ctx, &wallet, query, walletID, productID, at)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return nil, ErrWalletProductNotFound
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to set wallet product: %w", err)
+	}
+	return &wallet, nil
+}
+
+// GetInterestSummary returns the product of a wallet and the interest it has accrued but not been paid yet.
+func (ir *InterestRepoImpl) GetInterestSummary(ctx context.Context, userIDStr string, walletIDStr string) (*model.InterestSummary, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	if err = checkWalletAccess(ctx, ir.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	var wallet model.Wallet
+	queryWallet := `SELECT id, user_id, name, balance, created_at, updated_at, product_id
+                    FROM wallets
+                    WHERE id = $1`
+	err = ir.db.GetContext(ctx, &wallet, queryWallet, walletID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve wallet for interest: %w", err)
+	}
+
+	summary := model.InterestSummary{WalletID: walletID}
+	queryAccrued := `SELECT COALESCE(SUM(amount) FILTER (WHERE transaction_id IS NULL), 0) AS accrued_unpaid,
+                            MAX(accrual_date) AS last_accrual_date
+                     FROM interest_accruals
+                     WHERE wallet_id = $1`
+	if err = ir.db.GetContext(ctx, &summary, queryAccrued, walletID); err != nil {
+		return nil, fmt.Errorf("database error retrieving interest accruals: %w", err)
+	}
+	summary.WalletID = walletID
+
+	if wallet.ProductID != nil {
+		var product model.WalletProduct
+		queryProduct := `SELECT id, code, name, apr, created_at, updated_at FROM wallet_products WHERE id = $1`
+		if err = ir.db.GetContext(ctx, &product, queryProduct, *wallet.ProductID); err != nil {
+			return nil, fmt.Errorf("failed to retrieve wallet product: %w", err)
+		}
+		summary.Product = &product
+	}
+	return &summary, nil
+}
+
+// ListSavingsWallets returns every wallet on a product, with the last day interest was accrued for.
+func (ir *InterestRepoImpl) ListSavingsWallets(ctx context.Context) ([]model.SavingsWallet, error) {
+	wallets := []model.SavingsWallet{}
+	query := `SELECT w.id AS wallet_id, p.apr, w.product_since,
+                     (SELECT MAX(a.accrual_date) FROM interest_accruals a WHERE a.wallet_id = w.id) AS last_accrual_date
+              FROM wallets w
+              JOIN wallet_products p ON p.id = w.product_id
+              ORDER BY w.id`
+	if err := ir.db.SelectContext(ctx, &wallets, query); err != nil {
+		return nil, fmt.Errorf("database error retrieving savings wallets: %w", err)
+	}
+	return wallets, nil
+}
+
+// GetBalanceAt returns the balance the wallet had at the given instant, by taking back every
+// transaction booked since from the current balance.
+func (ir *InterestRepoImpl) GetBalanceAt(ctx context.Context, walletID uuid.UUID, at time.Time) (decimal.Decimal, error) {
+	tx, err := ir.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var balance decimal.Decimal
+	if err = tx.GetContext(ctx, &balance, `SELECT balance FROM wallets WHERE id = $1`, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return decimal.Zero, ErrWalletNotFound
+		}
+		return decimal.Zero, fmt.Errorf("failed to retrieve wallet balance: %w", err)
+	}
+
+	var since []model.Transaction
+	query := `SELECT id, wallet_id, type, amount, related_wallet_id, created_at
+              FROM transactions
+              WHERE (wallet_id = $1 OR (related_wallet_id = $1 AND type IN ('transfer', 'fee', 'reversal')))
+                AND created_at >= $2`
+	if err = tx.SelectContext(ctx, &since, query, walletID, at); err != nil {
+		return decimal.Zero, fmt.Errorf("database error retrieving transactions since: %w", err)
+	}
+	for _, t := range since {
+		balance = balance.Sub(t.NetAmountFor(walletID))
+	}
+	return balance, nil
+}
+
+// AddInterestAccrual stores the accrual of one wallet for one day.
+// It reports false, and stores nothing, when that day has already been accrued.
+func (ir *InterestRepoImpl) AddInterestAccrual(ctx context.Context, accrual *model.InterestAccrual) (bool, error) {
+	query := `INSERT INTO interest_accruals (id, wallet_id, accrual_date, balance, apr, day_count, amount, created_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+              ON CONFLICT (wallet_id, accrual_date) DO NOTHING`
+	res, err := ir.db.ExecContext(ctx, query, accrual.ID, accrual.WalletID, accrual.AccrualDate, accrual.Balance,
+		accrual.APR, accrual.DayCount, accrual.Amount, accrual.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to add interest accrual: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to add interest accrual: %w", err)
+	}
+	return n == 1, nil
+}
+
+// ListInterestPayoutsDue returns the wallets with unpaid accruals for days before the given date.
+func (ir *InterestRepoImpl) ListInterestPayoutsDue(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	walletIDs := []uuid.UUID{}
+	query := `SELECT DISTINCT wallet_id
+              FROM interest_accruals
+              WHERE transaction_id IS NULL AND accrual_date < $1
+              ORDER BY wallet_id`
+	if err := ir.db.SelectContext(ctx, &walletIDs, query, before); err != nil {
+		return nil, fmt.Errorf("database error retrieving interest payouts due: %w", err)
+	}
+	return walletIDs, nil
+}
+
+// PayInterest credits the wallet with its unpaid accruals for days before the given date, rounded to cents,
+// and marks them paid by the new interest transaction. The accrual rows are locked, so they are paid once.
+// When the accruals round to less than a cent nothing is paid and they carry over to the next payout.
+func (ir *InterestRepoImpl) PayInterest(ctx context.Context, walletID uuid.UUID, before time.Time, at time.Time) (*model.Transaction, error) {
+	tx, err := beginTx(ctx, ir.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var accrued []decimal.Decimal
+	queryAccruals := `SELECT amount
+                      FROM interest_accruals
+                      WHERE wallet_id = $1 AND transaction_id IS NULL AND accrual_date < $2
+                      FOR UPDATE`
+	if err = tx.SelectContext(ctx, &accrued, queryAccruals, walletID, before); err != nil {
+		return nil, fmt.Errorf("failed to retrieve interest accruals for payout: %w", err)
+	}
+	amount := decimal.Sum(decimal.Zero, accrued...).Round(2)
+	if !amount.IsPositive() {
+		return nil, nil
+	}
+
+	updateQuery := `UPDATE wallets SET balance = balance + $1, updated_at = $2 WHERE id = $3`
+	res, err := tx.ExecContext(ctx, updateQuery, amount, at, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update wallet balance for interest: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrWalletNotFound
+	}
+
+	transaction := &model.Transaction{
+		ID:        uuid.New(),
+		WalletID:  walletID,
+		Type:      model.TransactionTypeInterest,
+		Amount:    amount,
+		CreatedAt: at,
+	}
+	insertTxQuery := `INSERT INTO transactions (id, wallet_id, type, amount, created_at)
+                      VALUES ($1, $2, $3, $4, $5)`
+	_, err = tx.ExecContext(ctx, insertTxQuery, transaction.ID, transaction.WalletID, transaction.Type, transaction.Amount, transaction.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create interest transaction record: %w", err)
+	}
+
+	markQuery := `UPDATE interest_accruals SET transaction_id = $1
+                  WHERE wallet_id = $2 AND transaction_id IS NULL AND accrual_date < $3`
+	if _, err = tx.ExecContext(ctx, markQuery, transaction.ID, walletID, before); err != nil {
+		return nil, fmt.Errorf("failed to mark interest accruals paid: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit interest transaction: %w", err)
+	}
+	return transaction, nil
+}
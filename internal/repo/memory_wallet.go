@@ -0,0 +1,345 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+// WalletRepoMemory keeps wallets, their members and their transactions in memory, with the semantics of
+// WalletRepoImpl. It is safe for concurrent use; every operation either applies in full or not at all.
+// It is meant for tests and local demos that should run without a database.
+type WalletRepoMemory struct {
+	mu           sync.Mutex
+	wallets      map[uuid.UUID]*model.Wallet
+	members      map[uuid.UUID]map[uuid.UUID]model.WalletRole
+	policies     map[uuid.UUID]model.ApprovalPolicy
+	transactions []model.Transaction
+}
+
+func NewWalletMemory() *WalletRepoMemory {
+	return &WalletRepoMemory{
+		wallets:  make(map[uuid.UUID]*model.Wallet),
+		members:  make(map[uuid.UUID]map[uuid.UUID]model.WalletRole),
+		policies: make(map[uuid.UUID]model.ApprovalPolicy),
+	}
+}
+
+// AddWallet stores a copy of the wallet and makes its user the owner, as creating a wallet in the database does.
+// An empty status is stored as active.
+func (mr *WalletRepoMemory) AddWallet(_ context.Context, wallet model.Wallet) error {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if _, ok := mr.wallets[wallet.ID]; ok {
+		return fmt.Errorf("wallet %s already exists", wallet.ID)
+	}
+	now := time.Now()
+	if wallet.Status == "" {
+		wallet.Status = model.WalletStatusActive
+	}
+	if wallet.CreatedAt.IsZero() {
+		wallet.CreatedAt = now
+	}
+	if wallet.UpdatedAt.IsZero() {
+		wallet.UpdatedAt = wallet.CreatedAt
+	}
+	mr.wallets[wallet.ID] = &wallet
+	mr.members[wallet.ID] = map[uuid.UUID]model.WalletRole{wallet.UserID: model.WalletRoleOwner}
+	return nil
+}
+
+// AddMember makes the user an active member of the wallet with role, replacing any role it had.
+func (mr *WalletRepoMemory) AddMember(_ context.Context, walletID, userID uuid.UUID, role model.WalletRole) error {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if _, ok := mr.wallets[walletID]; !ok {
+		return ErrWalletNotFound
+	}
+	mr.members[walletID][userID] = role
+	return nil
+}
+
+// PutApprovalPolicy creates or replaces the approval policy of the wallet.
+func (mr *WalletRepoMemory) PutApprovalPolicy(_ context.Context, policy model.ApprovalPolicy) error {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if _, ok := mr.wallets[policy.WalletID]; !ok {
+		return ErrWalletNotFound
+	}
+	mr.policies[policy.WalletID] = policy
+	return nil
+}
+
+// GetTransactionsByWalletID retrieves all transactions for a specific wallet, most recent first.
+// It also checks that the given user is a member of the wallet for authorization.
+func (mr *WalletRepoMemory) GetTransactionsByWalletID(_ context.Context, userIDStr string, walletIDStr string) ([]model.Transaction, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if err = mr.checkAccess(userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+	transactions := []model.Transaction{}
+	for i := len(mr.transactions) - 1; i >= 0; i-- {
+		if mr.transactions[i].WalletID == walletID {
+			transactions = append(transactions, mr.transactions[i])
+		}
+	}
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].CreatedAt.After(transactions[j].CreatedAt)
+	})
+	return transactions, nil
+}
+
+// GetWalletInfo retrieves a specific wallet for a user. It returns nil without an error if the wallet does not
+// exist or the user is not a member of it.
+func (mr *WalletRepoMemory) GetWalletInfo(_ context.Context, userIDStr string, walletIDStr string) (*model.Wallet, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, errors.New("invalid wallet ID format")
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if err = mr.checkAccess(userID, walletID, model.WalletRoleViewer); err != nil {
+		if errors.Is(err, ErrWalletNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	wallet := *mr.wallets[walletID]
+	return &wallet, nil
+}
+
+// Deposit adds funds to a wallet and creates a transaction record.
+func (mr *WalletRepoMemory) Deposit(_ context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal) (*model.Transaction, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if err = mr.checkAccess(userID, walletID, model.WalletRoleSpender); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	wallet := mr.wallets[walletID]
+	if err = checkWalletCredit(*wallet, now); err != nil {
+		return nil, err
+	}
+
+	wallet.Balance = wallet.Balance.Add(amount)
+	wallet.UpdatedAt = now
+	transaction := mr.record(model.Transaction{
+		ID:          uuid.New(),
+		WalletID:    walletID,
+		Type:        model.TransactionTypeDeposit,
+		Amount:      amount,
+		CreatedAt:   now,
+		InitiatedBy: &userID,
+	})
+	return transaction, nil
+}
+
+// Withdraw removes funds from a wallet and creates a transaction record.
+// A non-zero fee is charged together with it; the wallet must cover amount plus fee.
+func (mr *WalletRepoMemory) Withdraw(_ context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal, fee model.Fee) (*model.Transaction, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if err = mr.checkAccess(userID, walletID, model.WalletRoleSpender); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	wallet := mr.wallets[walletID]
+	if err = checkWalletDebit(*wallet, now); err != nil {
+		return nil, err
+	}
+	if wallet.Available().LessThan(amount.Add(fee.Amount)) {
+		return nil, ErrInsufficientFunds
+	}
+	if err = mr.checkFee(walletID, amount, fee); err != nil {
+		return nil, err
+	}
+
+	wallet.Balance = wallet.Balance.Sub(amount)
+	wallet.UpdatedAt = now
+	transaction := mr.record(model.Transaction{
+		ID:          uuid.New(),
+		WalletID:    walletID,
+		Type:        model.TransactionTypeWithdrawal,
+		Amount:      amount,
+		CreatedAt:   now,
+		InitiatedBy: &userID,
+	})
+	transaction.Fee = mr.chargeFee(walletID, transaction.ID, userID, fee, now)
+	return transaction, nil
+}
+
+// Transfer moves funds from a source wallet to a destination wallet and creates a transaction record.
+// A non-zero fee is charged to the source wallet together with it.
+func (mr *WalletRepoMemory) Transfer(_ context.Context, sourceUserIDStr string, sourceWalletIDStr string, destinationWalletIDStr string, amount decimal.Decimal, fee model.Fee) (*model.Transaction, error) {
+	sourceUserID, err := uuid.Parse(sourceUserIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source user ID format: %w", err)
+	}
+	sourceWalletID, err := uuid.Parse(sourceWalletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source wallet ID format: %w", err)
+	}
+	destinationWalletID, err := uuid.Parse(destinationWalletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination wallet ID format: %w", err)
+	}
+
+	if sourceWalletID == destinationWalletID {
+		return nil, errors.New("source and destination wallets cannot be the same")
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if err = mr.checkAccess(sourceUserID, sourceWalletID, model.WalletRoleSpender); err != nil {
+		if errors.Is(err, ErrWalletNotFound) {
+			return nil, fmt.Errorf("source wallet not found: %w", err)
+		}
+		return nil, err
+	}
+	if policy, ok := mr.policies[sourceWalletID]; ok && policy.RequiresApproval(amount) {
+		return nil, ErrApprovalRequired
+	}
+
+	now := time.Now()
+	sourceWallet := mr.wallets[sourceWalletID]
+	if err = checkWalletDebit(*sourceWallet, now); err != nil {
+		return nil, fmt.Errorf("source wallet: %w", err)
+	}
+	if sourceWallet.Available().LessThan(amount) {
+		return nil, ErrInsufficientFunds
+	}
+	destinationWallet, ok := mr.wallets[destinationWalletID]
+	if !ok {
+		return nil, fmt.Errorf("destination wallet not found: %w", ErrWalletNotFound)
+	}
+	if err = checkWalletCredit(*destinationWallet, now); err != nil {
+		return nil, fmt.Errorf("destination wallet: %w", err)
+	}
+	if err = mr.checkFee(sourceWalletID, amount, fee); err != nil {
+		return nil, err
+	}
+
+	sourceWallet.Balance = sourceWallet.Balance.Sub(amount)
+	sourceWallet.UpdatedAt = now
+	destinationWallet.Balance = destinationWallet.Balance.Add(amount)
+	destinationWallet.UpdatedAt = now
+	transaction := mr.record(model.Transaction{
+		ID:              uuid.New(),
+		WalletID:        sourceWalletID,
+		Type:            model.TransactionTypeTransfer,
+		Amount:          amount,
+		RelatedWalletID: &destinationWalletID,
+		CreatedAt:       now,
+		InitiatedBy:     &sourceUserID,
+	})
+	transaction.Fee = mr.chargeFee(sourceWalletID, transaction.ID, sourceUserID, fee, now)
+	return transaction, nil
+}
+
+// checkAccess is checkWalletAccess over the wallets in memory. The caller must hold mr.mu.
+func (mr *WalletRepoMemory) checkAccess(userID, walletID uuid.UUID, min model.WalletRole) error {
+	role, ok := mr.members[walletID][userID]
+	if !ok {
+		return ErrWalletNotFound
+	}
+	if !role.Allows(min) {
+		return ErrWalletForbidden
+	}
+	return nil
+}
+
+// checkFee returns why fee cannot be charged to walletID once amount has been taken from it, or nil if it can,
+// so that nothing is changed when it cannot. The caller must hold mr.mu.
+func (mr *WalletRepoMemory) checkFee(walletID uuid.UUID, amount decimal.Decimal, fee model.Fee) error {
+	if !fee.Amount.IsPositive() || walletID == fee.WalletID {
+		return nil
+	}
+	if mr.wallets[walletID].Available().Sub(amount).LessThan(fee.Amount) {
+		return ErrInsufficientFunds
+	}
+	if _, ok := mr.wallets[fee.WalletID]; !ok {
+		return fmt.Errorf("fee wallet not found: %w", ErrWalletNotFound)
+	}
+	return nil
+}
+
+// chargeFee moves a fee that checkFee accepted from walletID to the fee wallet and records it, like chargeFeeTx.
+// A zero fee is not recorded. The caller must hold mr.mu.
+func (mr *WalletRepoMemory) chargeFee(walletID uuid.UUID, parentID uuid.UUID, initiatedBy uuid.UUID, fee model.Fee, now time.Time) *model.Transaction {
+	if !fee.Amount.IsPositive() || walletID == fee.WalletID {
+		return nil
+	}
+	wallet, feeWallet := mr.wallets[walletID], mr.wallets[fee.WalletID]
+	wallet.Balance = wallet.Balance.Sub(fee.Amount)
+	wallet.UpdatedAt = now
+	feeWallet.Balance = feeWallet.Balance.Add(fee.Amount)
+	feeWallet.UpdatedAt = now
+
+	feeWalletID := fee.WalletID
+	return mr.record(model.Transaction{
+		ID:                  uuid.New(),
+		WalletID:            walletID,
+		Type:                model.TransactionTypeFee,
+		Amount:              fee.Amount,
+		RelatedWalletID:     &feeWalletID,
+		CreatedAt:           now,
+		InitiatedBy:         &initiatedBy,
+		FeeScheduleID:       fee.ScheduleID,
+		ParentTransactionID: &parentID,
+	})
+}
+
+// record appends the transaction and returns a copy of it for the caller. The caller must hold mr.mu.
+func (mr *WalletRepoMemory) record(transaction model.Transaction) *model.Transaction {
+	mr.transactions = append(mr.transactions, transaction)
+	return &transaction
+}
@@ -0,0 +1,52 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+func TestWalletRepoMemory(t *testing.T) {
+	repotest.TestWalletRepo(t, func(t *testing.T) repotest.WalletStore {
+		return repo.NewWalletMemory()
+	})
+}
+
+func TestWalletRepoMemory_Invariants(t *testing.T) {
+	repotest.TestWalletRepoInvariants(t, func(t *testing.T) repotest.WalletStore {
+		return repo.NewWalletMemory()
+	}, 500)
+}
+
+// leakyWallets pays out every withdrawal above 50 without taking it from the wallet.
+type leakyWallets struct {
+	*repo.WalletRepoMemory
+}
+
+func (lw leakyWallets) Withdraw(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal, fee model.Fee) (*model.Transaction, error) {
+	if amount.GreaterThan(decimal.NewFromInt(50)) {
+		return &model.Transaction{Type: model.TransactionTypeWithdrawal, Amount: amount}, nil
+	}
+	return lw.WalletRepoMemory.Withdraw(ctx, userIDStr, walletIDStr, amount, fee)
+}
+
+func TestFindCounterexample_Shrinks(t *testing.T) {
+	found, err := repotest.FindCounterexample(context.Background(), func() repotest.WalletStore {
+		return leakyWallets{repo.NewWalletMemory()}
+	}, 1, 500)
+	require.NoError(t, err)
+	require.NotNil(t, found, "the leaky repository must be caught")
+
+	// The smallest sequence that shows the bug: a withdrawal of just over 50 from an empty wallet
+	require.Len(t, found.Ops, 1, found.String())
+	assert.Equal(t, repotest.OpWithdraw, found.Ops[0].Kind)
+	assert.Equal(t, int64(5001), found.Ops[0].Amount)
+	assert.Error(t, found.Err)
+}
@@ -0,0 +1,231 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+var (
+	// ErrPaymentRequestNotFound indicates that the payment request does not exist or is not visible to the user.
+	ErrPaymentRequestNotFound = errors.New("payment request not found")
+	// ErrPaymentRequestNotPending indicates that the payment request was already accepted, declined or cancelled.
+	ErrPaymentRequestNotPending = errors.New("payment request is no longer pending")
+	// ErrPaymentRequestExpired indicates that the payment request passed its expiry time before being answered.
+	ErrPaymentRequestExpired = errors.New("payment request has expired")
+	// ErrPayerNotFound indicates that the user asked to pay does not exist.
+	ErrPayerNotFound = errors.New("payer not found")
+)
+
+// paymentRequestColumns reports a pending request past its expiry as expired, whether or not that has been
+// written back yet. $1 is always the current time.
+const paymentRequestColumns = `id, requester_user_id, requester_wallet_id, payer_user_id, payer_wallet_id, amount, memo,
+                               CASE WHEN status = 'pending' AND expires_at <= $1 THEN 'expired' ELSE status END AS status,
+                               expires_at, transaction_id, created_at, updated_at`
+
+type PaymentRequestRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewPaymentRequestImpl(db *sqlx.DB) *PaymentRequestRepoImpl {
+	return &PaymentRequestRepoImpl{db}
+}
+
+// CreatePaymentRequest stores a new request. The requester must be allowed to spend from the requester
+// wallet and the payer must exist.
+func (pr *PaymentRequestRepoImpl) CreatePaymentRequest(ctx context.Context, req *model.PaymentRequest) error {
+	tx, err := beginTx(ctx, pr.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkWalletAccess(ctx, tx, req.RequesterUserID, req.RequesterWalletID, model.WalletRoleSpender); err != nil {
+		if errors.Is(err, ErrWalletNotFound) {
+			return fmt.Errorf("requester wallet not found: %w", err)
+		}
+		return err
+	}
+
+	var payerExists bool
+	checkQuery := `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`
+	if err = tx.GetContext(ctx, &payerExists, checkQuery, req.PayerUserID); err != nil {
+		return fmt.Errorf("failed to check payment request parties: %w", err)
+	}
+	if !payerExists {
+		return ErrPayerNotFound
+	}
+
+	insertQuery := `INSERT INTO payment_requests (id, requester_user_id, requester_wallet_id, payer_user_id, amount, memo,
+                                                  status, expires_at, created_at, updated_at)
+                    VALUES (:id, :requester_user_id, :requester_wallet_id, :payer_user_id, :amount, :memo,
+                            :status, :expires_at, :created_at, :updated_at)`
+	if _, err = tx.NamedExecContext(ctx, insertQuery, req); err != nil {
+		return fmt.Errorf("failed to create payment request: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit payment request: %w", err)
+	}
+	return nil
+}
+
+// ListIncomingPaymentRequests retrieves the requests addressed to the user, newest first.
+// An empty status returns requests in every state.
+func (pr *PaymentRequestRepoImpl) ListIncomingPaymentRequests(ctx context.Context, userIDStr string, status model.PaymentRequestStatus, now time.Time) ([]model.PaymentRequest, error) {
+	return pr.listPaymentRequests(ctx, "payer_user_id", userIDStr, status, now)
+}
+
+// ListOutgoingPaymentRequests retrieves the requests made by the user, newest first.
+// An empty status returns requests in every state.
+func (pr *PaymentRequestRepoImpl) ListOutgoingPaymentRequests(ctx context.Context, userIDStr string, status model.PaymentRequestStatus, now time.Time) ([]model.PaymentRequest, error) {
+	return pr.listPaymentRequests(ctx, "requester_user_id", userIDStr, status, now)
+}
+
+func (pr *PaymentRequestRepoImpl) listPaymentRequests(ctx context.Context, userColumn string, userIDStr string, status model.PaymentRequestStatus, now time.Time) ([]model.PaymentRequest, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	requests := []model.PaymentRequest{}
+	query := `SELECT * FROM (
+                  SELECT ` + paymentRequestColumns + `
+                  FROM payment_requests
+                  WHERE ` + userColumn + ` = $2
+              ) r
+              WHERE $3 = '' OR r.status::text = $3
+              ORDER BY r.created_at DESC`
+	if err = pr.db.SelectContext(ctx, &requests, query, now, userID, string(status)); err != nil {
+		return nil, fmt.Errorf("database error retrieving payment requests: %w", err)
+	}
+	return requests, nil
+}
+
+// GetPaymentRequest retrieves a request the user is either the requester or the payer of.
+func (pr *PaymentRequestRepoImpl) GetPaymentRequest(ctx context.Context, userIDStr string, requestIDStr string, now time.Time) (*model.PaymentRequest, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	requestID, err := uuid.Parse(requestIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment request ID format: %w", err)
+	}
+
+	var req model.PaymentRequest
+	query := `SELECT ` + paymentRequestColumns + `
+              FROM payment_requests
+              WHERE id = $2 AND (requester_user_id = $3 OR payer_user_id = $3)`
+	if err = pr.db.GetContext(ctx, &req, query, now, requestID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPaymentRequestNotFound
+		}
+		return nil, fmt.Errorf("database error retrieving payment request: %w", err)
+	}
+	return &req, nil
+}
+
+// AcceptPaymentRequest pays a pending request addressed to the payer from one of the payer's wallets, and charges
+// the payer's wallet fee in the same database transaction, as Transfer does.
+// The request row is locked for the whole transfer, so a request can be accepted at most once:
+// a concurrent second accept waits and then fails with ErrPaymentRequestNotPending.
+func (pr *PaymentRequestRepoImpl) AcceptPaymentRequest(ctx context.Context, payerUserIDStr string, requestIDStr string, payerWalletIDStr string,
+	fee model.Fee, now time.Time) (*model.PaymentRequest, error) {
+	payerWalletID, err := uuid.Parse(payerWalletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	return pr.answerPaymentRequest(ctx, "payer_user_id", payerUserIDStr, requestIDStr, model.PaymentRequestStatusAccepted, now,
+		func(tx *sqlx.Tx, req *model.PaymentRequest) error {
+			transaction, err := transferTx(ctx, tx, req.PayerUserID, payerWalletID, req.RequesterWalletID, req.Amount)
+			if err != nil {
+				return err
+			}
+			if _, err = chargeFeeTx(ctx, tx, payerWalletID, transaction.ID, req.PayerUserID, fee); err != nil {
+				return err
+			}
+			req.PayerWalletID = &payerWalletID
+			req.TransactionID = &transaction.ID
+			return nil
+		})
+}
+
+// DeclinePaymentRequest refuses a pending request addressed to the payer.
+func (pr *PaymentRequestRepoImpl) DeclinePaymentRequest(ctx context.Context, payerUserIDStr string, requestIDStr string, now time.Time) (*model.PaymentRequest, error) {
+	return pr.answerPaymentRequest(ctx, "payer_user_id", payerUserIDStr, requestIDStr, model.PaymentRequestStatusDeclined, now, nil)
+}
+
+// CancelPaymentRequest withdraws a pending request made by the requester.
+func (pr *PaymentRequestRepoImpl) CancelPaymentRequest(ctx context.Context, requesterUserIDStr string, requestIDStr string, now time.Time) (*model.PaymentRequest, error) {
+	return pr.answerPaymentRequest(ctx, "requester_user_id", requesterUserIDStr, requestIDStr, model.PaymentRequestStatusCancelled, now, nil)
+}
+
+// answerPaymentRequest moves a pending request to status. The request is locked FOR UPDATE and must belong to
+// the user through userColumn. apply, when set, runs in the same database transaction before the status changes.
+// A request found past its expiry is marked expired and ErrPaymentRequestExpired is returned.
+func (pr *PaymentRequestRepoImpl) answerPaymentRequest(ctx context.Context, userColumn string, userIDStr string, requestIDStr string,
+	status model.PaymentRequestStatus, now time.Time, apply func(tx *sqlx.Tx, req *model.PaymentRequest) error) (*model.PaymentRequest, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	requestID, err := uuid.Parse(requestIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment request ID format: %w", err)
+	}
+
+	tx, err := beginTx(ctx, pr.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var req model.PaymentRequest
+	lockQuery := `SELECT id, requester_user_id, requester_wallet_id, payer_user_id, payer_wallet_id, amount, memo,
+                         status, expires_at, transaction_id, created_at, updated_at
+                  FROM payment_requests
+                  WHERE id = $1 AND ` + userColumn + ` = $2 FOR UPDATE`
+	if err = tx.GetContext(ctx, &req, lockQuery, requestID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPaymentRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve payment request: %w", err)
+	}
+	if req.Status != model.PaymentRequestStatusPending {
+		return nil, fmt.Errorf("payment request is %s: %w", req.Status, ErrPaymentRequestNotPending)
+	}
+
+	if !req.ExpiresAt.After(now) {
+		status, apply = model.PaymentRequestStatusExpired, nil
+	}
+	if apply != nil {
+		if err = apply(tx, &req); err != nil {
+			return nil, err
+		}
+	}
+
+	updateQuery := `UPDATE payment_requests
+                    SET status = $1, payer_wallet_id = $2, transaction_id = $3
+                    WHERE id = $4`
+	if _, err = tx.ExecContext(ctx, updateQuery, status, req.PayerWalletID, req.TransactionID, req.ID); err != nil {
+		return nil, fmt.Errorf("failed to update payment request: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit payment request: %w", err)
+	}
+
+	if status == model.PaymentRequestStatusExpired {
+		return nil, ErrPaymentRequestExpired
+	}
+	req.Status = status
+	req.UpdatedAt = now
+	return &req, nil
+}
@@ -0,0 +1,136 @@
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+func TestAdminRepoPostgres_WalletStatus(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	ar := repo.NewAdminImpl(db)
+	ctx := context.Background()
+
+	wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "main", Balance: decimal.NewFromInt(100)}
+	require.NoError(t, store.AddWallet(ctx, wallet))
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	// A frozen wallet can neither pay nor be paid, and freezing it again changes nothing.
+	frozen, err := ar.SetWalletStatus(ctx, "ops", wallet.ID.String(), model.WalletStatusFrozen, "fraud check", nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, model.WalletStatusFrozen, frozen.Status)
+	_, err = ar.SetWalletStatus(ctx, "ops", wallet.ID.String(), model.WalletStatusFrozen, "again", nil, now)
+	assert.ErrorIs(t, err, repo.ErrWalletStatusUnchanged)
+	_, err = store.Withdraw(ctx, wallet.UserID.String(), wallet.ID.String(), decimal.NewFromInt(1), model.Fee{})
+	assert.ErrorIs(t, err, repo.ErrWalletFrozen)
+	_, err = store.Deposit(ctx, wallet.UserID.String(), wallet.ID.String(), decimal.NewFromInt(1))
+	assert.ErrorIs(t, err, repo.ErrWalletFrozen)
+	assert.Equal(t, "100", readWallet(t, db, wallet.ID).Balance.String())
+
+	// A restriction with an expiry is lifted by the sweep once it has passed, and only then.
+	expiresAt := now.Add(time.Hour)
+	_, err = ar.SetWalletStatus(ctx, "ops", wallet.ID.String(), model.WalletStatusDebitBlocked, "chargeback", &expiresAt, now.Add(time.Second))
+	require.NoError(t, err)
+	lifted, err := ar.LiftExpiredWalletStatuses(ctx, now)
+	require.NoError(t, err)
+	assert.NotContains(t, lifted, wallet.ID)
+	lifted, err = ar.LiftExpiredWalletStatuses(ctx, expiresAt)
+	require.NoError(t, err)
+	assert.Contains(t, lifted, wallet.ID)
+	stored := readWallet(t, db, wallet.ID)
+	assert.Equal(t, model.WalletStatusActive, stored.Status)
+	assert.Nil(t, stored.StatusExpiresAt)
+
+	entries, err := ar.ListAdminAuditLog(ctx, "", wallet.ID.String(), 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, repo.SystemActor, entries[0].Admin)
+	assert.Equal(t, "debit_blocked -> active: restriction expired", entries[0].Details)
+	assert.Equal(t, "ops", entries[2].Admin)
+	assert.Equal(t, "active -> frozen: fraud check", entries[2].Details)
+}
+
+func TestAdminRepoPostgres_AdjustWallet(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	ar := repo.NewAdminImpl(db)
+	ctx := context.Background()
+
+	wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "main", Balance: decimal.NewFromInt(100),
+		PotsBalance: decimal.NewFromInt(30), HeldBalance: decimal.NewFromInt(20), Status: model.WalletStatusFrozen}
+	require.NoError(t, store.AddWallet(ctx, wallet))
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	// Adjustments ignore the wallet's status, but a debit cannot take what is in pots or held.
+	credit, err := ar.AdjustWallet(ctx, "ops", wallet.ID.String(), decimal.NewFromInt(10), model.AdjustmentReasonGoodwill, "sorry", now)
+	require.NoError(t, err)
+	assert.Equal(t, model.TransactionTypeAdjustment, credit.Type)
+	assert.Equal(t, "110", readWallet(t, db, wallet.ID).Balance.String())
+	_, err = ar.AdjustWallet(ctx, "ops", wallet.ID.String(), decimal.NewFromInt(-61), model.AdjustmentReasonErrorCorrection, "", now)
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	_, err = ar.AdjustWallet(ctx, "ops", wallet.ID.String(), decimal.NewFromInt(-60), model.AdjustmentReasonErrorCorrection, "", now)
+	require.NoError(t, err)
+	assert.Equal(t, "50", readWallet(t, db, wallet.ID).Balance.String())
+	_, err = ar.AdjustWallet(ctx, "ops", uuid.NewString(), decimal.NewFromInt(1), model.AdjustmentReasonGoodwill, "", now)
+	assert.ErrorIs(t, err, repo.ErrWalletNotFound)
+
+	// Only the adjustments that went through are booked and audited.
+	var bookings int
+	require.NoError(t, db.GetContext(ctx, &bookings, `SELECT count(*) FROM transactions WHERE wallet_id = $1 AND type = $2`,
+		wallet.ID, model.TransactionTypeAdjustment))
+	assert.Equal(t, 2, bookings)
+	entries, err := ar.ListAdminAuditLog(ctx, "ops", wallet.ID.String(), 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, model.AdminActionAdjustWallet, entry.Action)
+		require.NotNil(t, entry.TransactionID)
+	}
+}
+
+func TestAdminRepoPostgres_ConcurrentDebits(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	ar := repo.NewAdminImpl(db)
+	ctx := context.Background()
+
+	wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "main", Balance: decimal.NewFromInt(100)}
+	require.NoError(t, store.AddWallet(ctx, wallet))
+	now := time.Now().UTC()
+
+	const attempts = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ar.AdjustWallet(ctx, "ops", wallet.ID.String(), decimal.NewFromInt(-30), model.AdjustmentReasonErrorCorrection, "", now)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	}
+	assert.Equal(t, 3, succeeded)
+	assert.Equal(t, "10", readWallet(t, db, wallet.ID).Balance.String())
+}
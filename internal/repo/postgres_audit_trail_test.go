@@ -0,0 +1,171 @@
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+func TestAuditTrailRepoPostgres(t *testing.T) {
+	db := repotest.Postgres(t)
+	ar := repo.NewAuditTrailImpl(db)
+	as := service.NewAuditTrailImpl(ar, clock.Real{})
+	chainer := service.NewAuditTrailChainer(ar, clock.Real{})
+	ctx := context.Background()
+
+	// Concurrent requests only queue their entries; the chainer gives every entry its own seq and chains it to the
+	// one before.
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entry := &model.AuditTrailEntry{RequestID: "req", Actor: "anonymous", Method: "POST", Route: "/v1/user"}
+			_, record := as.Begin(ctx, entry)
+			entry.Status = 201
+			assert.NoError(t, record(ctx))
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, chainer.RunOnce(ctx))
+
+	report, err := as.VerifyAuditTrail(ctx)
+	require.NoError(t, err)
+	require.True(t, report.Intact, report.Breaks)
+	require.GreaterOrEqual(t, report.Checked, int64(10))
+
+	head, err := ar.GetAuditTrailHead(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, head)
+	assert.Equal(t, report.LastSeq, head.Seq)
+	newest, err := ar.ListAuditTrail(ctx, head.Seq-1, 1)
+	require.NoError(t, err)
+	require.Len(t, newest, 1)
+	assert.Equal(t, newest[0].Hash, head.Hash)
+
+	// Entries can be neither changed nor deleted, and neither can the head.
+	_, err = db.ExecContext(ctx, `UPDATE audit_trail SET status = 200 WHERE seq = $1`, head.Seq)
+	assert.Error(t, err)
+	_, err = db.ExecContext(ctx, `DELETE FROM audit_trail WHERE seq = $1`, head.Seq)
+	assert.Error(t, err)
+	_, err = db.ExecContext(ctx, `DELETE FROM audit_trail_head`)
+	assert.Error(t, err)
+
+	// Deleting the newest entry past the trigger leaves an intact chain that ends short of the head.
+	tamper := func(query string, args ...any) {
+		t.Helper()
+		tx, err := db.BeginTxx(ctx, nil)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		_, err = tx.ExecContext(ctx, `ALTER TABLE audit_trail DISABLE TRIGGER reject_audit_trail_change`)
+		require.NoError(t, err)
+		_, err = tx.ExecContext(ctx, query, args...)
+		require.NoError(t, err)
+		_, err = tx.ExecContext(ctx, `ALTER TABLE audit_trail ENABLE TRIGGER reject_audit_trail_change`)
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit())
+	}
+	tamper(`DELETE FROM audit_trail WHERE seq = $1`, head.Seq)
+	t.Cleanup(func() {
+		e := newest[0]
+		_, err := db.ExecContext(context.Background(), `INSERT INTO audit_trail (seq, request_id, actor, method, route, params, status,
+                   wallet_id, balance_before, balance_after, created_at, prev_hash, hash)
+                   VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+			e.Seq, e.RequestID, e.Actor, e.Method, e.Route, e.Params, e.Status, e.WalletID, e.BalanceBefore,
+			e.BalanceAfter, e.CreatedAt.UTC().Truncate(time.Microsecond), e.PrevHash, e.Hash)
+		assert.NoError(t, err, "failed to restore the deleted audit trail entry")
+	})
+
+	report, err = as.VerifyAuditTrail(ctx)
+	require.NoError(t, err)
+	assert.False(t, report.Intact)
+	require.Len(t, report.Breaks, 1)
+	assert.Equal(t, head.Seq, report.Breaks[0].Seq)
+}
+
+func TestAuditTrailRepoPostgres_BalancesFromTransactions(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	ar := repo.NewAuditTrailImpl(db)
+	as := service.NewAuditTrailImpl(ar, clock.Real{})
+	ctx := context.Background()
+
+	wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "main", Balance: decimal.NewFromInt(100)}
+	require.NoError(t, store.AddWallet(ctx, wallet))
+	userID, walletID := wallet.UserID.String(), wallet.ID.String()
+	begin := func(requestID string) (context.Context, *model.AuditTrailEntry, func(context.Context) error) {
+		entry := &model.AuditTrailEntry{RequestID: requestID, Actor: "user:" + userID, Method: "POST",
+			Route: "/v1/user/:userId/wallet/:walletId/deposit", WalletID: &wallet.ID}
+		reqCtx, record := as.Begin(ctx, entry)
+		return reqCtx, entry, record
+	}
+	head, err := ar.GetAuditTrailHead(ctx)
+	require.NoError(t, err)
+	var afterSeq int64
+	if head != nil {
+		afterSeq = head.Seq
+	}
+
+	// A request records the balances its own transactions saw, not ones changed by others before it is recorded.
+	reqCtx, entry, record := begin("moved")
+	_, err = store.Deposit(reqCtx, userID, walletID, decimal.NewFromInt(25))
+	require.NoError(t, err)
+	_, err = store.Withdraw(reqCtx, userID, walletID, decimal.NewFromInt(5), model.Fee{})
+	require.NoError(t, err)
+	_, err = store.Deposit(ctx, userID, walletID, decimal.NewFromInt(1000))
+	require.NoError(t, err)
+	entry.Status = 200
+	require.NoError(t, record(ctx))
+
+	// A request that moved nothing records the balance as it stands, before and after.
+	reqCtx, entry, record = begin("rejected")
+	_, err = store.Withdraw(reqCtx, userID, walletID, decimal.NewFromInt(5000), model.Fee{})
+	require.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	entry.Status = 422
+	require.NoError(t, record(ctx))
+
+	// A request whose status was never recorded stays queued until it is taken to be abandoned.
+	reqCtx, _, _ = begin("abandoned")
+	_, err = store.Deposit(reqCtx, userID, walletID, decimal.NewFromInt(1))
+	require.NoError(t, err)
+
+	require.NoError(t, service.NewAuditTrailChainer(ar, clock.Real{}).RunOnce(ctx))
+	var queued int
+	require.NoError(t, db.GetContext(ctx, &queued, `SELECT count(*) FROM audit_trail_outbox WHERE request_id = 'abandoned'`))
+	assert.Equal(t, 1, queued)
+	later := clock.NewFake(time.Now().Add(service.AuditTrailAbandonAfter + time.Minute))
+	require.NoError(t, service.NewAuditTrailChainer(ar, later).RunOnce(ctx))
+
+	entries, err := ar.ListAuditTrail(ctx, afterSeq, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	balances := func(e model.AuditTrailEntry) [2]string {
+		require.NotNil(t, e.BalanceBefore)
+		require.NotNil(t, e.BalanceAfter)
+		return [2]string{e.BalanceBefore.String(), e.BalanceAfter.String()}
+	}
+	assert.Equal(t, "moved", entries[0].RequestID)
+	assert.Equal(t, 200, entries[0].Status)
+	assert.Equal(t, [2]string{"100", "120"}, balances(entries[0]))
+	assert.Equal(t, "rejected", entries[1].RequestID)
+	assert.Equal(t, [2]string{"1120", "1120"}, balances(entries[1]))
+	assert.Equal(t, "abandoned", entries[2].RequestID)
+	assert.Equal(t, 0, entries[2].Status)
+	assert.Equal(t, [2]string{"1120", "1121"}, balances(entries[2]))
+
+	report, err := as.VerifyAuditTrail(ctx)
+	require.NoError(t, err)
+	assert.True(t, report.Intact, report.Breaks)
+}
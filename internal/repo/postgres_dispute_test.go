@@ -0,0 +1,188 @@
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+// disputeFixture is a payer and a recipient wallet, and the transfers between them that can be disputed.
+type disputeFixture struct {
+	store     pgWalletStore
+	dr        *repo.DisputeRepoImpl
+	payer     model.Wallet
+	recipient model.Wallet
+	since     time.Time
+}
+
+func newDisputeFixture(t *testing.T) *disputeFixture {
+	t.Helper()
+	db := repotest.Postgres(t)
+	f := &disputeFixture{
+		store:     pgWalletStore{repo.NewWalletImpl(db), db},
+		dr:        repo.NewDisputeImpl(db),
+		payer:     model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "payer", Balance: decimal.NewFromInt(100)},
+		recipient: model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "recipient", Balance: decimal.Zero},
+		since:     time.Now().Add(-time.Minute),
+	}
+	require.NoError(t, f.store.AddWallet(context.Background(), f.payer))
+	require.NoError(t, f.store.AddWallet(context.Background(), f.recipient))
+	return f
+}
+
+// transfer pays amount from the payer to the recipient.
+func (f *disputeFixture) transfer(t *testing.T, amount int64) uuid.UUID {
+	t.Helper()
+	transfer, err := f.store.Transfer(context.Background(), f.payer.UserID.String(), f.payer.ID.String(), f.recipient.ID.String(),
+		decimal.NewFromInt(amount), model.Fee{Amount: decimal.Zero})
+	require.NoError(t, err)
+	return transfer.ID
+}
+
+// open has the payer dispute the transfer transactionID.
+func (f *disputeFixture) open(t *testing.T, transactionID uuid.UUID, hold bool) *model.Dispute {
+	t.Helper()
+	dispute := &model.Dispute{ID: uuid.New(), TransactionID: transactionID, PayerWalletID: f.payer.ID, Reason: "not delivered", CreatedAt: time.Now().UTC()}
+	require.NoError(t, f.dr.OpenDispute(context.Background(), f.payer.UserID.String(), dispute, hold, f.since))
+	return dispute
+}
+
+// dispute transfers amount from the payer to the recipient and has the payer dispute it.
+func (f *disputeFixture) dispute(t *testing.T, amount int64, hold bool) *model.Dispute {
+	t.Helper()
+	return f.open(t, f.transfer(t, amount), hold)
+}
+
+func TestDisputeRepoPostgres_OpenAndWithdraw(t *testing.T) {
+	f := newDisputeFixture(t)
+	db := repotest.Postgres(t)
+	ctx := context.Background()
+	noFee := model.Fee{Amount: decimal.Zero}
+
+	// Holding keeps the recipient from spending the disputed money.
+	dispute := f.dispute(t, 40, true)
+	assert.True(t, dispute.HeldAmount.Equal(decimal.NewFromInt(40)))
+	assert.True(t, readWallet(t, db, f.recipient.ID).HeldBalance.Equal(decimal.NewFromInt(40)))
+	_, err := f.store.Withdraw(ctx, f.recipient.UserID.String(), f.recipient.ID.String(), decimal.NewFromInt(1), noFee)
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+
+	again := &model.Dispute{ID: uuid.New(), TransactionID: dispute.TransactionID, PayerWalletID: f.payer.ID, Reason: "again", CreatedAt: time.Now().UTC()}
+	assert.ErrorIs(t, f.dr.OpenDispute(ctx, f.payer.UserID.String(), again, false, f.since), repo.ErrDisputeExists)
+	fromRecipient := &model.Dispute{ID: uuid.New(), TransactionID: dispute.TransactionID, PayerWalletID: f.recipient.ID, Reason: "mine", CreatedAt: time.Now().UTC()}
+	assert.ErrorIs(t, f.dr.OpenDispute(ctx, f.recipient.UserID.String(), fromRecipient, false, f.since), repo.ErrTransactionNotDisputable)
+	tooLate := &model.Dispute{ID: uuid.New(), TransactionID: dispute.TransactionID, PayerWalletID: f.payer.ID, Reason: "late", CreatedAt: time.Now().UTC()}
+	assert.ErrorIs(t, f.dr.OpenDispute(ctx, f.payer.UserID.String(), tooLate, false, time.Now().Add(time.Minute)), repo.ErrDisputeWindowClosed)
+
+	// Both sides can add notes, and so can an administrator.
+	require.NoError(t, f.dr.AddDisputeNote(ctx, f.recipient.UserID.String(), f.recipient.ID.String(),
+		&model.DisputeNote{ID: uuid.New(), DisputeID: dispute.ID, Body: "it was delivered", CreatedAt: time.Now().UTC()}))
+	admin := "ops"
+	require.NoError(t, f.dr.AddAdminDisputeNote(ctx, &model.DisputeNote{ID: uuid.New(), DisputeID: dispute.ID, AuthorAdmin: &admin, Body: "looking", CreatedAt: time.Now().UTC()}))
+	stored, err := f.dr.GetDispute(ctx, f.payer.UserID.String(), f.payer.ID.String(), dispute.ID.String())
+	require.NoError(t, err)
+	require.Len(t, stored.Notes, 2)
+	assert.Equal(t, &f.recipient.UserID, stored.Notes[0].AuthorUserID)
+
+	// Only the payer withdraws it, which releases the hold without moving money back.
+	_, err = f.dr.WithdrawDispute(ctx, f.recipient.UserID.String(), f.recipient.ID.String(), dispute.ID.String(), time.Now())
+	assert.ErrorIs(t, err, repo.ErrDisputeNotFound)
+	withdrawn, err := f.dr.WithdrawDispute(ctx, f.payer.UserID.String(), f.payer.ID.String(), dispute.ID.String(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, model.DisputeStatusWithdrawn, withdrawn.Status)
+	recipient := readWallet(t, db, f.recipient.ID)
+	assert.True(t, recipient.HeldBalance.IsZero())
+	assert.True(t, recipient.Balance.Equal(decimal.NewFromInt(40)))
+	assert.True(t, readWallet(t, db, f.payer.ID).Balance.Equal(decimal.NewFromInt(60)))
+	_, err = f.dr.WithdrawDispute(ctx, f.payer.UserID.String(), f.payer.ID.String(), dispute.ID.String(), time.Now())
+	assert.ErrorIs(t, err, repo.ErrDisputeNotOpen)
+}
+
+func TestDisputeRepoPostgres_Resolve(t *testing.T) {
+	f := newDisputeFixture(t)
+	db := repotest.Postgres(t)
+	ctx := context.Background()
+	noFee := model.Fee{Amount: decimal.Zero}
+
+	// The recipient spent most of the money before the dispute, so only what is left can be held.
+	lostTransfer := f.transfer(t, 40)
+	_, err := f.store.Withdraw(ctx, f.recipient.UserID.String(), f.recipient.ID.String(), decimal.NewFromInt(30), noFee)
+	require.NoError(t, err)
+	lost := f.open(t, lostTransfer, true)
+	assert.True(t, lost.HeldAmount.Equal(decimal.NewFromInt(10)))
+	kept := f.dispute(t, 30, true)
+	assert.True(t, kept.HeldAmount.Equal(decimal.NewFromInt(30)))
+	assert.True(t, readWallet(t, db, f.recipient.ID).HeldBalance.Equal(decimal.NewFromInt(40)))
+
+	// Resolving for the recipient only releases the hold.
+	resolved, err := f.dr.ResolveDispute(ctx, kept.ID.String(), model.DisputePartyRecipient, "ops", time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, resolved.ReversalTransactionID)
+	assert.True(t, readWallet(t, db, f.recipient.ID).HeldBalance.Equal(decimal.NewFromInt(10)))
+	_, err = f.dr.ResolveDispute(ctx, kept.ID.String(), model.DisputePartyPayer, "ops", time.Now())
+	assert.ErrorIs(t, err, repo.ErrDisputeNotOpen)
+
+	// A reversal the recipient cannot cover, even with the hold released, leaves the dispute open and held.
+	_, err = f.store.Withdraw(ctx, f.recipient.UserID.String(), f.recipient.ID.String(), decimal.NewFromInt(10), noFee)
+	require.NoError(t, err)
+	_, err = f.dr.ResolveDispute(ctx, lost.ID.String(), model.DisputePartyPayer, "ops", time.Now())
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	open, err := f.dr.GetDisputeByID(ctx, lost.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, model.DisputeStatusOpen, open.Status)
+	assert.True(t, readWallet(t, db, f.recipient.ID).HeldBalance.Equal(decimal.NewFromInt(10)))
+
+	_, err = f.store.Deposit(ctx, f.recipient.UserID.String(), f.recipient.ID.String(), decimal.NewFromInt(10))
+	require.NoError(t, err)
+	reversed, err := f.dr.ResolveDispute(ctx, lost.ID.String(), model.DisputePartyPayer, "ops", time.Now())
+	require.NoError(t, err)
+	require.NotNil(t, reversed.ReversalTransactionID)
+	recipient := readWallet(t, db, f.recipient.ID)
+	assert.True(t, recipient.Balance.IsZero(), "recipient balance %s", recipient.Balance)
+	assert.True(t, recipient.HeldBalance.IsZero())
+	assert.True(t, readWallet(t, db, f.payer.ID).Balance.Equal(decimal.NewFromInt(70)))
+}
+
+func TestDisputeRepoPostgres_ConcurrentClose(t *testing.T) {
+	f := newDisputeFixture(t)
+	db := repotest.Postgres(t)
+	ctx := context.Background()
+	dispute := f.dispute(t, 40, true)
+
+	// The payer withdraws while an administrator resolves in its favour: the dispute is locked, so either the
+	// money goes back once or not at all.
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, errs[0] = f.dr.WithdrawDispute(ctx, f.payer.UserID.String(), f.payer.ID.String(), dispute.ID.String(), time.Now())
+	}()
+	go func() {
+		defer wg.Done()
+		_, errs[1] = f.dr.ResolveDispute(ctx, dispute.ID.String(), model.DisputePartyPayer, "ops", time.Now())
+	}()
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			assert.ErrorIs(t, err, repo.ErrDisputeNotOpen)
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+	recipient := readWallet(t, db, f.recipient.ID)
+	assert.True(t, recipient.HeldBalance.IsZero())
+	total := recipient.Balance.Add(readWallet(t, db, f.payer.ID).Balance)
+	assert.True(t, total.Equal(decimal.NewFromInt(100)), "payer and recipient hold %s", total)
+}
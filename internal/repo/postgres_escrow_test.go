@@ -0,0 +1,157 @@
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+func TestEscrowRepoPostgres(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	er := repo.NewEscrowImpl(db)
+	ctx := context.Background()
+
+	buyer := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "buyer", Balance: decimal.NewFromInt(100)}
+	seller := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "seller", Balance: decimal.Zero}
+	escrowWallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "escrow", Balance: decimal.Zero}
+	for _, w := range []model.Wallet{buyer, seller, escrowWallet} {
+		require.NoError(t, store.AddWallet(ctx, w))
+	}
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	feeWallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "fees", Balance: decimal.Zero}
+	require.NoError(t, store.AddWallet(ctx, feeWallet))
+	fee := func(amount int64) model.Fee {
+		return model.Fee{Amount: decimal.NewFromInt(amount), WalletID: feeWallet.ID}
+	}
+	fund := func(amount int64, releaseAfter time.Time, fee model.Fee) (*model.Escrow, error) {
+		escrow := &model.Escrow{
+			ID:             uuid.New(),
+			BuyerWalletID:  buyer.ID,
+			SellerWalletID: seller.ID,
+			EscrowWalletID: escrowWallet.ID,
+			Amount:         decimal.NewFromInt(amount),
+			ReleaseAfter:   releaseAfter,
+			CreatedAt:      now,
+		}
+		return escrow, er.CreateEscrow(ctx, buyer.UserID.String(), escrow, fee)
+	}
+	balances := func() [3]string {
+		return [3]string{
+			readWallet(t, db, buyer.ID).Balance.String(),
+			readWallet(t, db, seller.ID).Balance.String(),
+			readWallet(t, db, escrowWallet.ID).Balance.String(),
+		}
+	}
+
+	// Funding moves the money from the buyer into the escrow wallet.
+	confirmed, err := fund(30, now.Add(time.Hour), fee(0))
+	require.NoError(t, err)
+	assert.Equal(t, model.EscrowStatusHeld, confirmed.Status)
+	assert.Equal(t, [3]string{"70", "0", "30"}, balances())
+	_, err = fund(71, now.Add(time.Hour), fee(0))
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	assert.Equal(t, [3]string{"70", "0", "30"}, balances())
+
+	// Only the buyer releases it early; the sweep has to wait for release_after.
+	_, err = er.ConfirmEscrow(ctx, seller.UserID.String(), seller.ID.String(), confirmed.ID.String(), now)
+	assert.ErrorIs(t, err, repo.ErrEscrowNotBuyer)
+	_, err = er.SettleEscrow(ctx, confirmed.ID.String(), model.EscrowStatusReleased, model.EscrowResolutionTimeout, nil, now)
+	assert.ErrorIs(t, err, repo.ErrEscrowNotDue)
+	released, err := er.ConfirmEscrow(ctx, buyer.UserID.String(), buyer.ID.String(), confirmed.ID.String(), now)
+	require.NoError(t, err)
+	assert.Equal(t, model.EscrowStatusReleased, released.Status)
+	require.NotNil(t, released.SettlementTransactionID)
+	assert.Equal(t, [3]string{"70", "30", "0"}, balances())
+	_, err = er.ConfirmEscrow(ctx, buyer.UserID.String(), buyer.ID.String(), confirmed.ID.String(), now)
+	assert.ErrorIs(t, err, repo.ErrEscrowNotHeld)
+
+	// An administrator refunds one, and one past release_after is released by the sweep.
+	refunded, err := fund(20, now.Add(time.Hour), fee(0))
+	require.NoError(t, err)
+	admin := "ops"
+	_, err = er.SettleEscrow(ctx, refunded.ID.String(), model.EscrowStatusRefunded, model.EscrowResolutionAdmin, &admin, now)
+	require.NoError(t, err)
+	assert.Equal(t, [3]string{"70", "30", "0"}, balances())
+
+	due, err := fund(10, now.Add(-time.Minute), fee(0))
+	require.NoError(t, err)
+	ids, err := er.ListEscrowsDue(ctx, now)
+	require.NoError(t, err)
+	assert.Contains(t, ids, due.ID)
+	assert.NotContains(t, ids, refunded.ID)
+	_, err = er.SettleEscrow(ctx, due.ID.String(), model.EscrowStatusReleased, model.EscrowResolutionTimeout, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, [3]string{"60", "40", "0"}, balances())
+
+	stored, err := er.GetEscrow(ctx, seller.UserID.String(), seller.ID.String(), refunded.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, model.EscrowStatusRefunded, stored.Status)
+	require.NotNil(t, stored.ResolvedBy)
+	assert.Equal(t, admin, *stored.ResolvedBy)
+
+	// Funding charges the buyer the escrow fee with it; when the buyer cannot cover both, neither moves.
+	_, err = fund(10, now.Add(time.Hour), fee(2))
+	require.NoError(t, err)
+	assert.Equal(t, [3]string{"48", "40", "10"}, balances())
+	assert.Equal(t, "2", readWallet(t, db, feeWallet.ID).Balance.String())
+	_, err = fund(48, now.Add(time.Hour), fee(1))
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	assert.Equal(t, [3]string{"48", "40", "10"}, balances())
+	assert.Equal(t, "2", readWallet(t, db, feeWallet.ID).Balance.String())
+}
+
+func TestEscrowRepoPostgres_ConcurrentSettlement(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	er := repo.NewEscrowImpl(db)
+	ctx := context.Background()
+
+	buyer := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "buyer", Balance: decimal.NewFromInt(100)}
+	seller := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "seller", Balance: decimal.Zero}
+	escrowWallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "escrow", Balance: decimal.Zero}
+	for _, w := range []model.Wallet{buyer, seller, escrowWallet} {
+		require.NoError(t, store.AddWallet(ctx, w))
+	}
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	escrow := &model.Escrow{ID: uuid.New(), BuyerWalletID: buyer.ID, SellerWalletID: seller.ID, EscrowWalletID: escrowWallet.ID,
+		Amount: decimal.NewFromInt(100), ReleaseAfter: now.Add(time.Hour), CreatedAt: now}
+	require.NoError(t, er.CreateEscrow(ctx, buyer.UserID.String(), escrow, model.Fee{Amount: decimal.Zero}))
+
+	// The buyer confirms while an administrator refunds: the escrow is locked, so exactly one of them settles it.
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, errs[0] = er.ConfirmEscrow(ctx, buyer.UserID.String(), buyer.ID.String(), escrow.ID.String(), now)
+	}()
+	go func() {
+		defer wg.Done()
+		admin := "ops"
+		_, errs[1] = er.SettleEscrow(ctx, escrow.ID.String(), model.EscrowStatusRefunded, model.EscrowResolutionAdmin, &admin, now)
+	}()
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			assert.ErrorIs(t, err, repo.ErrEscrowNotHeld)
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+	assert.True(t, readWallet(t, db, escrowWallet.ID).Balance.IsZero())
+	total := readWallet(t, db, buyer.ID).Balance.Add(readWallet(t, db, seller.ID).Balance)
+	assert.True(t, total.Equal(decimal.NewFromInt(100)), "buyer and seller hold %s", total)
+}
@@ -0,0 +1,67 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+func TestFeeScheduleRepoPostgres(t *testing.T) {
+	db := repotest.Postgres(t)
+	fr := repo.NewFeeScheduleImpl(db)
+	ctx := context.Background()
+
+	// XTS is the ISO code reserved for testing, so no other test's schedules get in the way. Schedules are never
+	// changed once stored, which also keeps the test repeatable on a database it already ran on.
+	const currency = "XTS"
+	schedule := func(version int, rule model.FeeRule, effectiveFrom time.Time) model.FeeSchedule {
+		return model.FeeSchedule{ID: uuid.New(), Operation: model.FeeOperationTransfer, Currency: currency, Version: version,
+			Rule: rule, EffectiveFrom: effectiveFrom, CreatedAt: time.Now()}
+	}
+	since2020 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	since2030 := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	flat := model.FeeRule{Flat: decimal.RequireFromString("1"), Percent: decimal.Zero}
+	percent := model.FeeRule{Flat: decimal.Zero, Percent: decimal.RequireFromString("1.5")}
+
+	require.NoError(t, fr.SyncFeeSchedules(ctx, []model.FeeSchedule{schedule(1, flat, since2020), schedule(2, percent, since2030)}))
+
+	// Syncing the same versions again is a no-op, however the decimals are written.
+	sameFlat := model.FeeRule{Flat: decimal.RequireFromString("1.00"), Percent: decimal.Zero}
+	require.NoError(t, fr.SyncFeeSchedules(ctx, []model.FeeSchedule{schedule(1, sameFlat, since2020)}))
+
+	// A changed rule for a stored version fails the whole sync, so the new version before it is not stored either.
+	changed := model.FeeRule{Flat: decimal.RequireFromString("2"), Percent: decimal.Zero}
+	err := fr.SyncFeeSchedules(ctx, []model.FeeSchedule{schedule(3, flat, since2030), schedule(1, changed, since2020)})
+	assert.ErrorIs(t, err, repo.ErrFeeScheduleConflict)
+	schedules, err := fr.ListFeeSchedules(ctx, currency)
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	assert.Equal(t, 2, schedules[0].Version)
+
+	// The highest version in effect applies.
+	active, err := fr.GetActiveFeeSchedule(ctx, model.FeeOperationTransfer, currency, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, active.Version)
+	assert.Equal(t, "1", active.Rule.Flat.String())
+	active, err = fr.GetActiveFeeSchedule(ctx, model.FeeOperationTransfer, currency, since2030)
+	require.NoError(t, err)
+	assert.Equal(t, 2, active.Version)
+	_, err = fr.GetActiveFeeSchedule(ctx, model.FeeOperationTransfer, currency, since2020.Add(-time.Second))
+	assert.ErrorIs(t, err, repo.ErrFeeScheduleNotFound)
+	_, err = fr.GetActiveFeeSchedule(ctx, model.FeeOperationWithdrawal, currency, since2030)
+	assert.ErrorIs(t, err, repo.ErrFeeScheduleNotFound)
+
+	// Escrow funding has a schedule of its own, free from the start.
+	escrow, err := fr.GetActiveFeeSchedule(ctx, model.FeeOperationEscrow, "USD", since2020.AddDate(5, 0, 0))
+	require.NoError(t, err)
+	assert.True(t, escrow.Rule.Calculate(decimal.NewFromInt(1000)).IsZero())
+}
@@ -0,0 +1,138 @@
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+func TestInterestRepoPostgres_SetWalletProduct(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	ir := repo.NewInterestImpl(db)
+	ctx := context.Background()
+
+	wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "savings", Balance: decimal.NewFromInt(100)}
+	require.NoError(t, store.AddWallet(ctx, wallet))
+	viewer := uuid.New()
+	require.NoError(t, store.AddMember(ctx, wallet.ID, viewer, model.WalletRoleViewer))
+	products, err := ir.ListWalletProducts(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, products)
+	productID := products[0].ID
+	now := time.Now().UTC()
+
+	// Only owners choose the product, and only one that exists.
+	_, err = ir.SetWalletProduct(ctx, viewer.String(), wallet.ID.String(), &productID, now)
+	assert.ErrorIs(t, err, repo.ErrWalletForbidden)
+	unknown := uuid.New()
+	_, err = ir.SetWalletProduct(ctx, wallet.UserID.String(), wallet.ID.String(), &unknown, now)
+	assert.ErrorIs(t, err, repo.ErrWalletProductNotFound)
+
+	updated, err := ir.SetWalletProduct(ctx, wallet.UserID.String(), wallet.ID.String(), &productID, now)
+	require.NoError(t, err)
+	require.NotNil(t, updated.ProductID)
+	assert.Equal(t, productID, *updated.ProductID)
+	savings, err := ir.ListSavingsWallets(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, walletIDsOf(savings), wallet.ID)
+
+	updated, err = ir.SetWalletProduct(ctx, wallet.UserID.String(), wallet.ID.String(), nil, now)
+	require.NoError(t, err)
+	assert.Nil(t, updated.ProductID)
+	savings, err = ir.ListSavingsWallets(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, walletIDsOf(savings), wallet.ID)
+}
+
+func walletIDsOf(savings []model.SavingsWallet) []uuid.UUID {
+	ids := make([]uuid.UUID, len(savings))
+	for i, s := range savings {
+		ids[i] = s.WalletID
+	}
+	return ids
+}
+
+func TestInterestRepoPostgres_Payout(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	ir := repo.NewInterestImpl(db)
+	ctx := context.Background()
+
+	wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "savings", Balance: decimal.NewFromInt(100)}
+	require.NoError(t, store.AddWallet(ctx, wallet))
+	day := func(n int) time.Time { return time.Date(2024, 3, n, 0, 0, 0, 0, time.UTC) }
+	accrue := func(n int, amount string) (bool, error) {
+		return ir.AddInterestAccrual(ctx, &model.InterestAccrual{
+			ID:          uuid.New(),
+			WalletID:    wallet.ID,
+			AccrualDate: day(n),
+			Balance:     decimal.NewFromInt(100),
+			APR:         decimal.RequireFromString("1.5"),
+			DayCount:    model.DayCountActual365,
+			Amount:      decimal.RequireFromString(amount),
+			CreatedAt:   day(n + 1),
+		})
+	}
+
+	// A day is accrued once, however often the job runs for it.
+	for n, amount := range map[int]string{1: "0.004", 2: "0.004", 3: "0.003"} {
+		added, err := accrue(n, amount)
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+	added, err := accrue(1, "0.004")
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	// Less than a cent carries over to the next payout.
+	transaction, err := ir.PayInterest(ctx, wallet.ID, day(2), day(2))
+	require.NoError(t, err)
+	assert.Nil(t, transaction)
+	assert.Equal(t, "100", readWallet(t, db, wallet.ID).Balance.String())
+	due, err := ir.ListInterestPayoutsDue(ctx, day(4))
+	require.NoError(t, err)
+	assert.Contains(t, due, wallet.ID)
+
+	// Concurrent payouts lock the accruals, so they are paid exactly once.
+	const attempts = 5
+	var wg sync.WaitGroup
+	paid := make(chan *model.Transaction, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			transaction, err := ir.PayInterest(ctx, wallet.ID, day(4), day(4))
+			assert.NoError(t, err)
+			paid <- transaction
+		}()
+	}
+	wg.Wait()
+	close(paid)
+
+	var payouts []*model.Transaction
+	for transaction := range paid {
+		if transaction != nil {
+			payouts = append(payouts, transaction)
+		}
+	}
+	require.Len(t, payouts, 1)
+	assert.Equal(t, "0.01", payouts[0].Amount.String())
+	assert.Equal(t, "100.01", readWallet(t, db, wallet.ID).Balance.String())
+	summary, err := ir.GetInterestSummary(ctx, wallet.UserID.String(), wallet.ID.String())
+	require.NoError(t, err)
+	assert.True(t, summary.AccruedUnpaid.IsZero())
+	due, err = ir.ListInterestPayoutsDue(ctx, day(4))
+	require.NoError(t, err)
+	assert.NotContains(t, due, wallet.ID)
+}
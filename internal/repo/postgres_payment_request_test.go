@@ -0,0 +1,154 @@
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+// paymentRequestFixture is a requester with an empty wallet and a payer with 100 to pay requests from.
+type paymentRequestFixture struct {
+	db        *sqlx.DB
+	pr        *repo.PaymentRequestRepoImpl
+	requester model.Wallet
+	payer     model.Wallet
+	now       time.Time
+}
+
+func newPaymentRequestFixture(t *testing.T) paymentRequestFixture {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	f := paymentRequestFixture{
+		db:        db,
+		pr:        repo.NewPaymentRequestImpl(db),
+		requester: model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "requester", Balance: decimal.Zero},
+		payer:     model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "payer", Balance: decimal.NewFromInt(100)},
+		now:       time.Now().UTC().Truncate(time.Microsecond),
+	}
+	for _, w := range []model.Wallet{f.requester, f.payer} {
+		require.NoError(t, store.AddWallet(context.Background(), w))
+	}
+	return f
+}
+
+func (f paymentRequestFixture) request(t *testing.T, amount int64) *model.PaymentRequest {
+	t.Helper()
+	req := &model.PaymentRequest{
+		ID:                uuid.New(),
+		RequesterUserID:   f.requester.UserID,
+		RequesterWalletID: f.requester.ID,
+		PayerUserID:       f.payer.UserID,
+		Amount:            decimal.NewFromInt(amount),
+		Status:            model.PaymentRequestStatusPending,
+		ExpiresAt:         f.now.Add(time.Hour),
+		CreatedAt:         f.now,
+		UpdatedAt:         f.now,
+	}
+	require.NoError(t, f.pr.CreatePaymentRequest(context.Background(), req))
+	return req
+}
+
+func (f paymentRequestFixture) balances(t *testing.T) [2]string {
+	return [2]string{readWallet(t, f.db, f.requester.ID).Balance.String(), readWallet(t, f.db, f.payer.ID).Balance.String()}
+}
+
+func TestPaymentRequestRepoPostgres(t *testing.T) {
+	f := newPaymentRequestFixture(t)
+	ctx := context.Background()
+	payer := f.payer.UserID.String()
+
+	// Accepting pays the requester once; the request cannot be answered again.
+	accepted := f.request(t, 30)
+	req, err := f.pr.AcceptPaymentRequest(ctx, payer, accepted.ID.String(), f.payer.ID.String(), model.Fee{}, f.now)
+	require.NoError(t, err)
+	assert.Equal(t, model.PaymentRequestStatusAccepted, req.Status)
+	require.NotNil(t, req.TransactionID)
+	assert.Equal(t, [2]string{"30", "70"}, f.balances(t))
+	_, err = f.pr.AcceptPaymentRequest(ctx, payer, accepted.ID.String(), f.payer.ID.String(), model.Fee{}, f.now)
+	assert.ErrorIs(t, err, repo.ErrPaymentRequestNotPending)
+	_, err = f.pr.CancelPaymentRequest(ctx, f.requester.UserID.String(), accepted.ID.String(), f.now)
+	assert.ErrorIs(t, err, repo.ErrPaymentRequestNotPending)
+
+	// A request the payer cannot cover stays pending and nothing moves.
+	tooBig := f.request(t, 71)
+	_, err = f.pr.AcceptPaymentRequest(ctx, payer, tooBig.ID.String(), f.payer.ID.String(), model.Fee{}, f.now)
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	assert.Equal(t, [2]string{"30", "70"}, f.balances(t))
+	req, err = f.pr.GetPaymentRequest(ctx, payer, tooBig.ID.String(), f.now)
+	require.NoError(t, err)
+	assert.Equal(t, model.PaymentRequestStatusPending, req.Status)
+
+	// Only the payer answers a request, and only the requester cancels it.
+	_, err = f.pr.AcceptPaymentRequest(ctx, f.requester.UserID.String(), tooBig.ID.String(), f.requester.ID.String(), model.Fee{}, f.now)
+	assert.ErrorIs(t, err, repo.ErrPaymentRequestNotFound)
+	_, err = f.pr.CancelPaymentRequest(ctx, payer, tooBig.ID.String(), f.now)
+	assert.ErrorIs(t, err, repo.ErrPaymentRequestNotFound)
+	_, err = f.pr.DeclinePaymentRequest(ctx, payer, tooBig.ID.String(), f.now)
+	require.NoError(t, err)
+
+	// A request past its expiry is marked expired instead of paid.
+	expired := f.request(t, 10)
+	_, err = f.pr.AcceptPaymentRequest(ctx, payer, expired.ID.String(), f.payer.ID.String(), model.Fee{}, expired.ExpiresAt)
+	assert.ErrorIs(t, err, repo.ErrPaymentRequestExpired)
+	assert.Equal(t, [2]string{"30", "70"}, f.balances(t))
+	incoming, err := f.pr.ListIncomingPaymentRequests(ctx, payer, model.PaymentRequestStatusExpired, f.now)
+	require.NoError(t, err)
+	require.Len(t, incoming, 1)
+	assert.Equal(t, expired.ID, incoming[0].ID)
+
+	// Accepting charges the payer the transfer fee with the payment; when the payer cannot cover both, neither moves.
+	feeWallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "fees", Balance: decimal.Zero}
+	require.NoError(t, pgWalletStore{repo.NewWalletImpl(f.db), f.db}.AddWallet(ctx, feeWallet))
+	fee := model.Fee{Amount: decimal.NewFromInt(5), WalletID: feeWallet.ID}
+	charged := f.request(t, 66)
+	_, err = f.pr.AcceptPaymentRequest(ctx, payer, charged.ID.String(), f.payer.ID.String(), fee, f.now)
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	assert.Equal(t, [2]string{"30", "70"}, f.balances(t))
+	charged = f.request(t, 50)
+	_, err = f.pr.AcceptPaymentRequest(ctx, payer, charged.ID.String(), f.payer.ID.String(), fee, f.now)
+	require.NoError(t, err)
+	assert.Equal(t, [2]string{"80", "15"}, f.balances(t))
+	assert.Equal(t, "5", readWallet(t, f.db, feeWallet.ID).Balance.String())
+}
+
+func TestPaymentRequestRepoPostgres_ConcurrentAccepts(t *testing.T) {
+	f := newPaymentRequestFixture(t)
+	ctx := context.Background()
+	req := f.request(t, 40)
+
+	const attempts = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := f.pr.AcceptPaymentRequest(ctx, f.payer.UserID.String(), req.ID.String(), f.payer.ID.String(), model.Fee{}, f.now)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, repo.ErrPaymentRequestNotPending)
+	}
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, [2]string{"40", "60"}, f.balances(t))
+}
@@ -0,0 +1,113 @@
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+func TestPotRepoPostgres(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	pr := repo.NewPotImpl(db)
+	ctx := context.Background()
+
+	wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "main", Balance: decimal.NewFromInt(100)}
+	viewerID := uuid.New()
+	require.NoError(t, store.AddWallet(ctx, wallet))
+	require.NoError(t, store.AddMember(ctx, wallet.ID, viewerID, model.WalletRoleViewer))
+	userID, walletID := wallet.UserID.String(), wallet.ID.String()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	pot := &model.Pot{ID: uuid.New(), WalletID: wallet.ID, Name: "Holiday", CreatedAt: now}
+	require.NoError(t, pr.CreatePot(ctx, userID, pot))
+	assert.ErrorIs(t, pr.CreatePot(ctx, userID, &model.Pot{ID: uuid.New(), WalletID: wallet.ID, Name: "holiday", CreatedAt: now}), repo.ErrPotNameTaken)
+	assert.ErrorIs(t, pr.CreatePot(ctx, viewerID.String(), &model.Pot{ID: uuid.New(), WalletID: wallet.ID, Name: "Car", CreatedAt: now}), repo.ErrWalletForbidden)
+	potID := pot.ID.String()
+
+	// Money in a pot stays in the wallet's balance but can no longer be spent.
+	moved, err := pr.MovePotMoney(ctx, userID, walletID, potID, model.PotMoveIn, decimal.NewFromInt(60), now)
+	require.NoError(t, err)
+	assert.True(t, moved.Balance.Equal(decimal.NewFromInt(60)))
+	stored := readWallet(t, db, wallet.ID)
+	assert.True(t, stored.Balance.Equal(decimal.NewFromInt(100)))
+	assert.True(t, stored.PotsBalance.Equal(decimal.NewFromInt(60)))
+
+	_, err = pr.MovePotMoney(ctx, userID, walletID, potID, model.PotMoveIn, decimal.NewFromInt(41), now)
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	_, err = store.Withdraw(ctx, userID, walletID, decimal.NewFromInt(41), model.Fee{Amount: decimal.Zero})
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	_, err = pr.MovePotMoney(ctx, userID, walletID, potID, model.PotMoveOut, decimal.NewFromInt(61), now)
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	_, err = pr.MovePotMoney(ctx, viewerID.String(), walletID, potID, model.PotMoveOut, decimal.NewFromInt(1), now)
+	assert.ErrorIs(t, err, repo.ErrWalletForbidden)
+
+	moved, err = pr.MovePotMoney(ctx, userID, walletID, potID, model.PotMoveOut, decimal.NewFromInt(10), now)
+	require.NoError(t, err)
+	assert.True(t, moved.Balance.Equal(decimal.NewFromInt(50)))
+
+	// Closing the pot gives its money back to the wallet, and frees its name.
+	closed, err := pr.ClosePot(ctx, userID, walletID, potID, now)
+	require.NoError(t, err)
+	assert.Equal(t, model.PotStatusClosed, closed.Status)
+	assert.True(t, closed.Balance.IsZero())
+	assert.True(t, readWallet(t, db, wallet.ID).PotsBalance.IsZero())
+	_, err = pr.MovePotMoney(ctx, userID, walletID, potID, model.PotMoveIn, decimal.NewFromInt(1), now)
+	assert.ErrorIs(t, err, repo.ErrPotClosed)
+	require.NoError(t, pr.CreatePot(ctx, userID, &model.Pot{ID: uuid.New(), WalletID: wallet.ID, Name: "Holiday", CreatedAt: now}))
+
+	var movements int
+	require.NoError(t, db.GetContext(ctx, &movements, `SELECT COUNT(*) FROM pot_movements WHERE pot_id = $1`, pot.ID))
+	assert.Equal(t, 3, movements)
+}
+
+func TestPotRepoPostgres_ConcurrentMoves(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	pr := repo.NewPotImpl(db)
+	ctx := context.Background()
+
+	wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "main", Balance: decimal.NewFromInt(100)}
+	require.NoError(t, store.AddWallet(ctx, wallet))
+	now := time.Now().UTC()
+	pots := make([]*model.Pot, 2)
+	for i := range pots {
+		pots[i] = &model.Pot{ID: uuid.New(), WalletID: wallet.ID, Name: uuid.NewString(), CreatedAt: now}
+		require.NoError(t, pr.CreatePot(ctx, wallet.UserID.String(), pots[i]))
+	}
+
+	// Moves into two pots of the wallet lock the wallet first, so together they never take more than it has.
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := pr.MovePotMoney(ctx, wallet.UserID.String(), wallet.ID.String(), pots[i%2].ID.String(), model.PotMoveIn, decimal.NewFromInt(15), now)
+			if err != nil {
+				assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+				return
+			}
+			mu.Lock()
+			succeeded++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 6, succeeded)
+	stored := readWallet(t, db, wallet.ID)
+	assert.True(t, stored.PotsBalance.Equal(decimal.NewFromInt(90)), "pots balance %s", stored.PotsBalance)
+	assert.True(t, stored.Balance.Equal(decimal.NewFromInt(100)))
+}
@@ -0,0 +1,116 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+func TestScheduledTransferRepoPostgres_ReleaseExpiredRuns(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	sr := repo.NewScheduledTransferImpl(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	source := model.Wallet{ID: uuid.New(), UserID: userID, Name: "source", Balance: decimal.NewFromInt(1000)}
+	destination := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "destination", Balance: decimal.Zero}
+	require.NoError(t, store.AddWallet(ctx, source))
+	require.NoError(t, store.AddWallet(ctx, destination))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	lease := 15 * time.Minute
+	claim := func(amount int64, claimedAt time.Time) *model.ScheduledTransferRun {
+		t.Helper()
+		occurrence := claimedAt.Add(-time.Minute)
+		s := &model.ScheduledTransfer{
+			ID:                  uuid.New(),
+			UserID:              userID,
+			SourceWalletID:      source.ID,
+			DestinationWalletID: destination.ID,
+			Amount:              decimal.NewFromInt(amount),
+			Expression:          "0 9 1 * *",
+			StartAt:             occurrence,
+			NextRunAt:           &occurrence,
+			Status:              model.ScheduledTransferStatusActive,
+			CreatedAt:           occurrence,
+			UpdatedAt:           occurrence,
+		}
+		require.NoError(t, sr.CreateScheduledTransfer(ctx, s))
+		run, err := sr.ClaimScheduledTransferRun(ctx, s, nil, claimedAt)
+		require.NoError(t, err)
+		require.NotNil(t, run)
+		return run
+	}
+
+	// The worker of abandoned died before transferring, the one of paid after; fresh is still within its lease.
+	// A transfer the user made by hand, like the payment of abandoned, is no payment of any run.
+	abandoned := claim(10, now.Add(-time.Hour))
+	paid := claim(20, now.Add(-time.Hour))
+	fresh := claim(30, now)
+	noFee := model.Fee{Amount: decimal.Zero}
+	transaction, err := store.Transfer(repo.WithScheduledTransferRun(ctx, paid.ID), userID.String(), source.ID.String(), destination.ID.String(),
+		decimal.NewFromInt(20), noFee)
+	require.NoError(t, err)
+	_, err = store.Transfer(ctx, userID.String(), source.ID.String(), destination.ID.String(), decimal.NewFromInt(10), noFee)
+	require.NoError(t, err)
+
+	// A run is paid once, however many attempts are made at it.
+	_, err = store.Transfer(repo.WithScheduledTransferRun(ctx, paid.ID), userID.String(), source.ID.String(), destination.ID.String(),
+		decimal.NewFromInt(20), noFee)
+	assert.ErrorIs(t, err, repo.ErrScheduledTransferRunPaid)
+	assert.Equal(t, "970", readWallet(t, db, source.ID).Balance.String())
+
+	released, err := sr.ReleaseExpiredScheduledTransferRuns(ctx, now.Add(-lease), now)
+	require.NoError(t, err)
+	require.Len(t, released, 2)
+
+	byID := map[uuid.UUID]model.ScheduledTransferRun{}
+	for _, run := range released {
+		byID[run.ID] = run
+	}
+	assert.Equal(t, model.ScheduledTransferRunStatusRetrying, byID[abandoned.ID].Status)
+	assert.Nil(t, byID[abandoned.ID].TransactionID)
+	assert.Equal(t, model.ScheduledTransferRunStatusSucceeded, byID[paid.ID].Status)
+	assert.Equal(t, &transaction.ID, byID[paid.ID].TransactionID)
+	assert.NotContains(t, byID, fresh.ID)
+
+	// Only the abandoned run is retried, and claiming it starts a new lease.
+	retries, err := sr.ListRetryableScheduledTransferRuns(ctx, now, 100)
+	require.NoError(t, err)
+	require.Len(t, retries, 1)
+	assert.Equal(t, abandoned.ID, retries[0].ID)
+
+	claimed, err := sr.ClaimScheduledTransferRetry(ctx, &retries[0], now)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	assert.Equal(t, 2, retries[0].Attempts)
+
+	released, err = sr.ReleaseExpiredScheduledTransferRuns(ctx, now.Add(-lease), now)
+	require.NoError(t, err)
+	assert.Empty(t, released)
+
+	// The worker whose lease expired cannot record an outcome over the attempt that took over; that attempt can.
+	abandoned.Status = model.ScheduledTransferRunStatusFailed
+	assert.ErrorIs(t, sr.FinishScheduledTransferRun(ctx, abandoned), repo.ErrScheduledTransferRunNotClaimed)
+	retries[0].Status = model.ScheduledTransferRunStatusSucceeded
+	require.NoError(t, sr.FinishScheduledTransferRun(ctx, &retries[0]))
+	assert.ErrorIs(t, sr.FinishScheduledTransferRun(ctx, &retries[0]), repo.ErrScheduledTransferRunNotClaimed)
+
+	// An attempt that found its run already paid is given the transaction of the attempt that paid it.
+	fresh.Status = model.ScheduledTransferRunStatusSucceeded
+	_, err = store.Transfer(repo.WithScheduledTransferRun(ctx, fresh.ID), userID.String(), source.ID.String(), destination.ID.String(),
+		decimal.NewFromInt(30), noFee)
+	require.NoError(t, err)
+	require.NoError(t, sr.FinishScheduledTransferRun(ctx, fresh))
+	require.NotNil(t, fresh.TransactionID)
+}
@@ -0,0 +1,153 @@
+package repo_test
+
+import (
+	"context"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+	"github.com/kylenguyen/wallet-app/internal/service"
+)
+
+// findTransaction returns the transaction of the given type in transactions, failing the test if there is not
+// exactly one.
+func findTransaction(t *testing.T, transactions []model.Transaction, transactionType model.TransactionType) model.Transaction {
+	t.Helper()
+	var found []model.Transaction
+	for _, transaction := range transactions {
+		if transaction.Type == transactionType {
+			found = append(found, transaction)
+		}
+	}
+	require.Len(t, found, 1, "%s transactions", transactionType)
+	return found[0]
+}
+
+func TestStatementRepoPostgres_FeeReferencesItsOperation(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	sr := repo.NewStatementImpl(db)
+	ctx := context.Background()
+
+	payer := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "payer", Balance: decimal.NewFromInt(100)}
+	payee := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "payee", Balance: decimal.Zero}
+	feeWallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "fees", Balance: decimal.Zero}
+	for _, w := range []model.Wallet{payer, payee, feeWallet} {
+		require.NoError(t, store.AddWallet(ctx, w))
+	}
+	since := time.Now().Add(-time.Minute)
+
+	transfer, err := store.Transfer(ctx, payer.UserID.String(), payer.ID.String(), payee.ID.String(), decimal.NewFromInt(40),
+		model.Fee{Amount: decimal.NewFromInt(2), WalletID: feeWallet.ID})
+	require.NoError(t, err)
+
+	activity, err := sr.GetWalletActivity(ctx, payer.UserID.String(), payer.ID.String(), since)
+	require.NoError(t, err)
+	require.Len(t, activity.Transactions, 2)
+	fee := findTransaction(t, activity.Transactions, model.TransactionTypeFee)
+	assert.Equal(t, &transfer.ID, fee.ParentTransactionID)
+	assert.Nil(t, findTransaction(t, activity.Transactions, model.TransactionTypeTransfer).ParentTransactionID)
+
+	// The fee wallet sees the same fee, as a credit.
+	activity, err = sr.GetWalletActivity(ctx, feeWallet.UserID.String(), feeWallet.ID.String(), since)
+	require.NoError(t, err)
+	fee = findTransaction(t, activity.Transactions, model.TransactionTypeFee)
+	assert.Equal(t, &transfer.ID, fee.ParentTransactionID)
+	assert.True(t, fee.NetAmountFor(feeWallet.ID).Equal(decimal.NewFromInt(2)))
+}
+
+func TestStatementRepoPostgres_DisputeResolvedForPayer(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	sr := repo.NewStatementImpl(db)
+	ir := repo.NewInterestImpl(db)
+	dr := repo.NewDisputeImpl(db)
+	ctx := context.Background()
+
+	payer := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "payer", Balance: decimal.NewFromInt(100)}
+	recipient := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "recipient", Balance: decimal.Zero}
+	require.NoError(t, store.AddWallet(ctx, payer))
+	require.NoError(t, store.AddWallet(ctx, recipient))
+	start := time.Now().Add(-time.Minute)
+
+	transfer, err := store.Transfer(ctx, payer.UserID.String(), payer.ID.String(), recipient.ID.String(), decimal.NewFromInt(40), model.Fee{Amount: decimal.Zero})
+	require.NoError(t, err)
+	afterTransfer := time.Now()
+
+	dispute := &model.Dispute{ID: uuid.New(), TransactionID: transfer.ID, PayerWalletID: payer.ID, Reason: "not delivered", CreatedAt: afterTransfer}
+	require.NoError(t, dr.OpenDispute(ctx, payer.UserID.String(), dispute, true, start))
+	resolved, err := dr.ResolveDispute(ctx, dispute.ID.String(), model.DisputePartyPayer, "ops", afterTransfer.Add(time.Second))
+	require.NoError(t, err)
+	require.NotNil(t, resolved.ReversalTransactionID)
+
+	// The reversal is recorded on the recipient's wallet, yet shows on the payer's statement as a credit.
+	activity, err := sr.GetWalletActivity(ctx, payer.UserID.String(), payer.ID.String(), start)
+	require.NoError(t, err)
+	require.Len(t, activity.Transactions, 2)
+	reversal := findTransaction(t, activity.Transactions, model.TransactionTypeReversal)
+	assert.Equal(t, *resolved.ReversalTransactionID, reversal.ID)
+	assert.Equal(t, &transfer.ID, reversal.ParentTransactionID)
+	assert.True(t, reversal.NetAmountFor(payer.ID).Equal(decimal.NewFromInt(40)))
+
+	// The payer is back where it started, and was 40 short in between.
+	out, err := service.NewStatementImpl(sr, "USD").GenerateCamt053(ctx, payer.UserID.String(), payer.ID.String(), start, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	var doc struct {
+		Bal []struct {
+			Cd  string `xml:"Tp>CdOrPrtry>Cd"`
+			Amt string `xml:"Amt"`
+		} `xml:"BkToCstmrStmt>Stmt>Bal"`
+	}
+	require.NoError(t, xml.Unmarshal(out, &doc))
+	require.Len(t, doc.Bal, 2)
+	assert.Equal(t, [2]string{"OPBD", "100.00"}, [2]string{doc.Bal[0].Cd, doc.Bal[0].Amt})
+	assert.Equal(t, [2]string{"CLBD", "100.00"}, [2]string{doc.Bal[1].Cd, doc.Bal[1].Amt})
+
+	for _, tt := range []struct {
+		at   time.Time
+		want int64
+	}{
+		{start, 100},
+		{afterTransfer, 60},
+		{time.Now().Add(time.Hour), 100},
+	} {
+		balance, err := ir.GetBalanceAt(ctx, payer.ID, tt.at)
+		require.NoError(t, err)
+		assert.True(t, balance.Equal(decimal.NewFromInt(tt.want)), "balance at %s: %s", tt.at, balance)
+	}
+}
+
+func TestStatementRepoPostgres_ViewerSeesWalletOwner(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	sr := repo.NewStatementImpl(db)
+	ctx := context.Background()
+
+	wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "household", Balance: decimal.NewFromInt(10)}
+	viewerID := uuid.New()
+	require.NoError(t, store.AddWallet(ctx, wallet))
+	require.NoError(t, store.AddMember(ctx, wallet.ID, viewerID, model.WalletRoleViewer))
+	_, err := db.ExecContext(ctx, `UPDATE users SET name = $1 WHERE id = $2`, "Wallet Owner", wallet.UserID)
+	require.NoError(t, err)
+
+	activity, err := sr.GetWalletActivity(ctx, viewerID.String(), wallet.ID.String(), time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, wallet.UserID, activity.Owner.ID)
+	assert.Equal(t, "Wallet Owner", activity.Owner.Name)
+
+	out, err := service.NewStatementImpl(sr, "USD").GenerateCamt053(ctx, viewerID.String(), wallet.ID.String(), time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	var doc struct {
+		Owner string `xml:"BkToCstmrStmt>Stmt>Acct>Ownr>Nm"`
+	}
+	require.NoError(t, xml.Unmarshal(out, &doc))
+	assert.Equal(t, "Wallet Owner", doc.Owner)
+}
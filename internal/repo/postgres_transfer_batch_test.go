@@ -0,0 +1,202 @@
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+// transferBatchFixture is a source wallet of 100 paying out to two empty wallets.
+type transferBatchFixture struct {
+	db     *sqlx.DB
+	br     *repo.TransferBatchRepoImpl
+	source model.Wallet
+	first  model.Wallet
+	second model.Wallet
+}
+
+func newTransferBatchFixture(t *testing.T) transferBatchFixture {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	f := transferBatchFixture{
+		db:     db,
+		br:     repo.NewTransferBatchImpl(db),
+		source: model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "payroll", Balance: decimal.NewFromInt(100)},
+		first:  model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "first", Balance: decimal.Zero},
+		second: model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "second", Balance: decimal.Zero},
+	}
+	for _, w := range []model.Wallet{f.source, f.first, f.second} {
+		require.NoError(t, store.AddWallet(context.Background(), w))
+	}
+	return f
+}
+
+// batch stores a batch paying amounts alternately to the first and second wallet.
+func (f transferBatchFixture) batch(t *testing.T, mode model.TransferBatchMode, amounts ...int64) *model.TransferBatch {
+	t.Helper()
+	return f.chargedBatch(t, mode, nil, amounts...)
+}
+
+// chargedBatch stores a batch like batch does, with fee quoted on every row when it is set.
+func (f transferBatchFixture) chargedBatch(t *testing.T, mode model.TransferBatchMode, fee *model.Fee, amounts ...int64) *model.TransferBatch {
+	t.Helper()
+	now := time.Now().UTC()
+	batch := &model.TransferBatch{
+		ID:             uuid.New(),
+		UserID:         f.source.UserID,
+		SourceWalletID: f.source.ID,
+		Mode:           mode,
+		Status:         model.TransferBatchStatusPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	for i, amount := range amounts {
+		destination := f.first.ID
+		if i%2 == 1 {
+			destination = f.second.ID
+		}
+		item := model.TransferBatchItem{
+			ID:                  uuid.New(),
+			BatchID:             batch.ID,
+			RowNumber:           i + 1,
+			DestinationWalletID: destination,
+			Amount:              decimal.NewFromInt(amount),
+			Fee:                 decimal.Zero,
+			Status:              model.TransferBatchItemStatusPending,
+			UpdatedAt:           now,
+		}
+		if fee != nil {
+			item.Fee, item.FeeWalletID, item.FeeScheduleID = fee.Amount, &fee.WalletID, fee.ScheduleID
+		}
+		batch.Items = append(batch.Items, item)
+	}
+	require.NoError(t, f.br.CreateTransferBatch(context.Background(), batch))
+	return batch
+}
+
+func (f transferBatchFixture) balances(t *testing.T) [3]string {
+	t.Helper()
+	return [3]string{
+		readWallet(t, f.db, f.source.ID).Balance.String(),
+		readWallet(t, f.db, f.first.ID).Balance.String(),
+		readWallet(t, f.db, f.second.ID).Balance.String(),
+	}
+}
+
+func (f transferBatchFixture) stored(t *testing.T, batch *model.TransferBatch) *model.TransferBatch {
+	t.Helper()
+	stored, err := f.br.GetTransferBatch(context.Background(), f.source.UserID.String(), f.source.ID.String(), batch.ID.String())
+	require.NoError(t, err)
+	return stored
+}
+
+func TestTransferBatchRepoPostgres_AllOrNothing(t *testing.T) {
+	f := newTransferBatchFixture(t)
+	ctx := context.Background()
+
+	missing, err := f.br.FindMissingWallets(ctx, []uuid.UUID{f.first.ID, uuid.Nil, uuid.Nil})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{uuid.Nil}, missing)
+
+	// The third row overdraws the source, so the first two are rolled back with it.
+	failed := f.batch(t, model.TransferBatchModeAllOrNothing, 30, 40, 50)
+	require.NoError(t, f.br.ExecuteTransferBatchAtomically(ctx, failed))
+	assert.Equal(t, [3]string{"100", "0", "0"}, f.balances(t))
+	stored := f.stored(t, failed)
+	assert.Equal(t, 1, stored.Summary.Failed)
+	assert.Equal(t, 2, stored.Summary.Skipped)
+	require.NotNil(t, stored.Items[2].Error)
+	for _, item := range stored.Items {
+		assert.Nil(t, item.TransactionID)
+	}
+
+	succeeded := f.batch(t, model.TransferBatchModeAllOrNothing, 30, 40)
+	require.NoError(t, f.br.ExecuteTransferBatchAtomically(ctx, succeeded))
+	assert.Equal(t, [3]string{"30", "30", "40"}, f.balances(t))
+	stored = f.stored(t, succeeded)
+	assert.Equal(t, 2, stored.Summary.Succeeded)
+	assert.Equal(t, "70", stored.Summary.Moved.String())
+}
+
+func TestTransferBatchRepoPostgres_BestEffort(t *testing.T) {
+	f := newTransferBatchFixture(t)
+	ctx := context.Background()
+
+	// Each row commits on its own, so only the row the source cannot cover fails.
+	batch := f.batch(t, model.TransferBatchModeBestEffort, 30, 80, 40)
+	for i := range batch.Items {
+		require.NoError(t, f.br.ExecuteTransferBatchItem(ctx, batch, &batch.Items[i]))
+	}
+	assert.Equal(t, [3]string{"30", "70", "0"}, f.balances(t))
+	stored := f.stored(t, batch)
+	assert.Equal(t, model.TransferBatchItemStatusSucceeded, stored.Items[0].Status)
+	assert.Equal(t, model.TransferBatchItemStatusFailed, stored.Items[1].Status)
+	assert.Equal(t, model.TransferBatchItemStatusSucceeded, stored.Items[2].Status)
+
+	_, err := f.br.GetTransferBatch(ctx, f.first.UserID.String(), f.source.ID.String(), batch.ID.String())
+	assert.ErrorIs(t, err, repo.ErrWalletNotFound)
+}
+
+func TestTransferBatchRepoPostgres_Fees(t *testing.T) {
+	f := newTransferBatchFixture(t)
+	ctx := context.Background()
+	feeWallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "fees", Balance: decimal.Zero}
+	require.NoError(t, pgWalletStore{repo.NewWalletImpl(f.db), f.db}.AddWallet(ctx, feeWallet))
+	fee := &model.Fee{Amount: decimal.NewFromInt(5), WalletID: feeWallet.ID}
+
+	// Every row is charged its quoted fee with it: 40 and 50 fit in 100, but not with 10 in fees.
+	failed := f.chargedBatch(t, model.TransferBatchModeAllOrNothing, fee, 40, 50)
+	require.NoError(t, f.br.ExecuteTransferBatchAtomically(ctx, failed))
+	assert.Equal(t, [3]string{"100", "0", "0"}, f.balances(t))
+	assert.Equal(t, 1, f.stored(t, failed).Summary.Failed)
+
+	batch := f.chargedBatch(t, model.TransferBatchModeBestEffort, fee, 40, 50)
+	for i := range batch.Items {
+		require.NoError(t, f.br.ExecuteTransferBatchItem(ctx, batch, &batch.Items[i]))
+	}
+	assert.Equal(t, [3]string{"55", "40", "0"}, f.balances(t))
+	assert.Equal(t, "5", readWallet(t, f.db, feeWallet.ID).Balance.String())
+	stored := f.stored(t, batch)
+	assert.Equal(t, "5", stored.Items[0].Fee.String())
+	assert.Equal(t, model.TransferBatchItemStatusFailed, stored.Items[1].Status)
+}
+
+func TestTransferBatchRepoPostgres_ConcurrentBatches(t *testing.T) {
+	f := newTransferBatchFixture(t)
+	ctx := context.Background()
+
+	// Two batches of 60 race for the same 100; the source row lock lets exactly one through.
+	batches := []*model.TransferBatch{
+		f.batch(t, model.TransferBatchModeAllOrNothing, 20, 40),
+		f.batch(t, model.TransferBatchModeAllOrNothing, 20, 40),
+	}
+	var wg sync.WaitGroup
+	for _, batch := range batches {
+		wg.Add(1)
+		go func(batch *model.TransferBatch) {
+			defer wg.Done()
+			assert.NoError(t, f.br.ExecuteTransferBatchAtomically(ctx, batch))
+		}(batch)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, batch := range batches {
+		if f.stored(t, batch).Summary.Succeeded == 2 {
+			succeeded++
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, [3]string{"40", "20", "40"}, f.balances(t))
+}
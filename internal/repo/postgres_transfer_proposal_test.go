@@ -0,0 +1,211 @@
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+// proposalFixture is a wallet holding 100 with two owners and a spender, under a policy that needs one approval
+// for transfers over 50, and the wallets it pays into.
+type proposalFixture struct {
+	store       pgWalletStore
+	pr          *repo.TransferProposalRepoImpl
+	wallet      model.Wallet
+	coOwner     uuid.UUID
+	spender     uuid.UUID
+	destination model.Wallet
+	feeWallet   model.Wallet
+	now         time.Time
+}
+
+func newProposalFixture(t *testing.T) *proposalFixture {
+	t.Helper()
+	db := repotest.Postgres(t)
+	ctx := context.Background()
+	f := &proposalFixture{
+		store:       pgWalletStore{repo.NewWalletImpl(db), db},
+		pr:          repo.NewTransferProposalImpl(db),
+		wallet:      model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "household", Balance: decimal.NewFromInt(100)},
+		coOwner:     uuid.New(),
+		spender:     uuid.New(),
+		destination: model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "landlord", Balance: decimal.Zero},
+		feeWallet:   model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "fees", Balance: decimal.Zero},
+		now:         time.Now().UTC().Truncate(time.Microsecond),
+	}
+	for _, w := range []model.Wallet{f.wallet, f.destination, f.feeWallet} {
+		require.NoError(t, f.store.AddWallet(ctx, w))
+	}
+	require.NoError(t, f.store.AddMember(ctx, f.wallet.ID, f.coOwner, model.WalletRoleOwner))
+	require.NoError(t, f.store.AddMember(ctx, f.wallet.ID, f.spender, model.WalletRoleSpender))
+
+	policy := &model.ApprovalPolicy{WalletID: f.wallet.ID, Threshold: decimal.NewFromInt(50), RequiredApprovals: 1, ProposalTTLHours: 24, UpdatedAt: f.now}
+	require.NoError(t, f.pr.SetApprovalPolicy(ctx, f.wallet.UserID.String(), policy))
+	return f
+}
+
+// propose has the user propose a transfer of amount to the destination, with a fee of 2.
+func (f *proposalFixture) propose(t *testing.T, userID uuid.UUID, amount int64) *model.TransferProposal {
+	t.Helper()
+	proposal := &model.TransferProposal{
+		ID:                  uuid.New(),
+		WalletID:            f.wallet.ID,
+		DestinationWalletID: f.destination.ID,
+		Amount:              decimal.NewFromInt(amount),
+		Fee:                 decimal.NewFromInt(2),
+		FeeWalletID:         &f.feeWallet.ID,
+		CreatedAt:           f.now,
+	}
+	require.NoError(t, f.pr.CreateTransferProposal(context.Background(), userID.String(), proposal))
+	return proposal
+}
+
+func TestTransferProposalRepoPostgres_Policy(t *testing.T) {
+	f := newProposalFixture(t)
+	ctx := context.Background()
+	walletID := f.wallet.ID.String()
+
+	tooMany := &model.ApprovalPolicy{WalletID: f.wallet.ID, Threshold: decimal.NewFromInt(50), RequiredApprovals: 3, ProposalTTLHours: 24, UpdatedAt: f.now}
+	assert.ErrorIs(t, f.pr.SetApprovalPolicy(ctx, f.wallet.UserID.String(), tooMany), repo.ErrTooFewApprovers)
+	tooMany.RequiredApprovals = 1
+	assert.ErrorIs(t, f.pr.SetApprovalPolicy(ctx, f.spender.String(), tooMany), repo.ErrWalletForbidden)
+
+	policy, err := f.pr.GetApprovalPolicy(ctx, f.spender.String(), walletID)
+	require.NoError(t, err)
+	assert.True(t, policy.Threshold.Equal(decimal.NewFromInt(50)))
+
+	// Over the threshold a transfer has to be proposed; at it, it does not.
+	noFee := model.Fee{Amount: decimal.Zero}
+	_, err = f.store.Transfer(ctx, f.spender.String(), walletID, f.destination.ID.String(), decimal.NewFromInt(51), noFee)
+	assert.ErrorIs(t, err, repo.ErrApprovalRequired)
+	_, err = f.store.Transfer(ctx, f.spender.String(), walletID, f.destination.ID.String(), decimal.NewFromInt(50), noFee)
+	require.NoError(t, err)
+
+	require.NoError(t, f.pr.DeleteApprovalPolicy(ctx, f.coOwner.String(), walletID))
+	_, err = f.pr.GetApprovalPolicy(ctx, f.spender.String(), walletID)
+	assert.ErrorIs(t, err, repo.ErrApprovalPolicyNotFound)
+	err = f.pr.CreateTransferProposal(ctx, f.spender.String(), &model.TransferProposal{
+		ID: uuid.New(), WalletID: f.wallet.ID, DestinationWalletID: f.destination.ID, Amount: decimal.NewFromInt(10), CreatedAt: f.now,
+	})
+	assert.ErrorIs(t, err, repo.ErrApprovalPolicyNotFound)
+}
+
+func TestTransferProposalRepoPostgres_Approve(t *testing.T) {
+	f := newProposalFixture(t)
+	db := repotest.Postgres(t)
+	ctx := context.Background()
+	walletID := f.wallet.ID.String()
+
+	// The amount and fee are held from the moment the transfer is proposed.
+	proposal := f.propose(t, f.spender, 60)
+	assert.True(t, proposal.HeldAmount.Equal(decimal.NewFromInt(62)))
+	assert.True(t, readWallet(t, db, f.wallet.ID).HeldBalance.Equal(decimal.NewFromInt(62)))
+	_, err := f.store.Withdraw(ctx, f.wallet.UserID.String(), walletID, decimal.NewFromInt(39), model.Fee{Amount: decimal.Zero})
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	err = f.pr.CreateTransferProposal(ctx, f.spender.String(), &model.TransferProposal{
+		ID: uuid.New(), WalletID: f.wallet.ID, DestinationWalletID: f.destination.ID, Amount: decimal.NewFromInt(39), CreatedAt: f.now,
+	})
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+
+	proposalID := proposal.ID.String()
+	_, err = f.pr.ApproveTransferProposal(ctx, f.spender.String(), walletID, proposalID, f.now)
+	assert.ErrorIs(t, err, repo.ErrWalletForbidden)
+
+	approved, err := f.pr.ApproveTransferProposal(ctx, f.coOwner.String(), walletID, proposalID, f.now)
+	require.NoError(t, err)
+	assert.Equal(t, model.TransferProposalStatusExecuted, approved.Status)
+	require.NotNil(t, approved.TransactionID)
+	require.Len(t, approved.Approvals, 1)
+	assert.Equal(t, f.coOwner, approved.Approvals[0].UserID)
+
+	wallet := readWallet(t, db, f.wallet.ID)
+	assert.True(t, wallet.Balance.Equal(decimal.NewFromInt(38)), "balance %s", wallet.Balance)
+	assert.True(t, wallet.HeldBalance.IsZero())
+	assert.True(t, readWallet(t, db, f.destination.ID).Balance.Equal(decimal.NewFromInt(60)))
+	assert.True(t, readWallet(t, db, f.feeWallet.ID).Balance.Equal(decimal.NewFromInt(2)))
+
+	_, err = f.pr.ApproveTransferProposal(ctx, f.wallet.UserID.String(), walletID, proposalID, f.now)
+	assert.ErrorIs(t, err, repo.ErrTransferProposalNotPending)
+	stored, err := f.pr.GetTransferProposal(ctx, f.spender.String(), walletID, proposalID)
+	require.NoError(t, err)
+	assert.Equal(t, approved.TransactionID, stored.TransactionID)
+}
+
+func TestTransferProposalRepoPostgres_CancelAndExpire(t *testing.T) {
+	f := newProposalFixture(t)
+	db := repotest.Postgres(t)
+	ctx := context.Background()
+	walletID := f.wallet.ID.String()
+
+	own := f.propose(t, f.wallet.UserID, 20)
+	_, err := f.pr.ApproveTransferProposal(ctx, f.wallet.UserID.String(), walletID, own.ID.String(), f.now)
+	assert.ErrorIs(t, err, repo.ErrSelfApproval)
+	_, err = f.pr.CancelTransferProposal(ctx, f.spender.String(), walletID, own.ID.String(), f.now)
+	assert.ErrorIs(t, err, repo.ErrWalletForbidden)
+	cancelled, err := f.pr.CancelTransferProposal(ctx, f.coOwner.String(), walletID, own.ID.String(), f.now)
+	require.NoError(t, err)
+	assert.Equal(t, model.TransferProposalStatusCancelled, cancelled.Status)
+	assert.True(t, readWallet(t, db, f.wallet.ID).HeldBalance.IsZero())
+
+	// A proposal answered after its expiry is expired instead, and so is one the sweep finds.
+	late := f.propose(t, f.spender, 20)
+	swept := f.propose(t, f.spender, 30)
+	assert.True(t, readWallet(t, db, f.wallet.ID).HeldBalance.Equal(decimal.NewFromInt(54)))
+	afterExpiry := f.now.Add(25 * time.Hour)
+	_, err = f.pr.ApproveTransferProposal(ctx, f.coOwner.String(), walletID, late.ID.String(), afterExpiry)
+	assert.ErrorIs(t, err, repo.ErrTransferProposalExpired)
+	assert.True(t, readWallet(t, db, f.wallet.ID).HeldBalance.Equal(decimal.NewFromInt(32)))
+
+	n, err := f.pr.ExpireTransferProposals(ctx, afterExpiry)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, n, 1)
+	wallet := readWallet(t, db, f.wallet.ID)
+	assert.True(t, wallet.HeldBalance.IsZero(), "held balance %s", wallet.HeldBalance)
+	assert.True(t, wallet.Balance.Equal(decimal.NewFromInt(100)))
+	for _, p := range []*model.TransferProposal{late, swept} {
+		stored, err := f.pr.GetTransferProposal(ctx, f.spender.String(), walletID, p.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, model.TransferProposalStatusExpired, stored.Status)
+	}
+}
+
+func TestTransferProposalRepoPostgres_ConcurrentApprovals(t *testing.T) {
+	f := newProposalFixture(t)
+	db := repotest.Postgres(t)
+	ctx := context.Background()
+	proposal := f.propose(t, f.spender, 60)
+
+	// Both owners approve at once: the proposal is locked, so the transfer runs once and the other approval
+	// finds it executed.
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, owner := range []uuid.UUID{f.wallet.UserID, f.coOwner} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = f.pr.ApproveTransferProposal(ctx, owner.String(), f.wallet.ID.String(), proposal.ID.String(), f.now)
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			assert.ErrorIs(t, err, repo.ErrTransferProposalNotPending)
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+	assert.True(t, readWallet(t, db, f.wallet.ID).Balance.Equal(decimal.NewFromInt(38)))
+	assert.True(t, readWallet(t, db, f.destination.ID).Balance.Equal(decimal.NewFromInt(60)))
+}
@@ -0,0 +1,62 @@
+package repo_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+func TestUserRepoPostgres(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	ur := repo.NewUserImpl(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	oldest := model.Wallet{ID: uuid.New(), UserID: userID, Name: "oldest", Balance: decimal.Zero}
+	savings := model.Wallet{ID: uuid.New(), UserID: userID, Name: "savings", Balance: decimal.Zero}
+	shared := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "shared", Balance: decimal.Zero}
+	for _, w := range []model.Wallet{oldest, savings, shared} {
+		require.NoError(t, store.AddWallet(ctx, w))
+	}
+
+	// Without a default wallet, transfers addressed to the user go to the oldest one.
+	recipient, err := ur.FindRecipientByEmail(ctx, strings.ToUpper(userID.String())+"@EXAMPLE.COM")
+	require.NoError(t, err)
+	assert.Equal(t, userID, recipient.UserID)
+	require.NotNil(t, recipient.WalletID)
+	assert.Equal(t, oldest.ID, *recipient.WalletID)
+	_, err = ur.FindRecipientByEmail(ctx, "nobody@example.com")
+	assert.ErrorIs(t, err, repo.ErrRecipientNotFound)
+
+	// Handles are unique whatever their case.
+	handle := "u" + strings.ReplaceAll(userID.String(), "-", "")[:12]
+	user, err := ur.SetHandle(ctx, userID.String(), handle)
+	require.NoError(t, err)
+	require.NotNil(t, user.Handle)
+	_, err = ur.SetHandle(ctx, shared.UserID.String(), strings.ToUpper(handle))
+	assert.ErrorIs(t, err, repo.ErrHandleTaken)
+	_, err = ur.SetHandle(ctx, uuid.NewString(), "nobody")
+	assert.ErrorIs(t, err, repo.ErrUserNotFound)
+
+	// The default wallet must be one the user may spend from.
+	require.NoError(t, ur.SetDefaultWallet(ctx, userID.String(), savings.ID.String()))
+	recipient, err = ur.FindRecipientByHandle(ctx, strings.ToUpper(handle))
+	require.NoError(t, err)
+	require.NotNil(t, recipient.WalletID)
+	assert.Equal(t, savings.ID, *recipient.WalletID)
+	assert.ErrorIs(t, ur.SetDefaultWallet(ctx, userID.String(), shared.ID.String()), repo.ErrWalletNotFound)
+	require.NoError(t, store.AddMember(ctx, shared.ID, userID, model.WalletRoleViewer))
+	assert.ErrorIs(t, ur.SetDefaultWallet(ctx, userID.String(), shared.ID.String()), repo.ErrWalletForbidden)
+	require.NoError(t, store.AddMember(ctx, shared.ID, userID, model.WalletRoleSpender))
+	require.NoError(t, ur.SetDefaultWallet(ctx, userID.String(), shared.ID.String()))
+}
@@ -0,0 +1,112 @@
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+func TestWalletMemberRepoPostgres(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	mr := repo.NewWalletMemberImpl(db)
+	ctx := context.Background()
+
+	wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "family", Balance: decimal.NewFromInt(100)}
+	require.NoError(t, store.AddWallet(ctx, wallet))
+	owner, invitee := wallet.UserID.String(), uuid.New()
+	require.NoError(t, store.addUser(ctx, invitee))
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	// An invitation grants nothing until it is accepted.
+	member := &model.WalletMember{WalletID: wallet.ID, UserID: invitee, Role: model.WalletRoleSpender, CreatedAt: now}
+	require.NoError(t, mr.InviteWalletMember(ctx, owner, member))
+	assert.ErrorIs(t, mr.InviteWalletMember(ctx, owner, member), repo.ErrWalletMemberExists)
+	unknown := &model.WalletMember{WalletID: wallet.ID, UserID: uuid.New(), Role: model.WalletRoleViewer, CreatedAt: now}
+	assert.ErrorIs(t, mr.InviteWalletMember(ctx, owner, unknown), repo.ErrUserNotFound)
+	_, err := store.Withdraw(ctx, invitee.String(), wallet.ID.String(), decimal.NewFromInt(10), model.Fee{})
+	assert.ErrorIs(t, err, repo.ErrWalletNotFound)
+	invitations, err := mr.ListWalletInvitations(ctx, invitee.String())
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+	assert.Equal(t, "family", invitations[0].WalletName)
+
+	accepted, err := mr.AcceptWalletInvitation(ctx, invitee.String(), wallet.ID.String(), now)
+	require.NoError(t, err)
+	assert.Equal(t, model.WalletMemberStatusActive, accepted.Status)
+	_, err = mr.AcceptWalletInvitation(ctx, invitee.String(), wallet.ID.String(), now)
+	assert.ErrorIs(t, err, repo.ErrWalletMemberNotFound)
+	_, err = store.Withdraw(ctx, invitee.String(), wallet.ID.String(), decimal.NewFromInt(10), model.Fee{})
+	require.NoError(t, err)
+	assert.Equal(t, "90", readWallet(t, db, wallet.ID).Balance.String())
+
+	// Only owners manage members, and the last owner can be neither demoted nor removed.
+	assert.ErrorIs(t, mr.InviteWalletMember(ctx, invitee.String(), unknown), repo.ErrWalletForbidden)
+	_, err = mr.UpdateWalletMemberRole(ctx, owner, wallet.ID.String(), owner, model.WalletRoleViewer, now)
+	assert.ErrorIs(t, err, repo.ErrLastWalletOwner)
+	assert.ErrorIs(t, mr.RemoveWalletMember(ctx, owner, wallet.ID.String(), owner), repo.ErrLastWalletOwner)
+	_, err = mr.UpdateWalletMemberRole(ctx, owner, wallet.ID.String(), invitee.String(), model.WalletRoleViewer, now)
+	require.NoError(t, err)
+	_, err = store.Withdraw(ctx, invitee.String(), wallet.ID.String(), decimal.NewFromInt(10), model.Fee{})
+	assert.ErrorIs(t, err, repo.ErrWalletForbidden)
+
+	// A member may leave by themselves.
+	require.NoError(t, mr.RemoveWalletMember(ctx, invitee.String(), wallet.ID.String(), invitee.String()))
+	members, err := mr.ListWalletMembers(ctx, owner, wallet.ID.String())
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, wallet.UserID, members[0].UserID)
+}
+
+func TestWalletMemberRepoPostgres_ConcurrentDemotions(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	mr := repo.NewWalletMemberImpl(db)
+	ctx := context.Background()
+
+	wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "shared", Balance: decimal.Zero}
+	require.NoError(t, store.AddWallet(ctx, wallet))
+	coOwner := uuid.New()
+	require.NoError(t, store.AddMember(ctx, wallet.ID, coOwner, model.WalletRoleOwner))
+	now := time.Now().UTC()
+
+	// Each owner demotes the other at once. The member locks let one through, and the other then finds it is no
+	// longer an owner.
+	pairs := [][2]uuid.UUID{{wallet.UserID, coOwner}, {coOwner, wallet.UserID}}
+	var wg sync.WaitGroup
+	errs := make(chan error, len(pairs))
+	for _, pair := range pairs {
+		wg.Add(1)
+		go func(by, demoted uuid.UUID) {
+			defer wg.Done()
+			_, err := mr.UpdateWalletMemberRole(ctx, by.String(), wallet.ID.String(), demoted.String(), model.WalletRoleViewer, now)
+			errs <- err
+		}(pair[0], pair[1])
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, repo.ErrWalletForbidden)
+	}
+	assert.Equal(t, 1, succeeded)
+	var owners int
+	require.NoError(t, db.GetContext(ctx, &owners, `SELECT count(*) FROM wallet_members WHERE wallet_id = $1 AND role = $2`,
+		wallet.ID, model.WalletRoleOwner))
+	assert.Equal(t, 1, owners)
+}
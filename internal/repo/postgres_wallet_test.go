@@ -0,0 +1,143 @@
+package repo_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/repo/repotest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(repotest.Main(m))
+}
+
+// pgWalletStore is the Postgres-backed WalletRepo with the seeding the conformance suite needs.
+type pgWalletStore struct {
+	*repo.WalletRepoImpl
+	db *sqlx.DB
+}
+
+func (s pgWalletStore) addUser(ctx context.Context, userID uuid.UUID) error {
+	query := `INSERT INTO users (id, name, email) VALUES ($1, $2, $3) ON CONFLICT (id) DO NOTHING`
+	if _, err := s.db.ExecContext(ctx, query, userID, "Conformance", fmt.Sprintf("%s@example.com", userID)); err != nil {
+		return fmt.Errorf("failed to add user: %w", err)
+	}
+	return nil
+}
+
+func (s pgWalletStore) AddWallet(ctx context.Context, wallet model.Wallet) error {
+	if err := s.addUser(ctx, wallet.UserID); err != nil {
+		return err
+	}
+	if wallet.Status == "" {
+		wallet.Status = model.WalletStatusActive
+	}
+	query := `INSERT INTO wallets (id, user_id, name, balance, pots_balance, held_balance, status, status_expires_at)
+              VALUES (:id, :user_id, :name, :balance, :pots_balance, :held_balance, :status, :status_expires_at)`
+	if _, err := s.db.NamedExecContext(ctx, query, wallet); err != nil {
+		return fmt.Errorf("failed to add wallet: %w", err)
+	}
+	return nil
+}
+
+func (s pgWalletStore) AddMember(ctx context.Context, walletID, userID uuid.UUID, role model.WalletRole) error {
+	if err := s.addUser(ctx, userID); err != nil {
+		return err
+	}
+	query := `INSERT INTO wallet_members (wallet_id, user_id, role, status) VALUES ($1, $2, $3, 'active')
+              ON CONFLICT (wallet_id, user_id) DO UPDATE SET role = EXCLUDED.role, status = 'active'`
+	if _, err := s.db.ExecContext(ctx, query, walletID, userID, role); err != nil {
+		return fmt.Errorf("failed to add wallet member: %w", err)
+	}
+	return nil
+}
+
+func (s pgWalletStore) PutApprovalPolicy(ctx context.Context, policy model.ApprovalPolicy) error {
+	query := `INSERT INTO wallet_approval_policies (wallet_id, threshold, required_approvals, proposal_ttl_hours)
+              VALUES ($1, $2, $3, $4)
+              ON CONFLICT (wallet_id) DO UPDATE
+              SET threshold = EXCLUDED.threshold, required_approvals = EXCLUDED.required_approvals,
+                  proposal_ttl_hours = EXCLUDED.proposal_ttl_hours`
+	if _, err := s.db.ExecContext(ctx, query, policy.WalletID, policy.Threshold, policy.RequiredApprovals, policy.ProposalTTLHours); err != nil {
+		return fmt.Errorf("failed to put approval policy: %w", err)
+	}
+	return nil
+}
+
+// readWallet returns the balances and status of a wallet as stored, whoever may see it.
+func readWallet(t *testing.T, db *sqlx.DB, walletID uuid.UUID) model.Wallet {
+	t.Helper()
+	var wallet model.Wallet
+	query := `SELECT id, user_id, name, balance, pots_balance, held_balance, status, status_reason, status_expires_at,
+                     product_id, created_at, updated_at
+              FROM wallets
+              WHERE id = $1`
+	require.NoError(t, db.GetContext(context.Background(), &wallet, query, walletID))
+	return wallet
+}
+
+func TestWalletRepoPostgres(t *testing.T) {
+	db := repotest.Postgres(t)
+	repotest.TestWalletRepo(t, func(t *testing.T) repotest.WalletStore {
+		return pgWalletStore{repo.NewWalletImpl(db), db}
+	})
+}
+
+func TestWalletRepoPostgres_Invariants(t *testing.T) {
+	db := repotest.Postgres(t)
+	repotest.TestWalletRepoInvariants(t, func(t *testing.T) repotest.WalletStore {
+		return pgWalletStore{repo.NewWalletImpl(db), db}
+	}, 50)
+}
+
+func TestWalletRepoPostgres_LockWaitObserver(t *testing.T) {
+	db := repotest.Postgres(t)
+	store := pgWalletStore{repo.NewWalletImpl(db), db}
+	ctx := context.Background()
+	source := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "source", Balance: decimal.NewFromInt(100)}
+	destination := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "destination", Balance: decimal.Zero}
+	require.NoError(t, store.AddWallet(ctx, source))
+	require.NoError(t, store.AddWallet(ctx, destination))
+	noFee := model.Fee{Amount: decimal.Zero}
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context) error
+		// wantLocks is how many wallet rows the operation locks.
+		wantLocks int
+	}{
+		{"deposit", func(ctx context.Context) error {
+			_, err := store.Deposit(ctx, source.UserID.String(), source.ID.String(), decimal.NewFromInt(1))
+			return err
+		}, 1},
+		{"withdraw", func(ctx context.Context) error {
+			_, err := store.Withdraw(ctx, source.UserID.String(), source.ID.String(), decimal.NewFromInt(1), noFee)
+			return err
+		}, 1},
+		{"transfer", func(ctx context.Context) error {
+			_, err := store.Transfer(ctx, source.UserID.String(), source.ID.String(), destination.ID.String(), decimal.NewFromInt(1), noFee)
+			return err
+		}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var waits []time.Duration
+			require.NoError(t, tt.run(repo.WithLockWaitObserver(ctx, func(d time.Duration) { waits = append(waits, d) })))
+			assert.Len(t, waits, tt.wantLocks)
+			for _, d := range waits {
+				assert.Positive(t, d)
+			}
+		})
+	}
+}
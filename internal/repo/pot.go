@@ -0,0 +1,308 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+var (
+	// ErrPotNotFound indicates that the requested pot was not found in the wallet.
+	ErrPotNotFound = errors.New("pot not found")
+	// ErrPotNameTaken indicates that the wallet already has an open pot with that name.
+	ErrPotNameTaken = errors.New("wallet already has a pot with this name")
+	// ErrPotClosed indicates an attempt to change a pot that has been closed.
+	ErrPotClosed = errors.New("pot is closed")
+)
+
+const potColumns = `id, wallet_id, name, balance, target_amount, target_date, status, created_at, updated_at`
+
+type PotRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewPotImpl(db *sqlx.DB) *PotRepoImpl {
+	return &PotRepoImpl{db}
+}
+
+// CreatePot adds an empty pot to a wallet the user may spend from.
+func (pr *PotRepoImpl) CreatePot(ctx context.Context, userIDStr string, pot *model.Pot) error {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	if err = checkWalletAccess(ctx, pr.db, userID, pot.WalletID, model.WalletRoleSpender); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO pots (id, wallet_id, name, balance, target_amount, target_date, status, created_at, updated_at)
+              VALUES ($1, $2, $3, 0, $4, $5, $6, $7, $7)`
+	_, err = pr.db.ExecContext(ctx, query, pot.ID, pot.WalletID, pot.Name, pot.TargetAmount, pot.TargetDate,
+		model.PotStatusOpen, pot.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return ErrPotNameTaken
+		}
+		return fmt.Errorf("failed to create pot: %w", err)
+	}
+	pot.Balance = decimal.Zero
+	pot.Status = model.PotStatusOpen
+	pot.UpdatedAt = pot.CreatedAt
+	return nil
+}
+
+// ListPots returns the pots of a wallet the user is a member of, open ones first.
+func (pr *PotRepoImpl) ListPots(ctx context.Context, userIDStr string, walletIDStr string) ([]model.Pot, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	if err = checkWalletAccess(ctx, pr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	pots := []model.Pot{}
+	query := `SELECT ` + potColumns + `
+              FROM pots
+              WHERE wallet_id = $1
+              ORDER BY status, created_at`
+	if err = pr.db.SelectContext(ctx, &pots, query, walletID); err != nil {
+		return nil, fmt.Errorf("database error retrieving pots: %w", err)
+	}
+	return pots, nil
+}
+
+// GetPot returns one pot of a wallet the user is a member of.
+func (pr *PotRepoImpl) GetPot(ctx context.Context, userIDStr string, walletIDStr string, potIDStr string) (*model.Pot, error) {
+	userID, walletID, potID, err := parsePotIDs(userIDStr, walletIDStr, potIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkWalletAccess(ctx, pr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	var pot model.Pot
+	query := `SELECT ` + potColumns + `
+              FROM pots
+              WHERE id = $1 AND wallet_id = $2`
+	if err = pr.db.GetContext(ctx, &pot, query, potID, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPotNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve pot: %w", err)
+	}
+	return &pot, nil
+}
+
+// UpdatePot replaces the name and target of an open pot.
+func (pr *PotRepoImpl) UpdatePot(ctx context.Context, userIDStr string, walletIDStr string, potIDStr string, name string, targetAmount *decimal.Decimal, targetDate *time.Time, at time.Time) (*model.Pot, error) {
+	userID, walletID, potID, err := parsePotIDs(userIDStr, walletIDStr, potIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := beginTx(ctx, pr.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = lockPotTx(ctx, tx, userID, walletID, potID); err != nil {
+		return nil, err
+	}
+
+	var pot model.Pot
+	query := `UPDATE pots SET name = $1, target_amount = $2, target_date = $3, updated_at = $4
+              WHERE id = $5
+              RETURNING ` + potColumns
+	if err = tx.GetContext(ctx, &pot, query, name, targetAmount, targetDate, at, potID); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, ErrPotNameTaken
+		}
+		return nil, fmt.Errorf("failed to update pot: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit pot update: %w", err)
+	}
+	return &pot, nil
+}
+
+// MovePotMoney moves amount from the wallet into the pot (PotMoveIn) or from the pot back to the wallet (PotMoveOut).
+// The wallet balance does not change; only the split between the pot and the spendable rest does.
+// ErrInsufficientFunds is returned when the source side does not hold amount.
+func (pr *PotRepoImpl) MovePotMoney(ctx context.Context, userIDStr string, walletIDStr string, potIDStr string, direction model.PotMoveDirection, amount decimal.Decimal, at time.Time) (*model.Pot, error) {
+	userID, walletID, potID, err := parsePotIDs(userIDStr, walletIDStr, potIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := beginTx(ctx, pr.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	wallet, err := lockPotTx(ctx, tx, userID, walletID, potID)
+	if err != nil {
+		return nil, err
+	}
+
+	pot, err := movePotMoneyTx(ctx, tx, wallet, potID, direction, amount, at)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit pot move: %w", err)
+	}
+	return pot, nil
+}
+
+// ClosePot moves whatever is left in the pot back to the wallet and closes it.
+func (pr *PotRepoImpl) ClosePot(ctx context.Context, userIDStr string, walletIDStr string, potIDStr string, at time.Time) (*model.Pot, error) {
+	userID, walletID, potID, err := parsePotIDs(userIDStr, walletIDStr, potIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := beginTx(ctx, pr.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	wallet, err := lockPotTx(ctx, tx, userID, walletID, potID)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance decimal.Decimal
+	if err = tx.GetContext(ctx, &balance, `SELECT balance FROM pots WHERE id = $1`, potID); err != nil {
+		return nil, fmt.Errorf("failed to retrieve pot balance: %w", err)
+	}
+	if balance.IsPositive() {
+		if _, err = movePotMoneyTx(ctx, tx, wallet, potID, model.PotMoveOut, balance, at); err != nil {
+			return nil, err
+		}
+	}
+
+	var pot model.Pot
+	query := `UPDATE pots SET status = $1, updated_at = $2
+              WHERE id = $3
+              RETURNING ` + potColumns
+	if err = tx.GetContext(ctx, &pot, query, model.PotStatusClosed, at, potID); err != nil {
+		return nil, fmt.Errorf("failed to close pot: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit pot close: %w", err)
+	}
+	return &pot, nil
+}
+
+func parsePotIDs(userIDStr string, walletIDStr string, potIDStr string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	potID, err := uuid.Parse(potIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid pot ID format: %w", err)
+	}
+	return userID, walletID, potID, nil
+}
+
+// lockPotTx checks the user may spend from the wallet, locks the wallet and then its open pot, in that order,
+// and returns the wallet.
+func lockPotTx(ctx context.Context, tx *sqlx.Tx, userID, walletID, potID uuid.UUID) (*model.Wallet, error) {
+	if err := checkWalletAccess(ctx, tx, userID, walletID, model.WalletRoleSpender); err != nil {
+		return nil, err
+	}
+
+	var wallet model.Wallet
+	queryWallet := `SELECT id, user_id, name, balance, pots_balance, held_balance, created_at, updated_at
+                    FROM wallets
+                    WHERE id = $1 FOR UPDATE`
+	if err := tx.GetContext(ctx, &wallet, queryWallet, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve wallet for pot: %w", err)
+	}
+
+	var status model.PotStatus
+	queryPot := `SELECT status FROM pots WHERE id = $1 AND wallet_id = $2 FOR UPDATE`
+	if err := tx.GetContext(ctx, &status, queryPot, potID, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPotNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve pot: %w", err)
+	}
+	if status != model.PotStatusOpen {
+		return nil, ErrPotClosed
+	}
+	return &wallet, nil
+}
+
+// movePotMoneyTx moves money between a locked wallet and its locked pot and records the movement.
+func movePotMoneyTx(ctx context.Context, tx *sqlx.Tx, wallet *model.Wallet, potID uuid.UUID, direction model.PotMoveDirection, amount decimal.Decimal, at time.Time) (*model.Pot, error) {
+	delta := amount
+	switch direction {
+	case model.PotMoveIn:
+		if wallet.Available().LessThan(amount) {
+			return nil, ErrInsufficientFunds
+		}
+	case model.PotMoveOut:
+		delta = amount.Neg()
+	default:
+		return nil, fmt.Errorf("unknown pot move direction %q", direction)
+	}
+
+	var pot model.Pot
+	updatePotQuery := `UPDATE pots SET balance = balance + $1, updated_at = $2
+                       WHERE id = $3 AND balance + $1 >= 0
+                       RETURNING ` + potColumns
+	if err := tx.GetContext(ctx, &pot, updatePotQuery, delta, at, potID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInsufficientFunds
+		}
+		return nil, fmt.Errorf("failed to update pot balance: %w", err)
+	}
+
+	updateWalletQuery := `UPDATE wallets SET pots_balance = pots_balance + $1, updated_at = $2 WHERE id = $3`
+	if _, err := tx.ExecContext(ctx, updateWalletQuery, delta, at, wallet.ID); err != nil {
+		return nil, fmt.Errorf("failed to update wallet pots balance: %w", err)
+	}
+	wallet.PotsBalance = wallet.PotsBalance.Add(delta)
+
+	insertQuery := `INSERT INTO pot_movements (id, pot_id, direction, amount, created_at)
+                    VALUES ($1, $2, $3, $4, $5)`
+	if _, err := tx.ExecContext(ctx, insertQuery, uuid.New(), potID, direction, amount, at); err != nil {
+		return nil, fmt.Errorf("failed to record pot movement: %w", err)
+	}
+	return &pot, nil
+}
@@ -0,0 +1,319 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+)
+
+// SeedEnv names the variable that fixes the seed TestWalletRepoInvariants generates sequences from, to replay a
+// failure it reported.
+const SeedEnv = "PROPERTY_SEED"
+
+// WalletOpKind is what a WalletOp does.
+type WalletOpKind string
+
+const (
+	OpDeposit  WalletOpKind = "deposit"
+	OpWithdraw WalletOpKind = "withdraw"
+	OpTransfer WalletOpKind = "transfer"
+)
+
+// WalletOp is one generated operation on the wallets of a sequence, which are referred to by index. To is only
+// used by transfers. Amounts are in cents, so shrinking them stays on whole cents.
+type WalletOp struct {
+	Kind   WalletOpKind
+	From   int
+	To     int
+	Amount int64
+}
+
+func (op WalletOp) amount() decimal.Decimal {
+	return decimal.New(op.Amount, -2)
+}
+
+func (op WalletOp) String() string {
+	if op.Kind == OpTransfer {
+		return fmt.Sprintf("transfer %s from w%d to w%d", op.amount(), op.From, op.To)
+	}
+	return fmt.Sprintf("%s %s w%d", op.Kind, op.amount(), op.From)
+}
+
+// Counterexample is a sequence of operations that breaks an invariant of a WalletRepo, shrunk to one that no
+// longer does when any operation is left out or any amount is made smaller.
+type Counterexample struct {
+	Wallets int
+	Ops     []WalletOp
+	Err     error
+}
+
+func (c *Counterexample) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v\nwith %d wallets starting empty, after:", c.Err, c.Wallets)
+	for i, op := range c.Ops {
+		fmt.Fprintf(&b, "\n  %d. %s", i+1, op)
+	}
+	return b.String()
+}
+
+const (
+	maxWallets = 5
+	maxOps     = 40
+	// maxAmount is the largest amount generated, in cents.
+	maxAmount = 10000
+	// selfTransferOneIn makes one transfer in that many go to its own source, to check that it is rejected.
+	selfTransferOneIn = 20
+)
+
+// TestWalletRepoInvariants applies runs random sequences of deposits, withdrawals and transfers to wallets of the
+// stores newStore returns and to a reference model, and fails with the shortest sequence it can find that makes
+// them disagree or breaks an invariant:
+//   - every operation succeeds, or fails with insufficient funds, exactly when the model says it does
+//   - no balance goes negative
+//   - the total held is what was deposited less what was withdrawn, whatever was transferred
+//   - the history of every wallet sums to its balance, and every balance is the model's
+func TestWalletRepoInvariants(t *testing.T, newStore func(t *testing.T) WalletStore, runs int) {
+	seed := uint64(time.Now().UnixNano())
+	if s := os.Getenv(SeedEnv); s != "" {
+		var err error
+		if seed, err = strconv.ParseUint(s, 10, 64); err != nil {
+			t.Fatalf("invalid %s: %v", SeedEnv, err)
+		}
+	}
+	found, err := FindCounterexample(context.Background(), func() WalletStore { return newStore(t) }, seed, runs)
+	if err != nil {
+		t.Fatalf("seed %d: %v", seed, err)
+	}
+	if found != nil {
+		t.Fatalf("seed %d (replay with %s=%d): %s", seed, SeedEnv, seed, found)
+	}
+}
+
+// FindCounterexample generates runs sequences from seed and returns the first one that breaks an invariant, shrunk,
+// or nil if none does. Every sequence, and every attempt to shrink one, runs on new wallets of a store from
+// newStore. It returns an error only if the wallets cannot be set up.
+func FindCounterexample(ctx context.Context, newStore func() WalletStore, seed uint64, runs int) (*Counterexample, error) {
+	rng := rand.New(rand.NewPCG(seed, seed>>1|1))
+	for i := 0; i < runs; i++ {
+		wallets, ops := generate(rng)
+		violation, err := checkSequence(ctx, newStore(), wallets, ops)
+		if err != nil {
+			return nil, err
+		}
+		if violation == nil {
+			continue
+		}
+		return shrink(ctx, newStore, wallets, ops, violation)
+	}
+	return nil, nil
+}
+
+func generate(rng *rand.Rand) (int, []WalletOp) {
+	wallets := 2 + rng.IntN(maxWallets-1)
+	ops := make([]WalletOp, 1+rng.IntN(maxOps))
+	for i := range ops {
+		op := WalletOp{From: rng.IntN(wallets), Amount: 1 + rng.Int64N(maxAmount)}
+		switch r := rng.IntN(10); {
+		case r < 4:
+			op.Kind = OpDeposit
+		case r < 7:
+			op.Kind = OpWithdraw
+		default:
+			op.Kind = OpTransfer
+			// A transfer to its own source is rejected before any money moves, so only one in selfTransferOneIn is
+			// one; the others go to one of the other wallets.
+			if rng.IntN(selfTransferOneIn) == 0 {
+				op.To = op.From
+			} else if op.To = rng.IntN(wallets - 1); op.To >= op.From {
+				op.To++
+			}
+		}
+		ops[i] = op
+	}
+	return wallets, ops
+}
+
+// shrink makes a failing sequence smaller while it keeps failing: first by leaving out ever smaller runs of
+// operations, then by lowering each amount to the smallest that still fails, until neither changes anything.
+func shrink(ctx context.Context, newStore func() WalletStore, wallets int, ops []WalletOp, violation error) (*Counterexample, error) {
+	var setupErr error
+	fails := func(candidate []WalletOp) bool {
+		if setupErr != nil {
+			return false
+		}
+		v, err := checkSequence(ctx, newStore(), wallets, candidate)
+		if err != nil {
+			setupErr = err
+			return false
+		}
+		if v != nil {
+			violation = v
+		}
+		return v != nil
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for size := len(ops) / 2; size >= 1; size /= 2 {
+			for start := 0; start+size <= len(ops); {
+				candidate := append(append([]WalletOp{}, ops[:start]...), ops[start+size:]...)
+				if len(candidate) > 0 && fails(candidate) {
+					ops, changed = candidate, true
+					continue
+				}
+				start += size
+			}
+		}
+		for i := range ops {
+			lo, hi := int64(1), ops[i].Amount
+			for lo < hi {
+				mid := lo + (hi-lo)/2
+				candidate := append([]WalletOp{}, ops...)
+				candidate[i].Amount = mid
+				if fails(candidate) {
+					hi = mid
+				} else {
+					lo = mid + 1
+				}
+			}
+			if hi < ops[i].Amount {
+				ops[i].Amount, changed = hi, true
+			}
+		}
+	}
+	if setupErr != nil {
+		return nil, setupErr
+	}
+	// Run the final sequence once more, so the reported violation is the one it causes.
+	fails(ops)
+	return &Counterexample{Wallets: wallets, Ops: ops, Err: violation}, setupErr
+}
+
+// checkSequence applies ops to new empty wallets of store and to the reference model, and returns the first
+// invariant the store breaks, or nil. It returns an error only if the wallets cannot be set up.
+func checkSequence(ctx context.Context, store WalletStore, wallets int, ops []WalletOp) (violation error, err error) {
+	ids := make([]model.Wallet, wallets)
+	for i := range ids {
+		ids[i] = model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: fmt.Sprintf("w%d", i), Balance: decimal.Zero, Status: model.WalletStatusActive}
+		if err = store.AddWallet(ctx, ids[i]); err != nil {
+			return nil, fmt.Errorf("failed to add wallet: %w", err)
+		}
+	}
+
+	// The reference model: balances in cents, and what came in and went out of the wallets as a whole.
+	balances := make([]int64, wallets)
+	var deposited, withdrawn int64
+	noFee := model.Fee{Amount: decimal.Zero}
+
+	for i, op := range ops {
+		from := ids[op.From]
+		var (
+			opErr    error
+			expected error
+		)
+		switch op.Kind {
+		case OpDeposit:
+			_, opErr = store.Deposit(ctx, from.UserID.String(), from.ID.String(), op.amount())
+			balances[op.From] += op.Amount
+			deposited += op.Amount
+		case OpWithdraw:
+			_, opErr = store.Withdraw(ctx, from.UserID.String(), from.ID.String(), op.amount(), noFee)
+			if balances[op.From] < op.Amount {
+				expected = repo.ErrInsufficientFunds
+			} else {
+				balances[op.From] -= op.Amount
+				withdrawn += op.Amount
+			}
+		case OpTransfer:
+			to := ids[op.To]
+			_, opErr = store.Transfer(ctx, from.UserID.String(), from.ID.String(), to.ID.String(), op.amount(), noFee)
+			switch {
+			case op.From == op.To:
+				expected = errSameWallet
+			case balances[op.From] < op.Amount:
+				expected = repo.ErrInsufficientFunds
+			default:
+				balances[op.From] -= op.Amount
+				balances[op.To] += op.Amount
+			}
+		}
+		if v := compareOutcome(opErr, expected); v != nil {
+			return fmt.Errorf("operation %d (%s): %w", i+1, op, v), nil
+		}
+	}
+
+	// What every wallet's history says it sent and received. A transfer is recorded on its source wallet only,
+	// so the money a wallet received is found in the history of the others.
+	history := make(map[uuid.UUID]decimal.Decimal, wallets)
+	var total decimal.Decimal
+	for i, w := range ids {
+		got, err := store.GetWalletInfo(ctx, w.UserID.String(), w.ID.String())
+		if err != nil || got == nil {
+			return fmt.Errorf("w%d cannot be read back: %v", i, err), nil
+		}
+		if got.Balance.IsNegative() {
+			return fmt.Errorf("w%d has a negative balance of %s", i, got.Balance), nil
+		}
+		if want := decimal.New(balances[i], -2); !got.Balance.Equal(want) {
+			return fmt.Errorf("w%d has a balance of %s, the model has %s", i, got.Balance, want), nil
+		}
+		total = total.Add(got.Balance)
+
+		transactions, err := store.GetTransactionsByWalletID(ctx, w.UserID.String(), w.ID.String())
+		if err != nil {
+			return fmt.Errorf("w%d history cannot be read back: %v", i, err), nil
+		}
+		for _, tx := range transactions {
+			switch tx.Type {
+			case model.TransactionTypeDeposit:
+				history[w.ID] = history[w.ID].Add(tx.Amount)
+			case model.TransactionTypeWithdrawal:
+				history[w.ID] = history[w.ID].Sub(tx.Amount)
+			case model.TransactionTypeTransfer:
+				history[w.ID] = history[w.ID].Sub(tx.Amount)
+				if tx.RelatedWalletID != nil {
+					history[*tx.RelatedWalletID] = history[*tx.RelatedWalletID].Add(tx.Amount)
+				}
+			}
+		}
+	}
+	if want := decimal.New(deposited-withdrawn, -2); !total.Equal(want) {
+		return fmt.Errorf("the wallets hold %s in total, but %s was deposited less withdrawn", total, want), nil
+	}
+	for i, w := range ids {
+		if want := decimal.New(balances[i], -2); !history[w.ID].Equal(want) {
+			return fmt.Errorf("w%d history sums to %s, its balance is %s", i, history[w.ID], want), nil
+		}
+	}
+	return nil, nil
+}
+
+// errSameWallet stands for the error a transfer from a wallet to itself is rejected with, which has no sentinel.
+var errSameWallet = errors.New("source and destination wallets cannot be the same")
+
+// compareOutcome returns why got, the error an operation returned, is not the expected one.
+func compareOutcome(got, expected error) error {
+	switch {
+	case expected == nil && got != nil:
+		return fmt.Errorf("failed with %q, the model expects success", got)
+	case expected != nil && got == nil:
+		return fmt.Errorf("succeeded, the model expects %q", expected)
+	case expected == errSameWallet:
+		return nil
+	case expected != nil && !errors.Is(got, expected):
+		return fmt.Errorf("failed with %q, the model expects %q", got, expected)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package repotest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // Import the postgres driver
+
+	"github.com/kylenguyen/wallet-app/migrations"
+	"github.com/kylenguyen/wallet-app/pkg/migrate"
+)
+
+// DatabaseURLEnv names the variable holding the connection string of a scratch database to run the integration
+// tests against. When it is unset, Postgres starts a throwaway server with the initdb and pg_ctl binaries found in
+// PGBinEnv or on the PATH. initdb refuses to run as root, so as root the server runs as the user PGUserEnv names,
+// or postgres, or nobody.
+const (
+	DatabaseURLEnv = "TEST_DATABASE_URL"
+	PGBinEnv       = "PG_BIN"
+	PGUserEnv      = "PG_USER"
+)
+
+var postgres struct {
+	once       sync.Once
+	db         *sqlx.DB
+	skipReason string
+	err        error
+	stop       func()
+}
+
+// Main runs the tests of a package that uses Postgres and stops the server it may have started. Call it from
+// TestMain:
+//
+//	func TestMain(m *testing.M) { os.Exit(repotest.Main(m)) }
+func Main(m *testing.M) int {
+	code := m.Run()
+	if postgres.db != nil {
+		postgres.db.Close()
+	}
+	if postgres.stop != nil {
+		postgres.stop()
+	}
+	return code
+}
+
+// Postgres returns a database with every schema migration applied, shared by all the tests of the package. The
+// test is skipped in short mode or when no database is available, and fails if one is but cannot be set up. With
+// CI set it fails rather than skips when no database is available, so a pass in CI means the SQL ran.
+func Postgres(tb testing.TB) *sqlx.DB {
+	tb.Helper()
+	if testing.Short() {
+		tb.Skip("integration test skipped in short mode")
+	}
+	postgres.once.Do(func() {
+		postgres.db, postgres.skipReason, postgres.err = startPostgres()
+	})
+	if postgres.err != nil {
+		tb.Fatalf("failed to set up postgres: %v", postgres.err)
+	}
+	if postgres.db == nil {
+		if os.Getenv("CI") != "" {
+			tb.Fatalf("postgres must be available in CI: %s", postgres.skipReason)
+		}
+		tb.Skipf("no postgres available: %s", postgres.skipReason)
+	}
+	return postgres.db
+}
+
+// startPostgres connects to the database in DatabaseURLEnv, or starts a server in a temporary data directory, and
+// migrates it. It returns why the tests should be skipped instead when neither is possible.
+func startPostgres() (*sqlx.DB, string, error) {
+	dsn := os.Getenv(DatabaseURLEnv)
+	if dsn == "" {
+		initdb, pgCtl, reason := findPGBinaries()
+		if reason != "" {
+			return nil, reason, nil
+		}
+		var err error
+		if dsn, err = startServer(initdb, pgCtl); err != nil {
+			return nil, "", err
+		}
+	}
+
+	db, err := sqlx.Open("postgres", dsn)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open database connection: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err = db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, "", fmt.Errorf("failed to ping database: %w", err)
+	}
+	all, err := migrations.Load()
+	if err != nil {
+		db.Close()
+		return nil, "", fmt.Errorf("failed to load migrations: %w", err)
+	}
+	if _, err = migrate.New(db, all).Up(ctx); err != nil {
+		db.Close()
+		return nil, "", fmt.Errorf("failed to migrate database: %w", err)
+	}
+	return db, "", nil
+}
+
+// findPGBinaries looks up initdb and pg_ctl, or returns why a server cannot be started.
+func findPGBinaries() (initdb, pgCtl, reason string) {
+	look := exec.LookPath
+	if dir := os.Getenv(PGBinEnv); dir != "" {
+		look = func(name string) (string, error) {
+			return exec.LookPath(filepath.Join(dir, name))
+		}
+	}
+	initdb, err := look("initdb")
+	if err != nil {
+		return "", "", fmt.Sprintf("initdb not found; set %s or %s", DatabaseURLEnv, PGBinEnv)
+	}
+	pgCtl, err = look("pg_ctl")
+	if err != nil {
+		return "", "", fmt.Sprintf("pg_ctl not found; set %s or %s", DatabaseURLEnv, PGBinEnv)
+	}
+	return initdb, pgCtl, ""
+}
+
+// startServer initialises a data directory in a new temporary directory and starts a server on a Unix socket in
+// it, so it cannot clash with a server already running. Main stops the server and removes the directory.
+func startServer(initdb, pgCtl string) (string, error) {
+	dir, err := os.MkdirTemp("", "wallet-pg-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create data directory: %w", err)
+	}
+	data := filepath.Join(dir, "data")
+	as, err := unprivileged(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	postgres.stop = func() {
+		// The server may not have started; stopping it is best effort before the directory goes.
+		_ = as(exec.Command(pgCtl, "stop", "-D", data, "-m", "immediate", "-w")).Run()
+		os.RemoveAll(dir)
+	}
+
+	if err = runPG(as(exec.Command(initdb, "-D", data, "-U", "postgres", "-A", "trust", "-E", "UTF8", "--no-sync"))); err != nil {
+		return "", err
+	}
+	options := fmt.Sprintf("-k %s -c listen_addresses='' -c fsync=off -c full_page_writes=off", dir)
+	if err = runPG(as(exec.Command(pgCtl, "start", "-D", data, "-l", filepath.Join(dir, "postgres.log"), "-w", "-o", options))); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("host=%s port=5432 user=postgres dbname=postgres sslmode=disable", dir), nil
+}
+
+func runPG(cmd *exec.Cmd) error {
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	if err := cmd.Run(); err != nil {
+		name := filepath.Base(cmd.Path)
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(out.String()))
+		}
+		return fmt.Errorf("failed to run %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package repotest
+
+import "os/exec"
+
+// unprivileged leaves commands as they are; only Unix has a root user that initdb refuses to run as.
+func unprivileged(string) (func(*exec.Cmd) *exec.Cmd, error) {
+	return func(cmd *exec.Cmd) *exec.Cmd { return cmd }, nil
+}
@@ -0,0 +1,52 @@
+//go:build unix
+
+package repotest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// unprivileged returns a function that makes a command run as a user other than root, which initdb and pg_ctl
+// refuse to run as, and gives that user dir. It leaves commands as they are when the tests do not run as root.
+func unprivileged(dir string) (func(*exec.Cmd) *exec.Cmd, error) {
+	if os.Geteuid() != 0 {
+		return func(cmd *exec.Cmd) *exec.Cmd { return cmd }, nil
+	}
+	names := []string{"postgres", "nobody"}
+	if name := os.Getenv(PGUserEnv); name != "" {
+		names = []string{name}
+	}
+	var account *user.User
+	for _, name := range names {
+		if u, err := user.Lookup(name); err == nil && u.Uid != "0" {
+			account = u
+			break
+		}
+	}
+	if account == nil {
+		return nil, fmt.Errorf("running as root and none of the users %v exists to run postgres as; set %s", names, PGUserEnv)
+	}
+	uid, err := strconv.ParseUint(account.Uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uid of user %s: %w", account.Username, err)
+	}
+	gid, err := strconv.ParseUint(account.Gid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gid of user %s: %w", account.Username, err)
+	}
+	if err = os.Chown(dir, int(uid), int(gid)); err != nil {
+		return nil, fmt.Errorf("failed to give the data directory to user %s: %w", account.Username, err)
+	}
+	return func(cmd *exec.Cmd) *exec.Cmd {
+		cmd.SysProcAttr = &syscall.SysProcAttr{Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)}}
+		// The server's own files go in dir, and HOME may point where the user cannot go.
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "HOME="+dir)
+		return cmd
+	}, nil
+}
@@ -0,0 +1,507 @@
+// Package repotest holds conformance tests that every implementation of a repository interface must pass, so the
+// in-memory and the Postgres-backed repositories cannot drift apart.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+)
+
+// WalletStore is a service.WalletRepo under test together with the means to arrange its state.
+type WalletStore interface {
+	service.WalletRepo
+	// AddWallet stores the wallet and makes its user the owner, creating the user if the store needs one.
+	AddWallet(ctx context.Context, wallet model.Wallet) error
+	// AddMember makes the user an active member of the wallet with role, creating the user if the store needs one.
+	AddMember(ctx context.Context, walletID, userID uuid.UUID, role model.WalletRole) error
+	// PutApprovalPolicy creates or replaces the approval policy of the wallet.
+	PutApprovalPolicy(ctx context.Context, policy model.ApprovalPolicy) error
+}
+
+// TestWalletRepo runs the WalletRepo conformance suite. newStore is called for each subtest; the stores it returns
+// may share state, as every subtest works on wallets and users of its own.
+func TestWalletRepo(t *testing.T, newStore func(t *testing.T) WalletStore) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, f *walletFixture)
+	}{
+		{"GetWalletInfo", testGetWalletInfo},
+		{"GetTransactionsByWalletID", testGetTransactionsByWalletID},
+		{"Deposit", testDeposit},
+		{"Withdraw", testWithdraw},
+		{"Transfer", testTransfer},
+		{"ConcurrentDepositsAndWithdrawals", testConcurrentDepositsAndWithdrawals},
+		{"ConcurrentTransfers", testConcurrentTransfers},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, &walletFixture{t: t, ctx: context.Background(), store: newStore(t)})
+		})
+	}
+}
+
+type walletFixture struct {
+	t     *testing.T
+	ctx   context.Context
+	store WalletStore
+}
+
+// with returns the fixture for the subtest t.
+func (f *walletFixture) with(t *testing.T) *walletFixture {
+	return &walletFixture{t: t, ctx: f.ctx, store: f.store}
+}
+
+// wallet adds an active wallet owned by owner with balance and returns it.
+func (f *walletFixture) wallet(owner uuid.UUID, balance string) model.Wallet {
+	f.t.Helper()
+	wallet := model.Wallet{
+		ID:          uuid.New(),
+		UserID:      owner,
+		Name:        "conformance",
+		Balance:     decimal.RequireFromString(balance),
+		PotsBalance: decimal.Zero,
+		HeldBalance: decimal.Zero,
+		Status:      model.WalletStatusActive,
+	}
+	require.NoError(f.t, f.store.AddWallet(f.ctx, wallet))
+	return wallet
+}
+
+// member adds a new user to the wallet with role and returns the user's ID.
+func (f *walletFixture) member(walletID uuid.UUID, role model.WalletRole) uuid.UUID {
+	f.t.Helper()
+	userID := uuid.New()
+	require.NoError(f.t, f.store.AddMember(f.ctx, walletID, userID, role))
+	return userID
+}
+
+// balance returns the balance of the wallet as seen by its owner.
+func (f *walletFixture) balance(wallet model.Wallet) decimal.Decimal {
+	f.t.Helper()
+	got, err := f.store.GetWalletInfo(f.ctx, wallet.UserID.String(), wallet.ID.String())
+	require.NoError(f.t, err)
+	require.NotNil(f.t, got)
+	return got.Balance
+}
+
+// assertBalance fails the test unless the wallet holds want.
+func (f *walletFixture) assertBalance(wallet model.Wallet, want string) {
+	f.t.Helper()
+	got := f.balance(wallet)
+	assert.Truef(f.t, got.Equal(decimal.RequireFromString(want)), "balance of wallet %s = %s, want %s", wallet.ID, got, want)
+}
+
+// transactionCount returns how many transactions the wallet has, as seen by its owner.
+func (f *walletFixture) transactionCount(wallet model.Wallet) int {
+	f.t.Helper()
+	transactions, err := f.store.GetTransactionsByWalletID(f.ctx, wallet.UserID.String(), wallet.ID.String())
+	require.NoError(f.t, err)
+	return len(transactions)
+}
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func noFee() model.Fee {
+	return model.Fee{Amount: decimal.Zero}
+}
+
+func testGetWalletInfo(t *testing.T, f *walletFixture) {
+	owner := uuid.New()
+	wallet := f.wallet(owner, "12.34")
+	viewer := f.member(wallet.ID, model.WalletRoleViewer)
+
+	tests := []struct {
+		name     string
+		userID   string
+		walletID string
+		wantNil  bool
+		wantErr  bool
+	}{
+		{"owner", owner.String(), wallet.ID.String(), false, false},
+		{"viewer", viewer.String(), wallet.ID.String(), false, false},
+		{"not a member", uuid.NewString(), wallet.ID.String(), true, false},
+		{"unknown wallet", owner.String(), uuid.NewString(), true, false},
+		{"invalid user ID", "not-a-uuid", wallet.ID.String(), true, true},
+		{"invalid wallet ID", owner.String(), "not-a-uuid", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := f.with(t)
+			got, err := f.store.GetWalletInfo(f.ctx, tt.userID, tt.walletID)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, wallet.ID, got.ID)
+			assert.Equal(t, owner, got.UserID)
+			assert.True(t, got.Balance.Equal(dec("12.34")), "balance = %s", got.Balance)
+			assert.Equal(t, model.WalletStatusActive, got.Status)
+		})
+	}
+}
+
+func testGetTransactionsByWalletID(t *testing.T, f *walletFixture) {
+	owner := uuid.New()
+	wallet := f.wallet(owner, "0")
+	other := f.wallet(uuid.New(), "0")
+	viewer := f.member(wallet.ID, model.WalletRoleViewer)
+
+	transactions, err := f.store.GetTransactionsByWalletID(f.ctx, owner.String(), wallet.ID.String())
+	require.NoError(t, err)
+	assert.Empty(t, transactions)
+
+	for _, amount := range []string{"1", "2", "3"} {
+		_, err = f.store.Deposit(f.ctx, owner.String(), wallet.ID.String(), dec(amount))
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+	}
+	// A transfer into the wallet is recorded on the source wallet only
+	_, err = f.store.Deposit(f.ctx, other.UserID.String(), other.ID.String(), dec("5"))
+	require.NoError(t, err)
+	_, err = f.store.Transfer(f.ctx, other.UserID.String(), other.ID.String(), wallet.ID.String(), dec("5"), noFee())
+	require.NoError(t, err)
+
+	transactions, err = f.store.GetTransactionsByWalletID(f.ctx, viewer.String(), wallet.ID.String())
+	require.NoError(t, err)
+	require.Len(t, transactions, 3)
+	for i, want := range []string{"3", "2", "1"} {
+		assert.Equal(t, wallet.ID, transactions[i].WalletID)
+		assert.Equal(t, model.TransactionTypeDeposit, transactions[i].Type)
+		assert.True(t, transactions[i].Amount.Equal(dec(want)), "transaction %d amount = %s, want %s", i, transactions[i].Amount, want)
+	}
+
+	_, err = f.store.GetTransactionsByWalletID(f.ctx, uuid.NewString(), wallet.ID.String())
+	assert.ErrorIs(t, err, repo.ErrWalletNotFound)
+	_, err = f.store.GetTransactionsByWalletID(f.ctx, owner.String(), "not-a-uuid")
+	assert.Error(t, err)
+}
+
+func testDeposit(t *testing.T, f *walletFixture) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	tests := []struct {
+		name      string
+		status    model.WalletStatus
+		expiresAt *time.Time
+		role      model.WalletRole
+		wantErr   error
+	}{
+		{"owner", model.WalletStatusActive, nil, model.WalletRoleOwner, nil},
+		{"spender", model.WalletStatusActive, nil, model.WalletRoleSpender, nil},
+		{"debit blocked wallet can be paid", model.WalletStatusDebitBlocked, nil, model.WalletRoleOwner, nil},
+		{"expired freeze", model.WalletStatusFrozen, &past, model.WalletRoleOwner, nil},
+		{"viewer", model.WalletStatusActive, nil, model.WalletRoleViewer, repo.ErrWalletForbidden},
+		{"frozen", model.WalletStatusFrozen, &future, model.WalletRoleOwner, repo.ErrWalletFrozen},
+		{"credit blocked", model.WalletStatusCreditBlocked, nil, model.WalletRoleOwner, repo.ErrWalletCreditBlocked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := f.with(t)
+			owner := uuid.New()
+			wallet := model.Wallet{ID: uuid.New(), UserID: owner, Name: "deposit", Balance: dec("10"), Status: tt.status, StatusExpiresAt: tt.expiresAt}
+			require.NoError(t, f.store.AddWallet(f.ctx, wallet))
+			userID := owner
+			if tt.role != model.WalletRoleOwner {
+				userID = f.member(wallet.ID, tt.role)
+			}
+
+			transaction, err := f.store.Deposit(f.ctx, userID.String(), wallet.ID.String(), dec("2.50"))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				f.assertBalance(wallet, "10")
+				assert.Zero(t, f.transactionCount(wallet))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, wallet.ID, transaction.WalletID)
+			assert.Equal(t, model.TransactionTypeDeposit, transaction.Type)
+			assert.True(t, transaction.Amount.Equal(dec("2.50")))
+			require.NotNil(t, transaction.InitiatedBy)
+			assert.Equal(t, userID, *transaction.InitiatedBy)
+			f.assertBalance(wallet, "12.50")
+			assert.Equal(t, 1, f.transactionCount(wallet))
+		})
+	}
+
+	t.Run("unknown wallet", func(t *testing.T) {
+		f := f.with(t)
+		_, err := f.store.Deposit(f.ctx, uuid.NewString(), uuid.NewString(), dec("1"))
+		assert.ErrorIs(t, err, repo.ErrWalletNotFound)
+	})
+	t.Run("invalid wallet ID", func(t *testing.T) {
+		f := f.with(t)
+		_, err := f.store.Deposit(f.ctx, uuid.NewString(), "not-a-uuid", dec("1"))
+		assert.Error(t, err)
+	})
+}
+
+func testWithdraw(t *testing.T, f *walletFixture) {
+	feeWallet := f.wallet(uuid.New(), "0")
+	scheduleID := uuid.New()
+
+	tests := []struct {
+		name        string
+		balance     string
+		pots        string
+		status      model.WalletStatus
+		amount      string
+		fee         model.Fee
+		wantErr     error
+		wantBalance string
+		wantFee     string
+	}{
+		{"without fee", "10", "0", model.WalletStatusActive, "4", noFee(), nil, "6", "0"},
+		{"with fee", "10", "0", model.WalletStatusActive, "4", model.Fee{Amount: dec("1"), WalletID: feeWallet.ID, ScheduleID: &scheduleID}, nil, "5", "1"},
+		{"whole balance", "10", "0", model.WalletStatusActive, "9", model.Fee{Amount: dec("1"), WalletID: feeWallet.ID}, nil, "0", "1"},
+		{"credit blocked wallet can pay", "10", "0", model.WalletStatusCreditBlocked, "4", noFee(), nil, "6", "0"},
+		{"insufficient funds", "10", "0", model.WalletStatusActive, "11", noFee(), repo.ErrInsufficientFunds, "10", "0"},
+		{"fee not covered", "10", "0", model.WalletStatusActive, "10", model.Fee{Amount: dec("0.01"), WalletID: feeWallet.ID}, repo.ErrInsufficientFunds, "10", "0"},
+		{"money in pots", "10", "7", model.WalletStatusActive, "4", noFee(), repo.ErrInsufficientFunds, "10", "0"},
+		{"fee wallet missing", "10", "0", model.WalletStatusActive, "4", model.Fee{Amount: dec("1"), WalletID: uuid.New()}, repo.ErrWalletNotFound, "10", "0"},
+		{"frozen", "10", "0", model.WalletStatusFrozen, "4", noFee(), repo.ErrWalletFrozen, "10", "0"},
+		{"debit blocked", "10", "0", model.WalletStatusDebitBlocked, "4", noFee(), repo.ErrWalletDebitBlocked, "10", "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := f.with(t)
+			feeBefore := f.balance(feeWallet)
+			wallet := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "withdraw", Balance: dec(tt.balance), PotsBalance: dec(tt.pots), Status: tt.status}
+			require.NoError(t, f.store.AddWallet(f.ctx, wallet))
+
+			transaction, err := f.store.Withdraw(f.ctx, wallet.UserID.String(), wallet.ID.String(), dec(tt.amount), tt.fee)
+			f.assertBalance(wallet, tt.wantBalance)
+			assert.True(t, f.balance(feeWallet).Sub(feeBefore).Equal(dec(tt.wantFee)), "fee wallet credited %s, want %s", f.balance(feeWallet).Sub(feeBefore), tt.wantFee)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Zero(t, f.transactionCount(wallet))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, model.TransactionTypeWithdrawal, transaction.Type)
+			assert.True(t, transaction.Amount.Equal(dec(tt.amount)))
+			if !tt.fee.Amount.IsPositive() {
+				assert.Nil(t, transaction.Fee)
+				assert.Equal(t, 1, f.transactionCount(wallet))
+				return
+			}
+			require.NotNil(t, transaction.Fee)
+			assert.Equal(t, model.TransactionTypeFee, transaction.Fee.Type)
+			assert.True(t, transaction.Fee.Amount.Equal(tt.fee.Amount))
+			assert.Equal(t, &feeWallet.ID, transaction.Fee.RelatedWalletID)
+			assert.Equal(t, &transaction.ID, transaction.Fee.ParentTransactionID)
+			assert.Equal(t, tt.fee.ScheduleID, transaction.Fee.FeeScheduleID)
+			assert.Equal(t, 2, f.transactionCount(wallet))
+		})
+	}
+
+	t.Run("viewer", func(t *testing.T) {
+		f := f.with(t)
+		wallet := f.wallet(uuid.New(), "10")
+		viewer := f.member(wallet.ID, model.WalletRoleViewer)
+		_, err := f.store.Withdraw(f.ctx, viewer.String(), wallet.ID.String(), dec("1"), noFee())
+		assert.ErrorIs(t, err, repo.ErrWalletForbidden)
+		f.assertBalance(wallet, "10")
+	})
+	t.Run("fee wallet pays no fee", func(t *testing.T) {
+		f := f.with(t)
+		wallet := f.wallet(uuid.New(), "10")
+		transaction, err := f.store.Withdraw(f.ctx, wallet.UserID.String(), wallet.ID.String(), dec("4"), model.Fee{Amount: dec("1"), WalletID: wallet.ID})
+		require.NoError(t, err)
+		assert.Nil(t, transaction.Fee)
+		f.assertBalance(wallet, "6")
+	})
+}
+
+func testTransfer(t *testing.T, f *walletFixture) {
+	feeWallet := f.wallet(uuid.New(), "0")
+	fee := model.Fee{Amount: dec("1"), WalletID: feeWallet.ID}
+
+	tests := []struct {
+		name            string
+		sourceStatus    model.WalletStatus
+		destStatus      model.WalletStatus
+		amount          string
+		fee             model.Fee
+		threshold       string
+		missingDest     bool
+		wantErr         error
+		wantSource      string
+		wantDestination string
+	}{
+		{"without fee", model.WalletStatusActive, model.WalletStatusActive, "4", noFee(), "", false, nil, "6", "4"},
+		{"with fee", model.WalletStatusActive, model.WalletStatusActive, "4", fee, "", false, nil, "5", "4"},
+		{"under approval threshold", model.WalletStatusActive, model.WalletStatusActive, "4", noFee(), "4", false, nil, "6", "4"},
+		{"approval required", model.WalletStatusActive, model.WalletStatusActive, "4", noFee(), "3.99", false, repo.ErrApprovalRequired, "10", "0"},
+		{"insufficient funds", model.WalletStatusActive, model.WalletStatusActive, "10.01", noFee(), "", false, repo.ErrInsufficientFunds, "10", "0"},
+		{"fee not covered", model.WalletStatusActive, model.WalletStatusActive, "10", fee, "", false, repo.ErrInsufficientFunds, "10", "0"},
+		{"destination missing", model.WalletStatusActive, model.WalletStatusActive, "4", noFee(), "", true, repo.ErrWalletNotFound, "10", "0"},
+		{"source frozen", model.WalletStatusFrozen, model.WalletStatusActive, "4", noFee(), "", false, repo.ErrWalletFrozen, "10", "0"},
+		{"source debit blocked", model.WalletStatusDebitBlocked, model.WalletStatusActive, "4", noFee(), "", false, repo.ErrWalletDebitBlocked, "10", "0"},
+		{"destination frozen", model.WalletStatusActive, model.WalletStatusFrozen, "4", noFee(), "", false, repo.ErrWalletFrozen, "10", "0"},
+		{"destination credit blocked", model.WalletStatusActive, model.WalletStatusCreditBlocked, "4", noFee(), "", false, repo.ErrWalletCreditBlocked, "10", "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := f.with(t)
+			feeBefore := f.balance(feeWallet)
+			source := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "source", Balance: dec("10"), Status: tt.sourceStatus}
+			require.NoError(t, f.store.AddWallet(f.ctx, source))
+			destination := model.Wallet{ID: uuid.New(), UserID: uuid.New(), Name: "destination", Balance: dec("0"), Status: tt.destStatus}
+			if !tt.missingDest {
+				require.NoError(t, f.store.AddWallet(f.ctx, destination))
+			}
+			if tt.threshold != "" {
+				require.NoError(t, f.store.PutApprovalPolicy(f.ctx, model.ApprovalPolicy{
+					WalletID: source.ID, Threshold: dec(tt.threshold), RequiredApprovals: 1, ProposalTTLHours: 72,
+				}))
+			}
+
+			transaction, err := f.store.Transfer(f.ctx, source.UserID.String(), source.ID.String(), destination.ID.String(), dec(tt.amount), tt.fee)
+			f.assertBalance(source, tt.wantSource)
+			if !tt.missingDest {
+				f.assertBalance(destination, tt.wantDestination)
+			}
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.True(t, f.balance(feeWallet).Equal(feeBefore), "fee wallet balance changed")
+				assert.Zero(t, f.transactionCount(source))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, source.ID, transaction.WalletID)
+			assert.Equal(t, model.TransactionTypeTransfer, transaction.Type)
+			assert.Equal(t, &destination.ID, transaction.RelatedWalletID)
+			require.NotNil(t, transaction.InitiatedBy)
+			assert.Equal(t, source.UserID, *transaction.InitiatedBy)
+			assert.True(t, f.balance(feeWallet).Sub(feeBefore).Equal(tt.fee.Amount))
+			assert.Equal(t, tt.fee.Amount.IsPositive(), transaction.Fee != nil)
+		})
+	}
+
+	t.Run("same wallet", func(t *testing.T) {
+		f := f.with(t)
+		wallet := f.wallet(uuid.New(), "10")
+		_, err := f.store.Transfer(f.ctx, wallet.UserID.String(), wallet.ID.String(), wallet.ID.String(), dec("1"), noFee())
+		assert.Error(t, err)
+		f.assertBalance(wallet, "10")
+	})
+	t.Run("not a member of the source", func(t *testing.T) {
+		f := f.with(t)
+		source, destination := f.wallet(uuid.New(), "10"), f.wallet(uuid.New(), "0")
+		_, err := f.store.Transfer(f.ctx, destination.UserID.String(), source.ID.String(), destination.ID.String(), dec("1"), noFee())
+		assert.ErrorIs(t, err, repo.ErrWalletNotFound)
+		f.assertBalance(source, "10")
+	})
+	t.Run("viewer of the source", func(t *testing.T) {
+		f := f.with(t)
+		source, destination := f.wallet(uuid.New(), "10"), f.wallet(uuid.New(), "0")
+		viewer := f.member(source.ID, model.WalletRoleViewer)
+		_, err := f.store.Transfer(f.ctx, viewer.String(), source.ID.String(), destination.ID.String(), dec("1"), noFee())
+		assert.ErrorIs(t, err, repo.ErrWalletForbidden)
+		f.assertBalance(source, "10")
+	})
+}
+
+// testConcurrentDepositsAndWithdrawals deposits to and withdraws from one wallet from many goroutines at once. The
+// balance never runs out, so every operation must succeed, and a lost update would leave the balance off.
+func testConcurrentDepositsAndWithdrawals(t *testing.T, f *walletFixture) {
+	const workers, perWorker = 8, 25
+	wallet := f.wallet(uuid.New(), "100")
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures []error
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				// Half the workers withdraw before they deposit, so both orders interleave
+				ops := []func() error{
+					func() error {
+						_, err := f.store.Deposit(f.ctx, wallet.UserID.String(), wallet.ID.String(), dec("1.25"))
+						return err
+					},
+					func() error {
+						_, err := f.store.Withdraw(f.ctx, wallet.UserID.String(), wallet.ID.String(), dec("1.25"), noFee())
+						return err
+					},
+				}
+				if i%2 == 1 {
+					ops[0], ops[1] = ops[1], ops[0]
+				}
+				for _, op := range ops {
+					if err := op(); err != nil {
+						mu.Lock()
+						failures = append(failures, err)
+						mu.Unlock()
+					}
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	require.Empty(t, failures)
+	f.assertBalance(wallet, "100")
+	assert.Equal(t, 2*workers*perWorker, f.transactionCount(wallet))
+}
+
+// testConcurrentTransfers drains a wallet from many goroutines at once: exactly as many transfers as the balance
+// covers must succeed, the others must fail with ErrInsufficientFunds, and no money may be created or lost.
+func testConcurrentTransfers(t *testing.T, f *walletFixture) {
+	const workers, perWorker = 8, 10
+	source := f.wallet(uuid.New(), "50")
+	destination := f.wallet(uuid.New(), "0")
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		failures  []error
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				_, err := f.store.Transfer(f.ctx, source.UserID.String(), source.ID.String(), destination.ID.String(), dec("1"), noFee())
+				mu.Lock()
+				if err == nil {
+					succeeded++
+				} else if !errors.Is(err, repo.ErrInsufficientFunds) {
+					failures = append(failures, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Empty(t, failures)
+	assert.Equal(t, 50, succeeded)
+	f.assertBalance(source, "0")
+	f.assertBalance(destination, "50")
+	assert.Equal(t, 50, f.transactionCount(source))
+}
@@ -0,0 +1,377 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+var (
+	// ErrScheduledTransferNotFound indicates that the requested schedule was not found.
+	ErrScheduledTransferNotFound = errors.New("scheduled transfer not found")
+	// ErrScheduledTransferRunPaid indicates a second transfer for a scheduled transfer run that was already paid.
+	ErrScheduledTransferRunPaid = errors.New("scheduled transfer run was already paid")
+	// ErrScheduledTransferRunNotClaimed indicates an outcome recorded by a worker whose claim on the run lapsed:
+	// its lease expired and the run was released, and possibly claimed again.
+	ErrScheduledTransferRunNotClaimed = errors.New("scheduled transfer run is no longer claimed by this worker")
+)
+
+const scheduledTransferColumns = `id, user_id, source_wallet_id, destination_wallet_id, amount, expression,
+                                  start_at, end_at, next_run_at, status, created_at, updated_at`
+
+const scheduledTransferRunColumns = `id, schedule_id, scheduled_for, status, attempts, next_attempt_at,
+                                     transaction_id, error, claimed_at, created_at, updated_at`
+
+type ScheduledTransferRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewScheduledTransferImpl(db *sqlx.DB) *ScheduledTransferRepoImpl {
+	return &ScheduledTransferRepoImpl{db}
+}
+
+// CreateScheduledTransfer stores a new schedule. The schedule's user must be allowed to spend from the source
+// wallet, and still be when each run is executed, and the destination wallet must exist.
+func (sr *ScheduledTransferRepoImpl) CreateScheduledTransfer(ctx context.Context, s *model.ScheduledTransfer) error {
+	tx, err := beginTx(ctx, sr.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkWalletAccess(ctx, tx, s.UserID, s.SourceWalletID, model.WalletRoleSpender); err != nil {
+		if errors.Is(err, ErrWalletNotFound) {
+			return fmt.Errorf("source wallet not found: %w", err)
+		}
+		return err
+	}
+
+	var destinationExists bool
+	checkQuery := `SELECT EXISTS(SELECT 1 FROM wallets WHERE id = $1)`
+	if err = tx.GetContext(ctx, &destinationExists, checkQuery, s.DestinationWalletID); err != nil {
+		return fmt.Errorf("failed to check scheduled transfer wallets: %w", err)
+	}
+	if !destinationExists {
+		return fmt.Errorf("destination wallet not found: %w", ErrWalletNotFound)
+	}
+
+	insertQuery := `INSERT INTO scheduled_transfers (` + scheduledTransferColumns + `)
+                    VALUES (:id, :user_id, :source_wallet_id, :destination_wallet_id, :amount, :expression,
+                            :start_at, :end_at, :next_run_at, :status, :created_at, :updated_at)`
+	if _, err = tx.NamedExecContext(ctx, insertQuery, s); err != nil {
+		return fmt.Errorf("failed to create scheduled transfer: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit scheduled transfer: %w", err)
+	}
+	return nil
+}
+
+// ListScheduledTransfers retrieves all schedules of a wallet the user is a member of, newest first.
+func (sr *ScheduledTransferRepoImpl) ListScheduledTransfers(ctx context.Context, userIDStr string, walletIDStr string) ([]model.ScheduledTransfer, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	if err = checkWalletAccess(ctx, sr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	schedules := []model.ScheduledTransfer{}
+	query := `SELECT ` + scheduledTransferColumns + `
+              FROM scheduled_transfers
+              WHERE source_wallet_id = $1
+              ORDER BY created_at DESC`
+	if err = sr.db.SelectContext(ctx, &schedules, query, walletID); err != nil {
+		return nil, fmt.Errorf("database error retrieving scheduled transfers: %w", err)
+	}
+	return schedules, nil
+}
+
+// GetScheduledTransfer retrieves one schedule of a wallet the user is a member of, with all of its runs.
+func (sr *ScheduledTransferRepoImpl) GetScheduledTransfer(ctx context.Context, userIDStr string, walletIDStr string, scheduleIDStr string) (*model.ScheduledTransfer, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	scheduleID, err := uuid.Parse(scheduleIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule ID format: %w", err)
+	}
+
+	if err = checkWalletAccess(ctx, sr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	var s model.ScheduledTransfer
+	query := `SELECT ` + scheduledTransferColumns + `
+              FROM scheduled_transfers
+              WHERE id = $1 AND source_wallet_id = $2`
+	if err = sr.db.GetContext(ctx, &s, query, scheduleID, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrScheduledTransferNotFound
+		}
+		return nil, fmt.Errorf("database error retrieving scheduled transfer: %w", err)
+	}
+
+	runsQuery := `SELECT ` + scheduledTransferRunColumns + `
+                  FROM scheduled_transfer_runs
+                  WHERE schedule_id = $1
+                  ORDER BY scheduled_for DESC`
+	if err = sr.db.SelectContext(ctx, &s.Runs, runsQuery, scheduleID); err != nil {
+		return nil, fmt.Errorf("database error retrieving scheduled transfer runs: %w", err)
+	}
+	return &s, nil
+}
+
+// GetScheduledTransferByID retrieves a schedule without any ownership check. It is meant for the worker.
+func (sr *ScheduledTransferRepoImpl) GetScheduledTransferByID(ctx context.Context, scheduleID uuid.UUID) (*model.ScheduledTransfer, error) {
+	var s model.ScheduledTransfer
+	query := `SELECT ` + scheduledTransferColumns + ` FROM scheduled_transfers WHERE id = $1`
+	if err := sr.db.GetContext(ctx, &s, query, scheduleID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrScheduledTransferNotFound
+		}
+		return nil, fmt.Errorf("database error retrieving scheduled transfer: %w", err)
+	}
+	return &s, nil
+}
+
+// CancelScheduledTransfer stops an active schedule; any member allowed to spend from the wallet may cancel it.
+// Runs already claimed are not affected.
+func (sr *ScheduledTransferRepoImpl) CancelScheduledTransfer(ctx context.Context, userIDStr string, walletIDStr string, scheduleIDStr string) error {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	scheduleID, err := uuid.Parse(scheduleIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid schedule ID format: %w", err)
+	}
+
+	if err = checkWalletAccess(ctx, sr.db, userID, walletID, model.WalletRoleSpender); err != nil {
+		return err
+	}
+
+	query := `UPDATE scheduled_transfers
+              SET status = $1, next_run_at = NULL
+              WHERE id = $2 AND source_wallet_id = $3 AND status = $4`
+	res, err := sr.db.ExecContext(ctx, query, model.ScheduledTransferStatusCancelled, scheduleID, walletID, model.ScheduledTransferStatusActive)
+	if err != nil {
+		return fmt.Errorf("failed to cancel scheduled transfer: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrScheduledTransferNotFound
+	}
+	return nil
+}
+
+// ListDueScheduledTransfers returns up to limit active schedules whose next occurrence is at or before now.
+func (sr *ScheduledTransferRepoImpl) ListDueScheduledTransfers(ctx context.Context, now time.Time, limit int) ([]model.ScheduledTransfer, error) {
+	var schedules []model.ScheduledTransfer
+	query := `SELECT ` + scheduledTransferColumns + `
+              FROM scheduled_transfers
+              WHERE status = $1 AND next_run_at <= $2
+              ORDER BY next_run_at
+              LIMIT $3`
+	if err := sr.db.SelectContext(ctx, &schedules, query, model.ScheduledTransferStatusActive, now, limit); err != nil {
+		return nil, fmt.Errorf("database error retrieving due scheduled transfers: %w", err)
+	}
+	return schedules, nil
+}
+
+// ClaimScheduledTransferRun records a run for the schedule's current occurrence (s.NextRunAt) and moves the
+// schedule on to nextRunAt, or completes it when nextRunAt is nil.
+// The schedule row is only advanced if it still points at the same occurrence, and the run row is unique per
+// occurrence, so concurrent workers can never both claim it: the loser gets a nil run and no error.
+func (sr *ScheduledTransferRepoImpl) ClaimScheduledTransferRun(ctx context.Context, s *model.ScheduledTransfer, nextRunAt *time.Time, now time.Time) (*model.ScheduledTransferRun, error) {
+	if s.NextRunAt == nil {
+		return nil, nil
+	}
+
+	tx, err := beginTx(ctx, sr.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	status := model.ScheduledTransferStatusActive
+	if nextRunAt == nil {
+		status = model.ScheduledTransferStatusCompleted
+	}
+	advanceQuery := `UPDATE scheduled_transfers
+                     SET next_run_at = $1, status = $2
+                     WHERE id = $3 AND status = $4 AND next_run_at = $5`
+	res, err := tx.ExecContext(ctx, advanceQuery, nextRunAt, status, s.ID, model.ScheduledTransferStatusActive, *s.NextRunAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to advance scheduled transfer: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, nil
+	}
+
+	// The claim is matched exactly when the outcome is recorded, so it must not be rounded on the way in.
+	claimedAt := now.Truncate(time.Microsecond)
+	run := &model.ScheduledTransferRun{
+		ID:           uuid.New(),
+		ScheduleID:   s.ID,
+		ScheduledFor: *s.NextRunAt,
+		Status:       model.ScheduledTransferRunStatusRunning,
+		Attempts:     1,
+		ClaimedAt:    &claimedAt,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	insertQuery := `INSERT INTO scheduled_transfer_runs (id, schedule_id, scheduled_for, status, attempts, claimed_at, created_at, updated_at)
+                    VALUES (:id, :schedule_id, :scheduled_for, :status, :attempts, :claimed_at, :created_at, :updated_at)
+                    ON CONFLICT (schedule_id, scheduled_for) DO NOTHING`
+	res, err = tx.NamedExecContext(ctx, insertQuery, run)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scheduled transfer run: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, nil
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit scheduled transfer run: %w", err)
+	}
+	s.NextRunAt = nextRunAt
+	s.Status = status
+	return run, nil
+}
+
+// ListRetryableScheduledTransferRuns returns up to limit runs waiting for a retry that is due at or before now.
+func (sr *ScheduledTransferRepoImpl) ListRetryableScheduledTransferRuns(ctx context.Context, now time.Time, limit int) ([]model.ScheduledTransferRun, error) {
+	var runs []model.ScheduledTransferRun
+	query := `SELECT ` + scheduledTransferRunColumns + `
+              FROM scheduled_transfer_runs
+              WHERE status = $1 AND next_attempt_at <= $2
+              ORDER BY next_attempt_at
+              LIMIT $3`
+	if err := sr.db.SelectContext(ctx, &runs, query, model.ScheduledTransferRunStatusRetrying, now, limit); err != nil {
+		return nil, fmt.Errorf("database error retrieving scheduled transfer retries: %w", err)
+	}
+	return runs, nil
+}
+
+// ClaimScheduledTransferRetry marks a retrying run as running again and counts the attempt.
+// It reports false when another worker claimed the retry first.
+func (sr *ScheduledTransferRepoImpl) ClaimScheduledTransferRetry(ctx context.Context, run *model.ScheduledTransferRun, now time.Time) (bool, error) {
+	claimedAt := now.Truncate(time.Microsecond)
+	query := `UPDATE scheduled_transfer_runs
+              SET status = $1, attempts = attempts + 1, next_attempt_at = NULL, claimed_at = $5
+              WHERE id = $2 AND status = $3 AND next_attempt_at <= $4
+              RETURNING attempts`
+	err := sr.db.GetContext(ctx, &run.Attempts, query, model.ScheduledTransferRunStatusRunning, run.ID, model.ScheduledTransferRunStatusRetrying,
+		now, claimedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim scheduled transfer retry: %w", err)
+	}
+	run.Status = model.ScheduledTransferRunStatusRunning
+	run.NextAttemptAt = nil
+	run.ClaimedAt = &claimedAt
+	return true, nil
+}
+
+// FinishScheduledTransferRun stores the outcome of a run attempt. It only does so while the run is still running
+// under the claim run.ClaimedAt, and fails with ErrScheduledTransferRunNotClaimed once the lease expired and the
+// run was released, so a late worker cannot overwrite the outcome of the attempt that took over.
+// A run recorded as succeeded without a transaction, because ErrScheduledTransferRunPaid showed an earlier
+// attempt paid it, is given the transaction of that attempt.
+func (sr *ScheduledTransferRepoImpl) FinishScheduledTransferRun(ctx context.Context, run *model.ScheduledTransferRun) error {
+	query := `UPDATE scheduled_transfer_runs r
+              SET status = $1, next_attempt_at = $2, error = $4,
+                  transaction_id = COALESCE($3, (SELECT t.id FROM transactions t WHERE t.scheduled_transfer_run_id = r.id))
+              WHERE r.id = $5 AND r.status = $6 AND r.claimed_at = $7
+              RETURNING r.transaction_id`
+	err := sr.db.GetContext(ctx, &run.TransactionID, query, run.Status, run.NextAttemptAt, run.TransactionID, run.Error, run.ID,
+		model.ScheduledTransferRunStatusRunning, run.ClaimedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrScheduledTransferRunNotClaimed
+		}
+		return fmt.Errorf("failed to record scheduled transfer run outcome: %w", err)
+	}
+	return nil
+}
+
+// ReleaseExpiredScheduledTransferRuns settles the runs still "running" that were claimed before claimedBefore,
+// whose worker must have died before recording the outcome.
+// A run already paid, found by the transaction recorded against it, is marked succeeded with that transaction;
+// any other run is handed back for a retry due at now. A transfer still in flight when its run is handed back
+// cannot pay it twice, since a run has at most one transaction. It returns the runs it settled.
+func (sr *ScheduledTransferRepoImpl) ReleaseExpiredScheduledTransferRuns(ctx context.Context, claimedBefore time.Time, now time.Time) ([]model.ScheduledTransferRun, error) {
+	tx, err := beginTx(ctx, sr.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var runs []model.ScheduledTransferRun
+	selectQuery := `SELECT ` + scheduledTransferRunColumns + `
+                    FROM scheduled_transfer_runs
+                    WHERE status = $1 AND claimed_at < $2
+                    ORDER BY claimed_at
+                    FOR UPDATE SKIP LOCKED`
+	if err = tx.SelectContext(ctx, &runs, selectQuery, model.ScheduledTransferRunStatusRunning, claimedBefore); err != nil {
+		return nil, fmt.Errorf("database error retrieving expired scheduled transfer runs: %w", err)
+	}
+
+	// The transfer of a run is recorded against the run in the same database transaction that moved the money.
+	transferQuery := `SELECT id FROM transactions WHERE scheduled_transfer_run_id = $1`
+	updateQuery := `UPDATE scheduled_transfer_runs
+                    SET status = $1, next_attempt_at = $2, transaction_id = $3, error = $4
+                    WHERE id = $5`
+	for i := range runs {
+		run := &runs[i]
+		var transactionID uuid.UUID
+		err = tx.GetContext(ctx, &transactionID, transferQuery, run.ID)
+		switch {
+		case err == nil:
+			run.Status = model.ScheduledTransferRunStatusSucceeded
+			run.TransactionID = &transactionID
+			run.NextAttemptAt = nil
+			run.Error = nil
+		case errors.Is(err, sql.ErrNoRows):
+			msg := "worker lease expired before the outcome was recorded"
+			run.Status = model.ScheduledTransferRunStatusRetrying
+			run.NextAttemptAt = &now
+			run.Error = &msg
+		default:
+			return nil, fmt.Errorf("database error looking up scheduled transfer run transaction: %w", err)
+		}
+		if _, err = tx.ExecContext(ctx, updateQuery, run.Status, run.NextAttemptAt, run.TransactionID, run.Error, run.ID); err != nil {
+			return nil, fmt.Errorf("failed to release expired scheduled transfer run: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit expired scheduled transfer runs: %w", err)
+	}
+	return runs, nil
+}
@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+type StatementRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewStatementImpl(db *sqlx.DB) *StatementRepoImpl {
+	return &StatementRepoImpl{db}
+}
+
+// GetWalletActivity loads the wallet, its owner and all transactions touching it (including the
+// transfers, fees and reversals paid into it, which are recorded on the paying wallet) created at or after since.
+// Everything is read in a single repeatable-read transaction so the current balance and the
+// history agree with each other.
+func (sr *StatementRepoImpl) GetWalletActivity(ctx context.Context, userIDStr string, walletIDStr string, since time.Time) (*model.WalletActivity, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	tx, err := sr.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	activity := model.WalletActivity{Since: since}
+
+	if err = checkWalletAccess(ctx, tx, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	queryWallet := `SELECT id, user_id, name, balance, created_at, updated_at
+                    FROM wallets
+                    WHERE id = $1`
+	err = tx.GetContext(ctx, &activity.Wallet, queryWallet, walletID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve wallet for statement: %w", err)
+	}
+
+	queryOwner := `SELECT id, name, email, created_at FROM users WHERE id = $1`
+	err = tx.GetContext(ctx, &activity.Owner, queryOwner, activity.Wallet.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve wallet owner for statement: %w", err)
+	}
+
+	queryTransactions := `SELECT id, wallet_id, type, amount, related_wallet_id, created_at, parent_transaction_id
+                          FROM transactions
+                          WHERE (wallet_id = $1 OR (related_wallet_id = $1 AND type IN ('transfer', 'fee', 'reversal')))
+                            AND created_at >= $2
+                          ORDER BY created_at, id`
+	err = tx.SelectContext(ctx, &activity.Transactions, queryTransactions, walletID, since)
+	if err != nil {
+		return nil, fmt.Errorf("database error retrieving statement transactions: %w", err)
+	}
+
+	return &activity, nil
+}
@@ -0,0 +1,239 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+// ErrTransferBatchNotFound indicates that the requested transfer batch was not found.
+var ErrTransferBatchNotFound = errors.New("transfer batch not found")
+
+type TransferBatchRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewTransferBatchImpl(db *sqlx.DB) *TransferBatchRepoImpl {
+	return &TransferBatchRepoImpl{db}
+}
+
+// FindMissingWallets returns the IDs among ids that do not match any wallet.
+func (br *TransferBatchRepoImpl) FindMissingWallets(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	var found []uuid.UUID
+	query := `SELECT id FROM wallets WHERE id = ANY($1)`
+	if err := br.db.SelectContext(ctx, &found, query, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to look up batch destination wallets: %w", err)
+	}
+
+	exists := make(map[uuid.UUID]bool, len(found))
+	for _, id := range found {
+		exists[id] = true
+	}
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if !exists[id] {
+			missing = append(missing, id)
+			exists[id] = true // report each missing ID once
+		}
+	}
+	return missing, nil
+}
+
+// CreateTransferBatch stores a new batch and all of its rows.
+// The batch's user must be allowed to spend from the source wallet.
+func (br *TransferBatchRepoImpl) CreateTransferBatch(ctx context.Context, batch *model.TransferBatch) error {
+	tx, err := beginTx(ctx, br.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkWalletAccess(ctx, tx, batch.UserID, batch.SourceWalletID, model.WalletRoleSpender); err != nil {
+		if errors.Is(err, ErrWalletNotFound) {
+			return fmt.Errorf("source wallet not found: %w", err)
+		}
+		return err
+	}
+
+	insertBatchQuery := `INSERT INTO transfer_batches (id, user_id, source_wallet_id, mode, status, created_at, updated_at)
+                         VALUES (:id, :user_id, :source_wallet_id, :mode, :status, :created_at, :updated_at)`
+	if _, err = tx.NamedExecContext(ctx, insertBatchQuery, batch); err != nil {
+		return fmt.Errorf("failed to create transfer batch: %w", err)
+	}
+
+	insertItemQuery := `INSERT INTO transfer_batch_items (id, batch_id, row_number, destination_wallet_id, amount, reference,
+                                                          fee, fee_wallet_id, fee_schedule_id, status, updated_at)
+                        VALUES (:id, :batch_id, :row_number, :destination_wallet_id, :amount, :reference,
+                                :fee, :fee_wallet_id, :fee_schedule_id, :status, :updated_at)`
+	for _, item := range batch.Items {
+		if _, err = tx.NamedExecContext(ctx, insertItemQuery, item); err != nil {
+			return fmt.Errorf("failed to create transfer batch row %d: %w", item.RowNumber, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transfer batch: %w", err)
+	}
+	return nil
+}
+
+// GetTransferBatch retrieves a batch of the wallet with its rows, checking that the user is a member of the wallet.
+func (br *TransferBatchRepoImpl) GetTransferBatch(ctx context.Context, userIDStr string, walletIDStr string, batchIDStr string) (*model.TransferBatch, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	batchID, err := uuid.Parse(batchIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch ID format: %w", err)
+	}
+
+	if err = checkWalletAccess(ctx, br.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	var batch model.TransferBatch
+	query := `SELECT id, user_id, source_wallet_id, mode, status, created_at, updated_at
+              FROM transfer_batches
+              WHERE id = $1 AND source_wallet_id = $2`
+	err = br.db.GetContext(ctx, &batch, query, batchID, walletID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTransferBatchNotFound
+		}
+		return nil, fmt.Errorf("database error retrieving transfer batch: %w", err)
+	}
+
+	itemsQuery := `SELECT id, batch_id, row_number, destination_wallet_id, amount, reference, fee, fee_wallet_id, fee_schedule_id,
+                          status, error, transaction_id, updated_at
+                   FROM transfer_batch_items
+                   WHERE batch_id = $1
+                   ORDER BY row_number`
+	if err = br.db.SelectContext(ctx, &batch.Items, itemsQuery, batchID); err != nil {
+		return nil, fmt.Errorf("database error retrieving transfer batch rows: %w", err)
+	}
+	batch.Summarize()
+	return &batch, nil
+}
+
+// SetTransferBatchStatus moves a batch to a new lifecycle state.
+func (br *TransferBatchRepoImpl) SetTransferBatchStatus(ctx context.Context, batchID uuid.UUID, status model.TransferBatchStatus) error {
+	query := `UPDATE transfer_batches SET status = $1 WHERE id = $2`
+	if _, err := br.db.ExecContext(ctx, query, status, batchID); err != nil {
+		return fmt.Errorf("failed to update transfer batch status: %w", err)
+	}
+	return nil
+}
+
+// ExecuteTransferBatchItem transfers a single row, charges its quoted fee and records its outcome in the same
+// database transaction.
+// A failed transfer is recorded on the row rather than returned; the returned error is only set when the
+// outcome itself could not be stored.
+func (br *TransferBatchRepoImpl) ExecuteTransferBatchItem(ctx context.Context, batch *model.TransferBatch, item *model.TransferBatchItem) error {
+	tx, err := beginTx(ctx, br.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	transaction, transferErr := transferBatchItemTx(ctx, tx, batch, item)
+	if transferErr != nil {
+		_ = tx.Rollback()
+		return br.markItems(ctx, br.db, []*model.TransferBatchItem{item}, model.TransferBatchItemStatusFailed, transferErr)
+	}
+
+	item.TransactionID = &transaction.ID
+	if err = br.markItems(ctx, tx, []*model.TransferBatchItem{item}, model.TransferBatchItemStatusSucceeded, nil); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transfer batch row %d: %w", item.RowNumber, err)
+	}
+	return nil
+}
+
+// ExecuteTransferBatchAtomically transfers every row of the batch, with its quoted fee, in one database transaction.
+// If any row fails the whole batch is rolled back: that row is recorded as failed and every other row as skipped.
+func (br *TransferBatchRepoImpl) ExecuteTransferBatchAtomically(ctx context.Context, batch *model.TransferBatch) error {
+	tx, err := beginTx(ctx, br.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	items := make([]*model.TransferBatchItem, len(batch.Items))
+	for i := range batch.Items {
+		item := &batch.Items[i]
+		items[i] = item
+
+		transaction, transferErr := transferBatchItemTx(ctx, tx, batch, item)
+		if transferErr != nil {
+			_ = tx.Rollback()
+			others := make([]*model.TransferBatchItem, 0, len(batch.Items)-1)
+			for j := range batch.Items {
+				if j != i {
+					batch.Items[j].TransactionID = nil
+					others = append(others, &batch.Items[j])
+				}
+			}
+			if err = br.markItems(ctx, br.db, []*model.TransferBatchItem{item}, model.TransferBatchItemStatusFailed, transferErr); err != nil {
+				return err
+			}
+			return br.markItems(ctx, br.db, others, model.TransferBatchItemStatusSkipped, nil)
+		}
+		item.TransactionID = &transaction.ID
+	}
+
+	if err = br.markItems(ctx, tx, items, model.TransferBatchItemStatusSucceeded, nil); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transfer batch: %w", err)
+	}
+	return nil
+}
+
+// transferBatchItemTx transfers a row from the batch's source wallet and charges its quoted fee, as Transfer does.
+func transferBatchItemTx(ctx context.Context, tx *sqlx.Tx, batch *model.TransferBatch, item *model.TransferBatchItem) (*model.Transaction, error) {
+	transaction, err := transferTx(ctx, tx, batch.UserID, batch.SourceWalletID, item.DestinationWalletID, item.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if item.FeeWalletID != nil {
+		fee := model.Fee{Amount: item.Fee, WalletID: *item.FeeWalletID, ScheduleID: item.FeeScheduleID}
+		if transaction.Fee, err = chargeFeeTx(ctx, tx, batch.SourceWalletID, transaction.ID, batch.UserID, fee); err != nil {
+			return nil, err
+		}
+	}
+	return transaction, nil
+}
+
+// markItems stores the outcome of rows, updating the given items in place.
+func (br *TransferBatchRepoImpl) markItems(ctx context.Context, db sqlx.ExtContext, items []*model.TransferBatchItem, status model.TransferBatchItemStatus, cause error) error {
+	var message *string
+	if cause != nil {
+		m := cause.Error()
+		message = &m
+	}
+
+	query := `UPDATE transfer_batch_items SET status = $1, error = $2, transaction_id = $3 WHERE id = $4`
+	for _, item := range items {
+		item.Status = status
+		item.Error = message
+		if _, err := db.ExecContext(ctx, query, status, message, item.TransactionID, item.ID); err != nil {
+			return fmt.Errorf("failed to record outcome of transfer batch row %d: %w", item.RowNumber, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,502 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+var (
+	// ErrApprovalRequired indicates that the wallet's approval policy requires the transfer to be proposed and approved.
+	ErrApprovalRequired = errors.New("transfer requires approval")
+	// ErrApprovalPolicyNotFound indicates that the wallet has no approval policy.
+	ErrApprovalPolicyNotFound = errors.New("approval policy not found")
+	// ErrTooFewApprovers indicates a policy requiring more approvals than the wallet has owners to give them.
+	ErrTooFewApprovers = errors.New("wallet has fewer owners than the required approvals")
+	// ErrTransferProposalNotFound indicates that the requested transfer proposal was not found in the wallet.
+	ErrTransferProposalNotFound = errors.New("transfer proposal not found")
+	// ErrTransferProposalNotPending indicates an attempt to approve or cancel a proposal that is no longer pending.
+	ErrTransferProposalNotPending = errors.New("transfer proposal is not pending")
+	// ErrTransferProposalExpired indicates that the proposal passed its expiry time before reaching its quorum.
+	ErrTransferProposalExpired = errors.New("transfer proposal has expired")
+	// ErrSelfApproval indicates an attempt to approve a transfer one proposed oneself.
+	ErrSelfApproval = errors.New("a transfer cannot be approved by the member who proposed it")
+	// ErrAlreadyApproved indicates that the user already approved the proposal.
+	ErrAlreadyApproved = errors.New("transfer proposal already approved by this user")
+)
+
+const transferProposalColumns = `id, wallet_id, destination_wallet_id, amount, fee, fee_wallet_id, fee_schedule_id, held_amount,
+                                 proposed_by, required_approvals, status, expires_at, transaction_id, created_at, updated_at`
+
+// checkApprovalPolicyTx returns ErrApprovalRequired if the wallet's approval policy requires a transfer of amount
+// to be approved.
+func checkApprovalPolicyTx(ctx context.Context, tx *sqlx.Tx, walletID uuid.UUID, amount decimal.Decimal) error {
+	var policy model.ApprovalPolicy
+	query := `SELECT wallet_id, threshold, required_approvals, proposal_ttl_hours, created_at, updated_at
+              FROM wallet_approval_policies
+              WHERE wallet_id = $1`
+	if err := tx.GetContext(ctx, &policy, query, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to retrieve approval policy: %w", err)
+	}
+	if policy.RequiresApproval(amount) {
+		return ErrApprovalRequired
+	}
+	return nil
+}
+
+type TransferProposalRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewTransferProposalImpl(db *sqlx.DB) *TransferProposalRepoImpl {
+	return &TransferProposalRepoImpl{db}
+}
+
+// SetApprovalPolicy creates or replaces the approval policy of the wallet. Only owners may do it, and the wallet
+// must have at least as many active owners as the approvals required.
+func (pr *TransferProposalRepoImpl) SetApprovalPolicy(ctx context.Context, userIDStr string, policy *model.ApprovalPolicy) error {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	tx, err := beginTx(ctx, pr.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkWalletAccess(ctx, tx, userID, policy.WalletID, model.WalletRoleOwner); err != nil {
+		return err
+	}
+	var owners int
+	countQuery := `SELECT COUNT(*) FROM wallet_members WHERE wallet_id = $1 AND role = $2 AND status = $3`
+	if err = tx.GetContext(ctx, &owners, countQuery, policy.WalletID, model.WalletRoleOwner, model.WalletMemberStatusActive); err != nil {
+		return fmt.Errorf("failed to count wallet owners: %w", err)
+	}
+	if owners < policy.RequiredApprovals {
+		return ErrTooFewApprovers
+	}
+
+	query := `INSERT INTO wallet_approval_policies (wallet_id, threshold, required_approvals, proposal_ttl_hours, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, $5)
+              ON CONFLICT (wallet_id) DO UPDATE
+              SET threshold = EXCLUDED.threshold,
+                  required_approvals = EXCLUDED.required_approvals,
+                  proposal_ttl_hours = EXCLUDED.proposal_ttl_hours,
+                  updated_at = EXCLUDED.updated_at
+              RETURNING created_at, updated_at`
+	err = tx.QueryRowxContext(ctx, query, policy.WalletID, policy.Threshold, policy.RequiredApprovals, policy.ProposalTTLHours,
+		policy.UpdatedAt).Scan(&policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save approval policy: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit approval policy: %w", err)
+	}
+	return nil
+}
+
+// GetApprovalPolicy returns the approval policy of a wallet the user is a member of.
+func (pr *TransferProposalRepoImpl) GetApprovalPolicy(ctx context.Context, userIDStr string, walletIDStr string) (*model.ApprovalPolicy, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	if err = checkWalletAccess(ctx, pr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	var policy model.ApprovalPolicy
+	query := `SELECT wallet_id, threshold, required_approvals, proposal_ttl_hours, created_at, updated_at
+              FROM wallet_approval_policies
+              WHERE wallet_id = $1`
+	if err = pr.db.GetContext(ctx, &policy, query, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrApprovalPolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve approval policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// DeleteApprovalPolicy removes the approval policy of the wallet. Only owners may do it.
+// Proposals already pending still need their approvals.
+func (pr *TransferProposalRepoImpl) DeleteApprovalPolicy(ctx context.Context, userIDStr string, walletIDStr string) error {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	if err = checkWalletAccess(ctx, pr.db, userID, walletID, model.WalletRoleOwner); err != nil {
+		return err
+	}
+
+	res, err := pr.db.ExecContext(ctx, `DELETE FROM wallet_approval_policies WHERE wallet_id = $1`, walletID)
+	if err != nil {
+		return fmt.Errorf("failed to delete approval policy: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrApprovalPolicyNotFound
+	}
+	return nil
+}
+
+// CreateTransferProposal proposes a transfer from a wallet the user may spend from and holds its amount and fee
+// in the wallet until the proposal is executed, cancelled or expires. The required approvals and the expiry
+// come from the wallet's approval policy; ErrApprovalPolicyNotFound is returned if it has none.
+func (pr *TransferProposalRepoImpl) CreateTransferProposal(ctx context.Context, userIDStr string, proposal *model.TransferProposal) error {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	tx, err := beginTx(ctx, pr.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkWalletAccess(ctx, tx, userID, proposal.WalletID, model.WalletRoleSpender); err != nil {
+		return err
+	}
+
+	var wallet model.Wallet
+	queryWallet := `SELECT id, user_id, name, balance, pots_balance, held_balance, created_at, updated_at
+                    FROM wallets
+                    WHERE id = $1 FOR UPDATE`
+	if err = tx.GetContext(ctx, &wallet, queryWallet, proposal.WalletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrWalletNotFound
+		}
+		return fmt.Errorf("failed to retrieve wallet: %w", err)
+	}
+
+	var policy model.ApprovalPolicy
+	queryPolicy := `SELECT wallet_id, threshold, required_approvals, proposal_ttl_hours, created_at, updated_at
+                    FROM wallet_approval_policies
+                    WHERE wallet_id = $1`
+	if err = tx.GetContext(ctx, &policy, queryPolicy, proposal.WalletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrApprovalPolicyNotFound
+		}
+		return fmt.Errorf("failed to retrieve approval policy: %w", err)
+	}
+
+	var destinationExists bool
+	checkDestinationQuery := `SELECT EXISTS(SELECT 1 FROM wallets WHERE id = $1)`
+	if err = tx.GetContext(ctx, &destinationExists, checkDestinationQuery, proposal.DestinationWalletID); err != nil {
+		return fmt.Errorf("failed to check destination wallet: %w", err)
+	}
+	if !destinationExists {
+		return fmt.Errorf("destination wallet not found: %w", ErrWalletNotFound)
+	}
+
+	proposal.HeldAmount = proposal.Amount.Add(proposal.Fee)
+	if wallet.Available().LessThan(proposal.HeldAmount) {
+		return ErrInsufficientFunds
+	}
+	holdQuery := `UPDATE wallets SET held_balance = held_balance + $1, updated_at = $2 WHERE id = $3`
+	if _, err = tx.ExecContext(ctx, holdQuery, proposal.HeldAmount, proposal.CreatedAt, proposal.WalletID); err != nil {
+		return fmt.Errorf("failed to hold funds: %w", err)
+	}
+
+	proposal.ProposedBy = userID
+	proposal.RequiredApprovals = policy.RequiredApprovals
+	proposal.Status = model.TransferProposalStatusPending
+	proposal.ExpiresAt = proposal.CreatedAt.Add(time.Duration(policy.ProposalTTLHours) * time.Hour)
+	proposal.UpdatedAt = proposal.CreatedAt
+	proposal.Approvals = []model.TransferApproval{}
+	insertQuery := `INSERT INTO transfer_proposals (id, wallet_id, destination_wallet_id, amount, fee, fee_wallet_id, fee_schedule_id,
+                                                    held_amount, proposed_by, required_approvals, status, expires_at, created_at, updated_at)
+                    VALUES (:id, :wallet_id, :destination_wallet_id, :amount, :fee, :fee_wallet_id, :fee_schedule_id,
+                            :held_amount, :proposed_by, :required_approvals, :status, :expires_at, :created_at, :updated_at)`
+	if _, err = tx.NamedExecContext(ctx, insertQuery, proposal); err != nil {
+		return fmt.Errorf("failed to create transfer proposal: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transfer proposal: %w", err)
+	}
+	return nil
+}
+
+// ListTransferProposals returns the proposals of a wallet the user is a member of, newest first, optionally
+// filtered by status.
+func (pr *TransferProposalRepoImpl) ListTransferProposals(ctx context.Context, userIDStr string, walletIDStr string, status model.TransferProposalStatus) ([]model.TransferProposal, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	if err = checkWalletAccess(ctx, pr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	proposals := []model.TransferProposal{}
+	query := `SELECT ` + transferProposalColumns + `
+              FROM transfer_proposals
+              WHERE wallet_id = $1 AND ($2 = '' OR status::text = $2)
+              ORDER BY created_at DESC`
+	if err = pr.db.SelectContext(ctx, &proposals, query, walletID, string(status)); err != nil {
+		return nil, fmt.Errorf("database error retrieving transfer proposals: %w", err)
+	}
+	if len(proposals) == 0 {
+		return proposals, nil
+	}
+
+	ids := make([]uuid.UUID, len(proposals))
+	byID := make(map[uuid.UUID]*model.TransferProposal, len(proposals))
+	for i := range proposals {
+		ids[i] = proposals[i].ID
+		proposals[i].Approvals = []model.TransferApproval{}
+		byID[proposals[i].ID] = &proposals[i]
+	}
+	var approvals []model.TransferApproval
+	approvalsQuery := `SELECT proposal_id, user_id, created_at
+                       FROM transfer_approvals
+                       WHERE proposal_id = ANY($1)
+                       ORDER BY created_at`
+	if err = pr.db.SelectContext(ctx, &approvals, approvalsQuery, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("database error retrieving transfer approvals: %w", err)
+	}
+	for _, a := range approvals {
+		byID[a.ProposalID].Approvals = append(byID[a.ProposalID].Approvals, a)
+	}
+	return proposals, nil
+}
+
+// GetTransferProposal returns a proposal of a wallet the user is a member of, with its approvals.
+func (pr *TransferProposalRepoImpl) GetTransferProposal(ctx context.Context, userIDStr string, walletIDStr string, proposalIDStr string) (*model.TransferProposal, error) {
+	userID, walletID, proposalID, err := parseTransferProposalIDs(userIDStr, walletIDStr, proposalIDStr)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkWalletAccess(ctx, pr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	var proposal model.TransferProposal
+	query := `SELECT ` + transferProposalColumns + `
+              FROM transfer_proposals
+              WHERE id = $1 AND wallet_id = $2`
+	if err = pr.db.GetContext(ctx, &proposal, query, proposalID, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTransferProposalNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve transfer proposal: %w", err)
+	}
+	if proposal.Approvals, err = transferApprovals(ctx, pr.db, proposalID); err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+// ApproveTransferProposal records the approval of an owner of the wallet. When the proposal reaches its quorum,
+// the hold is released and the transfer and its quoted fee are executed in the same database transaction.
+// A proposal found past its expiry is marked expired, its hold released, and ErrTransferProposalExpired returned.
+func (pr *TransferProposalRepoImpl) ApproveTransferProposal(ctx context.Context, userIDStr string, walletIDStr string, proposalIDStr string, now time.Time) (*model.TransferProposal, error) {
+	return pr.answerTransferProposal(ctx, userIDStr, walletIDStr, proposalIDStr, model.WalletRoleOwner, now,
+		func(tx *sqlx.Tx, userID uuid.UUID, proposal *model.TransferProposal) error {
+			if userID == proposal.ProposedBy {
+				return ErrSelfApproval
+			}
+			approveQuery := `INSERT INTO transfer_approvals (proposal_id, user_id, created_at) VALUES ($1, $2, $3)`
+			if _, err := tx.ExecContext(ctx, approveQuery, proposal.ID, userID, now); err != nil {
+				var pqErr *pq.Error
+				if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+					return ErrAlreadyApproved
+				}
+				return fmt.Errorf("failed to record approval: %w", err)
+			}
+
+			approvals, err := transferApprovals(ctx, tx, proposal.ID)
+			if err != nil {
+				return err
+			}
+			proposal.Approvals = approvals
+			if len(approvals) < proposal.RequiredApprovals {
+				return nil
+			}
+
+			if err = releaseHoldTx(ctx, tx, proposal, now); err != nil {
+				return err
+			}
+			transaction, err := moveFundsTx(ctx, tx, &proposal.ProposedBy, proposal.WalletID, proposal.DestinationWalletID, proposal.Amount)
+			if err != nil {
+				return err
+			}
+			if proposal.FeeWalletID != nil {
+				fee := model.Fee{Amount: proposal.Fee, WalletID: *proposal.FeeWalletID, ScheduleID: proposal.FeeScheduleID}
+				if _, err = chargeFeeTx(ctx, tx, proposal.WalletID, transaction.ID, proposal.ProposedBy, fee); err != nil {
+					return err
+				}
+			}
+			proposal.Status = model.TransferProposalStatusExecuted
+			proposal.TransactionID = &transaction.ID
+			return nil
+		})
+}
+
+// CancelTransferProposal withdraws a pending proposal and releases its hold. The member who proposed it and the
+// wallet's owners may cancel it.
+func (pr *TransferProposalRepoImpl) CancelTransferProposal(ctx context.Context, userIDStr string, walletIDStr string, proposalIDStr string, now time.Time) (*model.TransferProposal, error) {
+	return pr.answerTransferProposal(ctx, userIDStr, walletIDStr, proposalIDStr, model.WalletRoleViewer, now,
+		func(tx *sqlx.Tx, userID uuid.UUID, proposal *model.TransferProposal) error {
+			if userID != proposal.ProposedBy {
+				if err := checkWalletAccess(ctx, tx, userID, proposal.WalletID, model.WalletRoleOwner); err != nil {
+					return err
+				}
+			}
+			if err := releaseHoldTx(ctx, tx, proposal, now); err != nil {
+				return err
+			}
+			proposal.Status = model.TransferProposalStatusCancelled
+			return nil
+		})
+}
+
+// answerTransferProposal locks a pending proposal of the wallet and runs apply on it, in one database transaction,
+// then saves the status and transaction apply left on the proposal. The user must have at least role min in the wallet.
+func (pr *TransferProposalRepoImpl) answerTransferProposal(ctx context.Context, userIDStr string, walletIDStr string, proposalIDStr string,
+	min model.WalletRole, now time.Time, apply func(tx *sqlx.Tx, userID uuid.UUID, proposal *model.TransferProposal) error) (*model.TransferProposal, error) {
+	userID, walletID, proposalID, err := parseTransferProposalIDs(userIDStr, walletIDStr, proposalIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := beginTx(ctx, pr.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = checkWalletAccess(ctx, tx, userID, walletID, min); err != nil {
+		return nil, err
+	}
+
+	var proposal model.TransferProposal
+	lockQuery := `SELECT ` + transferProposalColumns + `
+                  FROM transfer_proposals
+                  WHERE id = $1 AND wallet_id = $2 FOR UPDATE`
+	if err = tx.GetContext(ctx, &proposal, lockQuery, proposalID, walletID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTransferProposalNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve transfer proposal: %w", err)
+	}
+	if proposal.Status != model.TransferProposalStatusPending {
+		return nil, fmt.Errorf("transfer proposal is %s: %w", proposal.Status, ErrTransferProposalNotPending)
+	}
+
+	if !proposal.ExpiresAt.After(now) {
+		if err = releaseHoldTx(ctx, tx, &proposal, now); err != nil {
+			return nil, err
+		}
+		proposal.Status = model.TransferProposalStatusExpired
+	} else if err = apply(tx, userID, &proposal); err != nil {
+		return nil, err
+	}
+
+	updateQuery := `UPDATE transfer_proposals SET status = $1, transaction_id = $2, updated_at = $3 WHERE id = $4`
+	if _, err = tx.ExecContext(ctx, updateQuery, proposal.Status, proposal.TransactionID, now, proposal.ID); err != nil {
+		return nil, fmt.Errorf("failed to update transfer proposal: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transfer proposal: %w", err)
+	}
+
+	if proposal.Status == model.TransferProposalStatusExpired {
+		return nil, ErrTransferProposalExpired
+	}
+	if proposal.Approvals == nil {
+		if proposal.Approvals, err = transferApprovals(ctx, pr.db, proposal.ID); err != nil {
+			return nil, err
+		}
+	}
+	proposal.UpdatedAt = now
+	return &proposal, nil
+}
+
+// ExpireTransferProposals marks every pending proposal past its expiry as expired and releases its hold.
+// It returns how many proposals expired.
+func (pr *TransferProposalRepoImpl) ExpireTransferProposals(ctx context.Context, now time.Time) (int, error) {
+	query := `WITH expired AS (
+                  UPDATE transfer_proposals
+                  SET status = 'expired', updated_at = $1
+                  WHERE status = 'pending' AND expires_at <= $1
+                  RETURNING wallet_id, held_amount
+              ), released AS (
+                  UPDATE wallets w
+                  SET held_balance = w.held_balance - e.total, updated_at = $1
+                  FROM (SELECT wallet_id, SUM(held_amount) AS total FROM expired GROUP BY wallet_id) e
+                  WHERE w.id = e.wallet_id
+              )
+              SELECT COUNT(*) FROM expired`
+	var n int
+	if err := pr.db.GetContext(ctx, &n, query, now); err != nil {
+		return 0, fmt.Errorf("failed to expire transfer proposals: %w", err)
+	}
+	return n, nil
+}
+
+func parseTransferProposalIDs(userIDStr string, walletIDStr string, proposalIDStr string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	proposalID, err := uuid.Parse(proposalIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid transfer proposal ID format: %w", err)
+	}
+	return userID, walletID, proposalID, nil
+}
+
+// releaseHoldTx takes the proposal's held amount off its wallet's held balance.
+func releaseHoldTx(ctx context.Context, tx *sqlx.Tx, proposal *model.TransferProposal, now time.Time) error {
+	query := `UPDATE wallets SET held_balance = held_balance - $1, updated_at = $2 WHERE id = $3`
+	if _, err := tx.ExecContext(ctx, query, proposal.HeldAmount, now, proposal.WalletID); err != nil {
+		return fmt.Errorf("failed to release held funds: %w", err)
+	}
+	return nil
+}
+
+// transferApprovals returns the approvals of a proposal, oldest first.
+func transferApprovals(ctx context.Context, q sqlx.QueryerContext, proposalID uuid.UUID) ([]model.TransferApproval, error) {
+	approvals := []model.TransferApproval{}
+	query := `SELECT proposal_id, user_id, created_at
+              FROM transfer_approvals
+              WHERE proposal_id = $1
+              ORDER BY created_at`
+	if err := sqlx.SelectContext(ctx, q, &approvals, query, proposalID); err != nil {
+		return nil, fmt.Errorf("database error retrieving transfer approvals: %w", err)
+	}
+	return approvals, nil
+}
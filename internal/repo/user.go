@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+var (
+	// ErrUserNotFound indicates that the user does not exist.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrRecipientNotFound indicates that no user has the email or handle a transfer was addressed to.
+	ErrRecipientNotFound = errors.New("recipient not found")
+	// ErrHandleTaken indicates that another user already claimed the handle.
+	ErrHandleTaken = errors.New("handle is already taken")
+)
+
+// uniqueViolation is the Postgres error code for a unique constraint violation.
+const uniqueViolation = "23505"
+
+// recipientColumns resolves the recipient's wallet: the default wallet if set, otherwise the oldest one.
+const recipientColumns = `u.id, u.name, u.handle,
+                          COALESCE(u.default_wallet_id,
+                                   (SELECT w.id FROM wallets w WHERE w.user_id = u.id ORDER BY w.created_at, w.id LIMIT 1)) AS wallet_id`
+
+type UserRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewUserImpl(db *sqlx.DB) *UserRepoImpl {
+	return &UserRepoImpl{db}
+}
+
+// FindRecipientByEmail resolves a user by email, ignoring case. An exact match wins over a case-insensitive one.
+func (ur *UserRepoImpl) FindRecipientByEmail(ctx context.Context, email string) (*model.Recipient, error) {
+	query := `SELECT ` + recipientColumns + `
+              FROM users u
+              WHERE lower(u.email) = lower($1)
+              ORDER BY (u.email = $1) DESC
+              LIMIT 1`
+	return ur.findRecipient(ctx, query, email)
+}
+
+// FindRecipientByHandle resolves a user by handle, ignoring case.
+func (ur *UserRepoImpl) FindRecipientByHandle(ctx context.Context, handle string) (*model.Recipient, error) {
+	query := `SELECT ` + recipientColumns + `
+              FROM users u
+              WHERE lower(u.handle) = lower($1)`
+	return ur.findRecipient(ctx, query, handle)
+}
+
+func (ur *UserRepoImpl) findRecipient(ctx context.Context, query string, arg string) (*model.Recipient, error) {
+	var recipient model.Recipient
+	if err := ur.db.GetContext(ctx, &recipient, query, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRecipientNotFound
+		}
+		return nil, fmt.Errorf("database error retrieving recipient: %w", err)
+	}
+	return &recipient, nil
+}
+
+// SetHandle claims handle for the user, replacing any previous one.
+func (ur *UserRepoImpl) SetHandle(ctx context.Context, userIDStr string, handle string) (*model.User, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	var user model.User
+	query := `UPDATE users SET handle = $1 WHERE id = $2
+              RETURNING id, name, email, created_at, handle, default_wallet_id`
+	if err = ur.db.GetContext(ctx, &user, query, handle, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, ErrHandleTaken
+		}
+		return nil, fmt.Errorf("failed to set handle: %w", err)
+	}
+	return &user, nil
+}
+
+// SetDefaultWallet makes one of the user's wallets the one that transfers addressed to the user are paid into.
+func (ur *UserRepoImpl) SetDefaultWallet(ctx context.Context, userIDStr string, walletIDStr string) error {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	if err = checkWalletAccess(ctx, ur.db, userID, walletID, model.WalletRoleSpender); err != nil {
+		return err
+	}
+
+	query := `UPDATE users SET default_wallet_id = $2 WHERE id = $1`
+	res, err := ur.db.ExecContext(ctx, query, userID, walletID)
+	if err != nil {
+		return fmt.Errorf("failed to set default wallet: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrWalletNotFound
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/lib/pq"
 	"github.com/shopspring/decimal"
 	"time"
 )
@@ -18,6 +19,15 @@ var ErrWalletNotFound = errors.New("wallet not found")
 // ErrInsufficientFunds indicates that the wallet does not have enough balance for the operation.
 var ErrInsufficientFunds = errors.New("insufficient funds")
 
+// ErrWalletFrozen indicates that the wallet was frozen by an administrator and cannot pay or be paid.
+var ErrWalletFrozen = errors.New("wallet is frozen")
+
+// ErrWalletDebitBlocked indicates that an administrator blocked payments from the wallet.
+var ErrWalletDebitBlocked = errors.New("wallet is blocked from paying")
+
+// ErrWalletCreditBlocked indicates that an administrator blocked payments into the wallet.
+var ErrWalletCreditBlocked = errors.New("wallet is blocked from being paid")
+
 type WalletRepoImpl struct {
 	db *sqlx.DB
 }
@@ -27,7 +37,7 @@ func NewWalletImpl(db *sqlx.DB) *WalletRepoImpl {
 }
 
 // GetTransactionsByWalletID retrieves all transactions for a specific wallet.
-// It also checks if the wallet belongs to the given user for authorization.
+// It also checks that the given user is a member of the wallet for authorization.
 func (wr *WalletRepoImpl) GetTransactionsByWalletID(ctx context.Context, userIDStr string, walletIDStr string) ([]model.Transaction, error) {
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
@@ -39,19 +49,13 @@ func (wr *WalletRepoImpl) GetTransactionsByWalletID(ctx context.Context, userIDS
 		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
 	}
 
-	// First, verify the wallet exists and belongs to the user
-	var walletExists bool
-	checkWalletQuery := `SELECT EXISTS(SELECT 1 FROM wallets WHERE id = $1 AND user_id = $2)`
-	err = wr.db.GetContext(ctx, &walletExists, checkWalletQuery, walletID, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check wallet existence: %w", err)
-	}
-	if !walletExists {
-		return nil, ErrWalletNotFound // Or a more specific "access denied" / "wallet not found for user"
+	// First, verify the wallet exists and the user is a member of it
+	if err = checkWalletAccess(ctx, wr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
 	}
 
 	var transactions []model.Transaction
-	query := `SELECT id, wallet_id, type, amount, related_wallet_id, created_at
+	query := `SELECT id, wallet_id, type, amount, related_wallet_id, created_at, initiated_by, fee_schedule_id, parent_transaction_id
               FROM transactions
               WHERE wallet_id = $1
               ORDER BY created_at DESC` // Order by most recent
@@ -79,12 +83,20 @@ func (wr *WalletRepoImpl) GetWalletInfo(ctx context.Context, userIDStr string, w
 		return nil, errors.New("invalid wallet ID format")
 	}
 
+	if err = checkWalletAccess(ctx, wr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		if errors.Is(err, ErrWalletNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
 	var wallet model.Wallet
-	query := `SELECT id, user_id, name, balance, created_at, updated_at
+	query := `SELECT id, user_id, name, balance, pots_balance, held_balance, status, status_reason, status_changed_by,
+                     status_changed_at, status_expires_at, created_at, updated_at, product_id
               FROM wallets
-              WHERE user_id = $1 AND id = $2`
+              WHERE id = $1`
 
-	err = wr.db.GetContext(ctx, &wallet, query, userID, walletID)
+	err = wr.db.GetContext(ctx, &wallet, query, walletID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -105,24 +117,30 @@ func (wr *WalletRepoImpl) Deposit(ctx context.Context, userIDStr string, walletI
 		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
 	}
 
-	tx, err := wr.db.BeginTxx(ctx, nil)
+	tx, err := beginTx(ctx, wr.db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback() // Rollback if not committed
 
-	// 1. Retrieve and lock the wallet row
+	// 1. Check the user may pay into the wallet, then retrieve and lock the wallet row
+	if err = checkWalletAccess(ctx, tx, userID, walletID, model.WalletRoleSpender); err != nil {
+		return nil, err
+	}
 	var wallet model.Wallet
-	queryWallet := `SELECT id, user_id, name, balance, created_at, updated_at
+	queryWallet := `SELECT id, user_id, name, balance, status, status_expires_at, created_at, updated_at
                     FROM wallets
-                    WHERE user_id = $1 AND id = $2 FOR UPDATE`
-	err = tx.GetContext(ctx, &wallet, queryWallet, userID, walletID)
+                    WHERE id = $1 FOR UPDATE`
+	err = lockWalletTx(ctx, tx, &wallet, queryWallet, walletID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrWalletNotFound
 		}
 		return nil, fmt.Errorf("failed to retrieve wallet for deposit: %w", err)
 	}
+	if err = checkWalletCredit(wallet, time.Now()); err != nil {
+		return nil, err
+	}
 
 	// 2. Update wallet balance
 	newBalance := wallet.Balance.Add(amount)
@@ -134,15 +152,16 @@ func (wr *WalletRepoImpl) Deposit(ctx context.Context, userIDStr string, walletI
 
 	// 3. Create transaction record
 	transaction := &model.Transaction{
-		ID:        uuid.New(),
-		WalletID:  walletID,
-		Type:      model.TransactionTypeDeposit,
-		Amount:    amount,
-		CreatedAt: time.Now(),
-	}
-	insertTxQuery := `INSERT INTO transactions (id, wallet_id, type, amount, created_at)
-                      VALUES ($1, $2, $3, $4, $5)`
-	_, err = tx.ExecContext(ctx, insertTxQuery, transaction.ID, transaction.WalletID, transaction.Type, transaction.Amount, transaction.CreatedAt)
+		ID:          uuid.New(),
+		WalletID:    walletID,
+		Type:        model.TransactionTypeDeposit,
+		Amount:      amount,
+		CreatedAt:   time.Now(),
+		InitiatedBy: &userID,
+	}
+	insertTxQuery := `INSERT INTO transactions (id, wallet_id, type, amount, created_at, initiated_by)
+                      VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = tx.ExecContext(ctx, insertTxQuery, transaction.ID, transaction.WalletID, transaction.Type, transaction.Amount, transaction.CreatedAt, transaction.InitiatedBy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create deposit transaction record: %w", err)
 	}
@@ -155,7 +174,8 @@ func (wr *WalletRepoImpl) Deposit(ctx context.Context, userIDStr string, walletI
 }
 
 // Withdraw removes funds from a wallet and creates a transaction record.
-func (wr *WalletRepoImpl) Withdraw(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal) (*model.Transaction, error) {
+// A non-zero fee is charged in the same database transaction; the wallet must cover amount plus fee.
+func (wr *WalletRepoImpl) Withdraw(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal, fee model.Fee) (*model.Transaction, error) {
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID format: %w", err)
@@ -165,27 +185,33 @@ func (wr *WalletRepoImpl) Withdraw(ctx context.Context, userIDStr string, wallet
 		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
 	}
 
-	tx, err := wr.db.BeginTxx(ctx, nil)
+	tx, err := beginTx(ctx, wr.db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	// 1. Retrieve and lock the wallet row
+	// 1. Check the user may spend from the wallet, then retrieve and lock the wallet row
+	if err = checkWalletAccess(ctx, tx, userID, walletID, model.WalletRoleSpender); err != nil {
+		return nil, err
+	}
 	var wallet model.Wallet
-	queryWallet := `SELECT id, user_id, name, balance, created_at, updated_at
+	queryWallet := `SELECT id, user_id, name, balance, pots_balance, held_balance, status, status_expires_at, created_at, updated_at
                     FROM wallets
-                    WHERE user_id = $1 AND id = $2 FOR UPDATE`
-	err = tx.GetContext(ctx, &wallet, queryWallet, userID, walletID)
+                    WHERE id = $1 FOR UPDATE`
+	err = lockWalletTx(ctx, tx, &wallet, queryWallet, walletID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrWalletNotFound
 		}
 		return nil, fmt.Errorf("failed to retrieve wallet for withdrawal: %w", err)
 	}
+	if err = checkWalletDebit(wallet, time.Now()); err != nil {
+		return nil, err
+	}
 
-	// 2. Check for sufficient funds
-	if wallet.Balance.LessThan(amount) {
+	// 2. Check for sufficient funds; money in pots cannot be withdrawn
+	if wallet.Available().LessThan(amount.Add(fee.Amount)) {
 		return nil, ErrInsufficientFunds
 	}
 
@@ -199,19 +225,25 @@ func (wr *WalletRepoImpl) Withdraw(ctx context.Context, userIDStr string, wallet
 
 	// 4. Create transaction record
 	transaction := &model.Transaction{
-		ID:        uuid.New(),
-		WalletID:  walletID,
-		Type:      model.TransactionTypeWithdrawal,
-		Amount:    amount,
-		CreatedAt: time.Now(),
-	}
-	insertTxQuery := `INSERT INTO transactions (id, wallet_id, type, amount, created_at)
-                      VALUES ($1, $2, $3, $4, $5)`
-	_, err = tx.ExecContext(ctx, insertTxQuery, transaction.ID, transaction.WalletID, transaction.Type, transaction.Amount, transaction.CreatedAt)
+		ID:          uuid.New(),
+		WalletID:    walletID,
+		Type:        model.TransactionTypeWithdrawal,
+		Amount:      amount,
+		CreatedAt:   time.Now(),
+		InitiatedBy: &userID,
+	}
+	insertTxQuery := `INSERT INTO transactions (id, wallet_id, type, amount, created_at, initiated_by)
+                      VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = tx.ExecContext(ctx, insertTxQuery, transaction.ID, transaction.WalletID, transaction.Type, transaction.Amount, transaction.CreatedAt, transaction.InitiatedBy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create withdrawal transaction record: %w", err)
 	}
 
+	// 5. Charge the fee
+	if transaction.Fee, err = chargeFeeTx(ctx, tx, walletID, transaction.ID, userID, fee); err != nil {
+		return nil, err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit withdrawal transaction: %w", err)
 	}
@@ -220,7 +252,8 @@ func (wr *WalletRepoImpl) Withdraw(ctx context.Context, userIDStr string, wallet
 }
 
 // Transfer moves funds from a source wallet to a destination wallet and creates a transaction record.
-func (wr *WalletRepoImpl) Transfer(ctx context.Context, sourceUserIDStr string, sourceWalletIDStr string, destinationWalletIDStr string, amount decimal.Decimal) (*model.Transaction, error) {
+// A non-zero fee is charged to the source wallet in the same database transaction.
+func (wr *WalletRepoImpl) Transfer(ctx context.Context, sourceUserIDStr string, sourceWalletIDStr string, destinationWalletIDStr string, amount decimal.Decimal, fee model.Fee) (*model.Transaction, error) {
 	sourceUserID, err := uuid.Parse(sourceUserIDStr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid source user ID format: %w", err)
@@ -238,45 +271,107 @@ func (wr *WalletRepoImpl) Transfer(ctx context.Context, sourceUserIDStr string,
 		return nil, errors.New("source and destination wallets cannot be the same")
 	}
 
-	tx, err := wr.db.BeginTxx(ctx, nil)
+	tx, err := beginTx(ctx, wr.db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	transaction, err := transferTx(ctx, tx, sourceUserID, sourceWalletID, destinationWalletID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Fee, err = chargeFeeTx(ctx, tx, sourceWalletID, transaction.ID, sourceUserID, fee); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transfer transaction: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// transferTx performs a transfer inside an existing database transaction.
+// sourceUserID must be allowed to spend from the source wallet; both wallet rows are locked FOR UPDATE.
+// ErrApprovalRequired is returned if the wallet's approval policy requires the transfer to be proposed instead.
+// It is shared by Transfer and by callers that need several transfers to commit atomically.
+func transferTx(ctx context.Context, tx *sqlx.Tx, sourceUserID, sourceWalletID, destinationWalletID uuid.UUID, amount decimal.Decimal) (*model.Transaction, error) {
+	if sourceWalletID == destinationWalletID {
+		return nil, errors.New("source and destination wallets cannot be the same")
+	}
+
+	// Check the user may spend from the source wallet and that the transfer does not need approval
+	if err := checkWalletAccess(ctx, tx, sourceUserID, sourceWalletID, model.WalletRoleSpender); err != nil {
+		if errors.Is(err, ErrWalletNotFound) {
+			return nil, fmt.Errorf("source wallet not found: %w", err)
+		}
+		return nil, err
+	}
+	if err := checkApprovalPolicyTx(ctx, tx, sourceWalletID, amount); err != nil {
+		return nil, err
+	}
+
+	return moveFundsTx(ctx, tx, &sourceUserID, sourceWalletID, destinationWalletID, amount)
+}
+
+// moveFundsTx moves amount from the source to the destination wallet and records the transfer as initiated by
+// initiatedBy, or by the system when it is nil, without checking who may do so. Both wallet rows are locked FOR UPDATE.
+func moveFundsTx(ctx context.Context, tx *sqlx.Tx, initiatedBy *uuid.UUID, sourceWalletID, destinationWalletID uuid.UUID, amount decimal.Decimal) (*model.Transaction, error) {
 	// 1. Retrieve and lock the source wallet
 	var sourceWallet model.Wallet
 	// Ensure wallets are locked in a consistent order (e.g., by ID) to prevent deadlocks if concurrent transfers happen between the same two wallets in reverse.
 	// For simplicity here, we assume different users or infrequent enough operations that deadlock isn't an immediate major concern for this example.
 	// A robust solution would involve sorting wallet IDs before locking.
-	querySourceWallet := `SELECT id, user_id, name, balance, created_at, updated_at
+	querySourceWallet := `SELECT id, user_id, name, balance, pots_balance, held_balance, status, status_expires_at, created_at, updated_at
                           FROM wallets
-                          WHERE user_id = $1 AND id = $2 FOR UPDATE`
-	err = tx.GetContext(ctx, &sourceWallet, querySourceWallet, sourceUserID, sourceWalletID)
+                          WHERE id = $1 FOR UPDATE`
+	err := lockWalletTx(ctx, tx, &sourceWallet, querySourceWallet, sourceWalletID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("source wallet not found: %w", ErrWalletNotFound)
 		}
 		return nil, fmt.Errorf("failed to retrieve source wallet for transfer: %w", err)
 	}
+	if err = checkWalletDebit(sourceWallet, time.Now()); err != nil {
+		return nil, fmt.Errorf("source wallet: %w", err)
+	}
 
-	// 2. Check for sufficient funds in source wallet
-	if sourceWallet.Balance.LessThan(amount) {
+	// A scheduled transfer run is paid at most once. An attempt that paid it holds the source wallet lock until it
+	// commits, so a later attempt sees its transaction here; the unique index on the run backs this up.
+	runID, _ := ctx.Value(scheduledTransferRunKey{}).(*uuid.UUID)
+	if runID != nil {
+		var paid bool
+		err = tx.GetContext(ctx, &paid, `SELECT EXISTS (SELECT 1 FROM transactions WHERE scheduled_transfer_run_id = $1)`, *runID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check scheduled transfer run payment: %w", err)
+		}
+		if paid {
+			return nil, ErrScheduledTransferRunPaid
+		}
+	}
+
+	// 2. Check for sufficient funds in source wallet; money in pots or held cannot be transferred
+	if sourceWallet.Available().LessThan(amount) {
 		return nil, ErrInsufficientFunds
 	}
 
 	// 3. Retrieve and lock the destination wallet
 	var destinationWallet model.Wallet
-	queryDestWallet := `SELECT id, user_id, name, balance, created_at, updated_at
+	queryDestWallet := `SELECT id, user_id, name, balance, status, status_expires_at, created_at, updated_at
                         FROM wallets
                         WHERE id = $1 FOR UPDATE` // Destination wallet can belong to any user
-	err = tx.GetContext(ctx, &destinationWallet, queryDestWallet, destinationWalletID)
+	err = lockWalletTx(ctx, tx, &destinationWallet, queryDestWallet, destinationWalletID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("destination wallet not found: %w", ErrWalletNotFound)
 		}
 		return nil, fmt.Errorf("failed to retrieve destination wallet for transfer: %w", err)
 	}
+	if err = checkWalletCredit(destinationWallet, time.Now()); err != nil {
+		return nil, fmt.Errorf("destination wallet: %w", err)
+	}
 
 	// 4. Update source wallet balance
 	newSourceBalance := sourceWallet.Balance.Sub(amount)
@@ -302,17 +397,122 @@ func (wr *WalletRepoImpl) Transfer(ctx context.Context, sourceUserIDStr string,
 		Amount:          amount, // Amount is positive, representing outgoing from source
 		RelatedWalletID: &destinationWalletID,
 		CreatedAt:       time.Now(),
+		InitiatedBy:     initiatedBy,
 	}
-	insertTxQuery := `INSERT INTO transactions (id, wallet_id, type, amount, related_wallet_id, created_at)
-                      VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err = tx.ExecContext(ctx, insertTxQuery, transaction.ID, transaction.WalletID, transaction.Type, transaction.Amount, transaction.RelatedWalletID, transaction.CreatedAt)
+	insertTxQuery := `INSERT INTO transactions (id, wallet_id, type, amount, related_wallet_id, created_at, initiated_by, scheduled_transfer_run_id)
+                      VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err = tx.ExecContext(ctx, insertTxQuery, transaction.ID, transaction.WalletID, transaction.Type, transaction.Amount, transaction.RelatedWalletID,
+		transaction.CreatedAt, transaction.InitiatedBy, runID)
 	if err != nil {
+		var pqErr *pq.Error
+		if runID != nil && errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, ErrScheduledTransferRunPaid
+		}
 		return nil, fmt.Errorf("failed to create transfer transaction record: %w", err)
 	}
 
-	if err = tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transfer transaction: %w", err)
+	return transaction, nil
+}
+
+type scheduledTransferRunKey struct{}
+
+// WithScheduledTransferRun returns a copy of ctx under which the transfer the wallet repository makes is recorded as
+// the payment of the scheduled transfer run runID. Each run is paid at most once: a second transfer for it fails
+// with ErrScheduledTransferRunPaid and moves no money.
+func WithScheduledTransferRun(ctx context.Context, runID uuid.UUID) context.Context {
+	return context.WithValue(ctx, scheduledTransferRunKey{}, &runID)
+}
+
+type lockWaitObserverKey struct{}
+
+// WithLockWaitObserver returns a copy of ctx under which the wallet repository tells observe how long each wallet
+// row it locked FOR UPDATE took to read, lock wait included, to measure contention on hot wallets.
+func WithLockWaitObserver(ctx context.Context, observe func(time.Duration)) context.Context {
+	return context.WithValue(ctx, lockWaitObserverKey{}, observe)
+}
+
+// lockWalletTx reads the wallet walletID into dest with query, a SELECT ... FOR UPDATE, and reports how long it
+// took to the observer of ctx, if any.
+func lockWalletTx(ctx context.Context, tx *sqlx.Tx, dest *model.Wallet, query string, walletID uuid.UUID) error {
+	start := time.Now()
+	err := tx.GetContext(ctx, dest, query, walletID)
+	if observe, ok := ctx.Value(lockWaitObserverKey{}).(func(time.Duration)); ok {
+		observe(time.Since(start))
+	}
+	return err
+}
+
+// checkWalletDebit returns why the status of a locked wallet does not allow it to pay at now, or nil if it does.
+func checkWalletDebit(wallet model.Wallet, now time.Time) error {
+	switch status := wallet.EffectiveStatus(now); {
+	case status == model.WalletStatusFrozen:
+		return ErrWalletFrozen
+	case !status.AllowsDebit():
+		return ErrWalletDebitBlocked
+	}
+	return nil
+}
+
+// checkWalletCredit returns why the status of a locked wallet does not allow it to be paid at now, or nil if it does.
+func checkWalletCredit(wallet model.Wallet, now time.Time) error {
+	switch status := wallet.EffectiveStatus(now); {
+	case status == model.WalletStatusFrozen:
+		return ErrWalletFrozen
+	case !status.AllowsCredit():
+		return ErrWalletCreditBlocked
+	}
+	return nil
+}
+
+// chargeFeeTx moves fee.Amount from walletID to the fee wallet inside an existing database transaction and
+// records it as a fee transaction linked to the operation parentID and initiated by initiatedBy. A zero fee is not recorded.
+// walletID must already be locked by the caller; ErrInsufficientFunds is returned if the money outside its
+// pots and holds cannot cover the fee.
+// The fee wallet is never charged fees.
+func chargeFeeTx(ctx context.Context, tx *sqlx.Tx, walletID uuid.UUID, parentID uuid.UUID, initiatedBy uuid.UUID, fee model.Fee) (*model.Transaction, error) {
+	if !fee.Amount.IsPositive() {
+		return nil, nil
+	}
+	if walletID == fee.WalletID {
+		return nil, nil
+	}
+
+	now := time.Now()
+	debitQuery := `UPDATE wallets SET balance = balance - $1, updated_at = $2 WHERE id = $3 AND balance - pots_balance - held_balance >= $1`
+	res, err := tx.ExecContext(ctx, debitQuery, fee.Amount, now, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to debit fee: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrInsufficientFunds
+	}
+
+	creditQuery := `UPDATE wallets SET balance = balance + $1, updated_at = $2 WHERE id = $3`
+	res, err = tx.ExecContext(ctx, creditQuery, fee.Amount, now, fee.WalletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to credit fee wallet: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, fmt.Errorf("fee wallet not found: %w", ErrWalletNotFound)
 	}
 
+	transaction := &model.Transaction{
+		ID:                  uuid.New(),
+		WalletID:            walletID,
+		Type:                model.TransactionTypeFee,
+		Amount:              fee.Amount,
+		RelatedWalletID:     &fee.WalletID,
+		CreatedAt:           now,
+		InitiatedBy:         &initiatedBy,
+		FeeScheduleID:       fee.ScheduleID,
+		ParentTransactionID: &parentID,
+	}
+	insertTxQuery := `INSERT INTO transactions (id, wallet_id, type, amount, related_wallet_id, created_at, initiated_by, fee_schedule_id, parent_transaction_id)
+                      VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err = tx.ExecContext(ctx, insertTxQuery, transaction.ID, transaction.WalletID, transaction.Type, transaction.Amount,
+		transaction.RelatedWalletID, transaction.CreatedAt, transaction.InitiatedBy, transaction.FeeScheduleID, transaction.ParentTransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fee transaction record: %w", err)
+	}
 	return transaction, nil
 }
@@ -0,0 +1,298 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+var (
+	// ErrWalletForbidden indicates that the user is a member of the wallet but its role does not allow the operation.
+	ErrWalletForbidden = errors.New("wallet role does not allow this operation")
+	// ErrWalletMemberNotFound indicates that the user is not a member of, nor invited to, the wallet.
+	ErrWalletMemberNotFound = errors.New("wallet member not found")
+	// ErrWalletMemberExists indicates that the user is already a member of, or invited to, the wallet.
+	ErrWalletMemberExists = errors.New("user is already a member of this wallet")
+	// ErrLastWalletOwner indicates an attempt to remove or demote the only owner of a wallet.
+	ErrLastWalletOwner = errors.New("a wallet must keep at least one owner")
+)
+
+// checkWalletAccess returns ErrWalletNotFound unless userID is an active member of walletID, so the wallet's existence
+// is not revealed to others, and ErrWalletForbidden unless the member's role is at least min.
+// q is the database or the transaction the caller goes on to use the wallet in.
+func checkWalletAccess(ctx context.Context, q sqlx.QueryerContext, userID, walletID uuid.UUID, min model.WalletRole) error {
+	var role model.WalletRole
+	query := `SELECT role FROM wallet_members WHERE wallet_id = $1 AND user_id = $2 AND status = 'active'`
+	if err := sqlx.GetContext(ctx, q, &role, query, walletID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrWalletNotFound
+		}
+		return fmt.Errorf("failed to check wallet membership: %w", err)
+	}
+	if !role.Allows(min) {
+		return ErrWalletForbidden
+	}
+	return nil
+}
+
+const walletMemberColumns = `m.wallet_id, m.user_id, m.role, m.status, m.invited_by, m.created_at, m.updated_at`
+
+type WalletMemberRepoImpl struct {
+	db *sqlx.DB
+}
+
+func NewWalletMemberImpl(db *sqlx.DB) *WalletMemberRepoImpl {
+	return &WalletMemberRepoImpl{db}
+}
+
+// ListWalletMembers returns the members of, and users invited to, a wallet the user can see.
+func (mr *WalletMemberRepoImpl) ListWalletMembers(ctx context.Context, userIDStr string, walletIDStr string) ([]model.WalletMember, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	if err = checkWalletAccess(ctx, mr.db, userID, walletID, model.WalletRoleViewer); err != nil {
+		return nil, err
+	}
+
+	members := []model.WalletMember{}
+	query := `SELECT ` + walletMemberColumns + `, u.name AS user_name
+              FROM wallet_members m
+              JOIN users u ON u.id = m.user_id
+              WHERE m.wallet_id = $1
+              ORDER BY m.created_at, m.user_id`
+	if err = mr.db.SelectContext(ctx, &members, query, walletID); err != nil {
+		return nil, fmt.Errorf("database error retrieving wallet members: %w", err)
+	}
+	return members, nil
+}
+
+// InviteWalletMember invites a user to the wallet with a role. Only owners may invite.
+func (mr *WalletMemberRepoImpl) InviteWalletMember(ctx context.Context, userIDStr string, member *model.WalletMember) error {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+	if err = checkWalletAccess(ctx, mr.db, userID, member.WalletID, model.WalletRoleOwner); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO wallet_members (wallet_id, user_id, role, status, invited_by, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $6)`
+	_, err = mr.db.ExecContext(ctx, query, member.WalletID, member.UserID, member.Role, model.WalletMemberStatusInvited, userID, member.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return ErrWalletMemberExists
+		}
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to invite wallet member: %w", err)
+	}
+	member.Status = model.WalletMemberStatusInvited
+	member.InvitedBy = &userID
+	member.UpdatedAt = member.CreatedAt
+	return nil
+}
+
+// UpdateWalletMemberRole changes the role of a member. Only owners may do it, and the last owner cannot be demoted.
+func (mr *WalletMemberRepoImpl) UpdateWalletMemberRole(ctx context.Context, userIDStr string, walletIDStr string, memberIDStr string, role model.WalletRole, at time.Time) (*model.WalletMember, error) {
+	userID, walletID, memberID, err := parseWalletMemberIDs(userIDStr, walletIDStr, memberIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := beginTx(ctx, mr.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the memberships before checking the caller is an owner, so a concurrent change cannot take the role away
+	// between the check and the update.
+	members, err := lockWalletMembersTx(ctx, tx, walletID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkWalletAccess(ctx, tx, userID, walletID, model.WalletRoleOwner); err != nil {
+		return nil, err
+	}
+	current, err := findWalletMember(members, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if role != model.WalletRoleOwner {
+		if err = checkNotLastOwnerTx(ctx, tx, walletID, current); err != nil {
+			return nil, err
+		}
+	}
+
+	var member model.WalletMember
+	query := `UPDATE wallet_members m SET role = $1, updated_at = $2
+              WHERE m.wallet_id = $3 AND m.user_id = $4
+              RETURNING ` + walletMemberColumns
+	if err = tx.GetContext(ctx, &member, query, role, at, walletID, memberID); err != nil {
+		return nil, fmt.Errorf("failed to update wallet member role: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit wallet member role: %w", err)
+	}
+	return &member, nil
+}
+
+// RemoveWalletMember removes a member or withdraws an invitation. Owners may remove anyone and any member may
+// remove themselves (leave, or decline an invitation); the last owner cannot be removed.
+func (mr *WalletMemberRepoImpl) RemoveWalletMember(ctx context.Context, userIDStr string, walletIDStr string, memberIDStr string) error {
+	userID, walletID, memberID, err := parseWalletMemberIDs(userIDStr, walletIDStr, memberIDStr)
+	if err != nil {
+		return err
+	}
+
+	tx, err := beginTx(ctx, mr.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the memberships before checking the caller is an owner, as UpdateWalletMemberRole does.
+	members, err := lockWalletMembersTx(ctx, tx, walletID)
+	if err != nil {
+		return err
+	}
+	if userID != memberID {
+		if err = checkWalletAccess(ctx, tx, userID, walletID, model.WalletRoleOwner); err != nil {
+			return err
+		}
+	}
+	current, err := findWalletMember(members, memberID)
+	if err != nil {
+		return err
+	}
+	if err = checkNotLastOwnerTx(ctx, tx, walletID, current); err != nil {
+		return err
+	}
+
+	query := `DELETE FROM wallet_members WHERE wallet_id = $1 AND user_id = $2`
+	if _, err = tx.ExecContext(ctx, query, walletID, memberID); err != nil {
+		return fmt.Errorf("failed to remove wallet member: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit wallet member removal: %w", err)
+	}
+	return nil
+}
+
+// ListWalletInvitations returns the wallets the user has been invited to and not answered yet.
+func (mr *WalletMemberRepoImpl) ListWalletInvitations(ctx context.Context, userIDStr string) ([]model.WalletMember, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	invitations := []model.WalletMember{}
+	query := `SELECT ` + walletMemberColumns + `, w.name AS wallet_name
+              FROM wallet_members m
+              JOIN wallets w ON w.id = m.wallet_id
+              WHERE m.user_id = $1 AND m.status = $2
+              ORDER BY m.created_at`
+	if err = mr.db.SelectContext(ctx, &invitations, query, userID, model.WalletMemberStatusInvited); err != nil {
+		return nil, fmt.Errorf("database error retrieving wallet invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+// AcceptWalletInvitation makes the user an active member of a wallet they were invited to.
+func (mr *WalletMemberRepoImpl) AcceptWalletInvitation(ctx context.Context, userIDStr string, walletIDStr string, at time.Time) (*model.WalletMember, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	var member model.WalletMember
+	query := `UPDATE wallet_members m SET status = $1, updated_at = $2
+              WHERE m.wallet_id = $3 AND m.user_id = $4 AND m.status = $5
+              RETURNING ` + walletMemberColumns
+	err = mr.db.GetContext(ctx, &member, query, model.WalletMemberStatusActive, at, walletID, userID, model.WalletMemberStatusInvited)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWalletMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to accept wallet invitation: %w", err)
+	}
+	return &member, nil
+}
+
+func parseWalletMemberIDs(userIDStr string, walletIDStr string, memberIDStr string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	walletID, err := uuid.Parse(walletIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	memberID, err := uuid.Parse(memberIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid member user ID format: %w", err)
+	}
+	return userID, walletID, memberID, nil
+}
+
+// lockWalletMembersTx locks and returns every membership of the wallet, so concurrent changes cannot together
+// remove the last owner, nor act on a role another change has just taken away.
+func lockWalletMembersTx(ctx context.Context, tx *sqlx.Tx, walletID uuid.UUID) ([]model.WalletMember, error) {
+	var members []model.WalletMember
+	query := `SELECT ` + walletMemberColumns + `
+              FROM wallet_members m
+              WHERE m.wallet_id = $1
+              FOR UPDATE`
+	if err := tx.SelectContext(ctx, &members, query, walletID); err != nil {
+		return nil, fmt.Errorf("failed to lock wallet members: %w", err)
+	}
+	return members, nil
+}
+
+// findWalletMember returns the membership of memberID among members.
+func findWalletMember(members []model.WalletMember, memberID uuid.UUID) (*model.WalletMember, error) {
+	for i := range members {
+		if members[i].UserID == memberID {
+			return &members[i], nil
+		}
+	}
+	return nil, ErrWalletMemberNotFound
+}
+
+// checkNotLastOwnerTx returns ErrLastWalletOwner if member is the only active owner of the wallet.
+func checkNotLastOwnerTx(ctx context.Context, tx *sqlx.Tx, walletID uuid.UUID, member *model.WalletMember) error {
+	if member.Role != model.WalletRoleOwner || member.Status != model.WalletMemberStatusActive {
+		return nil
+	}
+	var owners int
+	query := `SELECT COUNT(*) FROM wallet_members WHERE wallet_id = $1 AND role = $2 AND status = $3`
+	if err := tx.GetContext(ctx, &owners, query, walletID, model.WalletRoleOwner, model.WalletMemberStatusActive); err != nil {
+		return fmt.Errorf("failed to count wallet owners: %w", err)
+	}
+	if owners <= 1 {
+		return ErrLastWalletOwner
+	}
+	return nil
+}
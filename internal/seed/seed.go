@@ -0,0 +1,197 @@
+// Package seed generates users, wallets and transaction histories for load and demo environments.
+//
+// The same Options always generate the same data, IDs included. Histories are consistent: every wallet's balance
+// is the sum of its transactions, and no withdrawal or transfer ever takes a wallet below zero.
+package seed
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+// Options describe the data to generate.
+type Options struct {
+	// Seed selects the data; the same seed generates the same data.
+	Seed uint64
+	// Users is the number of users.
+	Users int
+	// WalletsPerUser is the number of wallets each user has.
+	WalletsPerUser int
+	// TransactionsPerWallet is the number of deposits, withdrawals and outgoing transfers each wallet makes.
+	TransactionsPerWallet int
+	// Start is when the first user signs up, and Days how long the history runs from then.
+	Start time.Time
+	Days  int
+}
+
+// Dataset is the generated data. Transactions are in the order they were made.
+type Dataset struct {
+	Users        []model.User
+	Wallets      []model.Wallet
+	Transactions []model.Transaction
+}
+
+var (
+	firstNames = []string{"Alice", "Bob", "Charlie", "Diana", "Ethan", "Fiona", "George", "Hannah", "Ivan", "Julia",
+		"Kevin", "Linh", "Minh", "Nora", "Oscar", "Priya", "Quang", "Rosa", "Sam", "Thao", "Umar", "Vy", "Will", "Yen"}
+	lastNames = []string{"Smith", "Johnson", "Brown", "Nguyen", "Tran", "Garcia", "Miller", "Davis", "Le", "Wilson",
+		"Pham", "Taylor", "Anderson", "Thomas", "Hoang", "Martin", "Lee", "Walker", "Hall", "Young"}
+	walletNames = []string{"Main", "Savings", "Bills", "Travel", "Groceries", "Rainy Day", "Household", "Holiday"}
+)
+
+// Generate returns the dataset described by opts.
+func Generate(opts Options) (*Dataset, error) {
+	if opts.Users < 0 || opts.WalletsPerUser < 0 || opts.TransactionsPerWallet < 0 {
+		return nil, fmt.Errorf("users, wallets and transactions must not be negative")
+	}
+	if opts.Days <= 0 {
+		return nil, fmt.Errorf("days must be positive")
+	}
+	g := &generator{
+		rng:   rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15)),
+		start: opts.Start.UTC().Truncate(time.Second),
+		span:  time.Duration(opts.Days) * 24 * time.Hour,
+	}
+	d := &Dataset{
+		Users:        make([]model.User, 0, opts.Users),
+		Wallets:      make([]model.Wallet, 0, opts.Users*opts.WalletsPerUser),
+		Transactions: make([]model.Transaction, 0, opts.Users*opts.WalletsPerUser*opts.TransactionsPerWallet),
+	}
+
+	// Users sign up during the first quarter of the period and open their wallets an hour apart, so the first
+	// wallet is the default one. Emails carry the seed, so data of different seeds can share a database.
+	for i := 0; i < opts.Users; i++ {
+		first, last := pick(g.rng, firstNames), pick(g.rng, lastNames)
+		user := model.User{
+			ID:        g.uuid(),
+			Name:      first + " " + last,
+			Email:     fmt.Sprintf("%s.%s+%d-%d@example.com", strings.ToLower(first), strings.ToLower(last), opts.Seed, i+1),
+			CreatedAt: g.start.Add(g.duration(g.span / 4)),
+		}
+		for j := 0; j < opts.WalletsPerUser; j++ {
+			createdAt := user.CreatedAt.Add(time.Duration(j) * time.Hour)
+			wallet := model.Wallet{
+				ID:        g.uuid(),
+				UserID:    user.ID,
+				Name:      walletNames[j%len(walletNames)],
+				Balance:   decimal.Zero,
+				Status:    model.WalletStatusActive,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+			}
+			if j == 0 {
+				user.DefaultWalletID = &wallet.ID
+			}
+			d.Wallets = append(d.Wallets, wallet)
+		}
+		d.Users = append(d.Users, user)
+	}
+
+	type event struct {
+		at     time.Time
+		wallet int
+	}
+	events := make([]event, 0, cap(d.Transactions))
+	end := g.start.Add(g.span)
+	for i, w := range d.Wallets {
+		for k := 0; k < opts.TransactionsPerWallet; k++ {
+			events = append(events, event{at: w.CreatedAt.Add(time.Minute + g.duration(end.Sub(w.CreatedAt)-time.Minute)), wallet: i})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].at.Equal(events[j].at) {
+			return events[i].at.Before(events[j].at)
+		}
+		return events[i].wallet < events[j].wallet
+	})
+
+	for _, e := range events {
+		w := &d.Wallets[e.wallet]
+		t := model.Transaction{ID: g.uuid(), WalletID: w.ID, CreatedAt: e.at, InitiatedBy: &w.UserID}
+
+		roll := g.rng.IntN(100)
+		dest := -1
+		if roll >= 65 {
+			dest = g.destination(d.Wallets, e.wallet, e.at)
+		}
+		switch {
+		case w.Balance.LessThan(decimal.NewFromInt(1)) || roll < 40:
+			t.Type, t.Amount = model.TransactionTypeDeposit, g.cents(1000, 200000)
+			w.Balance = w.Balance.Add(t.Amount)
+		case roll < 65 || dest < 0:
+			t.Type, t.Amount = model.TransactionTypeWithdrawal, g.share(w.Balance, 50)
+			w.Balance = w.Balance.Sub(t.Amount)
+		default:
+			to := &d.Wallets[dest]
+			t.Type, t.Amount, t.RelatedWalletID = model.TransactionTypeTransfer, g.share(w.Balance, 30), &to.ID
+			w.Balance = w.Balance.Sub(t.Amount)
+			to.Balance = to.Balance.Add(t.Amount)
+			to.UpdatedAt = e.at
+		}
+		w.UpdatedAt = e.at
+		d.Transactions = append(d.Transactions, t)
+	}
+	return d, nil
+}
+
+type generator struct {
+	rng   *rand.Rand
+	start time.Time
+	span  time.Duration
+}
+
+// uuid returns a version 4 UUID drawn from the generator, so IDs are deterministic too.
+func (g *generator) uuid() uuid.UUID {
+	var id uuid.UUID
+	binary.BigEndian.PutUint64(id[:8], g.rng.Uint64())
+	binary.BigEndian.PutUint64(id[8:], g.rng.Uint64())
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return id
+}
+
+// duration returns a whole number of seconds in [0, max).
+func (g *generator) duration(max time.Duration) time.Duration {
+	if max < time.Second {
+		return 0
+	}
+	return time.Duration(g.rng.Int64N(int64(max/time.Second))) * time.Second
+}
+
+// cents returns an amount between min and max cents.
+func (g *generator) cents(min, max int64) decimal.Decimal {
+	return decimal.New(min+g.rng.Int64N(max-min+1), -2)
+}
+
+// share returns an amount of at least 1.00 and at most percent of balance, which must be at least 1.00.
+func (g *generator) share(balance decimal.Decimal, percent int64) decimal.Decimal {
+	max := balance.Mul(decimal.New(percent, -2)).Shift(2).IntPart()
+	if max < 100 {
+		return decimal.New(100, -2)
+	}
+	return g.cents(100, max)
+}
+
+// destination picks a wallet other than from that exists at, or returns -1 if it finds none in a few tries.
+func (g *generator) destination(wallets []model.Wallet, from int, at time.Time) int {
+	for try := 0; try < 5; try++ {
+		i := g.rng.IntN(len(wallets))
+		if i != from && !wallets[i].CreatedAt.After(at) {
+			return i
+		}
+	}
+	return -1
+}
+
+func pick(rng *rand.Rand, names []string) string {
+	return names[rng.IntN(len(names))]
+}
@@ -0,0 +1,75 @@
+package seed_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/seed"
+)
+
+func options(seedValue uint64) seed.Options {
+	return seed.Options{
+		Seed:                  seedValue,
+		Users:                 30,
+		WalletsPerUser:        3,
+		TransactionsPerWallet: 15,
+		Start:                 time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Days:                  60,
+	}
+}
+
+func Test_Generate_Deterministic(t *testing.T) {
+	render := func(seedValue uint64) []byte {
+		d, err := seed.Generate(options(seedValue))
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, seed.WriteSQL(&buf, d))
+		return buf.Bytes()
+	}
+
+	assert.Equal(t, render(7), render(7))
+	assert.NotEqual(t, render(7), render(8))
+}
+
+func Test_Generate_ConsistentBalances(t *testing.T) {
+	for _, seedValue := range []uint64{1, 2, 3} {
+		d, err := seed.Generate(options(seedValue))
+		require.NoError(t, err)
+		require.Len(t, d.Users, 30)
+		require.Len(t, d.Wallets, 90)
+		require.Len(t, d.Transactions, 90*15)
+
+		created := map[uuid.UUID]time.Time{}
+		for _, w := range d.Wallets {
+			created[w.ID] = w.CreatedAt
+		}
+		running := map[uuid.UUID]decimal.Decimal{}
+		var last time.Time
+		for _, tx := range d.Transactions {
+			assert.False(t, tx.CreatedAt.Before(last), "transactions must be in time order")
+			last = tx.CreatedAt
+			assert.True(t, tx.Amount.IsPositive())
+			assert.True(t, tx.CreatedAt.After(created[tx.WalletID]))
+
+			running[tx.WalletID] = running[tx.WalletID].Add(tx.NetAmountFor(tx.WalletID))
+			assert.False(t, running[tx.WalletID].IsNegative(), "seed %d: wallet %s overdrawn", seedValue, tx.WalletID)
+			if tx.Type == model.TransactionTypeTransfer {
+				require.NotNil(t, tx.RelatedWalletID)
+				assert.NotEqual(t, tx.WalletID, *tx.RelatedWalletID)
+				assert.False(t, tx.CreatedAt.Before(created[*tx.RelatedWalletID]))
+				running[*tx.RelatedWalletID] = running[*tx.RelatedWalletID].Add(tx.NetAmountFor(*tx.RelatedWalletID))
+			}
+		}
+		for _, w := range d.Wallets {
+			assert.True(t, w.Balance.Equal(running[w.ID]), "seed %d: wallet %s balance %s, history sums to %s",
+				seedValue, w.ID, w.Balance, running[w.ID])
+		}
+	}
+}
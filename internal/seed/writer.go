@@ -0,0 +1,197 @@
+package seed
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+// batchSize is how many rows go into one INSERT.
+const batchSize = 1000
+
+// setDefaultWallets points every user without a default wallet at their first, as migration 005 does. It runs
+// after the wallets are in, since users and wallets reference each other.
+const setDefaultWallets = `UPDATE users u
+SET default_wallet_id = (SELECT w.id FROM wallets w WHERE w.user_id = u.id ORDER BY w.created_at, w.id LIMIT 1)
+WHERE u.default_wallet_id IS NULL`
+
+// Insert writes the dataset to the database in one transaction.
+func Insert(ctx context.Context, db *sqlx.DB, d *Dataset) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = insertBatches(ctx, tx, `INSERT INTO users (id, name, email, created_at) VALUES (:id, :name, :email, :created_at)`,
+		d.Users); err != nil {
+		return fmt.Errorf("failed to insert users: %w", err)
+	}
+	if err = insertBatches(ctx, tx, `INSERT INTO wallets (id, user_id, name, balance, created_at, updated_at)
+                                     VALUES (:id, :user_id, :name, :balance, :created_at, :updated_at)`, d.Wallets); err != nil {
+		return fmt.Errorf("failed to insert wallets: %w", err)
+	}
+	if err = insertBatches(ctx, tx, `INSERT INTO transactions (id, wallet_id, type, amount, related_wallet_id, created_at, initiated_by)
+                                     VALUES (:id, :wallet_id, :type, :amount, :related_wallet_id, :created_at, :initiated_by)`,
+		d.Transactions); err != nil {
+		return fmt.Errorf("failed to insert transactions: %w", err)
+	}
+	if _, err = tx.ExecContext(ctx, setDefaultWallets); err != nil {
+		return fmt.Errorf("failed to set default wallets: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit seed data: %w", err)
+	}
+	return nil
+}
+
+func insertBatches[T any](ctx context.Context, tx *sqlx.Tx, query string, rows []T) error {
+	for i := 0; i < len(rows); i += batchSize {
+		if _, err := tx.NamedExecContext(ctx, query, rows[i:min(i+batchSize, len(rows))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteSQL writes the dataset as a SQL script that inserts it in one transaction.
+func WriteSQL(w io.Writer, d *Dataset) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "-- Generated by cmd/seed: %d users, %d wallets, %d transactions.\nBEGIN;\n\n",
+		len(d.Users), len(d.Wallets), len(d.Transactions))
+
+	writeInserts(bw, "users (id, name, email, created_at)", len(d.Users), func(i int) []string {
+		u := d.Users[i]
+		return []string{quote(u.ID.String()), quote(u.Name), quote(u.Email), quote(timestamp(u.CreatedAt))}
+	})
+	writeInserts(bw, "wallets (id, user_id, name, balance, created_at, updated_at)", len(d.Wallets), func(i int) []string {
+		w := d.Wallets[i]
+		return []string{quote(w.ID.String()), quote(w.UserID.String()), quote(w.Name), w.Balance.StringFixed(2),
+			quote(timestamp(w.CreatedAt)), quote(timestamp(w.UpdatedAt))}
+	})
+	writeInserts(bw, "transactions (id, wallet_id, type, amount, related_wallet_id, created_at, initiated_by)", len(d.Transactions),
+		func(i int) []string {
+			row := transactionRow(d.Transactions[i])
+			for j, v := range row {
+				if j != 3 {
+					row[j] = quote(v)
+				}
+			}
+			return row
+		})
+
+	fmt.Fprintf(bw, "%s;\n\nCOMMIT;\n", setDefaultWallets)
+	return bw.Flush()
+}
+
+func writeInserts(w io.Writer, table string, n int, row func(i int) []string) {
+	for i := 0; i < n; i++ {
+		if i%batchSize == 0 {
+			fmt.Fprintf(w, "INSERT INTO %s VALUES\n", table)
+		}
+		sep := ",\n"
+		if i%batchSize == batchSize-1 || i == n-1 {
+			sep = ";\n\n"
+		}
+		fmt.Fprintf(w, "    (%s)%s", strings.Join(row(i), ", "), sep)
+	}
+}
+
+// quote renders s as a SQL string literal, or NULL when it is empty.
+func quote(s string) string {
+	if s == "" {
+		return "NULL"
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// WriteCSV writes the dataset to users.csv, wallets.csv and transactions.csv in dir, with a load.sql that loads
+// them with psql's \copy.
+func WriteCSV(dir string, d *Dataset) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	users := make([][]string, len(d.Users))
+	for i, u := range d.Users {
+		users[i] = []string{u.ID.String(), u.Name, u.Email, timestamp(u.CreatedAt)}
+	}
+	wallets := make([][]string, len(d.Wallets))
+	for i, w := range d.Wallets {
+		wallets[i] = []string{w.ID.String(), w.UserID.String(), w.Name, w.Balance.StringFixed(2), timestamp(w.CreatedAt), timestamp(w.UpdatedAt)}
+	}
+	transactions := make([][]string, len(d.Transactions))
+	for i, t := range d.Transactions {
+		transactions[i] = transactionRow(t)
+	}
+
+	files := []struct {
+		name   string
+		header []string
+		rows   [][]string
+	}{
+		{"users.csv", []string{"id", "name", "email", "created_at"}, users},
+		{"wallets.csv", []string{"id", "user_id", "name", "balance", "created_at", "updated_at"}, wallets},
+		{"transactions.csv", []string{"id", "wallet_id", "type", "amount", "related_wallet_id", "created_at", "initiated_by"}, transactions},
+	}
+	var load strings.Builder
+	load.WriteString("-- Generated by cmd/seed. Run with psql from this directory: psql -f load.sql\nBEGIN;\n")
+	for _, f := range files {
+		if err := writeCSVFile(filepath.Join(dir, f.name), f.header, f.rows); err != nil {
+			return err
+		}
+		fmt.Fprintf(&load, "\\copy %s (%s) FROM '%s' WITH (FORMAT csv, HEADER true)\n",
+			strings.TrimSuffix(f.name, ".csv"), strings.Join(f.header, ", "), f.name)
+	}
+	fmt.Fprintf(&load, "%s;\nCOMMIT;\n", setDefaultWallets)
+
+	if err := os.WriteFile(filepath.Join(dir, "load.sql"), []byte(load.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write load.sql: %w", err)
+	}
+	return nil
+}
+
+func writeCSVFile(path string, header []string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err = w.Write(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err = w.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// transactionRow renders a transaction as id, wallet_id, type, amount, related_wallet_id, created_at and
+// initiated_by, with empty strings for NULLs.
+func transactionRow(t model.Transaction) []string {
+	related, initiatedBy := "", ""
+	if t.RelatedWalletID != nil {
+		related = t.RelatedWalletID.String()
+	}
+	if t.InitiatedBy != nil {
+		initiatedBy = t.InitiatedBy.String()
+	}
+	return []string{t.ID.String(), t.WalletID.String(), string(t.Type), t.Amount.StringFixed(2), related, timestamp(t.CreatedAt), initiatedBy}
+}
+
+func timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
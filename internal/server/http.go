@@ -1,11 +1,21 @@
 package server
 
 import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/kylenguyen/wallet-app/internal/repo"
 	"github.com/kylenguyen/wallet-app/internal/service"
+	"io"
+	"net"
 	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,8 +26,15 @@ import (
 
 	"github.com/kylenguyen/wallet-app/internal/config"
 	"github.com/kylenguyen/wallet-app/internal/handler"
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/migrations"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+	"github.com/kylenguyen/wallet-app/pkg/restjson"
 )
 
+// requestIDKey is the gin context key ginZerolog stores the request ID under.
+const requestIDKey = "request-id"
+
 // Server represents the HTTP server.
 type Server struct {
 	engine *gin.Engine
@@ -25,47 +42,353 @@ type Server struct {
 	logger *zerolog.Logger
 	config config.Config
 	addr   string // Add addr to the struct
+	http   *http.Server
+
+	// stopWorkers is closed to stop the background jobs once their pass in progress is done, and cancelWorkers
+	// cancels the context those passes run with; workers tracks the jobs until they return.
+	stopWorkers   chan struct{}
+	cancelWorkers context.CancelFunc
+	workers       sync.WaitGroup
+	// transferBatches runs batches in the background, past the requests that created them.
+	transferBatches *service.TransferBatchServiceImpl
+
+	walletRepo            service.WalletRepo
+	feeRepo               service.FeeScheduleRepo
+	auditTrailRepo        service.AuditTrailRepo
+	healthRepo            service.HealthRepo
+	transferBatchRepo     service.TransferBatchRepo
+	userRepo              service.UserRepo
+	transferProposalRepo  service.TransferProposalRepo
+	statementRepo         service.StatementRepo
+	scheduledTransferRepo service.ScheduledTransferRepo
+	paymentRequestRepo    service.PaymentRequestRepo
+	interestRepo          service.InterestRepo
+	potRepo               service.PotRepo
+	escrowRepo            service.EscrowRepo
+	disputeRepo           service.DisputeRepo
+	adminRepo             service.AdminRepo
+	walletMemberRepo      service.WalletMemberRepo
+	health                *service.HealthServiceImpl
+	metrics               *serverMetrics
+}
+
+// Option replaces a repository the server would otherwise build on the database, so the routes that use it can
+// run without one, e.g. in tests.
+type Option func(*Server)
+
+// WithWalletRepo makes the wallet routes and the scheduled transfer worker use wr, e.g. a repo.WalletRepoMemory.
+func WithWalletRepo(wr service.WalletRepo) Option {
+	return func(s *Server) { s.walletRepo = wr }
+}
+
+// WithFeeScheduleRepo makes fees be worked out from the schedules in fr.
+func WithFeeScheduleRepo(fr service.FeeScheduleRepo) Option {
+	return func(s *Server) { s.feeRepo = fr }
+}
+
+// WithAuditTrailRepo makes the audit trail be recorded to and read from ar.
+func WithAuditTrailRepo(ar service.AuditTrailRepo) Option {
+	return func(s *Server) { s.auditTrailRepo = ar }
+}
+
+// WithTransferBatchRepo makes transfer batches be stored in and executed by br.
+func WithTransferBatchRepo(br service.TransferBatchRepo) Option {
+	return func(s *Server) { s.transferBatchRepo = br }
+}
+
+// WithUserRepo makes users, handles and default wallets be stored in ur.
+func WithUserRepo(ur service.UserRepo) Option {
+	return func(s *Server) { s.userRepo = ur }
+}
+
+// WithTransferProposalRepo makes approval policies and transfer proposals be stored in and executed by pr.
+func WithTransferProposalRepo(pr service.TransferProposalRepo) Option {
+	return func(s *Server) { s.transferProposalRepo = pr }
+}
+
+// WithStatementRepo makes statements be generated from the wallet activity in sr.
+func WithStatementRepo(sr service.StatementRepo) Option {
+	return func(s *Server) { s.statementRepo = sr }
+}
+
+// WithScheduledTransferRepo makes the schedule routes and the scheduled transfer worker use sr.
+func WithScheduledTransferRepo(sr service.ScheduledTransferRepo) Option {
+	return func(s *Server) { s.scheduledTransferRepo = sr }
+}
+
+// WithPaymentRequestRepo makes payment requests be stored in and paid by pr.
+func WithPaymentRequestRepo(pr service.PaymentRequestRepo) Option {
+	return func(s *Server) { s.paymentRequestRepo = pr }
+}
+
+// WithInterestRepo makes the interest routes and the interest accrual job use ir.
+func WithInterestRepo(ir service.InterestRepo) Option {
+	return func(s *Server) { s.interestRepo = ir }
+}
+
+// WithPotRepo makes pots be stored in pr.
+func WithPotRepo(pr service.PotRepo) Option {
+	return func(s *Server) { s.potRepo = pr }
+}
+
+// WithEscrowRepo makes the escrow routes and the escrow release job use er.
+func WithEscrowRepo(er service.EscrowRepo) Option {
+	return func(s *Server) { s.escrowRepo = er }
+}
+
+// WithDisputeRepo makes disputes be stored in and resolved by dr.
+func WithDisputeRepo(dr service.DisputeRepo) Option {
+	return func(s *Server) { s.disputeRepo = dr }
+}
+
+// WithAdminRepo makes the admin routes and the wallet status expiry job use ar.
+func WithAdminRepo(ar service.AdminRepo) Option {
+	return func(s *Server) { s.adminRepo = ar }
+}
+
+// WithWalletMemberRepo makes wallet memberships and invitations be stored in mr.
+func WithWalletMemberRepo(mr service.WalletMemberRepo) Option {
+	return func(s *Server) { s.walletMemberRepo = mr }
+}
+
+// WithHealthRepo makes the readiness probe check hr instead of the database.
+func WithHealthRepo(hr service.HealthRepo) Option {
+	return func(s *Server) { s.healthRepo = hr }
 }
 
 // New creates a new HTTP server.
-func New(db *sqlx.DB, logger *zerolog.Logger, cfg config.Config) *Server {
+func New(db *sqlx.DB, logger *zerolog.Logger, cfg config.Config, opts ...Option) *Server {
 	r := gin.New()
 
-	return &Server{
+	s := &Server{
 		engine: r,
 		db:     db,
 		logger: logger,
 		config: cfg,
 		addr:   fmt.Sprintf(":%d", cfg.ServicePort), // Initialize addr here
+
+		walletRepo:            repo.NewWalletImpl(db),
+		feeRepo:               repo.NewFeeScheduleImpl(db),
+		auditTrailRepo:        repo.NewAuditTrailImpl(db),
+		transferBatchRepo:     repo.NewTransferBatchImpl(db),
+		userRepo:              repo.NewUserImpl(db),
+		transferProposalRepo:  repo.NewTransferProposalImpl(db),
+		statementRepo:         repo.NewStatementImpl(db),
+		scheduledTransferRepo: repo.NewScheduledTransferImpl(db),
+		paymentRequestRepo:    repo.NewPaymentRequestImpl(db),
+		interestRepo:          repo.NewInterestImpl(db),
+		potRepo:               repo.NewPotImpl(db),
+		escrowRepo:            repo.NewEscrowImpl(db),
+		disputeRepo:           repo.NewDisputeImpl(db),
+		adminRepo:             repo.NewAdminImpl(db),
+		walletMemberRepo:      repo.NewWalletMemberImpl(db),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.healthRepo == nil {
+		// The migrations are embedded, so failing to load them is a broken build rather than a runtime condition.
+		schema, err := migrations.Load()
+		if err != nil {
+			logger.Panic().Err(err).Msg("Failed to load migrations")
+		}
+		s.healthRepo = repo.NewHealthImpl(db, schema)
+	}
+	s.health = service.NewHealthImpl(s.healthRepo, clock.Real{})
+	s.metrics = newServerMetrics(db)
+	s.walletRepo = meteredWalletRepo{s.walletRepo, s.metrics}
+	s.transferBatchRepo = meteredTransferBatchRepo{s.transferBatchRepo, s.metrics}
+	s.transferProposalRepo = meteredTransferProposalRepo{s.transferProposalRepo, s.metrics}
+	s.paymentRequestRepo = meteredPaymentRequestRepo{s.paymentRequestRepo, s.metrics}
+	s.escrowRepo = meteredEscrowRepo{s.escrowRepo, s.metrics}
+	s.disputeRepo = meteredDisputeRepo{s.disputeRepo, s.metrics}
+	s.adminRepo = meteredAdminRepo{s.adminRepo, s.metrics}
+	s.http = &http.Server{
+		Addr:         s.addr,
+		Handler:      r,
+		ReadTimeout:  cfg.HTTPVar.ReadTimeout,
+		WriteTimeout: cfg.HTTPVar.WriteTimeout,
+		IdleTimeout:  cfg.HTTPVar.IdleTimeout,
+	}
+	return s
+}
+
+// Handler returns the http.Handler serving the routes, e.g. for an httptest.Server.
+func (s *Server) Handler() http.Handler {
+	return s.engine
 }
 
-// Start starts the HTTP server.
+// Start listens on SERVICE_PORT and serves the routes until Shutdown is called, after which it returns nil.
 func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+	return s.Serve(ln)
+}
+
+// Serve serves the routes on ln until Shutdown is called, after which it returns nil.
+func (s *Server) Serve(ln net.Listener) error {
 	s.logger.Info().
-		Str("addr", s.addr).
+		Str("addr", ln.Addr().String()).
 		Str("service", s.config.ServiceName).
 		Str("env", s.config.Env).
 		Msg("Starting HTTP server")
-	s.logger.Info().Str("addr", s.addr).Msg("Starting HTTP server")
 
-	if err := s.engine.Run(s.addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		s.logger.Panic().Err(err).Msg("Failed to start HTTP server")
-		return err
+	if err := s.http.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve HTTP: %w", err)
+	}
+	return nil
+}
+
+// Shutdown stops the server in order, so that no request or job is cut off while it still has time to finish:
+//   - Fail readiness, and wait HTTP_DRAIN_DELAY for load balancers to notice
+//   - Stop accepting connections and wait for in-flight requests to complete
+//   - Stop the background jobs from starting another pass, and wait for the passes in progress and the transfer
+//     batches still executing to finish
+//
+// If ctx ends first, the connections still open are closed, the passes in progress are cancelled, so their
+// transactions roll back, and ctx's error is returned. The database is left open for the caller to close last.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Drain()
+	if delay := s.config.HTTPVar.DrainDelay; delay > 0 {
+		s.logger.Info().Dur("delay", delay).Msg("Failing readiness before draining")
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
+	s.logger.Info().Msg("Draining in-flight HTTP requests")
+	var httpErr error
+	if err := s.http.Shutdown(ctx); err != nil {
+		httpErr = fmt.Errorf("failed to drain HTTP requests: %w", err)
+		s.http.Close()
+	}
+
+	if s.stopWorkers != nil {
+		s.logger.Info().Msg("Stopping background jobs")
+		close(s.stopWorkers)
+		defer s.cancelWorkers()
+	}
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		if s.transferBatches != nil {
+			s.transferBatches.Wait()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return errors.Join(httpErr, fmt.Errorf("failed to stop background jobs and transfer batches: %w", ctx.Err()))
+	}
+	return httpErr
+}
+
+// SyncFeeSchedules stores the fee schedule versions from the file named by FEE_SCHEDULES_FILE, if any.
+func (s *Server) SyncFeeSchedules(ctx context.Context) error {
+	path := s.config.FeeVar.SchedulesFile
+	if path == "" {
+		return nil
 	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open fee schedules file: %w", err)
+	}
+	defer f.Close()
 
+	schedules, err := service.LoadFeeSchedules(f)
+	if err != nil {
+		return err
+	}
+	if err = s.feeService().SyncFeeSchedules(ctx, schedules); err != nil {
+		return err
+	}
+	s.logger.Info().Str("file", path).Int("schedules", len(schedules)).Msg("Fee schedules synced")
 	return nil
 }
 
+func (s *Server) feeService() *service.FeeServiceImpl {
+	return service.NewFeeImpl(s.feeRepo, s.config.Currency, uuid.MustParse(s.config.FeeVar.WalletID), clock.Real{})
+}
+
+// StartWorkers starts the background jobs. When the server is shut down, they finish the pass in progress and stop;
+// cancelling ctx stops them at once, with the database calls of that pass cancelled.
+//   - Scheduled transfer worker
+//   - Interest accrual job
+//   - Transfer proposal expiry job
+//   - Escrow release job
+//   - Wallet status expiry job
+//   - Audit trail chainer
+func (s *Server) StartWorkers(ctx context.Context) {
+	ctx, s.cancelWorkers = context.WithCancel(s.logger.WithContext(ctx))
+	s.stopWorkers = make(chan struct{})
+	stop := s.stopWorkers
+
+	worker := service.NewScheduledTransferWorker(
+		s.scheduledTransferRepo,
+		service.NewWalletImpl(s.walletRepo, s.feeService()),
+		clock.Real{},
+		service.RetryPolicy{
+			MaxAttempts: s.config.SchedulerVar.MaxAttempts,
+			Backoff:     s.config.SchedulerVar.RetryBackoff,
+			Lease:       s.config.SchedulerVar.RunLease,
+		},
+	)
+	s.logger.Info().Dur("interval", s.config.SchedulerVar.Interval).Msg("Starting scheduled transfer worker")
+	s.goWorker(func() { worker.Run(ctx, stop, s.config.SchedulerVar.Interval) })
+
+	interestJob := service.NewInterestAccrualJob(
+		s.interestRepo,
+		model.DayCountConvention(s.config.InterestVar.DayCount),
+		clock.Real{},
+	)
+	s.logger.Info().Dur("interval", s.config.InterestVar.Interval).Msg("Starting interest accrual job")
+	s.goWorker(func() { interestJob.Run(ctx, stop, s.config.InterestVar.Interval) })
+
+	proposalExpiryJob := service.NewTransferProposalExpiryJob(s.transferProposalRepo, clock.Real{})
+	s.logger.Info().Dur("interval", s.config.ApprovalVar.ExpiryInterval).Msg("Starting transfer proposal expiry job")
+	s.goWorker(func() { proposalExpiryJob.Run(ctx, stop, s.config.ApprovalVar.ExpiryInterval) })
+
+	escrowReleaseJob := service.NewEscrowReleaseJob(s.escrowRepo, clock.Real{})
+	s.logger.Info().Dur("interval", s.config.EscrowVar.Interval).Msg("Starting escrow release job")
+	s.goWorker(func() { escrowReleaseJob.Run(ctx, stop, s.config.EscrowVar.Interval) })
+
+	statusExpiryJob := service.NewWalletStatusExpiryJob(s.adminRepo, clock.Real{})
+	s.logger.Info().Dur("interval", s.config.AdminVar.StatusExpiryInterval).Msg("Starting wallet status expiry job")
+	s.goWorker(func() { statusExpiryJob.Run(ctx, stop, s.config.AdminVar.StatusExpiryInterval) })
+
+	auditTrailChainer := service.NewAuditTrailChainer(s.auditTrailRepo, clock.Real{})
+	s.logger.Info().Dur("interval", s.config.AuditTrailVar.ChainInterval).Msg("Starting audit trail chainer")
+	s.goWorker(func() { auditTrailChainer.Run(ctx, stop, s.config.AuditTrailVar.ChainInterval) })
+}
+
+// goWorker runs job in the background until it returns, which Shutdown waits for.
+func (s *Server) goWorker(job func()) {
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		job()
+	}()
+}
+
 // UseMiddleware adds middleware to the Gin engine.
 //   - Add DataDog middleware for Gin
 //   - Use Zerolog as Gin's logger
+//   - Time requests for /metrics
+//   - Record state-changing requests in the audit trail
 //   - Add Gin's recovery middleware
 func (s *Server) UseMiddleware() {
 	s.engine.Use(ddgin.Middleware(s.config.ServiceName))
 
 	s.engine.Use(s.ginZerolog())
 
+	s.engine.Use(s.httpMetrics())
+
+	s.engine.Use(s.auditTrail(service.NewAuditTrailImpl(s.auditTrailRepo, clock.Real{})))
+
 	s.engine.Use(gin.Recovery())
 }
 
@@ -83,6 +406,7 @@ func (s *Server) ginZerolog() gin.HandlerFunc {
 		}
 
 		c.Writer.Header().Set("X-Request-Id", requestID)
+		c.Set(requestIDKey, requestID)
 
 		// Add request ID to logger context
 		reqLogger := s.logger.With().Str("request-id", requestID).Logger()
@@ -127,12 +451,167 @@ func (s *Server) ginZerolog() gin.HandlerFunc {
 	}
 }
 
+// auditTrail is a middleware that records every POST, PUT, PATCH and DELETE request to a route in the audit trail,
+// with the values of the body's fields redacted. For a route with a walletId, the request's own transactions stage
+// the entry with the wallet's balance before and after they changed it, so the balances are the ones the request
+// saw and the entry commits with the money it records; the middleware then records the status and the actor.
+// A failure to record is logged and counted in audit_trail_record_failures_total. It runs outside the recovery
+// middleware, so a request that panics is still recorded, as a 500.
+func (s *Server) auditTrail(svc *service.AuditTrailServiceImpl) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+		if c.FullPath() == "" {
+			c.Next()
+			return
+		}
+
+		// The entry must be written even when the client goes away before the response.
+		ctx := context.WithoutCancel(c.Request.Context())
+		entry := &model.AuditTrailEntry{
+			RequestID: c.GetString(requestIDKey),
+			Actor:     auditActor(c),
+			Method:    c.Request.Method,
+			Route:     c.FullPath(),
+			Params:    auditParams(c),
+		}
+		if walletID, err := uuid.Parse(c.Param("walletId")); err == nil {
+			entry.WalletID = &walletID
+		}
+		reqCtx, record := svc.Begin(c.Request.Context(), entry)
+		c.Request = c.Request.WithContext(reqCtx)
+
+		c.Next()
+
+		entry.Status = c.Writer.Status()
+		entry.Actor = auditActor(c)
+		if err := record(ctx); err != nil {
+			s.metrics.auditTrailFailures.Inc(entry.Method, entry.Route)
+			zerolog.Ctx(ctx).Error().Err(err).Str("route", entry.Route).Msg("Failed to record audit trail entry")
+		}
+	}
+}
+
+// auditActor returns who is making the request, as the audit trail records it. The administrator is known only once
+// the admin API has authenticated them.
+func auditActor(c *gin.Context) string {
+	switch {
+	case c.GetString(handler.AdminActorKey) != "":
+		return "admin:" + c.GetString(handler.AdminActorKey)
+	case c.Param("userId") != "":
+		return "user:" + c.Param("userId")
+	default:
+		return "anonymous"
+	}
+}
+
+// auditParams collects the path and query parameters of a request and the names of its JSON body's fields,
+// leaving the body in place for the handler.
+func auditParams(c *gin.Context) model.AuditParams {
+	params := model.AuditParams{Query: c.Request.URL.Query()}
+	if len(c.Params) > 0 {
+		params.Path = make(map[string]string, len(c.Params))
+		for _, p := range c.Params {
+			params.Path[p.Key] = p.Value
+		}
+	}
+	if len(params.Query) == 0 {
+		params.Query = nil
+	}
+
+	if c.Request.Body == nil {
+		return params
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return params
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) == nil {
+		for name := range fields {
+			params.Body = append(params.Body, name)
+		}
+		sort.Strings(params.Body)
+	}
+	return params
+}
+
+// adminAuth is a middleware that only lets requests through with the bearer token of an administrator, whose
+// name it stores under handler.AdminActorKey.
+func (s *Server) adminAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if ok {
+			for t, name := range s.config.AdminVar.Tokens {
+				if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+					c.Set(handler.AdminActorKey, name)
+					c.Next()
+					return
+				}
+			}
+		}
+		restjson.ResponseError(c, http.StatusUnauthorized, errors.New("a valid admin token is required"))
+		c.Abort()
+	}
+}
+
 // RegisterRoutes registers the HTTP routes.
 func (s *Server) RegisterRoutes() {
 
-	wRepo := repo.NewWalletImpl(s.db)
-	walletService := service.NewWalletImpl(wRepo)
-	walletHandler := handler.NewWalletImpl(walletService)
+	userService := service.NewUserImpl(s.userRepo)
+	userHandler := handler.NewUserImpl(userService)
+
+	feeService := s.feeService()
+	feeHandler := handler.NewFeeImpl(feeService)
+
+	transferProposalService := service.NewTransferProposalImpl(s.transferProposalRepo, feeService, clock.Real{})
+	transferProposalHandler := handler.NewTransferProposalImpl(transferProposalService)
+
+	walletService := service.NewWalletImpl(s.walletRepo, feeService)
+	walletHandler := handler.NewWalletImpl(walletService, userService, transferProposalService)
+
+	statementService := service.NewStatementImpl(s.statementRepo, s.config.Currency)
+	statementHandler := handler.NewStatementImpl(statementService)
+
+	s.transferBatches = service.NewTransferBatchImpl(s.transferBatchRepo, feeService)
+	transferBatchHandler := handler.NewTransferBatchImpl(s.transferBatches)
+
+	scheduledTransferService := service.NewScheduledTransferImpl(s.scheduledTransferRepo, clock.Real{})
+	scheduledTransferHandler := handler.NewScheduledTransferImpl(scheduledTransferService)
+
+	paymentRequestService := service.NewPaymentRequestImpl(s.paymentRequestRepo, feeService, clock.Real{})
+	paymentRequestHandler := handler.NewPaymentRequestImpl(paymentRequestService)
+
+	interestService := service.NewInterestImpl(s.interestRepo, model.DayCountConvention(s.config.InterestVar.DayCount), clock.Real{})
+	interestHandler := handler.NewInterestImpl(interestService)
+
+	potService := service.NewPotImpl(s.potRepo, clock.Real{})
+	potHandler := handler.NewPotImpl(potService)
+
+	escrowService := service.NewEscrowImpl(s.escrowRepo, feeService, uuid.MustParse(s.config.EscrowVar.WalletID), clock.Real{})
+	escrowHandler := handler.NewEscrowImpl(escrowService)
+
+	disputeService := service.NewDisputeImpl(s.disputeRepo, clock.Real{})
+	disputeHandler := handler.NewDisputeImpl(disputeService)
+
+	adminService := service.NewAdminImpl(s.adminRepo, clock.Real{})
+	adminHandler := handler.NewAdminImpl(adminService)
+
+	auditTrailService := service.NewAuditTrailImpl(s.auditTrailRepo, clock.Real{})
+	auditTrailHandler := handler.NewAuditTrailImpl(auditTrailService)
+
+	walletMemberService := service.NewWalletMemberImpl(s.walletMemberRepo, clock.Real{})
+	walletMemberHandler := handler.NewWalletMemberImpl(walletMemberService)
+
+	healthHandler := handler.NewHealthImpl(s.health)
+	s.engine.GET("/healthz", healthHandler.Live)
+	s.engine.GET("/readyz", healthHandler.Ready)
+	s.engine.GET("/metrics", gin.WrapH(s.metrics.registry.Handler()))
 
 	s.engine.Group("/v1").
 		GET("/user/:userId/wallet/:walletId", walletHandler.GetWalletInfo)
@@ -149,4 +628,202 @@ func (s *Server) RegisterRoutes() {
 	s.engine.Group("/v1").
 		POST("/user/:userId/wallet/:walletId/transfer", walletHandler.Transfer)
 
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/statement/camt053", statementHandler.GetCamt053Statement)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/transfer-batches", transferBatchHandler.CreateTransferBatch)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/transfer-batches/:batchId", transferBatchHandler.GetTransferBatch)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/schedules", scheduledTransferHandler.CreateScheduledTransfer)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/schedules", scheduledTransferHandler.ListScheduledTransfers)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/schedules/:scheduleId", scheduledTransferHandler.GetScheduledTransfer)
+
+	s.engine.Group("/v1").
+		DELETE("/user/:userId/wallet/:walletId/schedules/:scheduleId", scheduledTransferHandler.CancelScheduledTransfer)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/payment-requests", paymentRequestHandler.CreatePaymentRequest)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/payment-requests/incoming", paymentRequestHandler.ListIncomingPaymentRequests)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/payment-requests/outgoing", paymentRequestHandler.ListOutgoingPaymentRequests)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/payment-requests/:requestId", paymentRequestHandler.GetPaymentRequest)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/payment-requests/:requestId/accept", paymentRequestHandler.AcceptPaymentRequest)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/payment-requests/:requestId/decline", paymentRequestHandler.DeclinePaymentRequest)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/payment-requests/:requestId/cancel", paymentRequestHandler.CancelPaymentRequest)
+
+	s.engine.Group("/v1").
+		GET("/recipients/lookup", userHandler.LookupRecipient)
+
+	s.engine.Group("/v1").
+		GET("/fees/quote", feeHandler.QuoteFee)
+
+	s.engine.Group("/v1").
+		GET("/fees/schedules", feeHandler.ListFeeSchedules)
+
+	s.engine.Group("/v1").
+		PUT("/user/:userId/handle", userHandler.SetHandle)
+
+	s.engine.Group("/v1").
+		PUT("/user/:userId/wallet/:walletId/default", userHandler.SetDefaultWallet)
+
+	s.engine.Group("/v1").
+		GET("/products", interestHandler.ListWalletProducts)
+
+	s.engine.Group("/v1").
+		PUT("/user/:userId/wallet/:walletId/product", interestHandler.SetWalletProduct)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/interest", interestHandler.GetInterestSummary)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/pots", potHandler.CreatePot)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/pots", potHandler.ListPots)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/pots/:potId", potHandler.GetPot)
+
+	s.engine.Group("/v1").
+		PUT("/user/:userId/wallet/:walletId/pots/:potId", potHandler.UpdatePot)
+
+	s.engine.Group("/v1").
+		DELETE("/user/:userId/wallet/:walletId/pots/:potId", potHandler.ClosePot)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/pots/:potId/move-in", potHandler.MoveIntoPot)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/pots/:potId/move-out", potHandler.MoveOutOfPot)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/members", walletMemberHandler.ListWalletMembers)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/members", walletMemberHandler.InviteWalletMember)
+
+	s.engine.Group("/v1").
+		PUT("/user/:userId/wallet/:walletId/members/:memberId", walletMemberHandler.UpdateWalletMemberRole)
+
+	s.engine.Group("/v1").
+		DELETE("/user/:userId/wallet/:walletId/members/:memberId", walletMemberHandler.RemoveWalletMember)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet-invitations", walletMemberHandler.ListWalletInvitations)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet-invitations/:walletId/accept", walletMemberHandler.AcceptWalletInvitation)
+
+	s.engine.Group("/v1").
+		PUT("/user/:userId/wallet/:walletId/approval-policy", transferProposalHandler.SetApprovalPolicy)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/approval-policy", transferProposalHandler.GetApprovalPolicy)
+
+	s.engine.Group("/v1").
+		DELETE("/user/:userId/wallet/:walletId/approval-policy", transferProposalHandler.DeleteApprovalPolicy)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/transfer-proposals", transferProposalHandler.ListTransferProposals)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/transfer-proposals/:proposalId", transferProposalHandler.GetTransferProposal)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/transfer-proposals/:proposalId/approve", transferProposalHandler.ApproveTransferProposal)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/transfer-proposals/:proposalId/cancel", transferProposalHandler.CancelTransferProposal)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/escrows", escrowHandler.CreateEscrow)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/escrows", escrowHandler.ListEscrows)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/escrows/:escrowId", escrowHandler.GetEscrow)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/escrows/:escrowId/confirm", escrowHandler.ConfirmEscrow)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		GET("/escrows", escrowHandler.ListEscrowsByStatus)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		POST("/escrows/:escrowId/decision", escrowHandler.DecideEscrow)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/disputes", disputeHandler.OpenDispute)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/disputes", disputeHandler.ListDisputes)
+
+	s.engine.Group("/v1").
+		GET("/user/:userId/wallet/:walletId/disputes/:disputeId", disputeHandler.GetDispute)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/disputes/:disputeId/notes", disputeHandler.AddDisputeNote)
+
+	s.engine.Group("/v1").
+		POST("/user/:userId/wallet/:walletId/disputes/:disputeId/withdraw", disputeHandler.WithdrawDispute)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		GET("/disputes", disputeHandler.ListDisputeQueue)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		GET("/disputes/:disputeId", disputeHandler.GetDisputeCase)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		POST("/disputes/:disputeId/notes", disputeHandler.AddAdminDisputeNote)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		POST("/disputes/:disputeId/resolution", disputeHandler.ResolveDispute)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		GET("/users", adminHandler.SearchUsers)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		GET("/wallets", adminHandler.SearchWallets)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		PUT("/wallets/:walletId/status", adminHandler.SetWalletStatus)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		POST("/wallets/:walletId/freeze", adminHandler.FreezeWallet)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		POST("/wallets/:walletId/unfreeze", adminHandler.UnfreezeWallet)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		POST("/wallets/:walletId/adjustments", adminHandler.AdjustWallet)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		GET("/audit-log", adminHandler.ListAuditLog)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		GET("/audit-trail", auditTrailHandler.ListAuditTrail)
+
+	s.engine.Group("/v1/admin", s.adminAuth()).
+		GET("/audit-trail/verify", auditTrailHandler.VerifyAuditTrail)
+
 }
@@ -0,0 +1,778 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/config"
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/server"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/internal/service/mocks"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+const (
+	user1         = "a0000000-0000-4000-8000-000000000001"
+	user2         = "a0000000-0000-4000-8000-000000000002"
+	viewer        = "a0000000-0000-4000-8000-000000000003"
+	stranger      = "a0000000-0000-4000-8000-000000000004"
+	wallet1       = "b0000000-0000-4000-8000-000000000001"
+	wallet2       = "b0000000-0000-4000-8000-000000000002"
+	frozenWallet  = "b0000000-0000-4000-8000-000000000003"
+	creditBlocked = "b0000000-0000-4000-8000-000000000004"
+	missingWallet = "b0000000-0000-4000-8000-0000000000ff"
+	feeWallet     = "c0000000-0000-4000-8000-000000000001"
+	escrowWallet  = "c0000000-0000-4000-8000-000000000002"
+	adminToken    = "e2e-admin-token"
+	potID         = "d0000000-0000-4000-8000-000000000001"
+	proposalID    = "d0000000-0000-4000-8000-000000000002"
+	escrowID      = "d0000000-0000-4000-8000-000000000003"
+	disputeID     = "d0000000-0000-4000-8000-000000000004"
+	requestID     = "d0000000-0000-4000-8000-000000000005"
+	scheduleID    = "d0000000-0000-4000-8000-000000000006"
+	batchID       = "d0000000-0000-4000-8000-000000000007"
+	transactionID = "d0000000-0000-4000-8000-000000000008"
+	productID     = "d0000000-0000-4000-8000-000000000009"
+)
+
+// placeholders stand in for the fixed IDs in golden files, so they read as names.
+var placeholders = map[string]string{
+	user1: "<user-1>", user2: "<user-2>", viewer: "<viewer>", stranger: "<stranger>",
+	wallet1: "<wallet-1>", wallet2: "<wallet-2>", frozenWallet: "<frozen-wallet>", creditBlocked: "<credit-blocked-wallet>",
+	missingWallet: "<missing-wallet>", feeWallet: "<fee-wallet>", escrowWallet: "<escrow-wallet>",
+	potID: "<pot>", proposalID: "<proposal>", escrowID: "<escrow>", disputeID: "<dispute>", requestID: "<payment-request>",
+	scheduleID: "<schedule>", batchID: "<batch>", transactionID: "<transaction>", productID: "<product>",
+}
+
+var (
+	uuidPattern = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	timePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	// latencyPattern matches how long a health check took, and messageIDPattern the random ID of a camt.053 message.
+	latencyPattern   = regexp.MustCompile(`"latency_ms": [0-9.e+-]+`)
+	messageIDPattern = regexp.MustCompile(`<MsgId>[0-9a-f]{32}</MsgId>`)
+
+	errConnection = errors.New("connection refused")
+	adminHeader   = http.Header{"Authorization": {"Bearer " + adminToken}}
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	flag.Parse()
+	os.Exit(m.Run())
+}
+
+// testServer is the full engine, middleware included, over an in-memory wallet repository and mocks of every
+// other repository.
+type testServer struct {
+	srv     *server.Server
+	handler http.Handler
+	repos   *routeRepos
+
+	mu      sync.Mutex
+	entries []model.AuditTrailEntry
+}
+
+// routeRepos are the mocked repositories behind the routes other than the wallet ones. A test sets the calls it
+// expects on them; any other call fails it.
+type routeRepos struct {
+	fees            *mocks.FeeScheduleRepoMock
+	auditTrail      *mocks.AuditTrailRepoMock
+	health          *mocks.HealthRepoMock
+	users           *mocks.UserRepoMock
+	proposals       *mocks.TransferProposalRepoMock
+	statements      *mocks.StatementRepoMock
+	batches         *mocks.TransferBatchRepoMock
+	schedules       *mocks.ScheduledTransferRepoMock
+	paymentRequests *mocks.PaymentRequestRepoMock
+	interest        *mocks.InterestRepoMock
+	pots            *mocks.PotRepoMock
+	escrows         *mocks.EscrowRepoMock
+	disputes        *mocks.DisputeRepoMock
+	admin           *mocks.AdminRepoMock
+	members         *mocks.WalletMemberRepoMock
+}
+
+func newTestServer(t *testing.T, wr service.WalletRepo, opts ...server.Option) *testServer {
+	ts := &testServer{}
+	r := &routeRepos{
+		fees:            mocks.NewFeeScheduleRepoMock(t),
+		auditTrail:      mocks.NewAuditTrailRepoMock(t),
+		health:          mocks.NewHealthRepoMock(t),
+		users:           mocks.NewUserRepoMock(t),
+		proposals:       mocks.NewTransferProposalRepoMock(t),
+		statements:      mocks.NewStatementRepoMock(t),
+		batches:         mocks.NewTransferBatchRepoMock(t),
+		schedules:       mocks.NewScheduledTransferRepoMock(t),
+		paymentRequests: mocks.NewPaymentRequestRepoMock(t),
+		interest:        mocks.NewInterestRepoMock(t),
+		pots:            mocks.NewPotRepoMock(t),
+		escrows:         mocks.NewEscrowRepoMock(t),
+		disputes:        mocks.NewDisputeRepoMock(t),
+		admin:           mocks.NewAdminRepoMock(t),
+		members:         mocks.NewWalletMemberRepoMock(t),
+	}
+	ts.repos = r
+
+	r.fees.On("GetActiveFeeSchedule", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, repo.ErrFeeScheduleNotFound).Maybe()
+
+	r.auditTrail.On("RecordAuditTrailEntry", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			ts.mu.Lock()
+			defer ts.mu.Unlock()
+			ts.entries = append(ts.entries, *args.Get(2).(*model.AuditTrailEntry))
+		}).
+		Return(nil).Maybe()
+
+	logger := zerolog.Nop()
+	cfg := config.Config{
+		ServiceName: "wallet-app-e2e",
+		Currency:    "USD",
+		FeeVar:      config.FeeVar{WalletID: feeWallet},
+		EscrowVar:   config.EscrowVar{WalletID: escrowWallet},
+		AdminVar:    config.AdminVar{Tokens: map[string]string{adminToken: "alice"}},
+	}
+	s := server.New(nil, &logger, cfg, append([]server.Option{
+		server.WithWalletRepo(wr),
+		server.WithFeeScheduleRepo(r.fees),
+		server.WithAuditTrailRepo(r.auditTrail),
+		server.WithHealthRepo(r.health),
+		server.WithUserRepo(r.users),
+		server.WithTransferProposalRepo(r.proposals),
+		server.WithStatementRepo(r.statements),
+		server.WithTransferBatchRepo(r.batches),
+		server.WithScheduledTransferRepo(r.schedules),
+		server.WithPaymentRequestRepo(r.paymentRequests),
+		server.WithInterestRepo(r.interest),
+		server.WithPotRepo(r.pots),
+		server.WithEscrowRepo(r.escrows),
+		server.WithDisputeRepo(r.disputes),
+		server.WithAdminRepo(r.admin),
+		server.WithWalletMemberRepo(r.members),
+	}, opts...)...)
+	s.UseMiddleware()
+	s.RegisterRoutes()
+	ts.srv = s
+	ts.handler = s.Handler()
+	return ts
+}
+
+func (ts *testServer) do(method, path, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	ts.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// memoryWallets returns an in-memory repository with:
+//   - wallet 1 of user 1 holding 100, which the viewer can see
+//   - wallet 2 of user 2, empty
+//   - a frozen wallet of user 1 holding 50
+//   - a wallet of user 2 that is blocked from being paid
+func memoryWallets(t *testing.T) service.WalletRepo {
+	t.Helper()
+	ctx := context.Background()
+	wr := repo.NewWalletMemory()
+	for _, w := range []model.Wallet{
+		{ID: uuid.MustParse(wallet1), UserID: uuid.MustParse(user1), Name: "Main", Balance: decimal.RequireFromString("100")},
+		{ID: uuid.MustParse(wallet2), UserID: uuid.MustParse(user2), Name: "Main", Balance: decimal.Zero},
+		{ID: uuid.MustParse(frozenWallet), UserID: uuid.MustParse(user1), Name: "Frozen", Balance: decimal.RequireFromString("50"), Status: model.WalletStatusFrozen},
+		{ID: uuid.MustParse(creditBlocked), UserID: uuid.MustParse(user2), Name: "Blocked", Balance: decimal.Zero, Status: model.WalletStatusCreditBlocked},
+	} {
+		require.NoError(t, wr.AddWallet(ctx, w))
+	}
+	require.NoError(t, wr.AddMember(ctx, uuid.MustParse(wallet1), uuid.MustParse(viewer), model.WalletRoleViewer))
+	return wr
+}
+
+// failingWallets returns a repository every call to which fails as a lost database connection would.
+func failingWallets(t *testing.T) service.WalletRepo {
+	wr := mocks.NewWalletRepoMock(t)
+	wr.On("GetWalletInfo", mock.Anything, mock.Anything, mock.Anything).Return(nil, errConnection).Maybe()
+	wr.On("GetTransactionsByWalletID", mock.Anything, mock.Anything, mock.Anything).Return(nil, errConnection).Maybe()
+	wr.On("Deposit", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errConnection).Maybe()
+	wr.On("Withdraw", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errConnection).Maybe()
+	wr.On("Transfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errConnection).Maybe()
+	return wr
+}
+
+func walletPath(userID, walletID, suffix string) string {
+	return fmt.Sprintf("/v1/user/%s/wallet/%s%s", userID, walletID, suffix)
+}
+
+func userPath(userID, suffix string) string {
+	return fmt.Sprintf("/v1/user/%s%s", userID, suffix)
+}
+
+// anything matches n arguments of a mocked call whatever they are.
+func anything(n int) []any {
+	args := make([]any, n)
+	for i := range args {
+		args[i] = mock.Anything
+	}
+	return args
+}
+
+func TestRoutes(t *testing.T) {
+	tests := []struct {
+		name       string
+		wallets    func(t *testing.T) service.WalletRepo
+		stub       func(r *routeRepos)
+		method     string
+		path       string
+		body       string
+		header     http.Header
+		wantStatus int
+	}{
+		// GET /v1/user/:userId/wallet/:walletId
+		{"wallet_info", memoryWallets, nil, http.MethodGet, walletPath(user1, wallet1, ""), "", nil, http.StatusOK},
+		{"wallet_info_viewer", memoryWallets, nil, http.MethodGet, walletPath(viewer, wallet1, ""), "", nil, http.StatusOK},
+		{"wallet_info_not_member", memoryWallets, nil, http.MethodGet, walletPath(stranger, wallet1, ""), "", nil, http.StatusNotFound},
+		{"wallet_info_repo_error", failingWallets, nil, http.MethodGet, walletPath(user1, wallet1, ""), "", nil, http.StatusInternalServerError},
+
+		// GET /v1/user/:userId/wallet/:walletId/transactions
+		{"transactions_empty", memoryWallets, nil, http.MethodGet, walletPath(user1, wallet1, "/transactions"), "", nil, http.StatusOK},
+		{"transactions_not_member", memoryWallets, nil, http.MethodGet, walletPath(stranger, wallet1, "/transactions"), "", nil, http.StatusNotFound},
+		{"transactions_repo_error", failingWallets, nil, http.MethodGet, walletPath(user1, wallet1, "/transactions"), "", nil, http.StatusInternalServerError},
+
+		// POST /v1/user/:userId/wallet/:walletId/deposit
+		{"deposit", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/deposit"), `{"amount": "25.50"}`, nil, http.StatusOK},
+		{"deposit_missing_amount", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/deposit"), `{}`, nil, http.StatusBadRequest},
+		{"deposit_malformed_body", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/deposit"), `{"amount":`, nil, http.StatusBadRequest},
+		{"deposit_negative_amount", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/deposit"), `{"amount": "-5"}`, nil, http.StatusBadRequest},
+		{"deposit_not_member", memoryWallets, nil, http.MethodPost, walletPath(stranger, wallet1, "/deposit"), `{"amount": "5"}`, nil, http.StatusNotFound},
+		{"deposit_viewer", memoryWallets, nil, http.MethodPost, walletPath(viewer, wallet1, "/deposit"), `{"amount": "5"}`, nil, http.StatusForbidden},
+		{"deposit_frozen", memoryWallets, nil, http.MethodPost, walletPath(user1, frozenWallet, "/deposit"), `{"amount": "5"}`, nil, http.StatusLocked},
+		{"deposit_repo_error", failingWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/deposit"), `{"amount": "5"}`, nil, http.StatusInternalServerError},
+
+		// POST /v1/user/:userId/wallet/:walletId/withdraw
+		{"withdraw", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/withdraw"), `{"amount": "40"}`, nil, http.StatusOK},
+		{"withdraw_insufficient_funds", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/withdraw"), `{"amount": "100.01"}`, nil, http.StatusBadRequest},
+		{"withdraw_missing_amount", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/withdraw"), `{}`, nil, http.StatusBadRequest},
+		{"withdraw_not_member", memoryWallets, nil, http.MethodPost, walletPath(stranger, wallet1, "/withdraw"), `{"amount": "5"}`, nil, http.StatusNotFound},
+		{"withdraw_frozen", memoryWallets, nil, http.MethodPost, walletPath(user1, frozenWallet, "/withdraw"), `{"amount": "5"}`, nil, http.StatusLocked},
+		{"withdraw_repo_error", failingWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/withdraw"), `{"amount": "5"}`, nil, http.StatusInternalServerError},
+
+		// POST /v1/user/:userId/wallet/:walletId/transfer
+		{"transfer", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/transfer"), `{"amount": "30", "destination_wallet_id": "` + wallet2 + `"}`, nil, http.StatusOK},
+		{"transfer_insufficient_funds", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/transfer"), `{"amount": "101", "destination_wallet_id": "` + wallet2 + `"}`, nil, http.StatusBadRequest},
+		{"transfer_same_wallet", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/transfer"), `{"amount": "1", "destination_wallet_id": "` + wallet1 + `"}`, nil, http.StatusBadRequest},
+		{"transfer_no_destination", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/transfer"), `{"amount": "1"}`, nil, http.StatusBadRequest},
+		{"transfer_source_not_member", memoryWallets, nil, http.MethodPost, walletPath(stranger, wallet1, "/transfer"), `{"amount": "1", "destination_wallet_id": "` + wallet2 + `"}`, nil, http.StatusNotFound},
+		{"transfer_destination_not_found", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/transfer"), `{"amount": "1", "destination_wallet_id": "` + missingWallet + `"}`, nil, http.StatusNotFound},
+		{"transfer_destination_credit_blocked", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/transfer"), `{"amount": "1", "destination_wallet_id": "` + creditBlocked + `"}`, nil, http.StatusConflict},
+		{"transfer_repo_error", failingWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/transfer"), `{"amount": "1", "destination_wallet_id": "` + wallet2 + `"}`, nil, http.StatusInternalServerError},
+		// /v1/user/:userId/wallet/:walletId/statement/camt053
+		{"statement", memoryWallets, func(r *routeRepos) {
+			r.statements.On("GetWalletActivity", anything(4)...).Return(&model.WalletActivity{
+				Wallet: model.Wallet{ID: uuid.MustParse(wallet1), UserID: uuid.MustParse(user1), Name: "Main", Balance: decimal.RequireFromString("100")},
+				Owner:  model.User{ID: uuid.MustParse(user1), Name: "Ada Lovelace"},
+			}, nil)
+		}, http.MethodGet, walletPath(user1, wallet1, "/statement/camt053?from=2024-01-01&to=2024-02-01"), "", nil, http.StatusOK},
+		{"statement_bad_date", memoryWallets, nil, http.MethodGet, walletPath(user1, wallet1, "/statement/camt053?from=yesterday&to=2024-02-01"), "", nil, http.StatusBadRequest},
+		{"statement_empty_period", memoryWallets, nil, http.MethodGet, walletPath(user1, wallet1, "/statement/camt053?from=2024-02-02&to=2024-02-01"), "", nil, http.StatusBadRequest},
+		{"statement_not_member", memoryWallets, func(r *routeRepos) {
+			r.statements.On("GetWalletActivity", anything(4)...).Return(nil, repo.ErrWalletNotFound)
+		}, http.MethodGet, walletPath(stranger, wallet1, "/statement/camt053?from=2024-01-01&to=2024-02-01"), "", nil, http.StatusNotFound},
+		{"statement_repo_error", memoryWallets, func(r *routeRepos) {
+			r.statements.On("GetWalletActivity", anything(4)...).Return(nil, errConnection)
+		}, http.MethodGet, walletPath(user1, wallet1, "/statement/camt053?from=2024-01-01&to=2024-02-01"), "", nil, http.StatusInternalServerError},
+
+		// /v1/user/:userId/wallet/:walletId/transfer-batches
+		{"transfer_batch_invalid", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/transfer-batches"), `{"mode": "sometimes", "items": []}`, nil, http.StatusBadRequest},
+		{"transfer_batch_destination_not_found", memoryWallets, func(r *routeRepos) {
+			r.batches.On("FindMissingWallets", anything(2)...).Return([]uuid.UUID{uuid.MustParse(missingWallet)}, nil)
+		}, http.MethodPost, walletPath(user1, wallet1, "/transfer-batches"), `{"mode": "best_effort", "items": [{"destination_wallet_id": "` + missingWallet + `", "amount": "5"}]}`, nil, http.StatusBadRequest},
+		{"transfer_batch_not_member", memoryWallets, func(r *routeRepos) {
+			r.batches.On("FindMissingWallets", anything(2)...).Return(nil, nil)
+			r.batches.On("CreateTransferBatch", anything(2)...).Return(repo.ErrWalletNotFound)
+		}, http.MethodPost, walletPath(stranger, wallet1, "/transfer-batches"), `{"mode": "best_effort", "items": [{"destination_wallet_id": "` + wallet2 + `", "amount": "5"}]}`, nil, http.StatusNotFound},
+		{"transfer_batch_viewer", memoryWallets, func(r *routeRepos) {
+			r.batches.On("FindMissingWallets", anything(2)...).Return(nil, nil)
+			r.batches.On("CreateTransferBatch", anything(2)...).Return(repo.ErrWalletForbidden)
+		}, http.MethodPost, walletPath(viewer, wallet1, "/transfer-batches"), `{"mode": "best_effort", "items": [{"destination_wallet_id": "` + wallet2 + `", "amount": "5"}]}`, nil, http.StatusForbidden},
+		{"transfer_batch_repo_error", memoryWallets, func(r *routeRepos) {
+			r.batches.On("FindMissingWallets", anything(2)...).Return(nil, errConnection)
+		}, http.MethodPost, walletPath(user1, wallet1, "/transfer-batches"), `{"mode": "best_effort", "items": [{"destination_wallet_id": "` + wallet2 + `", "amount": "5"}]}`, nil, http.StatusInternalServerError},
+		{"transfer_batch_get", memoryWallets, func(r *routeRepos) {
+			r.batches.On("GetTransferBatch", anything(4)...).Return(&model.TransferBatch{
+				ID: uuid.MustParse(batchID), SourceWalletID: uuid.MustParse(wallet1), Mode: model.TransferBatchModeBestEffort, Status: model.TransferBatchStatusCompleted,
+			}, nil)
+		}, http.MethodGet, walletPath(user1, wallet1, "/transfer-batches/"+batchID), "", nil, http.StatusOK},
+		{"transfer_batch_get_not_found", memoryWallets, func(r *routeRepos) {
+			r.batches.On("GetTransferBatch", anything(4)...).Return(nil, repo.ErrTransferBatchNotFound)
+		}, http.MethodGet, walletPath(user1, wallet1, "/transfer-batches/"+batchID), "", nil, http.StatusNotFound},
+
+		// /v1/user/:userId/wallet/:walletId/schedules
+		{"schedule_create", memoryWallets, func(r *routeRepos) {
+			r.schedules.On("CreateScheduledTransfer", anything(2)...).Return(nil)
+		}, http.MethodPost, walletPath(user1, wallet1, "/schedules"), `{"amount": "10", "destination_wallet_id": "` + wallet2 + `", "schedule": "0 9 1 * *", "start_at": "2030-01-01T00:00:00Z"}`, nil, http.StatusCreated},
+		{"schedule_create_invalid", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/schedules"), `{"amount": "10", "destination_wallet_id": "` + wallet2 + `", "schedule": "whenever"}`, nil, http.StatusBadRequest},
+		{"schedule_create_viewer", memoryWallets, func(r *routeRepos) {
+			r.schedules.On("CreateScheduledTransfer", anything(2)...).Return(repo.ErrWalletForbidden)
+		}, http.MethodPost, walletPath(viewer, wallet1, "/schedules"), `{"amount": "10", "destination_wallet_id": "` + wallet2 + `", "schedule": "0 9 1 * *"}`, nil, http.StatusForbidden},
+		{"schedule_create_repo_error", memoryWallets, func(r *routeRepos) {
+			r.schedules.On("CreateScheduledTransfer", anything(2)...).Return(errConnection)
+		}, http.MethodPost, walletPath(user1, wallet1, "/schedules"), `{"amount": "10", "destination_wallet_id": "` + wallet2 + `", "schedule": "0 9 1 * *"}`, nil, http.StatusInternalServerError},
+		{"schedule_list_repo_error", memoryWallets, func(r *routeRepos) {
+			r.schedules.On("ListScheduledTransfers", anything(3)...).Return(nil, errConnection)
+		}, http.MethodGet, walletPath(user1, wallet1, "/schedules"), "", nil, http.StatusInternalServerError},
+		{"schedule_get_not_found", memoryWallets, func(r *routeRepos) {
+			r.schedules.On("GetScheduledTransfer", anything(4)...).Return(nil, repo.ErrScheduledTransferNotFound)
+		}, http.MethodGet, walletPath(user1, wallet1, "/schedules/"+scheduleID), "", nil, http.StatusNotFound},
+		{"schedule_cancel", memoryWallets, func(r *routeRepos) {
+			r.schedules.On("CancelScheduledTransfer", anything(4)...).Return(nil)
+		}, http.MethodDelete, walletPath(user1, wallet1, "/schedules/"+scheduleID), "", nil, http.StatusNoContent},
+		{"schedule_cancel_not_found", memoryWallets, func(r *routeRepos) {
+			r.schedules.On("CancelScheduledTransfer", anything(4)...).Return(repo.ErrScheduledTransferNotFound)
+		}, http.MethodDelete, walletPath(user1, wallet1, "/schedules/"+scheduleID), "", nil, http.StatusNotFound},
+
+		// /v1/user/:userId/wallet/:walletId/payment-requests and /v1/user/:userId/payment-requests
+		{"payment_request_create", memoryWallets, func(r *routeRepos) {
+			r.paymentRequests.On("CreatePaymentRequest", anything(2)...).Return(nil)
+		}, http.MethodPost, walletPath(user2, wallet2, "/payment-requests"), `{"amount": "15", "payer_user_id": "` + user1 + `", "memo": "dinner"}`, nil, http.StatusCreated},
+		{"payment_request_create_invalid", memoryWallets, nil, http.MethodPost, walletPath(user2, wallet2, "/payment-requests"), `{"amount": "-15", "payer_user_id": "` + user1 + `"}`, nil, http.StatusBadRequest},
+		{"payment_request_create_payer_not_found", memoryWallets, func(r *routeRepos) {
+			r.paymentRequests.On("CreatePaymentRequest", anything(2)...).Return(repo.ErrPayerNotFound)
+		}, http.MethodPost, walletPath(user2, wallet2, "/payment-requests"), `{"amount": "15", "payer_user_id": "` + stranger + `"}`, nil, http.StatusNotFound},
+		{"payment_request_create_repo_error", memoryWallets, func(r *routeRepos) {
+			r.paymentRequests.On("CreatePaymentRequest", anything(2)...).Return(errConnection)
+		}, http.MethodPost, walletPath(user2, wallet2, "/payment-requests"), `{"amount": "15", "payer_user_id": "` + user1 + `"}`, nil, http.StatusInternalServerError},
+		{"payment_request_incoming", memoryWallets, func(r *routeRepos) {
+			r.paymentRequests.On("ListIncomingPaymentRequests", anything(4)...).Return([]model.PaymentRequest{}, nil)
+		}, http.MethodGet, userPath(user1, "/payment-requests/incoming"), "", nil, http.StatusOK},
+		{"payment_request_get_not_found", memoryWallets, func(r *routeRepos) {
+			r.paymentRequests.On("GetPaymentRequest", anything(4)...).Return(nil, repo.ErrPaymentRequestNotFound)
+		}, http.MethodGet, userPath(stranger, "/payment-requests/"+requestID), "", nil, http.StatusNotFound},
+		{"payment_request_accept_frozen", memoryWallets, func(r *routeRepos) {
+			r.paymentRequests.On("GetPaymentRequest", anything(4)...).Return(&model.PaymentRequest{Amount: decimal.NewFromInt(15)}, nil)
+			r.paymentRequests.On("AcceptPaymentRequest", anything(6)...).Return(nil, repo.ErrWalletFrozen)
+		}, http.MethodPost, userPath(user1, "/payment-requests/"+requestID+"/accept"), `{"wallet_id": "` + frozenWallet + `"}`, nil, http.StatusLocked},
+		{"payment_request_accept_insufficient_funds", memoryWallets, func(r *routeRepos) {
+			r.paymentRequests.On("GetPaymentRequest", anything(4)...).Return(&model.PaymentRequest{Amount: decimal.NewFromInt(15)}, nil)
+			r.paymentRequests.On("AcceptPaymentRequest", anything(6)...).Return(nil, repo.ErrInsufficientFunds)
+		}, http.MethodPost, userPath(user1, "/payment-requests/"+requestID+"/accept"), `{"wallet_id": "` + wallet1 + `"}`, nil, http.StatusBadRequest},
+		{"payment_request_accept_expired", memoryWallets, func(r *routeRepos) {
+			r.paymentRequests.On("GetPaymentRequest", anything(4)...).Return(&model.PaymentRequest{Amount: decimal.NewFromInt(15)}, nil)
+			r.paymentRequests.On("AcceptPaymentRequest", anything(6)...).Return(nil, repo.ErrPaymentRequestExpired)
+		}, http.MethodPost, userPath(user1, "/payment-requests/"+requestID+"/accept"), `{"wallet_id": "` + wallet1 + `"}`, nil, http.StatusGone},
+		{"payment_request_accept_missing_wallet", memoryWallets, nil, http.MethodPost, userPath(user1, "/payment-requests/"+requestID+"/accept"), `{}`, nil, http.StatusBadRequest},
+		{"payment_request_decline_not_pending", memoryWallets, func(r *routeRepos) {
+			r.paymentRequests.On("DeclinePaymentRequest", anything(4)...).Return(nil, repo.ErrPaymentRequestNotPending)
+		}, http.MethodPost, userPath(user1, "/payment-requests/"+requestID+"/decline"), "", nil, http.StatusConflict},
+		{"payment_request_cancel_repo_error", memoryWallets, func(r *routeRepos) {
+			r.paymentRequests.On("CancelPaymentRequest", anything(4)...).Return(nil, errConnection)
+		}, http.MethodPost, userPath(user2, "/payment-requests/"+requestID+"/cancel"), "", nil, http.StatusInternalServerError},
+
+		// /v1/recipients/lookup, /v1/user/:userId/handle and /v1/user/:userId/wallet/:walletId/default
+		{"recipient_lookup", memoryWallets, func(r *routeRepos) {
+			r.users.On("FindRecipientByHandle", mock.Anything, "ada").Return(&model.Recipient{Name: "Ada Lovelace"}, nil)
+		}, http.MethodGet, "/v1/recipients/lookup?handle=@ada", "", nil, http.StatusOK},
+		{"recipient_lookup_no_query", memoryWallets, nil, http.MethodGet, "/v1/recipients/lookup", "", nil, http.StatusBadRequest},
+		{"recipient_lookup_not_found", memoryWallets, func(r *routeRepos) {
+			r.users.On("FindRecipientByEmail", mock.Anything, "nobody@example.com").Return(nil, repo.ErrRecipientNotFound)
+		}, http.MethodGet, "/v1/recipients/lookup?email=nobody@example.com", "", nil, http.StatusNotFound},
+		{"handle_invalid", memoryWallets, nil, http.MethodPut, userPath(user1, "/handle"), `{"handle": "no spaces allowed"}`, nil, http.StatusBadRequest},
+		{"handle_taken", memoryWallets, func(r *routeRepos) {
+			r.users.On("SetHandle", anything(3)...).Return(nil, repo.ErrHandleTaken)
+		}, http.MethodPut, userPath(user1, "/handle"), `{"handle": "ada"}`, nil, http.StatusConflict},
+		{"handle_repo_error", memoryWallets, func(r *routeRepos) {
+			r.users.On("SetHandle", anything(3)...).Return(nil, errConnection)
+		}, http.MethodPut, userPath(user1, "/handle"), `{"handle": "ada"}`, nil, http.StatusInternalServerError},
+		{"default_wallet", memoryWallets, func(r *routeRepos) {
+			r.users.On("SetDefaultWallet", anything(3)...).Return(nil)
+		}, http.MethodPut, walletPath(user1, wallet1, "/default"), "", nil, http.StatusNoContent},
+		{"default_wallet_viewer", memoryWallets, func(r *routeRepos) {
+			r.users.On("SetDefaultWallet", anything(3)...).Return(repo.ErrWalletForbidden)
+		}, http.MethodPut, walletPath(viewer, wallet1, "/default"), "", nil, http.StatusForbidden},
+
+		// /v1/fees
+		{"fee_quote", memoryWallets, nil, http.MethodGet, "/v1/fees/quote?operation=withdrawal&amount=10", "", nil, http.StatusOK},
+		{"fee_quote_bad_amount", memoryWallets, nil, http.MethodGet, "/v1/fees/quote?operation=withdrawal&amount=ten", "", nil, http.StatusBadRequest},
+		{"fee_quote_bad_operation", memoryWallets, nil, http.MethodGet, "/v1/fees/quote?operation=deposit&amount=10", "", nil, http.StatusBadRequest},
+		{"fee_schedules_repo_error", memoryWallets, func(r *routeRepos) {
+			r.fees.On("ListFeeSchedules", anything(2)...).Return(nil, errConnection)
+		}, http.MethodGet, "/v1/fees/schedules", "", nil, http.StatusInternalServerError},
+
+		// /v1/products and /v1/user/:userId/wallet/:walletId/product and /interest
+		{"products_repo_error", memoryWallets, func(r *routeRepos) {
+			r.interest.On("ListWalletProducts", anything(1)...).Return(nil, errConnection)
+		}, http.MethodGet, "/v1/products", "", nil, http.StatusInternalServerError},
+		{"wallet_product_not_found", memoryWallets, func(r *routeRepos) {
+			r.interest.On("SetWalletProduct", anything(5)...).Return(nil, repo.ErrWalletProductNotFound)
+		}, http.MethodPut, walletPath(user1, wallet1, "/product"), `{"product_id": "` + productID + `"}`, nil, http.StatusNotFound},
+		{"wallet_product_viewer", memoryWallets, func(r *routeRepos) {
+			r.interest.On("SetWalletProduct", anything(5)...).Return(nil, repo.ErrWalletForbidden)
+		}, http.MethodPut, walletPath(viewer, wallet1, "/product"), `{"product_id": "` + productID + `"}`, nil, http.StatusForbidden},
+		{"wallet_product_malformed_body", memoryWallets, nil, http.MethodPut, walletPath(user1, wallet1, "/product"), `{"product_id": 7}`, nil, http.StatusBadRequest},
+		{"interest_summary_not_member", memoryWallets, func(r *routeRepos) {
+			r.interest.On("GetInterestSummary", anything(3)...).Return(nil, repo.ErrWalletNotFound)
+		}, http.MethodGet, walletPath(stranger, wallet1, "/interest"), "", nil, http.StatusNotFound},
+
+		// /v1/user/:userId/wallet/:walletId/pots
+		{"pot_create", memoryWallets, func(r *routeRepos) {
+			r.pots.On("CreatePot", anything(3)...).Return(nil)
+		}, http.MethodPost, walletPath(user1, wallet1, "/pots"), `{"name": "Holiday", "target_amount": "500"}`, nil, http.StatusCreated},
+		{"pot_create_invalid", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/pots"), `{"name": "Holiday", "target_amount": "-1"}`, nil, http.StatusBadRequest},
+		{"pot_create_name_taken", memoryWallets, func(r *routeRepos) {
+			r.pots.On("CreatePot", anything(3)...).Return(repo.ErrPotNameTaken)
+		}, http.MethodPost, walletPath(user1, wallet1, "/pots"), `{"name": "Holiday"}`, nil, http.StatusConflict},
+		{"pot_list_not_member", memoryWallets, func(r *routeRepos) {
+			r.pots.On("ListPots", anything(3)...).Return(nil, repo.ErrWalletNotFound)
+		}, http.MethodGet, walletPath(stranger, wallet1, "/pots"), "", nil, http.StatusNotFound},
+		{"pot_get_not_found", memoryWallets, func(r *routeRepos) {
+			r.pots.On("GetPot", anything(4)...).Return(nil, repo.ErrPotNotFound)
+		}, http.MethodGet, walletPath(user1, wallet1, "/pots/"+potID), "", nil, http.StatusNotFound},
+		{"pot_update_viewer", memoryWallets, func(r *routeRepos) {
+			r.pots.On("UpdatePot", anything(8)...).Return(nil, repo.ErrWalletForbidden)
+		}, http.MethodPut, walletPath(viewer, wallet1, "/pots/"+potID), `{"name": "Trip"}`, nil, http.StatusForbidden},
+		{"pot_move_in_insufficient_funds", memoryWallets, func(r *routeRepos) {
+			r.pots.On("MovePotMoney", anything(7)...).Return(nil, repo.ErrInsufficientFunds)
+		}, http.MethodPost, walletPath(user1, wallet1, "/pots/"+potID+"/move-in"), `{"amount": "1000"}`, nil, http.StatusBadRequest},
+		{"pot_move_out_closed", memoryWallets, func(r *routeRepos) {
+			r.pots.On("MovePotMoney", anything(7)...).Return(nil, repo.ErrPotClosed)
+		}, http.MethodPost, walletPath(user1, wallet1, "/pots/"+potID+"/move-out"), `{"amount": "5"}`, nil, http.StatusConflict},
+		{"pot_move_in_missing_amount", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/pots/"+potID+"/move-in"), `{}`, nil, http.StatusBadRequest},
+		{"pot_close_repo_error", memoryWallets, func(r *routeRepos) {
+			r.pots.On("ClosePot", anything(5)...).Return(nil, errConnection)
+		}, http.MethodDelete, walletPath(user1, wallet1, "/pots/"+potID), "", nil, http.StatusInternalServerError},
+
+		// /v1/user/:userId/wallet/:walletId/members and /v1/user/:userId/wallet-invitations
+		{"members_list", memoryWallets, func(r *routeRepos) {
+			r.members.On("ListWalletMembers", anything(3)...).Return([]model.WalletMember{
+				{WalletID: uuid.MustParse(wallet1), UserID: uuid.MustParse(user1), Role: model.WalletRoleOwner, Status: model.WalletMemberStatusActive},
+			}, nil)
+		}, http.MethodGet, walletPath(user1, wallet1, "/members"), "", nil, http.StatusOK},
+		{"member_invite_invalid_role", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/members"), `{"user_id": "` + user2 + `", "role": "boss"}`, nil, http.StatusBadRequest},
+		{"member_invite_exists", memoryWallets, func(r *routeRepos) {
+			r.members.On("InviteWalletMember", anything(3)...).Return(repo.ErrWalletMemberExists)
+		}, http.MethodPost, walletPath(user1, wallet1, "/members"), `{"user_id": "` + viewer + `", "role": "spender"}`, nil, http.StatusConflict},
+		{"member_invite_user_not_found", memoryWallets, func(r *routeRepos) {
+			r.members.On("InviteWalletMember", anything(3)...).Return(repo.ErrUserNotFound)
+		}, http.MethodPost, walletPath(user1, wallet1, "/members"), `{"user_id": "` + stranger + `", "role": "spender"}`, nil, http.StatusNotFound},
+		{"member_update_viewer", memoryWallets, func(r *routeRepos) {
+			r.members.On("UpdateWalletMemberRole", anything(6)...).Return(nil, repo.ErrWalletForbidden)
+		}, http.MethodPut, walletPath(viewer, wallet1, "/members/"+user2), `{"role": "owner"}`, nil, http.StatusForbidden},
+		{"member_remove_last_owner", memoryWallets, func(r *routeRepos) {
+			r.members.On("RemoveWalletMember", anything(4)...).Return(repo.ErrLastWalletOwner)
+		}, http.MethodDelete, walletPath(user1, wallet1, "/members/"+user1), "", nil, http.StatusConflict},
+		{"invitations_repo_error", memoryWallets, func(r *routeRepos) {
+			r.members.On("ListWalletInvitations", anything(2)...).Return(nil, errConnection)
+		}, http.MethodGet, userPath(user2, "/wallet-invitations"), "", nil, http.StatusInternalServerError},
+		{"invitation_accept_not_found", memoryWallets, func(r *routeRepos) {
+			r.members.On("AcceptWalletInvitation", anything(4)...).Return(nil, repo.ErrWalletMemberNotFound)
+		}, http.MethodPost, userPath(user2, "/wallet-invitations/"+wallet1+"/accept"), "", nil, http.StatusNotFound},
+
+		// /v1/user/:userId/wallet/:walletId/approval-policy and /transfer-proposals
+		{"approval_policy_set", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("SetApprovalPolicy", anything(3)...).Return(nil)
+		}, http.MethodPut, walletPath(user1, wallet1, "/approval-policy"), `{"threshold": "50", "required_approvals": 1}`, nil, http.StatusOK},
+		{"approval_policy_set_invalid", memoryWallets, nil, http.MethodPut, walletPath(user1, wallet1, "/approval-policy"), `{"threshold": "-50", "required_approvals": 1}`, nil, http.StatusBadRequest},
+		{"approval_policy_set_too_few_approvers", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("SetApprovalPolicy", anything(3)...).Return(repo.ErrTooFewApprovers)
+		}, http.MethodPut, walletPath(user1, wallet1, "/approval-policy"), `{"threshold": "50", "required_approvals": 3}`, nil, http.StatusConflict},
+		{"approval_policy_get_not_found", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("GetApprovalPolicy", anything(3)...).Return(nil, repo.ErrApprovalPolicyNotFound)
+		}, http.MethodGet, walletPath(user1, wallet1, "/approval-policy"), "", nil, http.StatusNotFound},
+		{"approval_policy_delete_viewer", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("DeleteApprovalPolicy", anything(3)...).Return(repo.ErrWalletForbidden)
+		}, http.MethodDelete, walletPath(viewer, wallet1, "/approval-policy"), "", nil, http.StatusForbidden},
+		{"proposals_list_repo_error", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("ListTransferProposals", anything(4)...).Return(nil, errConnection)
+		}, http.MethodGet, walletPath(user1, wallet1, "/transfer-proposals"), "", nil, http.StatusInternalServerError},
+		{"proposal_get_not_found", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("GetTransferProposal", anything(4)...).Return(nil, repo.ErrTransferProposalNotFound)
+		}, http.MethodGet, walletPath(user1, wallet1, "/transfer-proposals/"+proposalID), "", nil, http.StatusNotFound},
+		{"proposal_approve_self", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("ApproveTransferProposal", anything(5)...).Return(nil, repo.ErrSelfApproval)
+		}, http.MethodPost, walletPath(user1, wallet1, "/transfer-proposals/"+proposalID+"/approve"), "", nil, http.StatusForbidden},
+		{"proposal_approve_twice", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("ApproveTransferProposal", anything(5)...).Return(nil, repo.ErrAlreadyApproved)
+		}, http.MethodPost, walletPath(user2, wallet1, "/transfer-proposals/"+proposalID+"/approve"), "", nil, http.StatusConflict},
+		{"proposal_approve_expired", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("ApproveTransferProposal", anything(5)...).Return(nil, repo.ErrTransferProposalExpired)
+		}, http.MethodPost, walletPath(user2, wallet1, "/transfer-proposals/"+proposalID+"/approve"), "", nil, http.StatusGone},
+		{"proposal_approve_frozen", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("ApproveTransferProposal", anything(5)...).Return(nil, repo.ErrWalletFrozen)
+		}, http.MethodPost, walletPath(user2, frozenWallet, "/transfer-proposals/"+proposalID+"/approve"), "", nil, http.StatusLocked},
+		{"proposal_approve_insufficient_funds", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("ApproveTransferProposal", anything(5)...).Return(nil, repo.ErrInsufficientFunds)
+		}, http.MethodPost, walletPath(user2, wallet1, "/transfer-proposals/"+proposalID+"/approve"), "", nil, http.StatusBadRequest},
+		{"proposal_cancel_not_pending", memoryWallets, func(r *routeRepos) {
+			r.proposals.On("CancelTransferProposal", anything(5)...).Return(nil, repo.ErrTransferProposalNotPending)
+		}, http.MethodPost, walletPath(user1, wallet1, "/transfer-proposals/"+proposalID+"/cancel"), "", nil, http.StatusConflict},
+
+		// /v1/user/:userId/wallet/:walletId/escrows and /v1/admin/escrows
+		{"escrow_create", memoryWallets, func(r *routeRepos) {
+			r.escrows.On("CreateEscrow", anything(4)...).Return(nil)
+		}, http.MethodPost, walletPath(user1, wallet1, "/escrows"), `{"seller_wallet_id": "` + wallet2 + `", "amount": "20", "memo": "bike"}`, nil, http.StatusCreated},
+		{"escrow_create_invalid", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/escrows"), `{"seller_wallet_id": "` + wallet1 + `", "amount": "20"}`, nil, http.StatusBadRequest},
+		{"escrow_create_frozen", memoryWallets, func(r *routeRepos) {
+			r.escrows.On("CreateEscrow", anything(4)...).Return(repo.ErrWalletFrozen)
+		}, http.MethodPost, walletPath(user1, frozenWallet, "/escrows"), `{"seller_wallet_id": "` + wallet2 + `", "amount": "20"}`, nil, http.StatusLocked},
+		{"escrow_create_seller_credit_blocked", memoryWallets, func(r *routeRepos) {
+			r.escrows.On("CreateEscrow", anything(4)...).Return(repo.ErrWalletCreditBlocked)
+		}, http.MethodPost, walletPath(user1, wallet1, "/escrows"), `{"seller_wallet_id": "` + creditBlocked + `", "amount": "20"}`, nil, http.StatusConflict},
+		{"escrow_create_repo_error", memoryWallets, func(r *routeRepos) {
+			r.escrows.On("CreateEscrow", anything(4)...).Return(errConnection)
+		}, http.MethodPost, walletPath(user1, wallet1, "/escrows"), `{"seller_wallet_id": "` + wallet2 + `", "amount": "20"}`, nil, http.StatusInternalServerError},
+		{"escrow_get_not_found", memoryWallets, func(r *routeRepos) {
+			r.escrows.On("GetEscrow", anything(4)...).Return(nil, repo.ErrEscrowNotFound)
+		}, http.MethodGet, walletPath(user1, wallet1, "/escrows/"+escrowID), "", nil, http.StatusNotFound},
+		{"escrow_confirm_not_buyer", memoryWallets, func(r *routeRepos) {
+			r.escrows.On("ConfirmEscrow", anything(5)...).Return(nil, repo.ErrEscrowNotBuyer)
+		}, http.MethodPost, walletPath(user2, wallet2, "/escrows/"+escrowID+"/confirm"), "", nil, http.StatusForbidden},
+		{"escrow_confirm_not_held", memoryWallets, func(r *routeRepos) {
+			r.escrows.On("ConfirmEscrow", anything(5)...).Return(nil, repo.ErrEscrowNotHeld)
+		}, http.MethodPost, walletPath(user1, wallet1, "/escrows/"+escrowID+"/confirm"), "", nil, http.StatusConflict},
+		{"escrow_queue", memoryWallets, func(r *routeRepos) {
+			r.escrows.On("ListEscrowsByStatus", mock.Anything, model.EscrowStatusHeld).Return([]model.Escrow{}, nil)
+		}, http.MethodGet, "/v1/admin/escrows?status=held", "", adminHeader, http.StatusOK},
+		{"escrow_decision_invalid", memoryWallets, nil, http.MethodPost, "/v1/admin/escrows/" + escrowID + "/decision", `{"outcome": "held"}`, adminHeader, http.StatusBadRequest},
+		{"escrow_decision_not_held", memoryWallets, func(r *routeRepos) {
+			r.escrows.On("SettleEscrow", anything(6)...).Return(nil, repo.ErrEscrowNotHeld)
+		}, http.MethodPost, "/v1/admin/escrows/" + escrowID + "/decision", `{"outcome": "refunded"}`, adminHeader, http.StatusConflict},
+
+		// /v1/user/:userId/wallet/:walletId/disputes and /v1/admin/disputes
+		{"dispute_open_invalid", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/disputes"), `{"transaction_id": "not-a-uuid", "reason": "never arrived"}`, nil, http.StatusBadRequest},
+		{"dispute_open_not_disputable", memoryWallets, func(r *routeRepos) {
+			r.disputes.On("OpenDispute", anything(5)...).Return(repo.ErrTransactionNotDisputable)
+		}, http.MethodPost, walletPath(user1, wallet1, "/disputes"), `{"transaction_id": "` + transactionID + `", "reason": "never arrived"}`, nil, http.StatusNotFound},
+		{"dispute_open_exists", memoryWallets, func(r *routeRepos) {
+			r.disputes.On("OpenDispute", anything(5)...).Return(repo.ErrDisputeExists)
+		}, http.MethodPost, walletPath(user1, wallet1, "/disputes"), `{"transaction_id": "` + transactionID + `", "reason": "never arrived"}`, nil, http.StatusConflict},
+		{"dispute_open_window_closed", memoryWallets, func(r *routeRepos) {
+			r.disputes.On("OpenDispute", anything(5)...).Return(repo.ErrDisputeWindowClosed)
+		}, http.MethodPost, walletPath(user1, wallet1, "/disputes"), `{"transaction_id": "` + transactionID + `", "reason": "never arrived"}`, nil, http.StatusConflict},
+		{"dispute_open_viewer", memoryWallets, func(r *routeRepos) {
+			r.disputes.On("OpenDispute", anything(5)...).Return(repo.ErrWalletForbidden)
+		}, http.MethodPost, walletPath(viewer, wallet1, "/disputes"), `{"transaction_id": "` + transactionID + `", "reason": "never arrived"}`, nil, http.StatusForbidden},
+		{"disputes_list_repo_error", memoryWallets, func(r *routeRepos) {
+			r.disputes.On("ListDisputes", anything(3)...).Return(nil, errConnection)
+		}, http.MethodGet, walletPath(user1, wallet1, "/disputes"), "", nil, http.StatusInternalServerError},
+		{"dispute_get_not_found", memoryWallets, func(r *routeRepos) {
+			r.disputes.On("GetDispute", anything(4)...).Return(nil, repo.ErrDisputeNotFound)
+		}, http.MethodGet, walletPath(user1, wallet1, "/disputes/"+disputeID), "", nil, http.StatusNotFound},
+		{"dispute_note_missing_body", memoryWallets, nil, http.MethodPost, walletPath(user1, wallet1, "/disputes/"+disputeID+"/notes"), `{}`, nil, http.StatusBadRequest},
+		{"dispute_withdraw_not_open", memoryWallets, func(r *routeRepos) {
+			r.disputes.On("WithdrawDispute", anything(5)...).Return(nil, repo.ErrDisputeNotOpen)
+		}, http.MethodPost, walletPath(user1, wallet1, "/disputes/"+disputeID+"/withdraw"), "", nil, http.StatusConflict},
+		{"dispute_queue", memoryWallets, func(r *routeRepos) {
+			r.disputes.On("ListDisputesByStatus", mock.Anything, model.DisputeStatusOpen).Return([]model.Dispute{}, nil)
+		}, http.MethodGet, "/v1/admin/disputes?status=open", "", adminHeader, http.StatusOK},
+		{"dispute_case_not_found", memoryWallets, func(r *routeRepos) {
+			r.disputes.On("GetDisputeByID", anything(2)...).Return(nil, repo.ErrDisputeNotFound)
+		}, http.MethodGet, "/v1/admin/disputes/" + disputeID, "", adminHeader, http.StatusNotFound},
+		{"dispute_resolution_invalid", memoryWallets, nil, http.MethodPost, "/v1/admin/disputes/" + disputeID + "/resolution", `{"in_favor_of": "nobody"}`, adminHeader, http.StatusBadRequest},
+		{"dispute_resolution_insufficient_funds", memoryWallets, func(r *routeRepos) {
+			r.disputes.On("ResolveDispute", anything(5)...).Return(nil, repo.ErrInsufficientFunds)
+		}, http.MethodPost, "/v1/admin/disputes/" + disputeID + "/resolution", `{"in_favor_of": "payer"}`, adminHeader, http.StatusConflict},
+
+		// /v1/admin
+		{"admin_users", memoryWallets, func(r *routeRepos) {
+			r.admin.On("SearchUsers", mock.Anything, "alice", "ada", mock.Anything, mock.Anything).Return([]model.User{{ID: uuid.MustParse(user1), Name: "Ada Lovelace"}}, nil)
+		}, http.MethodGet, "/v1/admin/users?q=ada", "", adminHeader, http.StatusOK},
+		{"admin_wallets_repo_error", memoryWallets, func(r *routeRepos) {
+			r.admin.On("SearchWallets", anything(6)...).Return(nil, errConnection)
+		}, http.MethodGet, "/v1/admin/wallets?q=main", "", adminHeader, http.StatusInternalServerError},
+		{"admin_status_invalid", memoryWallets, nil, http.MethodPut, "/v1/admin/wallets/" + wallet1 + "/status", `{"status": "sleeping", "reason": "test"}`, adminHeader, http.StatusBadRequest},
+		{"admin_freeze_not_found", memoryWallets, func(r *routeRepos) {
+			r.admin.On("SetWalletStatus", anything(7)...).Return(nil, repo.ErrWalletNotFound)
+		}, http.MethodPost, "/v1/admin/wallets/" + missingWallet + "/freeze", `{"reason": "fraud report"}`, adminHeader, http.StatusNotFound},
+		{"admin_freeze_unchanged", memoryWallets, func(r *routeRepos) {
+			r.admin.On("SetWalletStatus", anything(7)...).Return(nil, repo.ErrWalletStatusUnchanged)
+		}, http.MethodPost, "/v1/admin/wallets/" + frozenWallet + "/freeze", `{"reason": "fraud report"}`, adminHeader, http.StatusConflict},
+		{"admin_unfreeze_missing_reason", memoryWallets, nil, http.MethodPost, "/v1/admin/wallets/" + frozenWallet + "/unfreeze", `{}`, adminHeader, http.StatusBadRequest},
+		{"admin_adjustment_insufficient_funds", memoryWallets, func(r *routeRepos) {
+			r.admin.On("AdjustWallet", anything(7)...).Return(nil, repo.ErrInsufficientFunds)
+		}, http.MethodPost, "/v1/admin/wallets/" + wallet1 + "/adjustments", `{"direction": "debit", "amount": "500", "reason_code": "error_correction"}`, adminHeader, http.StatusConflict},
+		{"admin_adjustment_invalid", memoryWallets, nil, http.MethodPost, "/v1/admin/wallets/" + wallet1 + "/adjustments", `{"direction": "sideways", "amount": "5", "reason_code": "error_correction"}`, adminHeader, http.StatusBadRequest},
+		{"admin_audit_log", memoryWallets, func(r *routeRepos) {
+			r.admin.On("ListAdminAuditLog", anything(4)...).Return([]model.AdminAuditEntry{}, nil)
+		}, http.MethodGet, "/v1/admin/audit-log?wallet_id=" + wallet1, "", adminHeader, http.StatusOK},
+		{"audit_trail_bad_cursor", memoryWallets, nil, http.MethodGet, "/v1/admin/audit-trail?after_seq=first", "", adminHeader, http.StatusBadRequest},
+		{"audit_trail_negative_limit", memoryWallets, nil, http.MethodGet, "/v1/admin/audit-trail?limit=-1", "", adminHeader, http.StatusBadRequest},
+		{"audit_trail_repo_error", memoryWallets, func(r *routeRepos) {
+			r.auditTrail.On("ListAuditTrail", anything(3)...).Return(nil, errConnection)
+		}, http.MethodGet, "/v1/admin/audit-trail", "", adminHeader, http.StatusInternalServerError},
+		{"audit_trail_verify_repo_error", memoryWallets, func(r *routeRepos) {
+			r.auditTrail.On("GetAuditTrailHead", anything(1)...).Return(nil, errConnection)
+		}, http.MethodGet, "/v1/admin/audit-trail/verify", "", adminHeader, http.StatusInternalServerError},
+
+		// /healthz and /readyz
+		{"healthz", memoryWallets, nil, http.MethodGet, "/healthz", "", nil, http.StatusOK},
+		{"readyz", memoryWallets, func(r *routeRepos) {
+			r.health.On("Ping", anything(1)...).Return(nil)
+			r.health.On("PoolStats").Return(model.PoolStats{MaxOpenConnections: 10, OpenConnections: 2, Idle: 2})
+			r.health.On("GetMigrationState", anything(1)...).Return(&model.MigrationState{Latest: 42, Pending: []int64{}}, nil)
+		}, http.MethodGet, "/readyz", "", nil, http.StatusOK},
+		{"readyz_database_down", memoryWallets, func(r *routeRepos) {
+			r.health.On("Ping", anything(1)...).Return(errConnection)
+			r.health.On("PoolStats").Return(model.PoolStats{MaxOpenConnections: 10})
+			r.health.On("GetMigrationState", anything(1)...).Return(nil, errConnection)
+		}, http.MethodGet, "/readyz", "", nil, http.StatusServiceUnavailable},
+
+		// Routing and middleware
+		{"unknown_route", memoryWallets, nil, http.MethodGet, "/v1/nowhere", "", nil, http.StatusNotFound},
+		{"admin_without_token", memoryWallets, nil, http.MethodGet, "/v1/admin/users", "", nil, http.StatusUnauthorized},
+		{"admin_wrong_token", memoryWallets, nil, http.MethodGet, "/v1/admin/users", "", http.Header{"Authorization": {"Bearer nope"}}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t, tt.wallets(t))
+			if tt.stub != nil {
+				tt.stub(ts.repos)
+			}
+
+			rec := ts.do(tt.method, tt.path, tt.body, tt.header)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assertGolden(t, tt.name, snapshot(t, rec))
+		})
+	}
+}
+
+func TestRoutes_Balances(t *testing.T) {
+	ts := newTestServer(t, memoryWallets(t))
+
+	steps := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodPost, walletPath(user1, wallet1, "/deposit"), `{"amount": "10"}`},
+		{http.MethodPost, walletPath(user1, wallet1, "/withdraw"), `{"amount": "20"}`},
+		{http.MethodPost, walletPath(user1, wallet1, "/transfer"), `{"amount": "30", "destination_wallet_id": "` + wallet2 + `"}`},
+	}
+	for _, step := range steps {
+		rec := ts.do(step.method, step.path, step.body, nil)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	for wallet, want := range map[[2]string]string{{user1, wallet1}: "60", {user2, wallet2}: "30"} {
+		rec := ts.do(http.MethodGet, walletPath(wallet[0], wallet[1], ""), "", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			Data model.Wallet `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.True(t, resp.Data.Balance.Equal(decimal.RequireFromString(want)), "balance of %s = %s, want %s", wallet[1], resp.Data.Balance, want)
+	}
+
+	rec := ts.do(http.MethodGet, walletPath(user1, wallet1, "/transactions"), "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct {
+		Data []model.Transaction `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 3)
+	assert.Equal(t, model.TransactionTypeTransfer, resp.Data[0].Type)
+	assert.Equal(t, model.TransactionTypeWithdrawal, resp.Data[1].Type)
+	assert.Equal(t, model.TransactionTypeDeposit, resp.Data[2].Type)
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+	}{
+		{"propagated", http.Header{"X-Request-Id": {"e2e-request-1"}}},
+		{"generated", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t, memoryWallets(t))
+
+			rec := ts.do(http.MethodPost, walletPath(user1, wallet1, "/deposit"), `{"amount": "1"}`, tt.header)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			requestID := rec.Header().Get("X-Request-Id")
+			if tt.header != nil {
+				assert.Equal(t, tt.header.Get("X-Request-Id"), requestID)
+			} else {
+				_, err := uuid.Parse(requestID)
+				assert.NoError(t, err, "generated request ID %q", requestID)
+			}
+
+			// The audit trail records the call under the same request ID
+			ts.mu.Lock()
+			defer ts.mu.Unlock()
+			require.Len(t, ts.entries, 1)
+			entry := ts.entries[0]
+			assert.Equal(t, requestID, entry.RequestID)
+			assert.Equal(t, "user:"+user1, entry.Actor)
+			assert.Equal(t, "/v1/user/:userId/wallet/:walletId/deposit", entry.Route)
+			assert.Equal(t, http.StatusOK, entry.Status)
+			assert.Equal(t, []string{"amount"}, entry.Params.Body)
+		})
+	}
+}
+
+// snapshot renders the status, content type and body of a response with the IDs and times that change from run
+// to run replaced by placeholders.
+func snapshot(t *testing.T, rec *httptest.ResponseRecorder) []byte {
+	t.Helper()
+	body := rec.Body.Bytes()
+	var indented bytes.Buffer
+	if json.Indent(&indented, body, "", "  ") == nil {
+		body = indented.Bytes()
+	}
+	text := string(body)
+	for id, name := range placeholders {
+		text = strings.ReplaceAll(text, id, name)
+	}
+	text = uuidPattern.ReplaceAllString(text, "<uuid>")
+	text = timePattern.ReplaceAllString(text, "<time>")
+	text = latencyPattern.ReplaceAllString(text, `"latency_ms": "<latency>"`)
+	text = messageIDPattern.ReplaceAllString(text, "<MsgId><uuid></MsgId>")
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "status: %d\n", rec.Code)
+	fmt.Fprintf(&b, "content-type: %s\n\n", rec.Header().Get("Content-Type"))
+	b.WriteString(strings.TrimSpace(text))
+	b.WriteString("\n")
+	return b.Bytes()
+}
+
+// assertGolden compares got with testdata/<name>.golden, or rewrites the file when the tests run with -update.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run the tests with -update to create the golden file")
+	assert.Equal(t, string(want), string(got))
+}
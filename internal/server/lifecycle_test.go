@@ -0,0 +1,226 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/server"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/internal/service/mocks"
+)
+
+// blockingWallets holds every deposit until release is closed, after telling entered it started.
+type blockingWallets struct {
+	service.WalletRepo
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (bw blockingWallets) Deposit(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal) (*model.Transaction, error) {
+	bw.entered <- struct{}{}
+	<-bw.release
+	return bw.WalletRepo.Deposit(ctx, userIDStr, walletIDStr, amount)
+}
+
+func TestShutdown(t *testing.T) {
+	tests := []struct {
+		name string
+		// finish is how long the in-flight deposit still takes once shutdown starts.
+		finish   time.Duration
+		deadline time.Duration
+		wantErr  error
+		wantCode int
+	}{
+		{name: "drains in-flight requests", finish: 50 * time.Millisecond, deadline: 5 * time.Second, wantCode: http.StatusOK},
+		{name: "cuts off requests past the deadline", finish: time.Second, deadline: 50 * time.Millisecond, wantErr: context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallets := blockingWallets{WalletRepo: memoryWallets(t), entered: make(chan struct{}, 1), release: make(chan struct{})}
+			ts := newTestServer(t, wallets)
+
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			served := make(chan error, 1)
+			go func() { served <- ts.srv.Serve(ln) }()
+
+			type result struct {
+				code int
+				err  error
+			}
+			responded := make(chan result, 1)
+			go func() {
+				resp, err := http.Post("http://"+ln.Addr().String()+walletPath(user1, wallet1, "/deposit"),
+					"application/json", strings.NewReader(`{"amount": "5"}`))
+				if err != nil {
+					responded <- result{err: err}
+					return
+				}
+				resp.Body.Close()
+				responded <- result{code: resp.StatusCode}
+			}()
+			<-wallets.entered
+
+			time.AfterFunc(tt.finish, func() { close(wallets.release) })
+			ctx, cancel := context.WithTimeout(context.Background(), tt.deadline)
+			defer cancel()
+			err = ts.srv.Shutdown(ctx)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Error(t, (<-responded).err, "the request must be cut off")
+			} else {
+				require.NoError(t, err)
+				got := <-responded
+				require.NoError(t, got.err)
+				assert.Equal(t, tt.wantCode, got.code)
+			}
+			assert.NoError(t, <-served, "Serve returns nil once shut down")
+
+			// The listener is closed: new connections are refused.
+			_, err = net.DialTimeout("tcp", ln.Addr().String(), time.Second)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// blockingBatches accepts every batch and holds its execution until release is closed, after telling entered
+// it started. finished receives the final status of every batch.
+type blockingBatches struct {
+	service.TransferBatchRepo
+	entered  chan struct{}
+	release  chan struct{}
+	finished chan model.TransferBatchStatus
+}
+
+func (bb blockingBatches) FindMissingWallets(context.Context, []uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (bb blockingBatches) CreateTransferBatch(context.Context, *model.TransferBatch) error {
+	return nil
+}
+
+func (bb blockingBatches) SetTransferBatchStatus(_ context.Context, _ uuid.UUID, status model.TransferBatchStatus) error {
+	if status != model.TransferBatchStatusRunning {
+		bb.finished <- status
+	}
+	return nil
+}
+
+func (bb blockingBatches) ExecuteTransferBatchAtomically(_ context.Context, batch *model.TransferBatch) error {
+	bb.entered <- struct{}{}
+	<-bb.release
+	for i := range batch.Items {
+		batch.Items[i].Status = model.TransferBatchItemStatusSucceeded
+	}
+	return nil
+}
+
+func TestShutdown_TransferBatches(t *testing.T) {
+	tests := []struct {
+		name string
+		// finish is how long the running batch still takes once shutdown starts.
+		finish   time.Duration
+		deadline time.Duration
+		wantErr  error
+	}{
+		{name: "waits for running batches", finish: 50 * time.Millisecond, deadline: 5 * time.Second},
+		{name: "gives up on batches past the deadline", finish: time.Second, deadline: 50 * time.Millisecond, wantErr: context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := blockingBatches{entered: make(chan struct{}, 1), release: make(chan struct{}), finished: make(chan model.TransferBatchStatus, 1)}
+			ts := newTestServer(t, memoryWallets(t), server.WithTransferBatchRepo(batches))
+
+			body := `{"mode": "all_or_nothing", "items": [{"destination_wallet_id": "` + wallet2 + `", "amount": "5"}]}`
+			rec := ts.do(http.MethodPost, walletPath(user1, wallet1, "/transfer-batches"), body, nil)
+			require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+			<-batches.entered
+
+			time.AfterFunc(tt.finish, func() { close(batches.release) })
+			ctx, cancel := context.WithTimeout(context.Background(), tt.deadline)
+			defer cancel()
+			err := ts.srv.Shutdown(ctx)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			select {
+			case status := <-batches.finished:
+				assert.Equal(t, model.TransferBatchStatusCompleted, status)
+			default:
+				t.Fatal("Shutdown returned before the batch finished")
+			}
+		})
+	}
+}
+
+func TestShutdown_NotStarted(t *testing.T) {
+	ts := newTestServer(t, memoryWallets(t))
+	assert.NoError(t, ts.srv.Shutdown(context.Background()))
+}
+
+func TestProbes(t *testing.T) {
+	healthRepo := mocks.NewHealthRepoMock(t)
+	healthRepo.On("Ping", mock.Anything).Return(nil)
+	healthRepo.On("PoolStats").Return(model.PoolStats{MaxOpenConnections: 25, OpenConnections: 1, Idle: 1})
+	healthRepo.On("GetMigrationState", mock.Anything).Return(&model.MigrationState{Latest: 24, Pending: []int64{}}, nil)
+	ts := newTestServer(t, memoryWallets(t), server.WithHealthRepo(healthRepo))
+
+	probe := func(path string) (int, model.HealthReport) {
+		rec := ts.do(http.MethodGet, path, "", nil)
+		var body struct {
+			Data model.HealthReport `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body), rec.Body.String())
+		return rec.Code, body.Data
+	}
+
+	code, live := probe("/healthz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, model.HealthStatusOK, live.Status)
+
+	code, ready := probe("/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, model.HealthStatusOK, ready.Status)
+	assert.ElementsMatch(t, []string{"shutdown", "database", "migrations"}, keys(ready.Checks))
+	assert.Equal(t, map[string]any{
+		"max_open_connections": 25.0, "open_connections": 1.0, "in_use": 0.0, "idle": 1.0,
+		"wait_count": 0.0, "wait_duration_ms": 0.0, "max_idle_closed": 0.0, "max_lifetime_closed": 0.0,
+	}, ready.Checks["database"].Details)
+
+	// Readiness fails from the start of a shutdown, while liveness holds.
+	require.NoError(t, ts.srv.Shutdown(context.Background()))
+	code, ready = probe("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, model.HealthStatusFail, ready.Status)
+	assert.Equal(t, model.HealthStatusFail, ready.Checks["shutdown"].Status)
+	assert.Equal(t, service.ErrShuttingDown.Error(), ready.Checks["shutdown"].Error)
+	assert.Equal(t, model.HealthStatusOK, ready.Checks["database"].Status)
+
+	code, _ = probe("/healthz")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func keys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,299 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	"github.com/kylenguyen/wallet-app/pkg/metrics"
+)
+
+// lockWaitBuckets are finer than metrics.DefBuckets at the low end, where an uncontended row lock is.
+var lockWaitBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5}
+
+// serverMetrics are the metrics served on /metrics.
+type serverMetrics struct {
+	registry *metrics.Registry
+
+	requestDuration   *metrics.HistogramVec
+	walletOperations  *metrics.CounterVec
+	amountMoved       *metrics.CounterVec
+	insufficientFunds *metrics.CounterVec
+	lockWait          *metrics.HistogramVec
+
+	auditTrailFailures *metrics.CounterVec
+}
+
+func newServerMetrics(db *sqlx.DB) *serverMetrics {
+	reg := metrics.NewRegistry()
+	m := &serverMetrics{
+		registry: reg,
+		requestDuration: reg.NewHistogramVec("http_request_duration_seconds",
+			"Time to handle HTTP requests, by method, route pattern and status.", metrics.DefBuckets, "method", "route", "status"),
+		walletOperations: reg.NewCounterVec("wallet_operations_total",
+			"Deposits, withdrawals, transfers and the other operations moving money between wallets, by outcome.", "operation", "outcome"),
+		amountMoved: reg.NewCounterVec("wallet_amount_moved_total",
+			"Amount moved by successful operations, in units of CURRENCY, fees excluded.", "operation"),
+		insufficientFunds: reg.NewCounterVec("wallet_insufficient_funds_total",
+			"Operations rejected because the paying wallet could not cover them.", "operation"),
+		lockWait: reg.NewHistogramVec("wallet_lock_wait_seconds",
+			"Time to read and lock a wallet row FOR UPDATE, by the operation locking it.", lockWaitBuckets, "operation"),
+		auditTrailFailures: reg.NewCounterVec("audit_trail_record_failures_total",
+			"Requests whose audit trail entry could not be recorded, by method and route pattern.", "method", "route"),
+	}
+	if db != nil {
+		registerPoolStats(reg, db)
+	}
+	return m
+}
+
+// registerPoolStats exposes the sql.DBStats of the connection pool.
+func registerPoolStats(reg *metrics.Registry, db *sqlx.DB) {
+	reg.NewGaugeFunc("db_pool_max_open_connections", "Maximum number of open connections to the database (MAXOPENCONNS).",
+		func() float64 { return float64(db.Stats().MaxOpenConnections) })
+	reg.NewGaugeFunc("db_pool_open_connections", "Established connections, in use or idle.",
+		func() float64 { return float64(db.Stats().OpenConnections) })
+	reg.NewGaugeFunc("db_pool_in_use_connections", "Connections currently in use.",
+		func() float64 { return float64(db.Stats().InUse) })
+	reg.NewGaugeFunc("db_pool_idle_connections", "Idle connections.",
+		func() float64 { return float64(db.Stats().Idle) })
+	reg.NewCounterFunc("db_pool_wait_count_total", "Times a request waited for a free connection.",
+		func() float64 { return float64(db.Stats().WaitCount) })
+	reg.NewCounterFunc("db_pool_wait_duration_seconds_total", "Time spent waiting for a free connection.",
+		func() float64 { return db.Stats().WaitDuration.Seconds() })
+	reg.NewCounterFunc("db_pool_max_idle_closed_total", "Connections closed because of MAXIDLECONNS.",
+		func() float64 { return float64(db.Stats().MaxIdleClosed) })
+	reg.NewCounterFunc("db_pool_max_lifetime_closed_total", "Connections closed because of CONNMAXLIFETIME.",
+		func() float64 { return float64(db.Stats().MaxLifetimeClosed) })
+}
+
+// httpMetrics is a middleware that times requests by route pattern, so wallet IDs do not each become a series.
+func (s *Server) httpMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		s.metrics.requestDuration.Observe(time.Since(start).Seconds(), c.Request.Method, route, strconv.Itoa(c.Writer.Status()))
+	}
+}
+
+// meteredWalletRepo counts the deposits, withdrawals and transfers made through a WalletRepo and times the row
+// locks they take.
+type meteredWalletRepo struct {
+	service.WalletRepo
+	metrics *serverMetrics
+}
+
+func (mr meteredWalletRepo) Deposit(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal) (*model.Transaction, error) {
+	transaction, err := mr.WalletRepo.Deposit(mr.metrics.observeLockWait(ctx, "deposit"), userIDStr, walletIDStr, amount)
+	mr.metrics.count("deposit", amount, err)
+	return transaction, err
+}
+
+func (mr meteredWalletRepo) Withdraw(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal, fee model.Fee) (*model.Transaction, error) {
+	transaction, err := mr.WalletRepo.Withdraw(mr.metrics.observeLockWait(ctx, "withdraw"), userIDStr, walletIDStr, amount, fee)
+	mr.metrics.count("withdraw", amount, err)
+	return transaction, err
+}
+
+func (mr meteredWalletRepo) Transfer(ctx context.Context, sourceUserIDStr string, sourceWalletIDStr string, destinationWalletIDStr string, amount decimal.Decimal, fee model.Fee) (*model.Transaction, error) {
+	transaction, err := mr.WalletRepo.Transfer(mr.metrics.observeLockWait(ctx, "transfer"), sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount, fee)
+	mr.metrics.count("transfer", amount, err)
+	return transaction, err
+}
+
+// meteredTransferBatchRepo counts the rows of transfer batches by outcome. A row whose transfer failed is recorded
+// as failed on the row rather than returned, so it is counted as failed whatever the reason.
+type meteredTransferBatchRepo struct {
+	service.TransferBatchRepo
+	metrics *serverMetrics
+}
+
+func (mr meteredTransferBatchRepo) ExecuteTransferBatchItem(ctx context.Context, batch *model.TransferBatch, item *model.TransferBatchItem) error {
+	err := mr.TransferBatchRepo.ExecuteTransferBatchItem(mr.metrics.observeLockWait(ctx, "batch_transfer"), batch, item)
+	if err != nil {
+		mr.metrics.count("batch_transfer", item.Amount, err)
+		return err
+	}
+	mr.countItem(item)
+	return nil
+}
+
+func (mr meteredTransferBatchRepo) ExecuteTransferBatchAtomically(ctx context.Context, batch *model.TransferBatch) error {
+	err := mr.TransferBatchRepo.ExecuteTransferBatchAtomically(mr.metrics.observeLockWait(ctx, "batch_transfer"), batch)
+	if err != nil {
+		mr.metrics.count("batch_transfer", decimal.Zero, err)
+		return err
+	}
+	for i := range batch.Items {
+		mr.countItem(&batch.Items[i])
+	}
+	return nil
+}
+
+// countItem counts a row that was executed. Rows skipped because another row of an atomic batch failed moved
+// nothing and were never tried, so they are not counted.
+func (mr meteredTransferBatchRepo) countItem(item *model.TransferBatchItem) {
+	switch item.Status {
+	case model.TransferBatchItemStatusSucceeded:
+		mr.metrics.count("batch_transfer", item.Amount, nil)
+	case model.TransferBatchItemStatusFailed:
+		mr.metrics.walletOperations.Inc("batch_transfer", "failed")
+	}
+}
+
+// meteredTransferProposalRepo counts the transfers made when a proposal gets its last approval. A failed approval is
+// counted too, as it may have been the one that would have executed the transfer.
+type meteredTransferProposalRepo struct {
+	service.TransferProposalRepo
+	metrics *serverMetrics
+}
+
+func (mr meteredTransferProposalRepo) ApproveTransferProposal(ctx context.Context, userID string, walletID string, proposalID string, now time.Time) (*model.TransferProposal, error) {
+	proposal, err := mr.TransferProposalRepo.ApproveTransferProposal(mr.metrics.observeLockWait(ctx, "proposal_transfer"), userID, walletID, proposalID, now)
+	switch {
+	case err != nil:
+		mr.metrics.count("proposal_transfer", decimal.Zero, err)
+	case proposal.Status == model.TransferProposalStatusExecuted:
+		mr.metrics.count("proposal_transfer", proposal.Amount, nil)
+	}
+	return proposal, err
+}
+
+// meteredPaymentRequestRepo counts the payments made by accepting payment requests.
+type meteredPaymentRequestRepo struct {
+	service.PaymentRequestRepo
+	metrics *serverMetrics
+}
+
+func (mr meteredPaymentRequestRepo) AcceptPaymentRequest(ctx context.Context, payerUserIDStr string, requestIDStr string, payerWalletIDStr string,
+	fee model.Fee, now time.Time) (*model.PaymentRequest, error) {
+	req, err := mr.PaymentRequestRepo.AcceptPaymentRequest(mr.metrics.observeLockWait(ctx, "payment_request"), payerUserIDStr, requestIDStr, payerWalletIDStr,
+		fee, now)
+	amount := decimal.Zero
+	if err == nil {
+		amount = req.Amount
+	}
+	mr.metrics.count("payment_request", amount, err)
+	return req, err
+}
+
+// meteredEscrowRepo counts the money moved into escrow when it is funded and out of it when it is released to the
+// seller or refunded to the buyer.
+type meteredEscrowRepo struct {
+	service.EscrowRepo
+	metrics *serverMetrics
+}
+
+func (mr meteredEscrowRepo) CreateEscrow(ctx context.Context, userID string, escrow *model.Escrow, fee model.Fee) error {
+	err := mr.EscrowRepo.CreateEscrow(mr.metrics.observeLockWait(ctx, "escrow_fund"), userID, escrow, fee)
+	mr.metrics.count("escrow_fund", escrow.Amount, err)
+	return err
+}
+
+func (mr meteredEscrowRepo) ConfirmEscrow(ctx context.Context, userID string, walletID string, escrowID string, at time.Time) (*model.Escrow, error) {
+	escrow, err := mr.EscrowRepo.ConfirmEscrow(mr.metrics.observeLockWait(ctx, "escrow_release"), userID, walletID, escrowID, at)
+	mr.countSettlement("escrow_release", escrow, err)
+	return escrow, err
+}
+
+func (mr meteredEscrowRepo) SettleEscrow(ctx context.Context, escrowID string, outcome model.EscrowStatus, resolution model.EscrowResolution, resolvedBy *string, at time.Time) (*model.Escrow, error) {
+	operation := "escrow_release"
+	if outcome == model.EscrowStatusRefunded {
+		operation = "escrow_refund"
+	}
+	escrow, err := mr.EscrowRepo.SettleEscrow(mr.metrics.observeLockWait(ctx, operation), escrowID, outcome, resolution, resolvedBy, at)
+	mr.countSettlement(operation, escrow, err)
+	return escrow, err
+}
+
+func (mr meteredEscrowRepo) countSettlement(operation string, escrow *model.Escrow, err error) {
+	amount := decimal.Zero
+	if err == nil {
+		amount = escrow.Amount
+	}
+	mr.metrics.count(operation, amount, err)
+}
+
+// meteredDisputeRepo counts the reversals made by resolving disputes for the payer. Resolving one for the recipient
+// only releases the hold, which moves nothing.
+type meteredDisputeRepo struct {
+	service.DisputeRepo
+	metrics *serverMetrics
+}
+
+func (mr meteredDisputeRepo) ResolveDispute(ctx context.Context, disputeID string, inFavorOf model.DisputeParty, resolvedBy string, at time.Time) (*model.Dispute, error) {
+	dispute, err := mr.DisputeRepo.ResolveDispute(ctx, disputeID, inFavorOf, resolvedBy, at)
+	if inFavorOf == model.DisputePartyPayer {
+		amount := decimal.Zero
+		if err == nil {
+			amount = dispute.Amount
+		}
+		mr.metrics.count("reversal", amount, err)
+	}
+	return dispute, err
+}
+
+// meteredAdminRepo counts balance adjustments made by administrators, credits and debits alike.
+type meteredAdminRepo struct {
+	service.AdminRepo
+	metrics *serverMetrics
+}
+
+func (mr meteredAdminRepo) AdjustWallet(ctx context.Context, admin string, walletID string, amount decimal.Decimal, reason model.AdjustmentReason, note string, at time.Time) (*model.Transaction, error) {
+	transaction, err := mr.AdminRepo.AdjustWallet(ctx, admin, walletID, amount, reason, note, at)
+	mr.metrics.count("adjustment", amount, err)
+	return transaction, err
+}
+
+// observeLockWait returns a copy of ctx under which the wallet row locks taken for operation are timed.
+func (m *serverMetrics) observeLockWait(ctx context.Context, operation string) context.Context {
+	return repo.WithLockWaitObserver(ctx, func(d time.Duration) {
+		m.lockWait.Observe(d.Seconds(), operation)
+	})
+}
+
+// count records the outcome of operation and, if it succeeded, the amount it moved.
+func (m *serverMetrics) count(operation string, amount decimal.Decimal, err error) {
+	outcome := walletOutcome(err)
+	m.walletOperations.Inc(operation, outcome)
+	switch outcome {
+	case "success":
+		m.amountMoved.Add(amount.Abs().InexactFloat64(), operation)
+	case "insufficient_funds":
+		m.insufficientFunds.Inc(operation)
+	}
+}
+
+// walletOutcome names the outcome of an operation moving money that returned err.
+func walletOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, repo.ErrInsufficientFunds):
+		return "insufficient_funds"
+	case errors.Is(err, repo.ErrApprovalRequired):
+		return "approval_required"
+	case errors.Is(err, repo.ErrWalletNotFound):
+		return "wallet_not_found"
+	case errors.Is(err, repo.ErrWalletForbidden):
+		return "forbidden"
+	case errors.Is(err, repo.ErrWalletFrozen), errors.Is(err, repo.ErrWalletDebitBlocked), errors.Is(err, repo.ErrWalletCreditBlocked):
+		return "wallet_restricted"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	}
+	return "error"
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service/mocks"
+)
+
+func TestMeteredRepos(t *testing.T) {
+	m := newServerMetrics(nil)
+	ctx := context.Background()
+	now := time.Now()
+	amount := func(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+	batches := mocks.NewTransferBatchRepoMock(t)
+	batches.EXPECT().ExecuteTransferBatchItem(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ *model.TransferBatch, item *model.TransferBatchItem) {
+			item.Status = model.TransferBatchItemStatusFailed
+		}).Return(nil).Once()
+	batches.EXPECT().ExecuteTransferBatchAtomically(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, batch *model.TransferBatch) {
+			for i := range batch.Items {
+				batch.Items[i].Status = model.TransferBatchItemStatusSucceeded
+			}
+		}).Return(nil).Once()
+	mb := meteredTransferBatchRepo{batches, m}
+	require.NoError(t, mb.ExecuteTransferBatchItem(ctx, &model.TransferBatch{}, &model.TransferBatchItem{Amount: amount("3")}))
+	require.NoError(t, mb.ExecuteTransferBatchAtomically(ctx, &model.TransferBatch{Items: []model.TransferBatchItem{{Amount: amount("1.5")}, {Amount: amount("2.5")}}}))
+
+	proposals := mocks.NewTransferProposalRepoMock(t)
+	proposals.EXPECT().ApproveTransferProposal(mock.Anything, "approver", "w", "pending", now).
+		Return(&model.TransferProposal{Amount: amount("7"), Status: model.TransferProposalStatusPending}, nil).Once()
+	proposals.EXPECT().ApproveTransferProposal(mock.Anything, "approver", "w", "last", now).
+		Return(&model.TransferProposal{Amount: amount("7"), Status: model.TransferProposalStatusExecuted}, nil).Once()
+	proposals.EXPECT().ApproveTransferProposal(mock.Anything, "approver", "w", "short", now).
+		Return(nil, repo.ErrInsufficientFunds).Once()
+	mp := meteredTransferProposalRepo{proposals, m}
+	for _, id := range []string{"pending", "last", "short"} {
+		_, _ = mp.ApproveTransferProposal(ctx, "approver", "w", id, now)
+	}
+
+	requests := mocks.NewPaymentRequestRepoMock(t)
+	requests.EXPECT().AcceptPaymentRequest(mock.Anything, "payer", "r", "w", mock.Anything, now).
+		Return(&model.PaymentRequest{Amount: amount("4"), Status: model.PaymentRequestStatusAccepted}, nil).Once()
+	_, err := meteredPaymentRequestRepo{requests, m}.AcceptPaymentRequest(ctx, "payer", "r", "w", model.Fee{}, now)
+	require.NoError(t, err)
+
+	escrows := mocks.NewEscrowRepoMock(t)
+	escrows.EXPECT().CreateEscrow(mock.Anything, "buyer", mock.Anything, mock.Anything).Return(nil).Once()
+	escrows.EXPECT().ConfirmEscrow(mock.Anything, "buyer", "w", "e1", now).
+		Return(&model.Escrow{Amount: amount("20"), Status: model.EscrowStatusReleased}, nil).Once()
+	escrows.EXPECT().SettleEscrow(mock.Anything, "e2", model.EscrowStatusRefunded, mock.Anything, mock.Anything, now).
+		Return(&model.Escrow{Amount: amount("5"), Status: model.EscrowStatusRefunded}, nil).Once()
+	me := meteredEscrowRepo{escrows, m}
+	require.NoError(t, me.CreateEscrow(ctx, "buyer", &model.Escrow{Amount: amount("25")}, model.Fee{}))
+	_, err = me.ConfirmEscrow(ctx, "buyer", "w", "e1", now)
+	require.NoError(t, err)
+	_, err = me.SettleEscrow(ctx, "e2", model.EscrowStatusRefunded, model.EscrowResolutionAdmin, nil, now)
+	require.NoError(t, err)
+
+	disputes := mocks.NewDisputeRepoMock(t)
+	disputes.EXPECT().ResolveDispute(mock.Anything, "d1", model.DisputePartyPayer, "ops", now).
+		Return(&model.Dispute{Amount: amount("40")}, nil).Once()
+	disputes.EXPECT().ResolveDispute(mock.Anything, "d2", model.DisputePartyRecipient, "ops", now).
+		Return(&model.Dispute{Amount: amount("60")}, nil).Once()
+	md := meteredDisputeRepo{disputes, m}
+	_, err = md.ResolveDispute(ctx, "d1", model.DisputePartyPayer, "ops", now)
+	require.NoError(t, err)
+	_, err = md.ResolveDispute(ctx, "d2", model.DisputePartyRecipient, "ops", now)
+	require.NoError(t, err)
+
+	admins := mocks.NewAdminRepoMock(t)
+	admins.EXPECT().AdjustWallet(mock.Anything, "ops", "w", amount("-8"), mock.Anything, "", now).Return(&model.Transaction{}, nil).Once()
+	admins.EXPECT().AdjustWallet(mock.Anything, "ops", "w", amount("-800"), mock.Anything, "", now).Return(nil, repo.ErrInsufficientFunds).Once()
+	ma := meteredAdminRepo{admins, m}
+	_, err = ma.AdjustWallet(ctx, "ops", "w", amount("-8"), model.AdjustmentReasonErrorCorrection, "", now)
+	require.NoError(t, err)
+	_, err = ma.AdjustWallet(ctx, "ops", "w", amount("-800"), model.AdjustmentReasonErrorCorrection, "", now)
+	require.ErrorIs(t, err, repo.ErrInsufficientFunds)
+
+	var buf bytes.Buffer
+	require.NoError(t, m.registry.WriteText(&buf))
+	body := buf.String()
+	for _, line := range []string{
+		`wallet_operations_total{operation="batch_transfer",outcome="failed"} 1`,
+		`wallet_operations_total{operation="batch_transfer",outcome="success"} 2`,
+		`wallet_amount_moved_total{operation="batch_transfer"} 4`,
+		`wallet_operations_total{operation="proposal_transfer",outcome="success"} 1`,
+		`wallet_operations_total{operation="proposal_transfer",outcome="insufficient_funds"} 1`,
+		`wallet_amount_moved_total{operation="proposal_transfer"} 7`,
+		`wallet_amount_moved_total{operation="payment_request"} 4`,
+		`wallet_amount_moved_total{operation="escrow_fund"} 25`,
+		`wallet_amount_moved_total{operation="escrow_release"} 20`,
+		`wallet_amount_moved_total{operation="escrow_refund"} 5`,
+		`wallet_operations_total{operation="reversal",outcome="success"} 1`,
+		`wallet_amount_moved_total{operation="reversal"} 40`,
+		`wallet_amount_moved_total{operation="adjustment"} 8`,
+		`wallet_insufficient_funds_total{operation="adjustment"} 1`,
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+	// A proposal still waiting for approvals and a dispute resolved for the recipient moved nothing.
+	assert.NotContains(t, body, `wallet_operations_total{operation="proposal_transfer",outcome="success"} 2`)
+	assert.NotContains(t, body, `wallet_operations_total{operation="reversal",outcome="success"} 2`)
+}
@@ -0,0 +1,52 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	ts := newTestServer(t, memoryWallets(t))
+	header := http.Header{"X-User-Id": {user1}}
+	for _, r := range []struct {
+		method, path, body string
+		wantCode           int
+	}{
+		{http.MethodPost, walletPath(user1, wallet1, "/deposit"), `{"amount": "5.25"}`, http.StatusOK},
+		{http.MethodPost, walletPath(user1, wallet1, "/deposit"), `{"amount": "4.75"}`, http.StatusOK},
+		{http.MethodPost, walletPath(user2, wallet2, "/withdraw"), `{"amount": "10"}`, http.StatusBadRequest},
+		{http.MethodPost, walletPath(user1, wallet1, "/transfer"), `{"amount": "1000", "destination_wallet_id": "` + wallet2 + `"}`, http.StatusBadRequest},
+		{http.MethodPost, walletPath(user1, frozenWallet, "/withdraw"), `{"amount": "1"}`, http.StatusLocked},
+		{http.MethodGet, "/nowhere", "", http.StatusNotFound},
+	} {
+		rec := ts.do(r.method, r.path, r.body, header)
+		require.Equal(t, r.wantCode, rec.Code, "%s %s: %s", r.method, r.path, rec.Body.String())
+	}
+
+	rec := ts.do(http.MethodGet, "/metrics", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		`wallet_operations_total{operation="deposit",outcome="success"} 2`,
+		`wallet_operations_total{operation="withdraw",outcome="insufficient_funds"} 1`,
+		`wallet_operations_total{operation="transfer",outcome="insufficient_funds"} 1`,
+		`wallet_operations_total{operation="withdraw",outcome="wallet_restricted"} 1`,
+		`wallet_amount_moved_total{operation="deposit"} 10`,
+		`wallet_insufficient_funds_total{operation="withdraw"} 1`,
+		`wallet_insufficient_funds_total{operation="transfer"} 1`,
+		`http_request_duration_seconds_count{method="POST",route="/v1/user/:userId/wallet/:walletId/deposit",status="200"} 2`,
+		`http_request_duration_seconds_count{method="POST",route="/v1/user/:userId/wallet/:walletId/withdraw",status="400"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`,
+		`# TYPE wallet_lock_wait_seconds histogram`,
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+	// Withdrawals that failed moved nothing, and without a database there are no pool stats.
+	assert.NotContains(t, body, `wallet_amount_moved_total{operation="withdraw"}`)
+	assert.NotContains(t, body, "db_pool_")
+}
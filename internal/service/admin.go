@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+const (
+	// AdminSearchLimit is the most users or wallets a back-office search returns.
+	AdminSearchLimit = 50
+	// AdminAuditLogLimit is the most audit entries returned at once.
+	AdminAuditLogLimit = 200
+	// minAdminSearchQuery is the shortest search query accepted, in characters.
+	minAdminSearchQuery = 2
+	// maxAdminNote is the longest status reason or adjustment note accepted, in characters.
+	maxAdminNote = 500
+)
+
+// ErrInvalidAdminRequest indicates that a back-office request was rejected during validation.
+var ErrInvalidAdminRequest = errors.New("invalid admin request")
+
+type AdminRepo interface {
+	SearchUsers(ctx context.Context, admin string, query string, limit int, at time.Time) ([]model.User, error)
+	SearchWallets(ctx context.Context, admin string, query string, userID string, limit int, at time.Time) ([]model.Wallet, error)
+	SetWalletStatus(ctx context.Context, admin string, walletID string, status model.WalletStatus, reason string, expiresAt *time.Time, at time.Time) (*model.Wallet, error)
+	LiftExpiredWalletStatuses(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+	AdjustWallet(ctx context.Context, admin string, walletID string, amount decimal.Decimal, reason model.AdjustmentReason, note string, at time.Time) (*model.Transaction, error)
+	ListAdminAuditLog(ctx context.Context, admin string, walletID string, limit int) ([]model.AdminAuditEntry, error)
+}
+
+type AdminServiceImpl struct {
+	aRepo AdminRepo
+	clock clock.Clock
+}
+
+func NewAdminImpl(ar AdminRepo, clk clock.Clock) *AdminServiceImpl {
+	return &AdminServiceImpl{aRepo: ar, clock: clk}
+}
+
+// SearchUsers finds users by ID, or by part of their name, email or handle.
+func (as *AdminServiceImpl) SearchUsers(ctx context.Context, admin, query string) ([]model.User, error) {
+	query = strings.TrimSpace(query)
+	if len([]rune(query)) < minAdminSearchQuery {
+		return nil, fmt.Errorf("%w: q must be at least %d characters", ErrInvalidAdminRequest, minAdminSearchQuery)
+	}
+	users, err := as.aRepo.SearchUsers(ctx, admin, query, AdminSearchLimit, as.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("service.SearchUsers: %w", err)
+	}
+	return users, nil
+}
+
+// SearchWallets finds wallets by ID or by part of their name, by the user owning or sharing them, or both.
+func (as *AdminServiceImpl) SearchWallets(ctx context.Context, admin, query, userId string) ([]model.Wallet, error) {
+	query, userId = strings.TrimSpace(query), strings.TrimSpace(userId)
+	if userId == "" && len([]rune(query)) < minAdminSearchQuery {
+		return nil, fmt.Errorf("%w: user_id or a q of at least %d characters is required", ErrInvalidAdminRequest, minAdminSearchQuery)
+	}
+	wallets, err := as.aRepo.SearchWallets(ctx, admin, query, userId, AdminSearchLimit, as.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("service.SearchWallets: %w", err)
+	}
+	return wallets, nil
+}
+
+// SetWalletStatus puts the wallet in req.Status. A restriction may be given an expires_at in the future,
+// after which the wallet becomes active again by itself.
+func (as *AdminServiceImpl) SetWalletStatus(ctx context.Context, admin, walletId string, req model.WalletStatusRequest) (*model.Wallet, error) {
+	if !req.Status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidAdminRequest, req.Status)
+	}
+	now := as.clock.Now().UTC()
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		if req.Status == model.WalletStatusActive {
+			return nil, fmt.Errorf("%w: expires_at is only allowed for a restriction", ErrInvalidAdminRequest)
+		}
+		if !req.ExpiresAt.After(now) {
+			return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidAdminRequest)
+		}
+		at := req.ExpiresAt.UTC()
+		expiresAt = &at
+	}
+	return as.setWalletStatus(ctx, admin, walletId, req.Status, req.Reason, expiresAt, now)
+}
+
+// FreezeWallet stops the wallet from paying or being paid until it is unfrozen.
+func (as *AdminServiceImpl) FreezeWallet(ctx context.Context, admin, walletId string, req model.WalletFreezeRequest) (*model.Wallet, error) {
+	return as.setWalletStatus(ctx, admin, walletId, model.WalletStatusFrozen, req.Reason, nil, as.clock.Now().UTC())
+}
+
+// UnfreezeWallet lifts any restriction on the wallet.
+func (as *AdminServiceImpl) UnfreezeWallet(ctx context.Context, admin, walletId string, req model.WalletFreezeRequest) (*model.Wallet, error) {
+	return as.setWalletStatus(ctx, admin, walletId, model.WalletStatusActive, req.Reason, nil, as.clock.Now().UTC())
+}
+
+func (as *AdminServiceImpl) setWalletStatus(ctx context.Context, admin, walletId string, status model.WalletStatus, reason string,
+	expiresAt *time.Time, now time.Time) (*model.Wallet, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidAdminRequest)
+	}
+	if len([]rune(reason)) > maxAdminNote {
+		return nil, fmt.Errorf("%w: reason is longer than %d characters", ErrInvalidAdminRequest, maxAdminNote)
+	}
+	wallet, err := as.aRepo.SetWalletStatus(ctx, admin, walletId, status, reason, expiresAt, now)
+	if err != nil {
+		return nil, fmt.Errorf("service.SetWalletStatus: %w", err)
+	}
+	return wallet, nil
+}
+
+// AdjustWallet posts a manual credit or debit under a reason code.
+func (as *AdminServiceImpl) AdjustWallet(ctx context.Context, admin, walletId string, req model.AdjustmentRequest) (*model.Transaction, error) {
+	if req.Direction != model.AdjustmentDirectionCredit && req.Direction != model.AdjustmentDirectionDebit {
+		return nil, fmt.Errorf("%w: direction must be credit or debit", ErrInvalidAdminRequest)
+	}
+	if req.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidAdminRequest)
+	}
+	if !req.Amount.Equal(req.Amount.Truncate(4)) {
+		return nil, fmt.Errorf("%w: amount has more than 4 decimal places", ErrInvalidAdminRequest)
+	}
+	if !req.ReasonCode.IsValid() {
+		return nil, fmt.Errorf("%w: unknown reason_code %q", ErrInvalidAdminRequest, req.ReasonCode)
+	}
+	note := strings.TrimSpace(req.Note)
+	if req.ReasonCode == model.AdjustmentReasonOther && note == "" {
+		return nil, fmt.Errorf("%w: a note is required for reason_code other", ErrInvalidAdminRequest)
+	}
+	if len([]rune(note)) > maxAdminNote {
+		return nil, fmt.Errorf("%w: note is longer than %d characters", ErrInvalidAdminRequest, maxAdminNote)
+	}
+
+	amount := req.Amount
+	if req.Direction == model.AdjustmentDirectionDebit {
+		amount = amount.Neg()
+	}
+	transaction, err := as.aRepo.AdjustWallet(ctx, admin, walletId, amount, req.ReasonCode, note, as.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("service.AdjustWallet: %w", err)
+	}
+	return transaction, nil
+}
+
+// ListAuditLog returns the most recent back-office actions, optionally of one administrator or about one wallet.
+func (as *AdminServiceImpl) ListAuditLog(ctx context.Context, admin, walletId string) ([]model.AdminAuditEntry, error) {
+	entries, err := as.aRepo.ListAdminAuditLog(ctx, strings.TrimSpace(admin), strings.TrimSpace(walletId), AdminAuditLogLimit)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListAuditLog: %w", err)
+	}
+	return entries, nil
+}
+
+// WalletStatusExpiryJob makes wallets active again once their restriction's expires_at has passed.
+type WalletStatusExpiryJob struct {
+	aRepo AdminRepo
+	clock clock.Clock
+}
+
+func NewWalletStatusExpiryJob(ar AdminRepo, clk clock.Clock) *WalletStatusExpiryJob {
+	return &WalletStatusExpiryJob{aRepo: ar, clock: clk}
+}
+
+// Run calls RunOnce every interval until stop is closed or ctx is cancelled, as runEvery does.
+func (j *WalletStatusExpiryJob) Run(ctx context.Context, stop <-chan struct{}, interval time.Duration) {
+	runEvery(ctx, stop, interval, "Wallet status expiry job", j.RunOnce)
+}
+
+// RunOnce lifts every restriction that has expired.
+func (j *WalletStatusExpiryJob) RunOnce(ctx context.Context) error {
+	ids, err := j.aRepo.LiftExpiredWalletStatuses(ctx, j.clock.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("service.RunOnce: %w", err)
+	}
+	for _, id := range ids {
+		zerolog.Ctx(ctx).Info().Str("wallet-id", id.String()).Msg("Wallet restriction expired")
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+func TestAdminServiceImpl_AdjustWallet(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	tests := []struct {
+		name       string
+		req        model.AdjustmentRequest
+		wantAmount string
+		wantNote   string
+		repoErr    error
+		wantErr    error
+	}{
+		{
+			name:       "credit",
+			req:        model.AdjustmentRequest{Direction: model.AdjustmentDirectionCredit, Amount: dec("25.50"), ReasonCode: model.AdjustmentReasonGoodwill},
+			wantAmount: "25.50",
+		},
+		{
+			name:       "debit is booked as a negative amount",
+			req:        model.AdjustmentRequest{Direction: model.AdjustmentDirectionDebit, Amount: dec("10"), ReasonCode: model.AdjustmentReasonErrorCorrection, Note: " double deposit "},
+			wantAmount: "-10",
+			wantNote:   "double deposit",
+		},
+		{
+			name:       "other with a note",
+			req:        model.AdjustmentRequest{Direction: model.AdjustmentDirectionCredit, Amount: dec("1"), ReasonCode: model.AdjustmentReasonOther, Note: "ticket 42"},
+			wantAmount: "1",
+			wantNote:   "ticket 42",
+		},
+		{
+			name:       "error - debit exceeds the money available",
+			req:        model.AdjustmentRequest{Direction: model.AdjustmentDirectionDebit, Amount: dec("10"), ReasonCode: model.AdjustmentReasonCompliance},
+			wantAmount: "-10",
+			repoErr:    repo.ErrInsufficientFunds,
+			wantErr:    repo.ErrInsufficientFunds,
+		},
+		{
+			name:    "error - unknown direction",
+			req:     model.AdjustmentRequest{Direction: "sideways", Amount: dec("10"), ReasonCode: model.AdjustmentReasonGoodwill},
+			wantErr: service.ErrInvalidAdminRequest,
+		},
+		{
+			name:    "error - amount not positive",
+			req:     model.AdjustmentRequest{Direction: model.AdjustmentDirectionDebit, Amount: dec("-10"), ReasonCode: model.AdjustmentReasonGoodwill},
+			wantErr: service.ErrInvalidAdminRequest,
+		},
+		{
+			name:    "error - more than 4 decimal places",
+			req:     model.AdjustmentRequest{Direction: model.AdjustmentDirectionCredit, Amount: dec("0.00001"), ReasonCode: model.AdjustmentReasonGoodwill},
+			wantErr: service.ErrInvalidAdminRequest,
+		},
+		{
+			name:    "error - unknown reason code",
+			req:     model.AdjustmentRequest{Direction: model.AdjustmentDirectionCredit, Amount: dec("10"), ReasonCode: "because"},
+			wantErr: service.ErrInvalidAdminRequest,
+		},
+		{
+			name:    "error - other without a note",
+			req:     model.AdjustmentRequest{Direction: model.AdjustmentDirectionCredit, Amount: dec("10"), ReasonCode: model.AdjustmentReasonOther},
+			wantErr: service.ErrInvalidAdminRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.AdminRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				var transaction *model.Transaction
+				if tt.repoErr == nil {
+					transaction = &model.Transaction{ID: uuid.New(), WalletID: testWallet1UUID, Type: model.TransactionTypeAdjustment, Amount: dec(tt.wantAmount)}
+				}
+				m.On("AdjustWallet", mock.Anything, "ops", testWallet1UUIDString, mock.MatchedBy(func(amount decimal.Decimal) bool {
+					return amount.Equal(dec(tt.wantAmount))
+				}), tt.req.ReasonCode, tt.wantNote, now).Return(transaction, tt.repoErr)
+			}
+			as := service.NewAdminImpl(m, clock.NewFake(now))
+
+			got, err := as.AdjustWallet(context.Background(), "ops", testWallet1UUIDString, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, model.TransactionTypeAdjustment, got.Type)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdminServiceImpl_FreezeWallet(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	tests := []struct {
+		name    string
+		reason  string
+		repoErr error
+		wantErr error
+	}{
+		{name: "success", reason: " suspected fraud "},
+		{name: "error - already frozen", reason: "suspected fraud", repoErr: repo.ErrWalletStatusUnchanged, wantErr: repo.ErrWalletStatusUnchanged},
+		{name: "error - no reason", reason: "  ", wantErr: service.ErrInvalidAdminRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.AdminRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				var wallet *model.Wallet
+				if tt.repoErr == nil {
+					wallet = &model.Wallet{ID: testWallet1UUID, Status: model.WalletStatusFrozen}
+				}
+				m.On("SetWalletStatus", mock.Anything, "ops", testWallet1UUIDString, model.WalletStatusFrozen, "suspected fraud", (*time.Time)(nil), now).
+					Return(wallet, tt.repoErr)
+			}
+			as := service.NewAdminImpl(m, clock.NewFake(now))
+
+			got, err := as.FreezeWallet(context.Background(), "ops", testWallet1UUIDString, model.WalletFreezeRequest{Reason: tt.reason})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, model.WalletStatusFrozen, got.Status)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdminServiceImpl_SetWalletStatus(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	later := mustTime("2025-06-22T12:00:00Z")
+
+	tests := []struct {
+		name          string
+		req           model.WalletStatusRequest
+		wantExpiresAt *time.Time
+		wantErr       error
+	}{
+		{
+			name:          "success - debit blocked until later",
+			req:           model.WalletStatusRequest{Status: model.WalletStatusDebitBlocked, Reason: "kyc review", ExpiresAt: ptr(later.In(time.FixedZone("ICT", 7*3600)))},
+			wantExpiresAt: &later,
+		},
+		{name: "success - credit blocked", req: model.WalletStatusRequest{Status: model.WalletStatusCreditBlocked, Reason: "closing"}},
+		{name: "success - active", req: model.WalletStatusRequest{Status: model.WalletStatusActive, Reason: "review passed"}},
+		{name: "error - unknown status", req: model.WalletStatusRequest{Status: "closed", Reason: "closing"}, wantErr: service.ErrInvalidAdminRequest},
+		{
+			name:    "error - expiry in the past",
+			req:     model.WalletStatusRequest{Status: model.WalletStatusFrozen, Reason: "fraud", ExpiresAt: ptr(now.Add(-time.Minute))},
+			wantErr: service.ErrInvalidAdminRequest,
+		},
+		{
+			name:    "error - expiry on active",
+			req:     model.WalletStatusRequest{Status: model.WalletStatusActive, Reason: "review passed", ExpiresAt: &later},
+			wantErr: service.ErrInvalidAdminRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.AdminRepoMock)
+			if tt.wantErr == nil {
+				m.On("SetWalletStatus", mock.Anything, "ops", testWallet1UUIDString, tt.req.Status, tt.req.Reason,
+					mock.MatchedBy(func(expiresAt *time.Time) bool {
+						if tt.wantExpiresAt == nil {
+							return expiresAt == nil
+						}
+						return expiresAt != nil && expiresAt.Equal(*tt.wantExpiresAt) && expiresAt.Location() == time.UTC
+					}), now).
+					Return(&model.Wallet{ID: testWallet1UUID, Status: tt.req.Status}, nil)
+			}
+			as := service.NewAdminImpl(m, clock.NewFake(now))
+
+			got, err := as.SetWalletStatus(context.Background(), "ops", testWallet1UUIDString, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.req.Status, got.Status)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWalletStatusExpiryJob_RunOnce(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	m := new(walletmocks.AdminRepoMock)
+	m.On("LiftExpiredWalletStatuses", mock.Anything, now).Return([]uuid.UUID{testWallet1UUID}, nil)
+
+	require.NoError(t, service.NewWalletStatusExpiryJob(m, clock.NewFake(now)).RunOnce(context.Background()))
+	m.AssertExpectations(t)
+}
+
+func TestWalletStatusExpiryJob_Run(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	t.Run("stop lets the pass in progress finish", func(t *testing.T) {
+		entered, release := make(chan struct{}), make(chan struct{})
+		var passErr error
+		m := new(walletmocks.AdminRepoMock)
+		m.On("LiftExpiredWalletStatuses", mock.Anything, now).
+			Run(func(args mock.Arguments) {
+				close(entered)
+				<-release
+				passErr = args.Get(0).(context.Context).Err()
+			}).
+			Return([]uuid.UUID{}, nil).Once()
+
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			service.NewWalletStatusExpiryJob(m, clock.NewFake(now)).Run(context.Background(), stop, time.Hour)
+			close(done)
+		}()
+		<-entered
+		close(stop)
+		close(release)
+		<-done
+
+		assert.NoError(t, passErr, "the pass must not see its context cancelled")
+		m.AssertExpectations(t)
+	})
+
+	t.Run("cancelling ctx stops at once", func(t *testing.T) {
+		m := new(walletmocks.AdminRepoMock)
+		m.On("LiftExpiredWalletStatuses", mock.Anything, now).Return([]uuid.UUID{}, nil).Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		service.NewWalletStatusExpiryJob(m, clock.NewFake(now)).Run(ctx, make(chan struct{}), time.Hour)
+		m.AssertExpectations(t)
+	})
+}
+
+func TestAdminServiceImpl_Search(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	t.Run("users", func(t *testing.T) {
+		m := new(walletmocks.AdminRepoMock)
+		m.On("SearchUsers", mock.Anything, "ops", "alice", service.AdminSearchLimit, now).Return([]model.User{{ID: testUser1UUID}}, nil)
+		as := service.NewAdminImpl(m, clock.NewFake(now))
+
+		got, err := as.SearchUsers(context.Background(), "ops", " alice ")
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+		m.AssertExpectations(t)
+	})
+
+	t.Run("wallets by user only", func(t *testing.T) {
+		m := new(walletmocks.AdminRepoMock)
+		m.On("SearchWallets", mock.Anything, "ops", "", testUser1UUIDString, service.AdminSearchLimit, now).Return([]model.Wallet{}, nil)
+		as := service.NewAdminImpl(m, clock.NewFake(now))
+
+		_, err := as.SearchWallets(context.Background(), "ops", "", testUser1UUIDString)
+		require.NoError(t, err)
+		m.AssertExpectations(t)
+	})
+
+	t.Run("error - query too short", func(t *testing.T) {
+		m := new(walletmocks.AdminRepoMock)
+		as := service.NewAdminImpl(m, clock.NewFake(now))
+
+		_, err := as.SearchUsers(context.Background(), "ops", "a")
+		assert.ErrorIs(t, err, service.ErrInvalidAdminRequest)
+		_, err = as.SearchWallets(context.Background(), "ops", " ", "")
+		assert.ErrorIs(t, err, service.ErrInvalidAdminRequest)
+		m.AssertExpectations(t)
+	})
+}
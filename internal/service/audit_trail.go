@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+const (
+	// AuditTrailPageLimit is the most audit trail entries returned at once.
+	AuditTrailPageLimit = 500
+	// auditTrailVerifyBatch is how many entries the verifier reads at a time.
+	auditTrailVerifyBatch = 1000
+	// auditTrailChainBatch is how many outbox entries the chainer appends in one transaction.
+	auditTrailChainBatch = 500
+	// AuditTrailAbandonAfter is how long after its request started an entry whose status was never recorded is
+	// taken to belong to a server that stopped, and is chained as it stands. It is well above HTTP_WRITE_TIMEOUT.
+	AuditTrailAbandonAfter = 15 * time.Minute
+)
+
+// ErrInvalidAuditTrailQuery indicates that an audit trail query was rejected during validation.
+var ErrInvalidAuditTrailQuery = errors.New("invalid audit trail query")
+
+type AuditTrailRepo interface {
+	RecordAuditTrailEntry(ctx context.Context, entryID uuid.UUID, entry *model.AuditTrailEntry) error
+	ChainAuditTrail(ctx context.Context, abandonedBefore time.Time, limit int) (int, error)
+	ListAuditTrail(ctx context.Context, afterSeq int64, limit int) ([]model.AuditTrailEntry, error)
+	GetAuditTrailHead(ctx context.Context) (*model.AuditTrailHead, error)
+}
+
+type AuditTrailServiceImpl struct {
+	atRepo AuditTrailRepo
+	clock  clock.Clock
+}
+
+func NewAuditTrailImpl(atr AuditTrailRepo, clk clock.Clock) *AuditTrailServiceImpl {
+	return &AuditTrailServiceImpl{atRepo: atr, clock: clk}
+}
+
+// Begin timestamps entry, a call about to be made, and returns a copy of ctx under which the call's transactions
+// stage entry in the audit trail outbox with the balances they saw, as repo.WithAuditTrailEntry does, and the
+// function that records entry, status and actor filled in, once the call is done. The audit trail chainer
+// later appends it to the audit trail.
+func (as *AuditTrailServiceImpl) Begin(ctx context.Context, entry *model.AuditTrailEntry) (context.Context, func(context.Context) error) {
+	entry.CreatedAt = as.clock.Now().UTC()
+	entryID := uuid.New()
+	ctx, done := repo.WithAuditTrailEntry(ctx, entryID, entry)
+	return ctx, func(ctx context.Context) error {
+		done()
+		if err := as.atRepo.RecordAuditTrailEntry(ctx, entryID, entry); err != nil {
+			return fmt.Errorf("service.Begin: %w", err)
+		}
+		return nil
+	}
+}
+
+// ListAuditTrail returns up to limit entries numbered after afterSeq, in order. A limit of zero or above
+// AuditTrailPageLimit returns AuditTrailPageLimit entries.
+func (as *AuditTrailServiceImpl) ListAuditTrail(ctx context.Context, afterSeq int64, limit int) ([]model.AuditTrailEntry, error) {
+	if afterSeq < 0 || limit < 0 {
+		return nil, fmt.Errorf("%w: after_seq and limit must not be negative", ErrInvalidAuditTrailQuery)
+	}
+	if limit == 0 || limit > AuditTrailPageLimit {
+		limit = AuditTrailPageLimit
+	}
+	entries, err := as.atRepo.ListAuditTrail(ctx, afterSeq, limit)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListAuditTrail: %w", err)
+	}
+	return entries, nil
+}
+
+// VerifyAuditTrail walks the whole audit trail and reports every point where its hash chain breaks: an entry whose
+// hash does not match its contents, one not chained to the hash of the entry before it, and a gap in the numbering
+// left by a deleted entry. The chain is then held against the head recorded when the last entry was appended, so
+// that deleting or replacing the newest entries shows too. Entries appended while it runs are checked as well.
+func (as *AuditTrailServiceImpl) VerifyAuditTrail(ctx context.Context) (*model.AuditChainReport, error) {
+	head, err := as.atRepo.GetAuditTrailHead(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.VerifyAuditTrail: %w", err)
+	}
+
+	report := &model.AuditChainReport{Breaks: []model.AuditChainBreak{}, Head: head}
+	prevHash := ""
+	var headHash *string
+	for {
+		entries, err := as.atRepo.ListAuditTrail(ctx, report.LastSeq, auditTrailVerifyBatch)
+		if err != nil {
+			return nil, fmt.Errorf("service.VerifyAuditTrail: %w", err)
+		}
+		for _, e := range entries {
+			if e.Seq != report.LastSeq+1 {
+				report.Breaks = append(report.Breaks, model.AuditChainBreak{
+					Seq:    e.Seq,
+					Reason: fmt.Sprintf("entries %d to %d are missing", report.LastSeq+1, e.Seq-1),
+				})
+			} else if e.PrevHash != prevHash {
+				report.Breaks = append(report.Breaks, model.AuditChainBreak{
+					Seq:    e.Seq,
+					Reason: "prev_hash does not match the hash of the previous entry",
+				})
+			}
+			if e.ComputeHash() != e.Hash {
+				report.Breaks = append(report.Breaks, model.AuditChainBreak{
+					Seq:    e.Seq,
+					Reason: "hash does not match the entry's contents",
+				})
+			}
+			if head != nil && e.Seq == head.Seq {
+				headHash = &e.Hash
+			}
+			prevHash = e.Hash
+			report.LastSeq = e.Seq
+			report.Checked++
+		}
+		if len(entries) < auditTrailVerifyBatch {
+			break
+		}
+	}
+
+	switch {
+	case head == nil && report.LastSeq > 0:
+		report.Breaks = append(report.Breaks, model.AuditChainBreak{
+			Seq:    report.LastSeq,
+			Reason: "the audit trail has entries but no head",
+		})
+	case head != nil && report.LastSeq < head.Seq:
+		report.Breaks = append(report.Breaks, model.AuditChainBreak{
+			Seq:    head.Seq,
+			Reason: fmt.Sprintf("entries %d to %d are missing", report.LastSeq+1, head.Seq),
+		})
+	case head != nil && headHash == nil:
+		report.Breaks = append(report.Breaks, model.AuditChainBreak{
+			Seq:    head.Seq,
+			Reason: "the head entry is missing",
+		})
+	case head != nil && *headHash != head.Hash:
+		report.Breaks = append(report.Breaks, model.AuditChainBreak{
+			Seq:    head.Seq,
+			Reason: "hash does not match the audit trail head",
+		})
+	}
+	report.Intact = len(report.Breaks) == 0
+	return report, nil
+}
+
+// AuditTrailChainer appends the entries waiting in the audit trail outbox to the hash-chained audit trail. Requests
+// only write to the outbox, so they never wait on one another for the lock that keeps the chain in order.
+type AuditTrailChainer struct {
+	atRepo AuditTrailRepo
+	clock  clock.Clock
+}
+
+func NewAuditTrailChainer(atr AuditTrailRepo, clk clock.Clock) *AuditTrailChainer {
+	return &AuditTrailChainer{atRepo: atr, clock: clk}
+}
+
+// Run calls RunOnce every interval until stop is closed or ctx is cancelled, as runEvery does.
+func (j *AuditTrailChainer) Run(ctx context.Context, stop <-chan struct{}, interval time.Duration) {
+	runEvery(ctx, stop, interval, "Audit trail chainer", j.RunOnce)
+}
+
+// RunOnce appends every entry of the outbox whose request is done, and every one abandoned for
+// AuditTrailAbandonAfter, to the audit trail.
+func (j *AuditTrailChainer) RunOnce(ctx context.Context) error {
+	abandonedBefore := j.clock.Now().UTC().Add(-AuditTrailAbandonAfter)
+	for {
+		n, err := j.atRepo.ChainAuditTrail(ctx, abandonedBefore, auditTrailChainBatch)
+		if err != nil {
+			return fmt.Errorf("service.RunOnce: %w", err)
+		}
+		if n < auditTrailChainBatch {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+// auditChain builds n correctly chained audit trail entries.
+func auditChain(n int) []model.AuditTrailEntry {
+	start := mustTime("2025-06-15T12:00:00Z")
+	entries := make([]model.AuditTrailEntry, n)
+	prevHash := ""
+	for i := range entries {
+		e := model.AuditTrailEntry{
+			Seq:       int64(i + 1),
+			RequestID: "req",
+			Actor:     "user:" + testUser1UUIDString,
+			Method:    "POST",
+			Route:     "/v1/user/:userId/wallet/:walletId/deposit",
+			Params: model.AuditParams{
+				Path: map[string]string{"userId": testUser1UUIDString, "walletId": testWallet1UUIDString},
+				Body: []string{"amount"},
+			},
+			Status:        201,
+			WalletID:      &testWallet1UUID,
+			BalanceBefore: ptr(dec("10")),
+			BalanceAfter:  ptr(dec("20.5")),
+			CreatedAt:     start.Add(time.Duration(i) * time.Second),
+			PrevHash:      prevHash,
+		}
+		e.Hash = e.ComputeHash()
+		prevHash = e.Hash
+		entries[i] = e
+	}
+	return entries
+}
+
+func TestAuditTrailServiceImpl_VerifyAuditTrail(t *testing.T) {
+	tests := []struct {
+		name string
+		// chain is how many entries were appended; the head points at the last of them unless noHead is set.
+		chain  int
+		noHead bool
+		// tamper changes the stored entries after the fact.
+		tamper     func(entries []model.AuditTrailEntry) []model.AuditTrailEntry
+		wantBreaks []int64
+	}{
+		{name: "intact - empty", chain: 0},
+		{name: "intact - spans batches", chain: 1001},
+		{
+			name:  "broken - entry changed",
+			chain: 3,
+			tamper: func(entries []model.AuditTrailEntry) []model.AuditTrailEntry {
+				entries[1].BalanceAfter = ptr(dec("2000"))
+				return entries
+			},
+			wantBreaks: []int64{2},
+		},
+		{
+			name:  "broken - entry changed and rehashed",
+			chain: 3,
+			tamper: func(entries []model.AuditTrailEntry) []model.AuditTrailEntry {
+				entries[1].Actor = "anonymous"
+				entries[1].Hash = entries[1].ComputeHash()
+				return entries
+			},
+			wantBreaks: []int64{3},
+		},
+		{
+			name:  "broken - entry deleted",
+			chain: 4,
+			tamper: func(entries []model.AuditTrailEntry) []model.AuditTrailEntry {
+				return append(entries[:1], entries[2:]...)
+			},
+			wantBreaks: []int64{3},
+		},
+		{
+			name:  "broken - first entry deleted",
+			chain: 2,
+			tamper: func(entries []model.AuditTrailEntry) []model.AuditTrailEntry {
+				return entries[1:]
+			},
+			wantBreaks: []int64{2},
+		},
+		{
+			name:  "broken - newest entries deleted",
+			chain: 4,
+			tamper: func(entries []model.AuditTrailEntry) []model.AuditTrailEntry {
+				return entries[:2]
+			},
+			wantBreaks: []int64{4},
+		},
+		{
+			name:  "broken - newest entry changed and rehashed",
+			chain: 3,
+			tamper: func(entries []model.AuditTrailEntry) []model.AuditTrailEntry {
+				entries[2].Status = 400
+				entries[2].Hash = entries[2].ComputeHash()
+				return entries
+			},
+			wantBreaks: []int64{3},
+		},
+		{name: "broken - no head", chain: 2, noHead: true, wantBreaks: []int64{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := auditChain(tt.chain)
+			var head *model.AuditTrailHead
+			if tt.chain > 0 && !tt.noHead {
+				head = &model.AuditTrailHead{Seq: entries[tt.chain-1].Seq, Hash: entries[tt.chain-1].Hash}
+			}
+			if tt.tamper != nil {
+				entries = tt.tamper(entries)
+			}
+
+			m := new(walletmocks.AuditTrailRepoMock)
+			m.On("GetAuditTrailHead", mock.Anything).Return(head, nil)
+			m.On("ListAuditTrail", mock.Anything, mock.AnythingOfType("int64"), 1000).
+				Return(func(_ context.Context, afterSeq int64, limit int) ([]model.AuditTrailEntry, error) {
+					var page []model.AuditTrailEntry
+					for _, e := range entries {
+						if e.Seq > afterSeq && len(page) < limit {
+							page = append(page, e)
+						}
+					}
+					return page, nil
+				})
+			as := service.NewAuditTrailImpl(m, clock.NewFake(mustTime("2025-06-15T12:00:00Z")))
+
+			report, err := as.VerifyAuditTrail(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(entries)), report.Checked)
+			assert.Equal(t, len(tt.wantBreaks) == 0, report.Intact)
+			assert.Equal(t, head, report.Head)
+			var gotBreaks []int64
+			for _, b := range report.Breaks {
+				gotBreaks = append(gotBreaks, b.Seq)
+			}
+			assert.Equal(t, tt.wantBreaks, gotBreaks)
+		})
+	}
+}
+
+func TestAuditTrailServiceImpl_VerifyAuditTrail_AppendedWhileRunning(t *testing.T) {
+	// The head was read when there were two entries; a third was appended before the entries were listed.
+	entries := auditChain(3)
+	m := new(walletmocks.AuditTrailRepoMock)
+	m.On("GetAuditTrailHead", mock.Anything).Return(&model.AuditTrailHead{Seq: 2, Hash: entries[1].Hash}, nil)
+	m.On("ListAuditTrail", mock.Anything, int64(0), 1000).Return(entries, nil)
+	as := service.NewAuditTrailImpl(m, clock.NewFake(mustTime("2025-06-15T12:00:00Z")))
+
+	report, err := as.VerifyAuditTrail(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.Intact, report.Breaks)
+	assert.Equal(t, int64(3), report.LastSeq)
+}
+
+func TestAuditTrailServiceImpl_ListAuditTrail(t *testing.T) {
+	tests := []struct {
+		name      string
+		afterSeq  int64
+		limit     int
+		wantLimit int
+		wantErr   error
+	}{
+		{name: "success - default limit", afterSeq: 10, limit: 0, wantLimit: service.AuditTrailPageLimit},
+		{name: "success - capped limit", limit: 10_000, wantLimit: service.AuditTrailPageLimit},
+		{name: "success - limit", limit: 20, wantLimit: 20},
+		{name: "error - negative after_seq", afterSeq: -1, wantErr: service.ErrInvalidAuditTrailQuery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.AuditTrailRepoMock)
+			if tt.wantErr == nil {
+				m.On("ListAuditTrail", mock.Anything, tt.afterSeq, tt.wantLimit).Return([]model.AuditTrailEntry{}, nil)
+			}
+			as := service.NewAuditTrailImpl(m, clock.NewFake(mustTime("2025-06-15T12:00:00Z")))
+
+			_, err := as.ListAuditTrail(context.Background(), tt.afterSeq, tt.limit)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuditTrailServiceImpl_Begin(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	dbErr := errors.New("connection reset")
+	m := new(walletmocks.AuditTrailRepoMock)
+	var recorded []uuid.UUID
+	capture := func(args mock.Arguments) { recorded = append(recorded, args.Get(1).(uuid.UUID)) }
+	m.On("RecordAuditTrailEntry", mock.Anything, mock.Anything, mock.MatchedBy(func(e *model.AuditTrailEntry) bool {
+		return e.Status == 201
+	})).Run(capture).Return(nil).Once()
+	m.On("RecordAuditTrailEntry", mock.Anything, mock.Anything, mock.Anything).Run(capture).Return(dbErr).Once()
+	as := service.NewAuditTrailImpl(m, clock.NewFake(now))
+
+	// The entry is timestamped when the call starts and recorded with its status once it is done.
+	entry := &model.AuditTrailEntry{RequestID: "req", Method: "POST", Route: "/v1/user"}
+	_, record := as.Begin(context.Background(), entry)
+	assert.Equal(t, now, entry.CreatedAt)
+	entry.Status = 201
+	require.NoError(t, record(context.Background()))
+
+	// Every call is recorded under its own entry ID, and a failure to record it is returned.
+	_, record = as.Begin(context.Background(), &model.AuditTrailEntry{RequestID: "req"})
+	assert.ErrorIs(t, record(context.Background()), dbErr)
+
+	m.AssertExpectations(t)
+	require.Len(t, recorded, 2)
+	assert.NotEqual(t, recorded[0], recorded[1])
+}
+
+func TestAuditTrailChainer_RunOnce(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	abandonedBefore := now.Add(-service.AuditTrailAbandonAfter)
+	tests := []struct {
+		name    string
+		batches []int
+		err     error
+	}{
+		{name: "success - nothing queued", batches: []int{0}},
+		{name: "success - drains full batches", batches: []int{500, 500, 12}},
+		{name: "error - repo", batches: []int{500}, err: errors.New("connection reset")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.AuditTrailRepoMock)
+			for _, n := range tt.batches {
+				m.On("ChainAuditTrail", mock.Anything, abandonedBefore, 500).Return(n, nil).Once()
+			}
+			if tt.err != nil {
+				m.On("ChainAuditTrail", mock.Anything, abandonedBefore, 500).Return(0, tt.err).Once()
+			}
+			j := service.NewAuditTrailChainer(m, clock.NewFake(now))
+
+			err := j.RunOnce(context.Background())
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
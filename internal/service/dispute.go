@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+const (
+	// DisputeWindow is how long after a transfer it can still be disputed.
+	DisputeWindow = 120 * 24 * time.Hour
+	// maxDisputeReason is the longest dispute reason accepted, in characters.
+	maxDisputeReason = 500
+	// maxDisputeNote is the longest dispute note accepted, in characters.
+	maxDisputeNote = 2000
+)
+
+// ErrInvalidDispute indicates that a dispute, a note or a resolution was rejected during validation.
+var ErrInvalidDispute = errors.New("invalid dispute")
+
+type DisputeRepo interface {
+	OpenDispute(ctx context.Context, userID string, dispute *model.Dispute, hold bool, notBefore time.Time) error
+	ListDisputes(ctx context.Context, userID string, walletID string) ([]model.Dispute, error)
+	GetDispute(ctx context.Context, userID string, walletID string, disputeID string) (*model.Dispute, error)
+	AddDisputeNote(ctx context.Context, userID string, walletID string, note *model.DisputeNote) error
+	WithdrawDispute(ctx context.Context, userID string, walletID string, disputeID string, at time.Time) (*model.Dispute, error)
+	ListDisputesByStatus(ctx context.Context, status model.DisputeStatus) ([]model.Dispute, error)
+	GetDisputeByID(ctx context.Context, disputeID string) (*model.Dispute, error)
+	AddAdminDisputeNote(ctx context.Context, note *model.DisputeNote) error
+	ResolveDispute(ctx context.Context, disputeID string, inFavorOf model.DisputeParty, resolvedBy string, at time.Time) (*model.Dispute, error)
+}
+
+type DisputeServiceImpl struct {
+	dRepo DisputeRepo
+	clock clock.Clock
+}
+
+func NewDisputeImpl(dr DisputeRepo, clk clock.Clock) *DisputeServiceImpl {
+	return &DisputeServiceImpl{dRepo: dr, clock: clk}
+}
+
+// OpenDispute disputes a transfer made from the wallet, optionally holding the amount on the recipient's wallet.
+func (ds *DisputeServiceImpl) OpenDispute(ctx context.Context, userId, walletId string, req model.DisputeRequest) (*model.Dispute, error) {
+	walletID, err := uuid.Parse(walletId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	transactionID, err := uuid.Parse(strings.TrimSpace(req.TransactionID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid transaction ID", ErrInvalidDispute)
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidDispute)
+	}
+	if len([]rune(reason)) > maxDisputeReason {
+		return nil, fmt.Errorf("%w: reason is longer than %d characters", ErrInvalidDispute, maxDisputeReason)
+	}
+
+	now := ds.clock.Now().UTC()
+	dispute := &model.Dispute{
+		ID:            uuid.New(),
+		TransactionID: transactionID,
+		PayerWalletID: walletID,
+		Reason:        reason,
+		CreatedAt:     now,
+	}
+	if err = ds.dRepo.OpenDispute(ctx, userId, dispute, req.HoldFunds, now.Add(-DisputeWindow)); err != nil {
+		return nil, fmt.Errorf("service.OpenDispute: %w", err)
+	}
+	return dispute, nil
+}
+
+func (ds *DisputeServiceImpl) ListDisputes(ctx context.Context, userId, walletId string) ([]model.Dispute, error) {
+	disputes, err := ds.dRepo.ListDisputes(ctx, userId, walletId)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListDisputes: %w", err)
+	}
+	return disputes, nil
+}
+
+func (ds *DisputeServiceImpl) GetDispute(ctx context.Context, userId, walletId, disputeId string) (*model.Dispute, error) {
+	dispute, err := ds.dRepo.GetDispute(ctx, userId, walletId, disputeId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetDispute: %w", err)
+	}
+	return dispute, nil
+}
+
+// AddDisputeNote attaches evidence from a member of the payer's or the recipient's wallet to an open dispute.
+func (ds *DisputeServiceImpl) AddDisputeNote(ctx context.Context, userId, walletId, disputeId string, req model.DisputeNoteRequest) (*model.DisputeNote, error) {
+	note, err := ds.newDisputeNote(disputeId, req)
+	if err != nil {
+		return nil, err
+	}
+	if err = ds.dRepo.AddDisputeNote(ctx, userId, walletId, note); err != nil {
+		return nil, fmt.Errorf("service.AddDisputeNote: %w", err)
+	}
+	return note, nil
+}
+
+// WithdrawDispute withdraws an open dispute the wallet raised, releasing any held funds.
+func (ds *DisputeServiceImpl) WithdrawDispute(ctx context.Context, userId, walletId, disputeId string) (*model.Dispute, error) {
+	dispute, err := ds.dRepo.WithdrawDispute(ctx, userId, walletId, disputeId, ds.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("service.WithdrawDispute: %w", err)
+	}
+	return dispute, nil
+}
+
+// ListDisputeQueue returns every dispute in status, oldest first, for administrators. It defaults to open disputes.
+func (ds *DisputeServiceImpl) ListDisputeQueue(ctx context.Context, status model.DisputeStatus) ([]model.Dispute, error) {
+	if status == "" {
+		status = model.DisputeStatusOpen
+	}
+	disputes, err := ds.dRepo.ListDisputesByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListDisputeQueue: %w", err)
+	}
+	return disputes, nil
+}
+
+func (ds *DisputeServiceImpl) GetDisputeCase(ctx context.Context, disputeId string) (*model.Dispute, error) {
+	dispute, err := ds.dRepo.GetDisputeByID(ctx, disputeId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetDisputeCase: %w", err)
+	}
+	return dispute, nil
+}
+
+// AddAdminDisputeNote attaches a note from an administrator to an open dispute.
+func (ds *DisputeServiceImpl) AddAdminDisputeNote(ctx context.Context, admin, disputeId string, req model.DisputeNoteRequest) (*model.DisputeNote, error) {
+	note, err := ds.newDisputeNote(disputeId, req)
+	if err != nil {
+		return nil, err
+	}
+	note.AuthorAdmin = &admin
+	if err = ds.dRepo.AddAdminDisputeNote(ctx, note); err != nil {
+		return nil, fmt.Errorf("service.AddAdminDisputeNote: %w", err)
+	}
+	return note, nil
+}
+
+// ResolveDispute resolves an open dispute on an administrator's decision: in favour of the payer the transfer is
+// reversed, in favour of the recipient it stands. Either way the held funds are released.
+func (ds *DisputeServiceImpl) ResolveDispute(ctx context.Context, admin, disputeId string, req model.DisputeResolutionRequest) (*model.Dispute, error) {
+	if !req.InFavorOf.IsValid() {
+		return nil, fmt.Errorf("%w: in_favor_of must be payer or recipient", ErrInvalidDispute)
+	}
+	dispute, err := ds.dRepo.ResolveDispute(ctx, disputeId, req.InFavorOf, admin, ds.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("service.ResolveDispute: %w", err)
+	}
+	return dispute, nil
+}
+
+func (ds *DisputeServiceImpl) newDisputeNote(disputeId string, req model.DisputeNoteRequest) (*model.DisputeNote, error) {
+	disputeID, err := uuid.Parse(disputeId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dispute ID format: %w", err)
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, fmt.Errorf("%w: note body is required", ErrInvalidDispute)
+	}
+	if len([]rune(body)) > maxDisputeNote {
+		return nil, fmt.Errorf("%w: note is longer than %d characters", ErrInvalidDispute, maxDisputeNote)
+	}
+	return &model.DisputeNote{
+		ID:        uuid.New(),
+		DisputeID: disputeID,
+		Body:      body,
+		CreatedAt: ds.clock.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,184 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+func TestDisputeServiceImpl_OpenDispute(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	transactionID := uuid.New()
+
+	tests := []struct {
+		name       string
+		req        model.DisputeRequest
+		wantReason string
+		repoErr    error
+		wantErr    error
+	}{
+		{
+			name:       "success - with hold",
+			req:        model.DisputeRequest{TransactionID: transactionID.String(), Reason: " never delivered ", HoldFunds: true},
+			wantReason: "never delivered",
+		},
+		{
+			name:       "success - without hold",
+			req:        model.DisputeRequest{TransactionID: transactionID.String(), Reason: "duplicate payment"},
+			wantReason: "duplicate payment",
+		},
+		{
+			name:       "error - already disputed",
+			req:        model.DisputeRequest{TransactionID: transactionID.String(), Reason: "duplicate payment"},
+			wantReason: "duplicate payment",
+			repoErr:    repo.ErrDisputeExists,
+			wantErr:    repo.ErrDisputeExists,
+		},
+		{
+			name:       "error - too old",
+			req:        model.DisputeRequest{TransactionID: transactionID.String(), Reason: "duplicate payment"},
+			wantReason: "duplicate payment",
+			repoErr:    repo.ErrDisputeWindowClosed,
+			wantErr:    repo.ErrDisputeWindowClosed,
+		},
+		{
+			name:    "error - invalid transaction ID",
+			req:     model.DisputeRequest{TransactionID: "not-a-uuid", Reason: "duplicate payment"},
+			wantErr: service.ErrInvalidDispute,
+		},
+		{
+			name:    "error - blank reason",
+			req:     model.DisputeRequest{TransactionID: transactionID.String(), Reason: "   "},
+			wantErr: service.ErrInvalidDispute,
+		},
+		{
+			name:    "error - reason too long",
+			req:     model.DisputeRequest{TransactionID: transactionID.String(), Reason: strings.Repeat("x", 501)},
+			wantErr: service.ErrInvalidDispute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.DisputeRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				m.On("OpenDispute", mock.Anything, testUser1UUIDString, mock.MatchedBy(func(d *model.Dispute) bool {
+					return d.TransactionID == transactionID && d.PayerWalletID == testWallet1UUID && d.Reason == tt.wantReason
+				}), tt.req.HoldFunds, now.Add(-service.DisputeWindow)).Return(tt.repoErr)
+			}
+			ds := service.NewDisputeImpl(m, clock.NewFake(now))
+
+			got, err := ds.OpenDispute(context.Background(), testUser1UUIDString, testWallet1UUIDString, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantReason, got.Reason)
+				assert.Equal(t, now, got.CreatedAt)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDisputeServiceImpl_AddNotes(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	disputeID := uuid.New()
+
+	t.Run("member note", func(t *testing.T) {
+		m := new(walletmocks.DisputeRepoMock)
+		m.On("AddDisputeNote", mock.Anything, testUser1UUIDString, testWallet1UUIDString, mock.MatchedBy(func(n *model.DisputeNote) bool {
+			return n.DisputeID == disputeID && n.Body == "receipt attached" && n.AuthorAdmin == nil
+		})).Return(nil)
+		ds := service.NewDisputeImpl(m, clock.NewFake(now))
+
+		got, err := ds.AddDisputeNote(context.Background(), testUser1UUIDString, testWallet1UUIDString, disputeID.String(),
+			model.DisputeNoteRequest{Body: " receipt attached "})
+		require.NoError(t, err)
+		assert.Equal(t, now, got.CreatedAt)
+		m.AssertExpectations(t)
+	})
+
+	t.Run("admin note", func(t *testing.T) {
+		m := new(walletmocks.DisputeRepoMock)
+		m.On("AddAdminDisputeNote", mock.Anything, mock.MatchedBy(func(n *model.DisputeNote) bool {
+			return n.DisputeID == disputeID && n.AuthorAdmin != nil && *n.AuthorAdmin == "ops"
+		})).Return(nil)
+		ds := service.NewDisputeImpl(m, clock.NewFake(now))
+
+		_, err := ds.AddAdminDisputeNote(context.Background(), "ops", disputeID.String(), model.DisputeNoteRequest{Body: "called the merchant"})
+		require.NoError(t, err)
+		m.AssertExpectations(t)
+	})
+
+	t.Run("error - blank note", func(t *testing.T) {
+		m := new(walletmocks.DisputeRepoMock)
+		ds := service.NewDisputeImpl(m, clock.NewFake(now))
+
+		_, err := ds.AddAdminDisputeNote(context.Background(), "ops", disputeID.String(), model.DisputeNoteRequest{Body: " "})
+		assert.ErrorIs(t, err, service.ErrInvalidDispute)
+		m.AssertExpectations(t)
+	})
+}
+
+func TestDisputeServiceImpl_ResolveDispute(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	disputeID := uuid.New()
+
+	tests := []struct {
+		name      string
+		inFavorOf model.DisputeParty
+		repoErr   error
+		wantErr   error
+	}{
+		{name: "for the payer", inFavorOf: model.DisputePartyPayer},
+		{name: "for the recipient", inFavorOf: model.DisputePartyRecipient},
+		{name: "error - recipient cannot cover the reversal", inFavorOf: model.DisputePartyPayer, repoErr: repo.ErrInsufficientFunds, wantErr: repo.ErrInsufficientFunds},
+		{name: "error - already resolved", inFavorOf: model.DisputePartyRecipient, repoErr: repo.ErrDisputeNotOpen, wantErr: repo.ErrDisputeNotOpen},
+		{name: "error - unknown party", inFavorOf: "bank", wantErr: service.ErrInvalidDispute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.DisputeRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				var dispute *model.Dispute
+				if tt.repoErr == nil {
+					dispute = &model.Dispute{ID: disputeID, Status: model.DisputeStatusResolved, ResolvedInFavorOf: ptr(tt.inFavorOf)}
+				}
+				m.On("ResolveDispute", mock.Anything, disputeID.String(), tt.inFavorOf, "ops", now).Return(dispute, tt.repoErr)
+			}
+			ds := service.NewDisputeImpl(m, clock.NewFake(now))
+
+			got, err := ds.ResolveDispute(context.Background(), "ops", disputeID.String(), model.DisputeResolutionRequest{InFavorOf: tt.inFavorOf})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.inFavorOf, *got.ResolvedInFavorOf)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDisputeServiceImpl_ListDisputeQueue(t *testing.T) {
+	m := new(walletmocks.DisputeRepoMock)
+	m.On("ListDisputesByStatus", mock.Anything, model.DisputeStatusOpen).Return([]model.Dispute{}, nil)
+	ds := service.NewDisputeImpl(m, clock.NewFake(mustTime("2025-06-15T12:00:00Z")))
+
+	_, err := ds.ListDisputeQueue(context.Background(), "")
+	require.NoError(t, err)
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+const (
+	// DefaultEscrowTimeout is how long funds stay in escrow before they are released to the seller when no
+	// release time is given.
+	DefaultEscrowTimeout = 14 * 24 * time.Hour
+	// MaxEscrowTimeout is the latest release time an escrow may be given.
+	MaxEscrowTimeout = 90 * 24 * time.Hour
+	// maxEscrowMemo is the longest memo accepted, in characters.
+	maxEscrowMemo = 140
+)
+
+// ErrInvalidEscrow indicates that an escrow or a decision on one was rejected during validation.
+var ErrInvalidEscrow = errors.New("invalid escrow")
+
+type EscrowRepo interface {
+	CreateEscrow(ctx context.Context, userID string, escrow *model.Escrow, fee model.Fee) error
+	ListEscrows(ctx context.Context, userID string, walletID string) ([]model.Escrow, error)
+	GetEscrow(ctx context.Context, userID string, walletID string, escrowID string) (*model.Escrow, error)
+	ConfirmEscrow(ctx context.Context, userID string, walletID string, escrowID string, at time.Time) (*model.Escrow, error)
+	SettleEscrow(ctx context.Context, escrowID string, outcome model.EscrowStatus, resolution model.EscrowResolution, resolvedBy *string, at time.Time) (*model.Escrow, error)
+	ListEscrowsByStatus(ctx context.Context, status model.EscrowStatus) ([]model.Escrow, error)
+	ListEscrowsDue(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+}
+
+type EscrowServiceImpl struct {
+	eRepo          EscrowRepo
+	fees           FeeCalculator
+	escrowWalletID uuid.UUID
+	clock          clock.Clock
+}
+
+// NewEscrowImpl creates the escrow service. Escrowed funds are held in the wallet escrowWalletID.
+// With a nil FeeCalculator no fees are charged.
+func NewEscrowImpl(er EscrowRepo, fc FeeCalculator, escrowWalletID uuid.UUID, clk clock.Clock) *EscrowServiceImpl {
+	return &EscrowServiceImpl{eRepo: er, fees: fc, escrowWalletID: escrowWalletID, clock: clk}
+}
+
+// CreateEscrow moves funds from the buyer's wallet into escrow for the seller. The buyer is charged the escrow fee.
+func (es *EscrowServiceImpl) CreateEscrow(ctx context.Context, userId, walletId string, req model.EscrowRequest) (*model.Escrow, error) {
+	buyerWalletID, err := uuid.Parse(walletId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	sellerWalletID, err := uuid.Parse(strings.TrimSpace(req.SellerWalletID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid seller wallet ID", ErrInvalidEscrow)
+	}
+	if sellerWalletID == buyerWalletID {
+		return nil, fmt.Errorf("%w: buyer and seller wallets cannot be the same", ErrInvalidEscrow)
+	}
+	if sellerWalletID == es.escrowWalletID || buyerWalletID == es.escrowWalletID {
+		return nil, fmt.Errorf("%w: the escrow account cannot be a party to an escrow", ErrInvalidEscrow)
+	}
+	if req.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidEscrow)
+	}
+	if !req.Amount.Equal(req.Amount.Truncate(4)) {
+		return nil, fmt.Errorf("%w: amount has more than 4 decimal places", ErrInvalidEscrow)
+	}
+	memo := strings.TrimSpace(req.Memo)
+	if len([]rune(memo)) > maxEscrowMemo {
+		return nil, fmt.Errorf("%w: memo is longer than %d characters", ErrInvalidEscrow, maxEscrowMemo)
+	}
+
+	now := es.clock.Now().UTC()
+	releaseAfter := now.Add(DefaultEscrowTimeout)
+	if req.ReleaseAfter != nil {
+		releaseAfter = req.ReleaseAfter.UTC()
+		if !releaseAfter.After(now) {
+			return nil, fmt.Errorf("%w: release_after must be in the future", ErrInvalidEscrow)
+		}
+		if releaseAfter.After(now.Add(MaxEscrowTimeout)) {
+			return nil, fmt.Errorf("%w: release_after must be within %d days", ErrInvalidEscrow, int(MaxEscrowTimeout.Hours()/24))
+		}
+	}
+
+	escrow := &model.Escrow{
+		ID:             uuid.New(),
+		BuyerWalletID:  buyerWalletID,
+		SellerWalletID: sellerWalletID,
+		EscrowWalletID: es.escrowWalletID,
+		Amount:         req.Amount,
+		Memo:           memo,
+		ReleaseAfter:   releaseAfter,
+		CreatedAt:      now,
+	}
+	fee, err := feeOrZero(ctx, es.fees, model.FeeOperationEscrow, escrow.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("service.CreateEscrow: %w", err)
+	}
+	if err = es.eRepo.CreateEscrow(ctx, userId, escrow, fee); err != nil {
+		return nil, fmt.Errorf("service.CreateEscrow: %w", err)
+	}
+	return escrow, nil
+}
+
+func (es *EscrowServiceImpl) ListEscrows(ctx context.Context, userId, walletId string) ([]model.Escrow, error) {
+	escrows, err := es.eRepo.ListEscrows(ctx, userId, walletId)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListEscrows: %w", err)
+	}
+	return escrows, nil
+}
+
+func (es *EscrowServiceImpl) GetEscrow(ctx context.Context, userId, walletId, escrowId string) (*model.Escrow, error) {
+	escrow, err := es.eRepo.GetEscrow(ctx, userId, walletId, escrowId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetEscrow: %w", err)
+	}
+	return escrow, nil
+}
+
+// ConfirmEscrow releases the escrow to the seller on the buyer's confirmation.
+func (es *EscrowServiceImpl) ConfirmEscrow(ctx context.Context, userId, walletId, escrowId string) (*model.Escrow, error) {
+	escrow, err := es.eRepo.ConfirmEscrow(ctx, userId, walletId, escrowId, es.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("service.ConfirmEscrow: %w", err)
+	}
+	return escrow, nil
+}
+
+// DecideEscrow settles a held escrow on an administrator's decision: released pays the seller and refunded pays
+// the buyer back.
+func (es *EscrowServiceImpl) DecideEscrow(ctx context.Context, admin, escrowId string, req model.EscrowDecisionRequest) (*model.Escrow, error) {
+	if req.Outcome != model.EscrowStatusReleased && req.Outcome != model.EscrowStatusRefunded {
+		return nil, fmt.Errorf("%w: outcome must be released or refunded", ErrInvalidEscrow)
+	}
+	escrow, err := es.eRepo.SettleEscrow(ctx, escrowId, req.Outcome, model.EscrowResolutionAdmin, &admin, es.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("service.DecideEscrow: %w", err)
+	}
+	return escrow, nil
+}
+
+// ListEscrowsByStatus returns every escrow in status, for administrators. It defaults to held escrows.
+func (es *EscrowServiceImpl) ListEscrowsByStatus(ctx context.Context, status model.EscrowStatus) ([]model.Escrow, error) {
+	if status == "" {
+		status = model.EscrowStatusHeld
+	}
+	escrows, err := es.eRepo.ListEscrowsByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListEscrowsByStatus: %w", err)
+	}
+	return escrows, nil
+}
+
+// EscrowReleaseJob releases held escrows to their sellers once their release_after has passed.
+type EscrowReleaseJob struct {
+	eRepo EscrowRepo
+	clock clock.Clock
+}
+
+func NewEscrowReleaseJob(er EscrowRepo, clk clock.Clock) *EscrowReleaseJob {
+	return &EscrowReleaseJob{eRepo: er, clock: clk}
+}
+
+// Run calls RunOnce every interval until stop is closed or ctx is cancelled, as runEvery does.
+func (j *EscrowReleaseJob) Run(ctx context.Context, stop <-chan struct{}, interval time.Duration) {
+	runEvery(ctx, stop, interval, "Escrow release job", j.RunOnce)
+}
+
+// RunOnce releases every escrow due. An escrow settled by someone else in the meantime is skipped, and so is one
+// whose seller's wallet is restricted; it is retried on the next pass.
+func (j *EscrowReleaseJob) RunOnce(ctx context.Context) error {
+	now := j.clock.Now().UTC()
+	due, err := j.eRepo.ListEscrowsDue(ctx, now)
+	if err != nil {
+		return fmt.Errorf("service.RunOnce: %w", err)
+	}
+	for _, id := range due {
+		escrow, err := j.eRepo.SettleEscrow(ctx, id.String(), model.EscrowStatusReleased, model.EscrowResolutionTimeout, nil, now)
+		if errors.Is(err, repo.ErrEscrowNotHeld) {
+			continue
+		}
+		if errors.Is(err, repo.ErrWalletFrozen) || errors.Is(err, repo.ErrWalletCreditBlocked) || errors.Is(err, repo.ErrWalletDebitBlocked) {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("escrow-id", id.String()).Msg("Escrow release skipped")
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("service.RunOnce: %w", err)
+		}
+		zerolog.Ctx(ctx).Info().
+			Str("escrow-id", escrow.ID.String()).
+			Str("amount", escrow.Amount.String()).
+			Msg("Escrow released on timeout")
+	}
+	return nil
+}
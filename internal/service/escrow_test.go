@@ -0,0 +1,165 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+var testEscrowWalletUUID = uuid.MustParse("00000000-0000-0000-0000-0000000000e5")
+
+func TestEscrowServiceImpl_CreateEscrow(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	tests := []struct {
+		name             string
+		req              model.EscrowRequest
+		wantReleaseAfter time.Time
+		wantMemo         string
+		repoErr          error
+		wantErr          error
+	}{
+		{
+			name:             "success - default timeout",
+			req:              model.EscrowRequest{SellerWalletID: testWallet2UUID.String(), Amount: dec("120"), Memo: " bike "},
+			wantReleaseAfter: now.Add(service.DefaultEscrowTimeout),
+			wantMemo:         "bike",
+		},
+		{
+			name:             "success - release time given",
+			req:              model.EscrowRequest{SellerWalletID: testWallet2UUID.String(), Amount: dec("120"), ReleaseAfter: ptr(now.Add(48 * time.Hour))},
+			wantReleaseAfter: now.Add(48 * time.Hour),
+		},
+		{
+			name:             "error - insufficient funds",
+			req:              model.EscrowRequest{SellerWalletID: testWallet2UUID.String(), Amount: dec("120")},
+			wantReleaseAfter: now.Add(service.DefaultEscrowTimeout),
+			repoErr:          repo.ErrInsufficientFunds,
+			wantErr:          repo.ErrInsufficientFunds,
+		},
+		{
+			name:    "error - seller is the buyer",
+			req:     model.EscrowRequest{SellerWalletID: testWallet1UUIDString, Amount: dec("120")},
+			wantErr: service.ErrInvalidEscrow,
+		},
+		{
+			name:    "error - seller is the escrow account",
+			req:     model.EscrowRequest{SellerWalletID: testEscrowWalletUUID.String(), Amount: dec("120")},
+			wantErr: service.ErrInvalidEscrow,
+		},
+		{
+			name:    "error - amount not positive",
+			req:     model.EscrowRequest{SellerWalletID: testWallet2UUID.String(), Amount: dec("-1")},
+			wantErr: service.ErrInvalidEscrow,
+		},
+		{
+			name:    "error - memo too long",
+			req:     model.EscrowRequest{SellerWalletID: testWallet2UUID.String(), Amount: dec("120"), Memo: strings.Repeat("x", 141)},
+			wantErr: service.ErrInvalidEscrow,
+		},
+		{
+			name:    "error - release time in the past",
+			req:     model.EscrowRequest{SellerWalletID: testWallet2UUID.String(), Amount: dec("120"), ReleaseAfter: ptr(now.Add(-time.Minute))},
+			wantErr: service.ErrInvalidEscrow,
+		},
+		{
+			name:    "error - release time too far",
+			req:     model.EscrowRequest{SellerWalletID: testWallet2UUID.String(), Amount: dec("120"), ReleaseAfter: ptr(now.Add(service.MaxEscrowTimeout + time.Hour))},
+			wantErr: service.ErrInvalidEscrow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.EscrowRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				m.On("CreateEscrow", mock.Anything, testUser1UUIDString, mock.MatchedBy(func(e *model.Escrow) bool {
+					return e.BuyerWalletID == testWallet1UUID && e.SellerWalletID == testWallet2UUID &&
+						e.EscrowWalletID == testEscrowWalletUUID && e.ReleaseAfter.Equal(tt.wantReleaseAfter)
+				}), mock.Anything).Return(tt.repoErr)
+			}
+			es := service.NewEscrowImpl(m, nil, testEscrowWalletUUID, clock.NewFake(now))
+
+			got, err := es.CreateEscrow(context.Background(), testUser1UUIDString, testWallet1UUIDString, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantMemo, got.Memo)
+				assert.True(t, got.Amount.Equal(tt.req.Amount))
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEscrowServiceImpl_DecideEscrow(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	escrowID := uuid.New()
+
+	tests := []struct {
+		name    string
+		outcome model.EscrowStatus
+		repoErr error
+		wantErr error
+	}{
+		{name: "release", outcome: model.EscrowStatusReleased},
+		{name: "refund", outcome: model.EscrowStatusRefunded},
+		{name: "error - already settled", outcome: model.EscrowStatusRefunded, repoErr: repo.ErrEscrowNotHeld, wantErr: repo.ErrEscrowNotHeld},
+		{name: "error - held is not an outcome", outcome: model.EscrowStatusHeld, wantErr: service.ErrInvalidEscrow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.EscrowRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				var escrow *model.Escrow
+				if tt.repoErr == nil {
+					escrow = &model.Escrow{ID: escrowID, Status: tt.outcome}
+				}
+				m.On("SettleEscrow", mock.Anything, escrowID.String(), tt.outcome, model.EscrowResolutionAdmin, ptr("ops"), now).
+					Return(escrow, tt.repoErr)
+			}
+			es := service.NewEscrowImpl(m, nil, testEscrowWalletUUID, clock.NewFake(now))
+
+			got, err := es.DecideEscrow(context.Background(), "ops", escrowID.String(), model.EscrowDecisionRequest{Outcome: tt.outcome})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.outcome, got.Status)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEscrowReleaseJob_RunOnce(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	settled, blocked, due := uuid.New(), uuid.New(), uuid.New()
+
+	m := new(walletmocks.EscrowRepoMock)
+	m.On("ListEscrowsDue", mock.Anything, now).Return([]uuid.UUID{settled, blocked, due}, nil)
+	m.On("SettleEscrow", mock.Anything, settled.String(), model.EscrowStatusReleased, model.EscrowResolutionTimeout, (*string)(nil), now).
+		Return(nil, fmt.Errorf("escrow is released: %w", repo.ErrEscrowNotHeld))
+	m.On("SettleEscrow", mock.Anything, blocked.String(), model.EscrowStatusReleased, model.EscrowResolutionTimeout, (*string)(nil), now).
+		Return(nil, fmt.Errorf("destination wallet: %w", repo.ErrWalletCreditBlocked))
+	m.On("SettleEscrow", mock.Anything, due.String(), model.EscrowStatusReleased, model.EscrowResolutionTimeout, (*string)(nil), now).
+		Return(&model.Escrow{ID: due, Amount: dec("10"), Status: model.EscrowStatusReleased}, nil)
+
+	require.NoError(t, service.NewEscrowReleaseJob(m, clock.NewFake(now)).RunOnce(context.Background()))
+	m.AssertExpectations(t)
+}
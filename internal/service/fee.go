@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+// ErrInvalidFeeSchedule indicates that a fee schedule loaded from configuration is malformed.
+var ErrInvalidFeeSchedule = errors.New("invalid fee schedule")
+
+// ErrInvalidFeeQuote indicates that a fee quote was asked for an unknown operation or a non-positive amount.
+var ErrInvalidFeeQuote = errors.New("invalid fee quote request")
+
+type FeeScheduleRepo interface {
+	GetActiveFeeSchedule(ctx context.Context, operation model.FeeOperation, currency string, at time.Time) (*model.FeeSchedule, error)
+	ListFeeSchedules(ctx context.Context, currency string) ([]model.FeeSchedule, error)
+	SyncFeeSchedules(ctx context.Context, schedules []model.FeeSchedule) error
+}
+
+// FeeCalculator works out the fee to charge on an operation. *FeeServiceImpl satisfies it.
+type FeeCalculator interface {
+	Fee(ctx context.Context, operation model.FeeOperation, amount decimal.Decimal) (model.Fee, error)
+}
+
+// feeOrZero works out the fee for an operation with fc, or a zero fee when fc is nil and no fees are charged.
+func feeOrZero(ctx context.Context, fc FeeCalculator, operation model.FeeOperation, amount decimal.Decimal) (model.Fee, error) {
+	if fc == nil {
+		return model.Fee{Amount: decimal.Zero}, nil
+	}
+	return fc.Fee(ctx, operation, amount)
+}
+
+type FeeServiceImpl struct {
+	fRepo       FeeScheduleRepo
+	currency    string
+	feeWalletID uuid.UUID
+	clock       clock.Clock
+}
+
+func NewFeeImpl(fr FeeScheduleRepo, currency string, feeWalletID uuid.UUID, clk clock.Clock) *FeeServiceImpl {
+	return &FeeServiceImpl{fRepo: fr, currency: currency, feeWalletID: feeWalletID, clock: clk}
+}
+
+// Quote tells what an operation of the given amount would cost right now, without performing it.
+func (fs *FeeServiceImpl) Quote(ctx context.Context, operation model.FeeOperation, amount decimal.Decimal) (*model.FeeQuote, error) {
+	if !operation.IsValid() {
+		return nil, fmt.Errorf("%w: operation must be %q, %q or %q", ErrInvalidFeeQuote, model.FeeOperationWithdrawal, model.FeeOperationTransfer,
+			model.FeeOperationEscrow)
+	}
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidFeeQuote)
+	}
+
+	schedule, err := fs.activeSchedule(ctx, operation)
+	if err != nil {
+		return nil, fmt.Errorf("service.Quote: %w", err)
+	}
+	quote := &model.FeeQuote{Operation: operation, Currency: fs.currency, Amount: amount, Fee: decimal.Zero}
+	if schedule != nil {
+		quote.Fee = schedule.Rule.Calculate(amount)
+		quote.ScheduleID = &schedule.ID
+		quote.ScheduleVersion = schedule.Version
+	}
+	quote.Total = amount.Add(quote.Fee)
+	return quote, nil
+}
+
+// Fee returns the fee to charge on an operation, ready to be posted with it.
+func (fs *FeeServiceImpl) Fee(ctx context.Context, operation model.FeeOperation, amount decimal.Decimal) (model.Fee, error) {
+	fee := model.Fee{Amount: decimal.Zero, WalletID: fs.feeWalletID}
+	schedule, err := fs.activeSchedule(ctx, operation)
+	if err != nil {
+		return fee, fmt.Errorf("service.Fee: %w", err)
+	}
+	if schedule != nil {
+		fee.Amount = schedule.Rule.Calculate(amount)
+		fee.ScheduleID = &schedule.ID
+	}
+	return fee, nil
+}
+
+// activeSchedule returns the schedule in effect for the operation, or nil when the operation is free.
+func (fs *FeeServiceImpl) activeSchedule(ctx context.Context, operation model.FeeOperation) (*model.FeeSchedule, error) {
+	schedule, err := fs.fRepo.GetActiveFeeSchedule(ctx, operation, fs.currency, fs.clock.Now())
+	if errors.Is(err, repo.ErrFeeScheduleNotFound) {
+		return nil, nil
+	}
+	return schedule, err
+}
+
+// ListFeeSchedules returns every version of the fee schedules for the wallet currency.
+func (fs *FeeServiceImpl) ListFeeSchedules(ctx context.Context) ([]model.FeeSchedule, error) {
+	schedules, err := fs.fRepo.ListFeeSchedules(ctx, fs.currency)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListFeeSchedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// SyncFeeSchedules validates schedules loaded from configuration and stores the versions not yet known.
+func (fs *FeeServiceImpl) SyncFeeSchedules(ctx context.Context, schedules []model.FeeSchedule) error {
+	now := fs.clock.Now()
+	for i := range schedules {
+		s := &schedules[i]
+		if !s.Operation.IsValid() {
+			return fmt.Errorf("%w: unknown operation %q", ErrInvalidFeeSchedule, s.Operation)
+		}
+		s.Currency = strings.ToUpper(s.Currency)
+		if len(s.Currency) != 3 {
+			return fmt.Errorf("%w: currency must be a 3-letter code", ErrInvalidFeeSchedule)
+		}
+		if s.Version <= 0 {
+			return fmt.Errorf("%w: %s %s: version must be positive", ErrInvalidFeeSchedule, s.Operation, s.Currency)
+		}
+		if err := s.Rule.Validate(); err != nil {
+			return fmt.Errorf("%w: %s %s version %d: %w", ErrInvalidFeeSchedule, s.Operation, s.Currency, s.Version, err)
+		}
+		if s.ID == uuid.Nil {
+			s.ID = uuid.New()
+		}
+		if s.EffectiveFrom.IsZero() {
+			return fmt.Errorf("%w: %s %s version %d: effective_from is required", ErrInvalidFeeSchedule, s.Operation, s.Currency, s.Version)
+		}
+		s.CreatedAt = now
+	}
+	if err := fs.fRepo.SyncFeeSchedules(ctx, schedules); err != nil {
+		return fmt.Errorf("service.SyncFeeSchedules: %w", err)
+	}
+	return nil
+}
+
+// LoadFeeSchedules reads a JSON array of model.FeeSchedule, as found in the file named by FEE_SCHEDULES_FILE.
+func LoadFeeSchedules(r io.Reader) ([]model.FeeSchedule, error) {
+	var schedules []model.FeeSchedule
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&schedules); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFeeSchedule, err)
+	}
+	return schedules, nil
+}
@@ -0,0 +1,229 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+var testFeeWalletUUID = uuid.MustParse("00000000-0000-0000-0000-0000000000fe")
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestFeeServiceImpl_Quote(t *testing.T) {
+	now := mustTime("2025-06-01T00:00:00Z")
+	tiered := model.FeeRule{Tiers: []model.FeeTier{
+		{UpTo: ptr(dec("100")), Flat: dec("1")},
+		{UpTo: ptr(dec("1000")), Percent: dec("0.5")},
+		{Flat: dec("2"), Percent: dec("0.25")},
+	}}
+
+	tests := []struct {
+		name     string
+		rule     *model.FeeRule
+		amount   string
+		wantFee  string
+		wantErr  error
+		noSearch bool
+	}{
+		{name: "flat", rule: &model.FeeRule{Flat: dec("1.50")}, amount: "80", wantFee: "1.5"},
+		{name: "percentage rounded to cents", rule: &model.FeeRule{Percent: dec("1.5")}, amount: "33.33", wantFee: "0.5"},
+		{name: "flat plus percentage", rule: &model.FeeRule{Flat: dec("0.30"), Percent: dec("2.9")}, amount: "100", wantFee: "3.2"},
+		{name: "minimum", rule: &model.FeeRule{Percent: dec("1"), Min: ptr(dec("0.50"))}, amount: "10", wantFee: "0.5"},
+		{name: "maximum", rule: &model.FeeRule{Percent: dec("1"), Max: ptr(dec("10"))}, amount: "5000", wantFee: "10"},
+		{name: "tier - first band is inclusive", rule: &tiered, amount: "100", wantFee: "1"},
+		{name: "tier - middle band", rule: &tiered, amount: "400", wantFee: "2"},
+		{name: "tier - open last band", rule: &tiered, amount: "2000", wantFee: "7"},
+		{name: "no schedule - free", amount: "100", wantFee: "0"},
+		{name: "error - amount not positive", amount: "0", wantErr: service.ErrInvalidFeeQuote, noSearch: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.FeeScheduleRepoMock)
+			schedule := &model.FeeSchedule{ID: uuid.New(), Operation: model.FeeOperationWithdrawal, Currency: "USD", Version: 3}
+			if !tt.noSearch {
+				if tt.rule != nil {
+					schedule.Rule = *tt.rule
+					m.On("GetActiveFeeSchedule", mock.Anything, model.FeeOperationWithdrawal, "USD", now).Return(schedule, nil)
+				} else {
+					m.On("GetActiveFeeSchedule", mock.Anything, model.FeeOperationWithdrawal, "USD", now).Return(nil, repo.ErrFeeScheduleNotFound)
+				}
+			}
+			fs := service.NewFeeImpl(m, "USD", testFeeWalletUUID, clock.NewFake(now))
+
+			got, err := fs.Quote(context.Background(), model.FeeOperationWithdrawal, dec(tt.amount))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFee, got.Fee.String())
+			assert.Equal(t, dec(tt.amount).Add(dec(tt.wantFee)).String(), got.Total.String())
+			if tt.rule != nil {
+				assert.Equal(t, &schedule.ID, got.ScheduleID)
+				assert.Equal(t, 3, got.ScheduleVersion)
+			} else {
+				assert.Nil(t, got.ScheduleID)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFeeServiceImpl_SyncFeeSchedules(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			json: `[{"operation": "transfer", "currency": "usd", "version": 2, "effective_from": "2025-07-01T00:00:00Z",
+			         "rule": {"flat": "0.10", "percent": "0", "max": "1"}}]`,
+		},
+		{name: "error - unknown field", json: `[{"operation": "transfer", "currency": "USD", "version": 1, "cost": 1}]`, wantErr: true},
+		{name: "error - unknown operation", json: `[{"operation": "deposit", "currency": "USD", "version": 1, "effective_from": "2025-07-01T00:00:00Z"}]`, wantErr: true},
+		{name: "error - negative fee", json: `[{"operation": "transfer", "currency": "USD", "version": 1, "effective_from": "2025-07-01T00:00:00Z", "rule": {"flat": "-1"}}]`, wantErr: true},
+		{name: "error - min above max", json: `[{"operation": "transfer", "currency": "USD", "version": 1, "effective_from": "2025-07-01T00:00:00Z", "rule": {"min": "5", "max": "1"}}]`, wantErr: true},
+		{name: "error - open tier not last", json: `[{"operation": "transfer", "currency": "USD", "version": 1, "effective_from": "2025-07-01T00:00:00Z", "rule": {"tiers": [{"flat": "1"}, {"up_to": "10", "flat": "2"}]}}]`, wantErr: true},
+		{name: "error - missing version", json: `[{"operation": "transfer", "currency": "USD", "effective_from": "2025-07-01T00:00:00Z"}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.FeeScheduleRepoMock)
+			if !tt.wantErr {
+				m.On("SyncFeeSchedules", mock.Anything, mock.MatchedBy(func(s []model.FeeSchedule) bool {
+					return len(s) == 1 && s[0].Currency == "USD" && s[0].ID != uuid.Nil && s[0].Rule.Flat.Equal(dec("0.1"))
+				})).Return(nil)
+			}
+			fs := service.NewFeeImpl(m, "USD", testFeeWalletUUID, clock.NewFake(mustTime("2025-06-01T00:00:00Z")))
+
+			schedules, err := service.LoadFeeSchedules(strings.NewReader(tt.json))
+			if err == nil {
+				err = fs.SyncFeeSchedules(context.Background(), schedules)
+			}
+			if tt.wantErr {
+				assert.ErrorIs(t, err, service.ErrInvalidFeeSchedule)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWalletServiceImpl_Withdraw_ChargesFee(t *testing.T) {
+	scheduleID := uuid.New()
+	fee := model.Fee{Amount: dec("1"), WalletID: testFeeWalletUUID, ScheduleID: &scheduleID}
+
+	fc := new(walletmocks.FeeCalculatorMock)
+	fc.On("Fee", mock.Anything, model.FeeOperationWithdrawal, dec("100")).Return(fee, nil)
+	wr := new(walletmocks.WalletRepoMock)
+	wr.On("Withdraw", mock.Anything, testUser1UUIDString, testWallet1UUIDString, dec("100"), fee).
+		Return(&model.Transaction{ID: uuid.New(), Fee: &model.Transaction{Amount: fee.Amount, FeeScheduleID: &scheduleID}}, nil)
+
+	got, err := service.NewWalletImpl(wr, fc).Withdraw(context.Background(), testUser1UUIDString, testWallet1UUIDString, dec("100"))
+	require.NoError(t, err)
+	require.NotNil(t, got.Fee)
+	assert.Equal(t, &scheduleID, got.Fee.FeeScheduleID)
+	fc.AssertExpectations(t)
+	wr.AssertExpectations(t)
+}
+
+func TestWalletServiceImpl_Transfer_InsufficientFundsForFee(t *testing.T) {
+	fee := model.Fee{Amount: dec("2.5"), WalletID: testFeeWalletUUID}
+
+	fc := new(walletmocks.FeeCalculatorMock)
+	fc.On("Fee", mock.Anything, model.FeeOperationTransfer, dec("50")).Return(fee, nil)
+	wr := new(walletmocks.WalletRepoMock)
+	wr.On("Transfer", mock.Anything, testUser1UUIDString, testWallet1UUIDString, testWallet2UUID.String(), dec("50"), fee).
+		Return(nil, repo.ErrInsufficientFunds)
+
+	got, err := service.NewWalletImpl(wr, fc).Transfer(context.Background(), testUser1UUIDString, testWallet1UUIDString, testWallet2UUID.String(), dec("50"))
+	assert.ErrorIs(t, err, repo.ErrInsufficientFunds)
+	assert.Nil(t, got)
+	fc.AssertExpectations(t)
+	wr.AssertExpectations(t)
+}
+
+func TestPaymentRequestServiceImpl_AcceptPaymentRequest_ChargesFee(t *testing.T) {
+	now := mustTime("2025-01-15T09:30:00Z")
+	requestID := uuid.New().String()
+	fee := model.Fee{Amount: dec("3"), WalletID: testFeeWalletUUID}
+
+	fc := new(walletmocks.FeeCalculatorMock)
+	fc.On("Fee", mock.Anything, model.FeeOperationTransfer, dec("1200")).Return(fee, nil)
+	m := new(walletmocks.PaymentRequestRepoMock)
+	m.On("GetPaymentRequest", mock.Anything, testUser2UUID.String(), requestID, now).
+		Return(&model.PaymentRequest{Amount: dec("1200"), Status: model.PaymentRequestStatusPending}, nil)
+	m.On("AcceptPaymentRequest", mock.Anything, testUser2UUID.String(), requestID, testWallet2UUID.String(), fee, now).
+		Return(&model.PaymentRequest{Amount: dec("1200"), Status: model.PaymentRequestStatusAccepted}, nil)
+
+	_, err := service.NewPaymentRequestImpl(m, fc, clock.NewFake(now)).
+		AcceptPaymentRequest(context.Background(), testUser2UUID.String(), requestID, testWallet2UUID.String())
+	require.NoError(t, err)
+	fc.AssertExpectations(t)
+	m.AssertExpectations(t)
+}
+
+func TestEscrowServiceImpl_CreateEscrow_ChargesFee(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	fee := model.Fee{Amount: dec("1.20"), WalletID: testFeeWalletUUID}
+
+	fc := new(walletmocks.FeeCalculatorMock)
+	fc.On("Fee", mock.Anything, model.FeeOperationEscrow, dec("120")).Return(fee, nil)
+	m := new(walletmocks.EscrowRepoMock)
+	m.On("CreateEscrow", mock.Anything, testUser1UUIDString, mock.Anything, fee).Return(nil)
+
+	_, err := service.NewEscrowImpl(m, fc, testEscrowWalletUUID, clock.NewFake(now)).
+		CreateEscrow(context.Background(), testUser1UUIDString, testWallet1UUIDString, model.EscrowRequest{SellerWalletID: testWallet2UUID.String(), Amount: dec("120")})
+	require.NoError(t, err)
+	fc.AssertExpectations(t)
+	m.AssertExpectations(t)
+}
+
+func TestTransferBatchServiceImpl_CreateTransferBatch_QuotesFees(t *testing.T) {
+	scheduleID := uuid.New()
+	rows := []model.TransferBatchRow{
+		{DestinationWalletID: testWallet2UUID.String(), Amount: dec("100")},
+		{DestinationWalletID: testWallet3UUID.String(), Amount: dec("2000")},
+	}
+
+	fc := new(walletmocks.FeeCalculatorMock)
+	fc.On("Fee", mock.Anything, model.FeeOperationTransfer, dec("100")).Return(model.Fee{Amount: decimal.Zero, WalletID: testFeeWalletUUID, ScheduleID: &scheduleID}, nil)
+	fc.On("Fee", mock.Anything, model.FeeOperationTransfer, dec("2000")).Return(model.Fee{Amount: dec("5"), WalletID: testFeeWalletUUID, ScheduleID: &scheduleID}, nil)
+	m := new(walletmocks.TransferBatchRepoMock)
+	m.On("FindMissingWallets", mock.Anything, mock.Anything).Return(nil, nil)
+	m.On("CreateTransferBatch", mock.Anything, mock.Anything).Return(nil)
+	m.On("SetTransferBatchStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.On("ExecuteTransferBatchItem", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	bs := service.NewTransferBatchImpl(m, fc)
+	batch, err := bs.CreateTransferBatch(context.Background(), testUser1UUIDString, testWallet1UUIDString, model.TransferBatchModeBestEffort, rows)
+	require.NoError(t, err)
+	bs.Wait()
+
+	// Every row carries the fee it will be charged, so rows are charged what they were quoted.
+	require.Len(t, batch.Items, 2)
+	for i, want := range []string{"0", "5"} {
+		assert.True(t, dec(want).Equal(batch.Items[i].Fee), "row %d fee", i+1)
+		assert.Equal(t, &testFeeWalletUUID, batch.Items[i].FeeWalletID)
+		assert.Equal(t, &scheduleID, batch.Items[i].FeeScheduleID)
+	}
+	fc.AssertExpectations(t)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+// healthCheckTimeout bounds each readiness check, so a hung database fails the probe instead of timing it out.
+const healthCheckTimeout = 2 * time.Second
+
+var (
+	// ErrShuttingDown indicates that the server is draining and should receive no new traffic.
+	ErrShuttingDown = errors.New("server is shutting down")
+	// ErrMigrationsPending indicates that the database schema is older than this build expects.
+	ErrMigrationsPending = errors.New("migrations are pending")
+)
+
+type HealthRepo interface {
+	Ping(ctx context.Context) error
+	PoolStats() model.PoolStats
+	GetMigrationState(ctx context.Context) (*model.MigrationState, error)
+}
+
+type HealthServiceImpl struct {
+	hRepo    HealthRepo
+	clock    clock.Clock
+	draining atomic.Bool
+}
+
+func NewHealthImpl(hr HealthRepo, clock clock.Clock) *HealthServiceImpl {
+	return &HealthServiceImpl{hRepo: hr, clock: clock}
+}
+
+// Drain makes readiness fail from now on, so load balancers stop sending traffic while in-flight requests finish.
+func (hs *HealthServiceImpl) Drain() {
+	hs.draining.Store(true)
+}
+
+// Live reports whether the process is able to serve at all. It checks nothing outside the process, so a database
+// outage does not get every instance restarted.
+func (hs *HealthServiceImpl) Live(ctx context.Context) model.HealthReport {
+	return hs.report(ctx, []healthCheck{{"process", func(context.Context) (any, error) { return nil, nil }}})
+}
+
+// Ready reports whether the server should receive traffic:
+//   - it is not shutting down
+//   - the database answers, with the connection pool's stats as details
+//   - every migration of this build is applied
+func (hs *HealthServiceImpl) Ready(ctx context.Context) model.HealthReport {
+	return hs.report(ctx, []healthCheck{
+		{"shutdown", func(context.Context) (any, error) {
+			if hs.draining.Load() {
+				return nil, ErrShuttingDown
+			}
+			return nil, nil
+		}},
+		{"database", func(ctx context.Context) (any, error) {
+			err := hs.hRepo.Ping(ctx)
+			return hs.hRepo.PoolStats(), err
+		}},
+		{"migrations", func(ctx context.Context) (any, error) {
+			state, err := hs.hRepo.GetMigrationState(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if len(state.Pending) > 0 {
+				return state, fmt.Errorf("%w: %d of them", ErrMigrationsPending, len(state.Pending))
+			}
+			return state, nil
+		}},
+	})
+}
+
+type healthCheck struct {
+	name string
+	run  func(ctx context.Context) (any, error)
+}
+
+// report runs the checks one after the other, each within healthCheckTimeout.
+func (hs *HealthServiceImpl) report(ctx context.Context, checks []healthCheck) model.HealthReport {
+	report := model.HealthReport{Status: model.HealthStatusOK, Checks: make(map[string]model.HealthCheck, len(checks))}
+	for _, c := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		start := hs.clock.Now()
+		details, err := c.run(checkCtx)
+		latency := hs.clock.Now().Sub(start)
+		cancel()
+
+		result := model.HealthCheck{
+			Status:    model.HealthStatusOK,
+			LatencyMs: float64(latency) / float64(time.Millisecond),
+			Details:   details,
+		}
+		if err != nil {
+			result.Status = model.HealthStatusFail
+			result.Error = err.Error()
+			report.Status = model.HealthStatusFail
+		}
+		report.Checks[c.name] = result
+	}
+	return report
+}
@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+func TestHealthServiceImpl_Live(t *testing.T) {
+	hs := service.NewHealthImpl(walletmocks.NewHealthRepoMock(t), clock.NewFake(time.Now()))
+
+	report := hs.Live(context.Background())
+	assert.Equal(t, model.HealthStatusOK, report.Status)
+	assert.Equal(t, model.HealthStatusOK, report.Checks["process"].Status)
+
+	// Liveness does not depend on the database, nor on shutting down.
+	hs.Drain()
+	assert.Equal(t, model.HealthStatusOK, hs.Live(context.Background()).Status)
+}
+
+func TestHealthServiceImpl_Ready(t *testing.T) {
+	stats := model.PoolStats{MaxOpenConnections: 25, OpenConnections: 3, InUse: 1, Idle: 2}
+	current := &model.MigrationState{Latest: 24, Pending: []int64{}}
+	behind := &model.MigrationState{Latest: 24, Pending: []int64{23, 24}}
+
+	tests := []struct {
+		name       string
+		drain      bool
+		pingErr    error
+		migrations *model.MigrationState
+		stateErr   error
+		wantStatus model.HealthStatus
+		// wantFailed maps each failing check to the error it reports.
+		wantFailed map[string]string
+	}{
+		{
+			name:       "ready",
+			migrations: current,
+			wantStatus: model.HealthStatusOK,
+		},
+		{
+			name:       "database down",
+			pingErr:    errors.New("failed to ping database: connection refused"),
+			migrations: current,
+			wantStatus: model.HealthStatusFail,
+			wantFailed: map[string]string{"database": "failed to ping database: connection refused"},
+		},
+		{
+			name:       "migrations pending",
+			migrations: behind,
+			wantStatus: model.HealthStatusFail,
+			wantFailed: map[string]string{"migrations": "migrations are pending: 2 of them"},
+		},
+		{
+			name:       "migration status unreadable",
+			stateErr:   errors.New("failed to read migration status: timeout"),
+			wantStatus: model.HealthStatusFail,
+			wantFailed: map[string]string{"migrations": "failed to read migration status: timeout"},
+		},
+		{
+			name:       "shutting down",
+			drain:      true,
+			migrations: current,
+			wantStatus: model.HealthStatusFail,
+			wantFailed: map[string]string{"shutdown": service.ErrShuttingDown.Error()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := walletmocks.NewHealthRepoMock(t)
+			m.On("Ping", mock.Anything).Return(tt.pingErr)
+			m.On("PoolStats").Return(stats)
+			m.On("GetMigrationState", mock.Anything).Return(tt.migrations, tt.stateErr)
+
+			hs := service.NewHealthImpl(m, clock.NewFake(time.Now()))
+			if tt.drain {
+				hs.Drain()
+			}
+			report := hs.Ready(context.Background())
+
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Len(t, report.Checks, 3)
+			for name, check := range report.Checks {
+				if msg, ok := tt.wantFailed[name]; ok {
+					assert.Equal(t, model.HealthStatusFail, check.Status, name)
+					assert.Equal(t, msg, check.Error, name)
+				} else {
+					assert.Equal(t, model.HealthStatusOK, check.Status, name)
+					assert.Empty(t, check.Error, name)
+				}
+			}
+			// The pool stats are reported whether or not the database answers.
+			assert.Equal(t, stats, report.Checks["database"].Details)
+			if tt.migrations != nil {
+				assert.Equal(t, tt.migrations, report.Checks["migrations"].Details)
+			}
+		})
+	}
+}
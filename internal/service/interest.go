@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+type InterestRepo interface {
+	ListWalletProducts(ctx context.Context) ([]model.WalletProduct, error)
+	SetWalletProduct(ctx context.Context, userID string, walletID string, productID *uuid.UUID, at time.Time) (*model.Wallet, error)
+	GetInterestSummary(ctx context.Context, userID string, walletID string) (*model.InterestSummary, error)
+	ListSavingsWallets(ctx context.Context) ([]model.SavingsWallet, error)
+	GetBalanceAt(ctx context.Context, walletID uuid.UUID, at time.Time) (decimal.Decimal, error)
+	AddInterestAccrual(ctx context.Context, accrual *model.InterestAccrual) (bool, error)
+	ListInterestPayoutsDue(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+	PayInterest(ctx context.Context, walletID uuid.UUID, before time.Time, at time.Time) (*model.Transaction, error)
+}
+
+type InterestServiceImpl struct {
+	iRepo    InterestRepo
+	dayCount model.DayCountConvention
+	clock    clock.Clock
+}
+
+func NewInterestImpl(ir InterestRepo, dayCount model.DayCountConvention, clk clock.Clock) *InterestServiceImpl {
+	return &InterestServiceImpl{iRepo: ir, dayCount: dayCount, clock: clk}
+}
+
+func (is *InterestServiceImpl) ListWalletProducts(ctx context.Context) ([]model.WalletProduct, error) {
+	products, err := is.iRepo.ListWalletProducts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListWalletProducts: %w", err)
+	}
+	return products, nil
+}
+
+// SetWalletProduct makes the wallet a savings wallet on the given product, or a plain wallet again when productID is nil.
+// Interest already accrued stays and is paid out with the next monthly payout.
+func (is *InterestServiceImpl) SetWalletProduct(ctx context.Context, userID string, walletID string, productID *uuid.UUID) (*model.Wallet, error) {
+	wallet, err := is.iRepo.SetWalletProduct(ctx, userID, walletID, productID, is.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("service.SetWalletProduct: %w", err)
+	}
+	return wallet, nil
+}
+
+func (is *InterestServiceImpl) GetInterestSummary(ctx context.Context, userID string, walletID string) (*model.InterestSummary, error) {
+	summary, err := is.iRepo.GetInterestSummary(ctx, userID, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetInterestSummary: %w", err)
+	}
+	summary.DayCount = is.dayCount
+	return summary, nil
+}
+
+// InterestAccrualJob accrues interest on savings wallets every day and pays it out every month.
+//
+// Each day of each wallet is accrued at most once (the database rejects a second accrual for the same
+// day), and a payout marks the accruals it paid, so passes can be repeated or run concurrently safely.
+// Days are UTC calendar days; a day is accrued once it is over, on its closing balance.
+type InterestAccrualJob struct {
+	iRepo    InterestRepo
+	dayCount model.DayCountConvention
+	clock    clock.Clock
+}
+
+func NewInterestAccrualJob(ir InterestRepo, dayCount model.DayCountConvention, clk clock.Clock) *InterestAccrualJob {
+	return &InterestAccrualJob{iRepo: ir, dayCount: dayCount, clock: clk}
+}
+
+// Run calls RunOnce every interval until stop is closed or ctx is cancelled, as runEvery does.
+func (j *InterestAccrualJob) Run(ctx context.Context, stop <-chan struct{}, interval time.Duration) {
+	runEvery(ctx, stop, interval, "Interest accrual job", j.RunOnce)
+}
+
+// RunOnce accrues every savings wallet for each finished day not accrued yet, catching up on days missed
+// while the job was not running, then pays out the accruals of every finished month.
+func (j *InterestAccrualJob) RunOnce(ctx context.Context) error {
+	today := startOfDay(j.clock.Now())
+
+	wallets, err := j.iRepo.ListSavingsWallets(ctx)
+	if err != nil {
+		return fmt.Errorf("service.RunOnce: %w", err)
+	}
+	for i := range wallets {
+		if err = j.accrue(ctx, &wallets[i], today); err != nil {
+			return fmt.Errorf("service.RunOnce: %w", err)
+		}
+	}
+
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	due, err := j.iRepo.ListInterestPayoutsDue(ctx, monthStart)
+	if err != nil {
+		return fmt.Errorf("service.RunOnce: %w", err)
+	}
+	for _, walletID := range due {
+		transaction, err := j.iRepo.PayInterest(ctx, walletID, monthStart, j.clock.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("service.RunOnce: %w", err)
+		}
+		if transaction != nil {
+			zerolog.Ctx(ctx).Info().
+				Str("wallet-id", walletID.String()).
+				Str("transaction-id", transaction.ID.String()).
+				Str("amount", transaction.Amount.String()).
+				Msg("Interest paid")
+		}
+	}
+	return nil
+}
+
+// accrue stores the accruals of one wallet for the days from the first one not accrued yet up to, but excluding, today.
+func (j *InterestAccrualJob) accrue(ctx context.Context, w *model.SavingsWallet, today time.Time) error {
+	day := startOfDay(w.ProductSince)
+	if w.LastAccrualDate != nil {
+		if next := startOfDay(*w.LastAccrualDate).AddDate(0, 0, 1); next.After(day) {
+			day = next
+		}
+	}
+	for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		balance, err := j.iRepo.GetBalanceAt(ctx, w.WalletID, end)
+		if err != nil {
+			return err
+		}
+		accrual := &model.InterestAccrual{
+			ID:          uuid.New(),
+			WalletID:    w.WalletID,
+			AccrualDate: day,
+			Balance:     balance,
+			APR:         w.APR,
+			DayCount:    j.dayCount,
+			Amount:      model.DailyInterest(balance, w.APR, j.dayCount, day),
+			CreatedAt:   j.clock.Now().UTC(),
+		}
+		if _, err = j.iRepo.AddInterestAccrual(ctx, accrual); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startOfDay truncates t to midnight UTC.
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,145 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+func TestDailyInterest(t *testing.T) {
+	tests := []struct {
+		name       string
+		balance    string
+		apr        string
+		convention model.DayCountConvention
+		day        string
+		want       string
+	}{
+		{name: "ACT/365", balance: "1000", apr: "3.65", convention: model.DayCountActual365, day: "2024-02-29T00:00:00Z", want: "0.1"},
+		{name: "ACT/360", balance: "3600", apr: "3.6", convention: model.DayCountActual360, day: "2025-03-01T00:00:00Z", want: "0.36"},
+		{name: "ACT/ACT leap year", balance: "3660", apr: "3.66", convention: model.DayCountActualActual, day: "2024-02-29T00:00:00Z", want: "0.366"},
+		{name: "ACT/ACT common year", balance: "3650", apr: "3.65", convention: model.DayCountActualActual, day: "2025-02-28T00:00:00Z", want: "0.365"},
+		{name: "kept to 10 places", balance: "100", apr: "2.5", convention: model.DayCountActual365, day: "2025-01-01T00:00:00Z", want: "0.0068493151"},
+		{name: "empty wallet", balance: "0", apr: "5", convention: model.DayCountActual365, day: "2025-01-01T00:00:00Z", want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := model.DailyInterest(dec(tt.balance), dec(tt.apr), tt.convention, mustTime(tt.day))
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestInterestAccrualJob_RunOnce(t *testing.T) {
+	walletID := testWallet1UUID
+	feb1 := mustTime("2025-02-01T00:00:00Z")
+
+	t.Run("accrues each finished day on its closing balance and pays the finished month", func(t *testing.T) {
+		now := mustTime("2025-02-02T08:00:00Z")
+		m := new(walletmocks.InterestRepoMock)
+		m.On("ListSavingsWallets", mock.Anything).Return([]model.SavingsWallet{
+			{WalletID: walletID, APR: dec("3.65"), ProductSince: mustTime("2025-01-30T10:00:00Z")},
+		}, nil)
+		m.On("GetBalanceAt", mock.Anything, walletID, mustTime("2025-01-31T00:00:00Z")).Return(dec("1000"), nil)
+		m.On("GetBalanceAt", mock.Anything, walletID, feb1).Return(dec("1000"), nil)
+		m.On("GetBalanceAt", mock.Anything, walletID, mustTime("2025-02-02T00:00:00Z")).Return(dec("2000"), nil)
+
+		var accrued []*model.InterestAccrual
+		m.On("AddInterestAccrual", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { accrued = append(accrued, args.Get(1).(*model.InterestAccrual)) }).
+			Return(true, nil)
+		m.On("ListInterestPayoutsDue", mock.Anything, feb1).Return([]uuid.UUID{walletID}, nil)
+		m.On("PayInterest", mock.Anything, walletID, feb1, now).
+			Return(&model.Transaction{ID: uuid.New(), WalletID: walletID, Type: model.TransactionTypeInterest, Amount: dec("0.2")}, nil)
+
+		job := service.NewInterestAccrualJob(m, model.DayCountActual365, clock.NewFake(now))
+		require.NoError(t, job.RunOnce(context.Background()))
+
+		require.Len(t, accrued, 3)
+		wantDays := []string{"2025-01-30", "2025-01-31", "2025-02-01"}
+		wantAmounts := []string{"0.1", "0.1", "0.2"}
+		for i, a := range accrued {
+			assert.Equal(t, wantDays[i], a.AccrualDate.Format(time.DateOnly))
+			assert.Equal(t, wantAmounts[i], a.Amount.String())
+			assert.Equal(t, model.DayCountActual365, a.DayCount)
+		}
+		m.AssertExpectations(t)
+	})
+
+	t.Run("a second pass the same day accrues nothing again", func(t *testing.T) {
+		now := mustTime("2025-02-02T20:00:00Z")
+		last := mustTime("2025-02-01T00:00:00Z")
+		m := new(walletmocks.InterestRepoMock)
+		m.On("ListSavingsWallets", mock.Anything).Return([]model.SavingsWallet{
+			{WalletID: walletID, APR: dec("3.65"), ProductSince: mustTime("2025-01-30T10:00:00Z"), LastAccrualDate: &last},
+		}, nil)
+		m.On("ListInterestPayoutsDue", mock.Anything, feb1).Return([]uuid.UUID{}, nil)
+
+		job := service.NewInterestAccrualJob(m, model.DayCountActual365, clock.NewFake(now))
+		require.NoError(t, job.RunOnce(context.Background()))
+
+		m.AssertNotCalled(t, "GetBalanceAt", mock.Anything, mock.Anything, mock.Anything)
+		m.AssertNotCalled(t, "AddInterestAccrual", mock.Anything, mock.Anything)
+		m.AssertExpectations(t)
+	})
+
+	t.Run("the next day accrues only the day that has just finished", func(t *testing.T) {
+		clk := clock.NewFake(mustTime("2025-02-02T20:00:00Z"))
+		last := mustTime("2025-02-01T00:00:00Z")
+		clk.Advance(24 * time.Hour)
+
+		m := new(walletmocks.InterestRepoMock)
+		m.On("ListSavingsWallets", mock.Anything).Return([]model.SavingsWallet{
+			{WalletID: walletID, APR: dec("3.65"), ProductSince: mustTime("2025-01-30T10:00:00Z"), LastAccrualDate: &last},
+		}, nil)
+		m.On("GetBalanceAt", mock.Anything, walletID, mustTime("2025-02-03T00:00:00Z")).Return(dec("500"), nil)
+		m.On("AddInterestAccrual", mock.Anything, mock.MatchedBy(func(a *model.InterestAccrual) bool {
+			return a.AccrualDate.Equal(mustTime("2025-02-02T00:00:00Z")) && a.Amount.String() == "0.05"
+		})).Return(true, nil).Once()
+		m.On("ListInterestPayoutsDue", mock.Anything, feb1).Return([]uuid.UUID{}, nil)
+
+		job := service.NewInterestAccrualJob(m, model.DayCountActual365, clk)
+		require.NoError(t, job.RunOnce(context.Background()))
+		m.AssertExpectations(t)
+	})
+
+	t.Run("accruals below a cent carry over", func(t *testing.T) {
+		now := mustTime("2025-03-01T00:30:00Z")
+		last := mustTime("2025-02-28T00:00:00Z")
+		m := new(walletmocks.InterestRepoMock)
+		m.On("ListSavingsWallets", mock.Anything).Return([]model.SavingsWallet{
+			{WalletID: walletID, APR: dec("1"), ProductSince: mustTime("2025-02-01T00:00:00Z"), LastAccrualDate: &last},
+		}, nil)
+		march1 := mustTime("2025-03-01T00:00:00Z")
+		m.On("ListInterestPayoutsDue", mock.Anything, march1).Return([]uuid.UUID{walletID}, nil)
+		m.On("PayInterest", mock.Anything, walletID, march1, now).Return(nil, nil)
+
+		job := service.NewInterestAccrualJob(m, model.DayCountActual365, clock.NewFake(now))
+		require.NoError(t, job.RunOnce(context.Background()))
+		m.AssertExpectations(t)
+	})
+}
+
+func TestInterestServiceImpl_SetWalletProduct(t *testing.T) {
+	now := mustTime("2025-02-02T08:00:00Z")
+	productID := uuid.New()
+	m := new(walletmocks.InterestRepoMock)
+	m.On("SetWalletProduct", mock.Anything, testUser1UUIDString, testWallet1UUIDString, &productID, now).
+		Return(&model.Wallet{ID: testWallet1UUID, ProductID: &productID}, nil)
+
+	got, err := service.NewInterestImpl(m, model.DayCountActual365, clock.NewFake(now)).
+		SetWalletProduct(context.Background(), testUser1UUIDString, testWallet1UUIDString, &productID)
+	require.NoError(t, err)
+	assert.Equal(t, &productID, got.ProductID)
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// runEvery calls runOnce every interval until stop is closed or ctx is cancelled, logging the passes that fail
+// under name. A pass in progress when stop is closed runs to completion; cancelling ctx cuts it short.
+func runEvery(ctx context.Context, stop <-chan struct{}, interval time.Duration, name string, runOnce func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := runOnce(ctx); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg(name + " pass failed")
+		}
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
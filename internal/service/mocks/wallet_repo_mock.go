@@ -205,9 +205,9 @@ func (_c *WalletRepoMock_GetWalletInfo_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
-// Transfer provides a mock function with given fields: ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount
-func (_m *WalletRepoMock) Transfer(ctx context.Context, sourceUserIDStr string, sourceWalletIDStr string, destinationWalletIDStr string, amount decimal.Decimal) (*model.Transaction, error) {
-	ret := _m.Called(ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount)
+// Transfer provides a mock function with given fields: ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount, fee
+func (_m *WalletRepoMock) Transfer(ctx context.Context, sourceUserIDStr string, sourceWalletIDStr string, destinationWalletIDStr string, amount decimal.Decimal, fee model.Fee) (*model.Transaction, error) {
+	ret := _m.Called(ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount, fee)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Transfer")
@@ -215,19 +215,19 @@ func (_m *WalletRepoMock) Transfer(ctx context.Context, sourceUserIDStr string,
 
 	var r0 *model.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, decimal.Decimal) (*model.Transaction, error)); ok {
-		return rf(ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, decimal.Decimal, model.Fee) (*model.Transaction, error)); ok {
+		return rf(ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount, fee)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, decimal.Decimal) *model.Transaction); ok {
-		r0 = rf(ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, decimal.Decimal, model.Fee) *model.Transaction); ok {
+		r0 = rf(ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount, fee)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, decimal.Decimal) error); ok {
-		r1 = rf(ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, decimal.Decimal, model.Fee) error); ok {
+		r1 = rf(ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount, fee)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -246,13 +246,14 @@ type WalletRepoMock_Transfer_Call struct {
 //   - sourceWalletIDStr string
 //   - destinationWalletIDStr string
 //   - amount decimal.Decimal
-func (_e *WalletRepoMock_Expecter) Transfer(ctx interface{}, sourceUserIDStr interface{}, sourceWalletIDStr interface{}, destinationWalletIDStr interface{}, amount interface{}) *WalletRepoMock_Transfer_Call {
-	return &WalletRepoMock_Transfer_Call{Call: _e.mock.On("Transfer", ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount)}
+//   - fee model.Fee
+func (_e *WalletRepoMock_Expecter) Transfer(ctx interface{}, sourceUserIDStr interface{}, sourceWalletIDStr interface{}, destinationWalletIDStr interface{}, amount interface{}, fee interface{}) *WalletRepoMock_Transfer_Call {
+	return &WalletRepoMock_Transfer_Call{Call: _e.mock.On("Transfer", ctx, sourceUserIDStr, sourceWalletIDStr, destinationWalletIDStr, amount, fee)}
 }
 
-func (_c *WalletRepoMock_Transfer_Call) Run(run func(ctx context.Context, sourceUserIDStr string, sourceWalletIDStr string, destinationWalletIDStr string, amount decimal.Decimal)) *WalletRepoMock_Transfer_Call {
+func (_c *WalletRepoMock_Transfer_Call) Run(run func(ctx context.Context, sourceUserIDStr string, sourceWalletIDStr string, destinationWalletIDStr string, amount decimal.Decimal, fee model.Fee)) *WalletRepoMock_Transfer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(decimal.Decimal))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(decimal.Decimal), args[5].(model.Fee))
 	})
 	return _c
 }
@@ -262,14 +263,14 @@ func (_c *WalletRepoMock_Transfer_Call) Return(_a0 *model.Transaction, _a1 error
 	return _c
 }
 
-func (_c *WalletRepoMock_Transfer_Call) RunAndReturn(run func(context.Context, string, string, string, decimal.Decimal) (*model.Transaction, error)) *WalletRepoMock_Transfer_Call {
+func (_c *WalletRepoMock_Transfer_Call) RunAndReturn(run func(context.Context, string, string, string, decimal.Decimal, model.Fee) (*model.Transaction, error)) *WalletRepoMock_Transfer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Withdraw provides a mock function with given fields: ctx, userIDStr, walletIDStr, amount
-func (_m *WalletRepoMock) Withdraw(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal) (*model.Transaction, error) {
-	ret := _m.Called(ctx, userIDStr, walletIDStr, amount)
+// Withdraw provides a mock function with given fields: ctx, userIDStr, walletIDStr, amount, fee
+func (_m *WalletRepoMock) Withdraw(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal, fee model.Fee) (*model.Transaction, error) {
+	ret := _m.Called(ctx, userIDStr, walletIDStr, amount, fee)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Withdraw")
@@ -277,19 +278,19 @@ func (_m *WalletRepoMock) Withdraw(ctx context.Context, userIDStr string, wallet
 
 	var r0 *model.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, decimal.Decimal) (*model.Transaction, error)); ok {
-		return rf(ctx, userIDStr, walletIDStr, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, decimal.Decimal, model.Fee) (*model.Transaction, error)); ok {
+		return rf(ctx, userIDStr, walletIDStr, amount, fee)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, decimal.Decimal) *model.Transaction); ok {
-		r0 = rf(ctx, userIDStr, walletIDStr, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, decimal.Decimal, model.Fee) *model.Transaction); ok {
+		r0 = rf(ctx, userIDStr, walletIDStr, amount, fee)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, decimal.Decimal) error); ok {
-		r1 = rf(ctx, userIDStr, walletIDStr, amount)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, decimal.Decimal, model.Fee) error); ok {
+		r1 = rf(ctx, userIDStr, walletIDStr, amount, fee)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -307,13 +308,14 @@ type WalletRepoMock_Withdraw_Call struct {
 //   - userIDStr string
 //   - walletIDStr string
 //   - amount decimal.Decimal
-func (_e *WalletRepoMock_Expecter) Withdraw(ctx interface{}, userIDStr interface{}, walletIDStr interface{}, amount interface{}) *WalletRepoMock_Withdraw_Call {
-	return &WalletRepoMock_Withdraw_Call{Call: _e.mock.On("Withdraw", ctx, userIDStr, walletIDStr, amount)}
+//   - fee model.Fee
+func (_e *WalletRepoMock_Expecter) Withdraw(ctx interface{}, userIDStr interface{}, walletIDStr interface{}, amount interface{}, fee interface{}) *WalletRepoMock_Withdraw_Call {
+	return &WalletRepoMock_Withdraw_Call{Call: _e.mock.On("Withdraw", ctx, userIDStr, walletIDStr, amount, fee)}
 }
 
-func (_c *WalletRepoMock_Withdraw_Call) Run(run func(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal)) *WalletRepoMock_Withdraw_Call {
+func (_c *WalletRepoMock_Withdraw_Call) Run(run func(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal, fee model.Fee)) *WalletRepoMock_Withdraw_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(decimal.Decimal))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(decimal.Decimal), args[4].(model.Fee))
 	})
 	return _c
 }
@@ -323,7 +325,7 @@ func (_c *WalletRepoMock_Withdraw_Call) Return(_a0 *model.Transaction, _a1 error
 	return _c
 }
 
-func (_c *WalletRepoMock_Withdraw_Call) RunAndReturn(run func(context.Context, string, string, decimal.Decimal) (*model.Transaction, error)) *WalletRepoMock_Withdraw_Call {
+func (_c *WalletRepoMock_Withdraw_Call) RunAndReturn(run func(context.Context, string, string, decimal.Decimal, model.Fee) (*model.Transaction, error)) *WalletRepoMock_Withdraw_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+const (
+	// DefaultPaymentRequestTTL is how long a payment request stays payable when no expiry is given.
+	DefaultPaymentRequestTTL = 7 * 24 * time.Hour
+	// MaxPaymentRequestTTL is the latest expiry a payment request may be given.
+	MaxPaymentRequestTTL = 30 * 24 * time.Hour
+	// maxPaymentRequestMemo is the longest memo accepted, in characters.
+	maxPaymentRequestMemo = 140
+)
+
+// ErrInvalidPaymentRequest indicates that a payment request was rejected during validation.
+var ErrInvalidPaymentRequest = errors.New("invalid payment request")
+
+type PaymentRequestRepo interface {
+	CreatePaymentRequest(ctx context.Context, req *model.PaymentRequest) error
+	ListIncomingPaymentRequests(ctx context.Context, userIDStr string, status model.PaymentRequestStatus, now time.Time) ([]model.PaymentRequest, error)
+	ListOutgoingPaymentRequests(ctx context.Context, userIDStr string, status model.PaymentRequestStatus, now time.Time) ([]model.PaymentRequest, error)
+	GetPaymentRequest(ctx context.Context, userIDStr string, requestIDStr string, now time.Time) (*model.PaymentRequest, error)
+	AcceptPaymentRequest(ctx context.Context, payerUserIDStr string, requestIDStr string, payerWalletIDStr string, fee model.Fee, now time.Time) (*model.PaymentRequest, error)
+	DeclinePaymentRequest(ctx context.Context, payerUserIDStr string, requestIDStr string, now time.Time) (*model.PaymentRequest, error)
+	CancelPaymentRequest(ctx context.Context, requesterUserIDStr string, requestIDStr string, now time.Time) (*model.PaymentRequest, error)
+}
+
+type PaymentRequestServiceImpl struct {
+	pRepo PaymentRequestRepo
+	fees  FeeCalculator
+	clock clock.Clock
+}
+
+// NewPaymentRequestImpl creates the payment request service. With a nil FeeCalculator no fees are charged.
+func NewPaymentRequestImpl(pr PaymentRequestRepo, fc FeeCalculator, clk clock.Clock) *PaymentRequestServiceImpl {
+	return &PaymentRequestServiceImpl{pRepo: pr, fees: fc, clock: clk}
+}
+
+// CreatePaymentRequest asks payerUserId for money to be paid into the requester's wallet.
+func (ps *PaymentRequestServiceImpl) CreatePaymentRequest(ctx context.Context, userId, walletId string, req model.PaymentRequestRequest) (*model.PaymentRequest, error) {
+	requesterUserID, err := uuid.Parse(userId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	requesterWalletID, err := uuid.Parse(walletId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	payerUserID, err := uuid.Parse(strings.TrimSpace(req.PayerUserID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid payer user ID", ErrInvalidPaymentRequest)
+	}
+	if payerUserID == requesterUserID {
+		return nil, fmt.Errorf("%w: cannot request money from yourself", ErrInvalidPaymentRequest)
+	}
+	if req.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidPaymentRequest)
+	}
+	if !req.Amount.Equal(req.Amount.Truncate(4)) {
+		return nil, fmt.Errorf("%w: amount has more than 4 decimal places", ErrInvalidPaymentRequest)
+	}
+	memo := strings.TrimSpace(req.Memo)
+	if len([]rune(memo)) > maxPaymentRequestMemo {
+		return nil, fmt.Errorf("%w: memo is longer than %d characters", ErrInvalidPaymentRequest, maxPaymentRequestMemo)
+	}
+
+	now := ps.clock.Now().UTC()
+	expiresAt := now.Add(DefaultPaymentRequestTTL)
+	if req.ExpiresAt != nil {
+		expiresAt = req.ExpiresAt.UTC()
+		if !expiresAt.After(now) {
+			return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidPaymentRequest)
+		}
+		if expiresAt.After(now.Add(MaxPaymentRequestTTL)) {
+			return nil, fmt.Errorf("%w: expires_at must be within %d days", ErrInvalidPaymentRequest, int(MaxPaymentRequestTTL.Hours()/24))
+		}
+	}
+
+	pr := &model.PaymentRequest{
+		ID:                uuid.New(),
+		RequesterUserID:   requesterUserID,
+		RequesterWalletID: requesterWalletID,
+		PayerUserID:       payerUserID,
+		Amount:            req.Amount,
+		Memo:              memo,
+		Status:            model.PaymentRequestStatusPending,
+		ExpiresAt:         expiresAt,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err = ps.pRepo.CreatePaymentRequest(ctx, pr); err != nil {
+		return nil, fmt.Errorf("service.CreatePaymentRequest: %w", err)
+	}
+	return pr, nil
+}
+
+// ListIncomingPaymentRequests returns the requests the user has been asked to pay.
+func (ps *PaymentRequestServiceImpl) ListIncomingPaymentRequests(ctx context.Context, userId string, status model.PaymentRequestStatus) ([]model.PaymentRequest, error) {
+	requests, err := ps.pRepo.ListIncomingPaymentRequests(ctx, userId, status, ps.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("service.ListIncomingPaymentRequests: %w", err)
+	}
+	return requests, nil
+}
+
+// ListOutgoingPaymentRequests returns the requests the user has made.
+func (ps *PaymentRequestServiceImpl) ListOutgoingPaymentRequests(ctx context.Context, userId string, status model.PaymentRequestStatus) ([]model.PaymentRequest, error) {
+	requests, err := ps.pRepo.ListOutgoingPaymentRequests(ctx, userId, status, ps.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("service.ListOutgoingPaymentRequests: %w", err)
+	}
+	return requests, nil
+}
+
+// GetPaymentRequest returns a request the user is a party to.
+func (ps *PaymentRequestServiceImpl) GetPaymentRequest(ctx context.Context, userId, requestId string) (*model.PaymentRequest, error) {
+	pr, err := ps.pRepo.GetPaymentRequest(ctx, userId, requestId, ps.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("service.GetPaymentRequest: %w", err)
+	}
+	return pr, nil
+}
+
+// AcceptPaymentRequest pays a pending request from one of the payer's wallets. The payer is charged the transfer fee.
+func (ps *PaymentRequestServiceImpl) AcceptPaymentRequest(ctx context.Context, userId, requestId, walletId string) (*model.PaymentRequest, error) {
+	req, err := ps.pRepo.GetPaymentRequest(ctx, userId, requestId, ps.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("service.AcceptPaymentRequest: %w", err)
+	}
+	fee, err := feeOrZero(ctx, ps.fees, model.FeeOperationTransfer, req.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("service.AcceptPaymentRequest: %w", err)
+	}
+	pr, err := ps.pRepo.AcceptPaymentRequest(ctx, userId, requestId, walletId, fee, ps.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("service.AcceptPaymentRequest: %w", err)
+	}
+	return pr, nil
+}
+
+// DeclinePaymentRequest refuses a pending request.
+func (ps *PaymentRequestServiceImpl) DeclinePaymentRequest(ctx context.Context, userId, requestId string) (*model.PaymentRequest, error) {
+	pr, err := ps.pRepo.DeclinePaymentRequest(ctx, userId, requestId, ps.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("service.DeclinePaymentRequest: %w", err)
+	}
+	return pr, nil
+}
+
+// CancelPaymentRequest withdraws a pending request the user made.
+func (ps *PaymentRequestServiceImpl) CancelPaymentRequest(ctx context.Context, userId, requestId string) (*model.PaymentRequest, error) {
+	pr, err := ps.pRepo.CancelPaymentRequest(ctx, userId, requestId, ps.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("service.CancelPaymentRequest: %w", err)
+	}
+	return pr, nil
+}
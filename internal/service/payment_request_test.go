@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+var testUser2UUID = uuid.MustParse("0b6f2b71-4f0e-4a67-9d4c-2f1f5e9c8a10")
+
+func TestPaymentRequestServiceImpl_CreatePaymentRequest(t *testing.T) {
+	now := mustTime("2025-01-15T09:30:00Z")
+
+	tests := []struct {
+		name          string
+		req           model.PaymentRequestRequest
+		repoErr       error
+		wantExpiresAt time.Time
+		wantErr       error
+		wantRepoCall  bool
+	}{
+		{
+			name:          "success - default expiry",
+			req:           model.PaymentRequestRequest{Amount: decimal.NewFromInt(25), PayerUserID: testUser2UUID.String(), Memo: "  pizza  "},
+			wantExpiresAt: now.Add(service.DefaultPaymentRequestTTL),
+			wantRepoCall:  true,
+		},
+		{
+			name:          "success - explicit expiry",
+			req:           model.PaymentRequestRequest{Amount: decimal.NewFromInt(25), PayerUserID: testUser2UUID.String(), ExpiresAt: ptr(now.Add(time.Hour))},
+			wantExpiresAt: now.Add(time.Hour),
+			wantRepoCall:  true,
+		},
+		{
+			name:    "error - requesting from yourself",
+			req:     model.PaymentRequestRequest{Amount: decimal.NewFromInt(25), PayerUserID: testUser1UUIDString},
+			wantErr: service.ErrInvalidPaymentRequest,
+		},
+		{
+			name:    "error - amount not positive",
+			req:     model.PaymentRequestRequest{Amount: decimal.NewFromInt(-1), PayerUserID: testUser2UUID.String()},
+			wantErr: service.ErrInvalidPaymentRequest,
+		},
+		{
+			name:    "error - expiry in the past",
+			req:     model.PaymentRequestRequest{Amount: decimal.NewFromInt(25), PayerUserID: testUser2UUID.String(), ExpiresAt: ptr(now.Add(-time.Minute))},
+			wantErr: service.ErrInvalidPaymentRequest,
+		},
+		{
+			name:    "error - expiry too far away",
+			req:     model.PaymentRequestRequest{Amount: decimal.NewFromInt(25), PayerUserID: testUser2UUID.String(), ExpiresAt: ptr(now.Add(service.MaxPaymentRequestTTL + time.Second))},
+			wantErr: service.ErrInvalidPaymentRequest,
+		},
+		{
+			name:         "error - payer not found",
+			req:          model.PaymentRequestRequest{Amount: decimal.NewFromInt(25), PayerUserID: testUser2UUID.String()},
+			repoErr:      repo.ErrPayerNotFound,
+			wantErr:      repo.ErrPayerNotFound,
+			wantRepoCall: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.PaymentRequestRepoMock)
+			if tt.wantRepoCall {
+				m.On("CreatePaymentRequest", mock.Anything, mock.Anything).Return(tt.repoErr)
+			}
+			ps := service.NewPaymentRequestImpl(m, nil, clock.NewFake(now))
+
+			got, err := ps.CreatePaymentRequest(context.Background(), testUser1UUIDString, testWallet1UUIDString, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, model.PaymentRequestStatusPending, got.Status)
+				assert.Equal(t, tt.wantExpiresAt, got.ExpiresAt)
+				assert.Equal(t, testUser2UUID, got.PayerUserID)
+				assert.Equal(t, testWallet1UUID, got.RequesterWalletID)
+				assert.Equal(t, strings.TrimSpace(tt.req.Memo), got.Memo)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPaymentRequestServiceImpl_AcceptPaymentRequest(t *testing.T) {
+	now := mustTime("2025-01-15T09:30:00Z")
+	requestID := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		repoRes *model.PaymentRequest
+		repoErr error
+		wantErr error
+	}{
+		{
+			name:    "success",
+			repoRes: &model.PaymentRequest{Status: model.PaymentRequestStatusAccepted},
+		},
+		{
+			name:    "error - already accepted",
+			repoErr: repo.ErrPaymentRequestNotPending,
+			wantErr: repo.ErrPaymentRequestNotPending,
+		},
+		{
+			name:    "error - expired",
+			repoErr: repo.ErrPaymentRequestExpired,
+			wantErr: repo.ErrPaymentRequestExpired,
+		},
+		{
+			name:    "error - insufficient funds",
+			repoErr: repo.ErrInsufficientFunds,
+			wantErr: repo.ErrInsufficientFunds,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.PaymentRequestRepoMock)
+			m.On("GetPaymentRequest", mock.Anything, testUser2UUID.String(), requestID, now).
+				Return(&model.PaymentRequest{Amount: dec("40"), Status: model.PaymentRequestStatusPending}, nil)
+			m.On("AcceptPaymentRequest", mock.Anything, testUser2UUID.String(), requestID, testWallet2UUID.String(), model.Fee{Amount: decimal.Zero}, now).
+				Return(tt.repoRes, tt.repoErr)
+			ps := service.NewPaymentRequestImpl(m, nil, clock.NewFake(now))
+
+			got, err := ps.AcceptPaymentRequest(context.Background(), testUser2UUID.String(), requestID, testWallet2UUID.String())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.repoRes, got)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+// maxPotName is the longest pot name accepted, in characters.
+const maxPotName = 100
+
+// ErrInvalidPot indicates that a pot or a move of money into or out of it was rejected during validation.
+var ErrInvalidPot = errors.New("invalid pot")
+
+type PotRepo interface {
+	CreatePot(ctx context.Context, userID string, pot *model.Pot) error
+	ListPots(ctx context.Context, userID string, walletID string) ([]model.Pot, error)
+	GetPot(ctx context.Context, userID string, walletID string, potID string) (*model.Pot, error)
+	UpdatePot(ctx context.Context, userID string, walletID string, potID string, name string, targetAmount *decimal.Decimal, targetDate *time.Time, at time.Time) (*model.Pot, error)
+	MovePotMoney(ctx context.Context, userID string, walletID string, potID string, direction model.PotMoveDirection, amount decimal.Decimal, at time.Time) (*model.Pot, error)
+	ClosePot(ctx context.Context, userID string, walletID string, potID string, at time.Time) (*model.Pot, error)
+}
+
+type PotServiceImpl struct {
+	pRepo PotRepo
+	clock clock.Clock
+}
+
+func NewPotImpl(pr PotRepo, clk clock.Clock) *PotServiceImpl {
+	return &PotServiceImpl{pRepo: pr, clock: clk}
+}
+
+// CreatePot adds an empty pot to the wallet.
+func (ps *PotServiceImpl) CreatePot(ctx context.Context, userId, walletId string, req model.PotRequest) (*model.Pot, error) {
+	walletID, err := uuid.Parse(walletId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	now := ps.clock.Now().UTC()
+	name, targetAmount, targetDate, err := validatePotRequest(req, now)
+	if err != nil {
+		return nil, err
+	}
+
+	pot := &model.Pot{
+		ID:           uuid.New(),
+		WalletID:     walletID,
+		Name:         name,
+		TargetAmount: targetAmount,
+		TargetDate:   targetDate,
+		CreatedAt:    now,
+	}
+	if err = ps.pRepo.CreatePot(ctx, userId, pot); err != nil {
+		return nil, fmt.Errorf("service.CreatePot: %w", err)
+	}
+	pot.Progress = potProgress(pot, now)
+	return pot, nil
+}
+
+func (ps *PotServiceImpl) ListPots(ctx context.Context, userId, walletId string) ([]model.Pot, error) {
+	pots, err := ps.pRepo.ListPots(ctx, userId, walletId)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListPots: %w", err)
+	}
+	now := ps.clock.Now().UTC()
+	for i := range pots {
+		pots[i].Progress = potProgress(&pots[i], now)
+	}
+	return pots, nil
+}
+
+func (ps *PotServiceImpl) GetPot(ctx context.Context, userId, walletId, potId string) (*model.Pot, error) {
+	pot, err := ps.pRepo.GetPot(ctx, userId, walletId, potId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetPot: %w", err)
+	}
+	pot.Progress = potProgress(pot, ps.clock.Now().UTC())
+	return pot, nil
+}
+
+// UpdatePot replaces the name and target of an open pot; omitting the target removes it.
+func (ps *PotServiceImpl) UpdatePot(ctx context.Context, userId, walletId, potId string, req model.PotRequest) (*model.Pot, error) {
+	now := ps.clock.Now().UTC()
+	name, targetAmount, targetDate, err := validatePotRequest(req, now)
+	if err != nil {
+		return nil, err
+	}
+	pot, err := ps.pRepo.UpdatePot(ctx, userId, walletId, potId, name, targetAmount, targetDate, now)
+	if err != nil {
+		return nil, fmt.Errorf("service.UpdatePot: %w", err)
+	}
+	pot.Progress = potProgress(pot, now)
+	return pot, nil
+}
+
+// MoveIntoPot sets amount of the wallet's spendable money aside in the pot.
+func (ps *PotServiceImpl) MoveIntoPot(ctx context.Context, userId, walletId, potId string, amount decimal.Decimal) (*model.Pot, error) {
+	return ps.move(ctx, userId, walletId, potId, model.PotMoveIn, amount)
+}
+
+// MoveOutOfPot makes amount held in the pot spendable again.
+func (ps *PotServiceImpl) MoveOutOfPot(ctx context.Context, userId, walletId, potId string, amount decimal.Decimal) (*model.Pot, error) {
+	return ps.move(ctx, userId, walletId, potId, model.PotMoveOut, amount)
+}
+
+func (ps *PotServiceImpl) move(ctx context.Context, userId, walletId, potId string, direction model.PotMoveDirection, amount decimal.Decimal) (*model.Pot, error) {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidPot)
+	}
+	if !amount.Equal(amount.Truncate(4)) {
+		return nil, fmt.Errorf("%w: amount has more than 4 decimal places", ErrInvalidPot)
+	}
+	now := ps.clock.Now().UTC()
+	pot, err := ps.pRepo.MovePotMoney(ctx, userId, walletId, potId, direction, amount, now)
+	if err != nil {
+		return nil, fmt.Errorf("service.MovePotMoney: %w", err)
+	}
+	pot.Progress = potProgress(pot, now)
+	return pot, nil
+}
+
+// ClosePot returns whatever is left in the pot to the wallet and closes it.
+func (ps *PotServiceImpl) ClosePot(ctx context.Context, userId, walletId, potId string) (*model.Pot, error) {
+	pot, err := ps.pRepo.ClosePot(ctx, userId, walletId, potId, ps.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("service.ClosePot: %w", err)
+	}
+	return pot, nil
+}
+
+func validatePotRequest(req model.PotRequest, now time.Time) (string, *decimal.Decimal, *time.Time, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return "", nil, nil, fmt.Errorf("%w: name is required", ErrInvalidPot)
+	}
+	if len([]rune(name)) > maxPotName {
+		return "", nil, nil, fmt.Errorf("%w: name is longer than %d characters", ErrInvalidPot, maxPotName)
+	}
+
+	if req.TargetAmount != nil {
+		if req.TargetAmount.LessThanOrEqual(decimal.Zero) {
+			return "", nil, nil, fmt.Errorf("%w: target_amount must be positive", ErrInvalidPot)
+		}
+		if !req.TargetAmount.Equal(req.TargetAmount.Truncate(4)) {
+			return "", nil, nil, fmt.Errorf("%w: target_amount has more than 4 decimal places", ErrInvalidPot)
+		}
+	}
+
+	var targetDate *time.Time
+	if req.TargetDate != nil {
+		if req.TargetAmount == nil {
+			return "", nil, nil, fmt.Errorf("%w: target_date needs a target_amount", ErrInvalidPot)
+		}
+		d, err := time.Parse(time.DateOnly, strings.TrimSpace(*req.TargetDate))
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("%w: target_date must be a date like 2006-01-02", ErrInvalidPot)
+		}
+		if d.Before(startOfDay(now)) {
+			return "", nil, nil, fmt.Errorf("%w: target_date must not be in the past", ErrInvalidPot)
+		}
+		targetDate = &d
+	}
+	return name, req.TargetAmount, targetDate, nil
+}
+
+// daysPerMonth is the month length used to spread what is missing from a pot over the time left.
+var daysPerMonth = decimal.NewFromInt(30)
+
+// potProgress reports how far the pot is towards its target at now, or nil when it has no target.
+func potProgress(p *model.Pot, now time.Time) *model.PotProgress {
+	if p.TargetAmount == nil || !p.TargetAmount.IsPositive() {
+		return nil
+	}
+	target := *p.TargetAmount
+
+	progress := &model.PotProgress{
+		Percent:   decimal.Min(p.Balance.Div(target).Mul(decimal.NewFromInt(100)), decimal.NewFromInt(100)).Round(2),
+		Remaining: decimal.Max(target.Sub(p.Balance), decimal.Zero),
+	}
+	progress.Reached = progress.Remaining.IsZero()
+
+	if p.TargetDate != nil {
+		daysLeft := int(startOfDay(*p.TargetDate).Sub(startOfDay(now)).Hours() / 24)
+		progress.DaysLeft = &daysLeft
+		if !progress.Reached && daysLeft > 0 {
+			months := decimal.NewFromInt(int64(daysLeft)).Div(daysPerMonth).Ceil()
+			monthly := progress.Remaining.Div(months).RoundCeil(2)
+			progress.MonthlyNeeded = &monthly
+		}
+	}
+	return progress
+}
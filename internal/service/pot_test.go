@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+func TestPotServiceImpl_CreatePot(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	tests := []struct {
+		name    string
+		req     model.PotRequest
+		repoErr error
+		wantErr error
+	}{
+		{name: "success - no target", req: model.PotRequest{Name: " Holiday "}},
+		{name: "success - target amount and date", req: model.PotRequest{Name: "Rent", TargetAmount: ptr(dec("1200")), TargetDate: ptr("2025-06-15")}},
+		{name: "error - name taken", req: model.PotRequest{Name: "Rent"}, repoErr: repo.ErrPotNameTaken, wantErr: repo.ErrPotNameTaken},
+		{name: "error - blank name", req: model.PotRequest{Name: "  "}, wantErr: service.ErrInvalidPot},
+		{name: "error - target not positive", req: model.PotRequest{Name: "Rent", TargetAmount: ptr(dec("0"))}, wantErr: service.ErrInvalidPot},
+		{name: "error - target date without amount", req: model.PotRequest{Name: "Rent", TargetDate: ptr("2025-12-01")}, wantErr: service.ErrInvalidPot},
+		{name: "error - target date in the past", req: model.PotRequest{Name: "Rent", TargetAmount: ptr(dec("10")), TargetDate: ptr("2025-06-14")}, wantErr: service.ErrInvalidPot},
+		{name: "error - malformed target date", req: model.PotRequest{Name: "Rent", TargetAmount: ptr(dec("10")), TargetDate: ptr("15/06/2025")}, wantErr: service.ErrInvalidPot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.PotRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				m.On("CreatePot", mock.Anything, testUser1UUIDString, mock.MatchedBy(func(p *model.Pot) bool {
+					return p.WalletID == testWallet1UUID && p.Name == strings.TrimSpace(tt.req.Name) && p.CreatedAt.Equal(now)
+				})).Return(tt.repoErr)
+			}
+			ps := service.NewPotImpl(m, clock.NewFake(now))
+
+			got, err := ps.CreatePot(context.Background(), testUser1UUIDString, testWallet1UUIDString, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.req.TargetAmount != nil, got.Progress != nil)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPotServiceImpl_GetPot_Progress(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	tests := []struct {
+		name          string
+		balance       string
+		target        *string
+		targetDate    string
+		wantNil       bool
+		wantPercent   string
+		wantRemaining string
+		wantReached   bool
+		wantDaysLeft  *int
+		wantMonthly   *string
+	}{
+		{name: "no target", balance: "50", wantNil: true},
+		{name: "part way, no date", balance: "250", target: ptr("1000"), wantPercent: "25", wantRemaining: "750"},
+		{name: "over target", balance: "1100", target: ptr("1000"), targetDate: "2025-07-15", wantPercent: "100", wantRemaining: "0", wantReached: true, wantDaysLeft: ptr(30)},
+		{name: "monthly needed rounds up to cents", balance: "0", target: ptr("100"), targetDate: "2025-09-13", wantPercent: "0", wantRemaining: "100", wantDaysLeft: ptr(90), wantMonthly: ptr("33.34")},
+		{name: "less than a month left", balance: "10", target: ptr("30"), targetDate: "2025-06-20", wantPercent: "33.33", wantRemaining: "20", wantDaysLeft: ptr(5), wantMonthly: ptr("20")},
+		{name: "date passed", balance: "10", target: ptr("30"), targetDate: "2025-06-10", wantPercent: "33.33", wantRemaining: "20", wantDaysLeft: ptr(-5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pot := &model.Pot{ID: uuid.New(), WalletID: testWallet1UUID, Name: "Holiday", Balance: dec(tt.balance), Status: model.PotStatusOpen}
+			if tt.target != nil {
+				pot.TargetAmount = ptr(dec(*tt.target))
+			}
+			if tt.targetDate != "" {
+				pot.TargetDate = ptr(mustTime(tt.targetDate + "T00:00:00Z"))
+			}
+			m := new(walletmocks.PotRepoMock)
+			m.On("GetPot", mock.Anything, testUser1UUIDString, testWallet1UUIDString, pot.ID.String()).Return(pot, nil)
+
+			got, err := service.NewPotImpl(m, clock.NewFake(now)).GetPot(context.Background(), testUser1UUIDString, testWallet1UUIDString, pot.ID.String())
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, got.Progress)
+				return
+			}
+			require.NotNil(t, got.Progress)
+			assert.Equal(t, tt.wantPercent, got.Progress.Percent.String())
+			assert.Equal(t, tt.wantRemaining, got.Progress.Remaining.String())
+			assert.Equal(t, tt.wantReached, got.Progress.Reached)
+			assert.Equal(t, tt.wantDaysLeft, got.Progress.DaysLeft)
+			if tt.wantMonthly == nil {
+				assert.Nil(t, got.Progress.MonthlyNeeded)
+			} else {
+				require.NotNil(t, got.Progress.MonthlyNeeded)
+				assert.Equal(t, *tt.wantMonthly, got.Progress.MonthlyNeeded.String())
+			}
+		})
+	}
+}
+
+func TestPotServiceImpl_MoveIntoPot(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	potID := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		amount  string
+		repoErr error
+		wantErr error
+	}{
+		{name: "success", amount: "25.50"},
+		{name: "error - more than the spendable balance", amount: "1000", repoErr: repo.ErrInsufficientFunds, wantErr: repo.ErrInsufficientFunds},
+		{name: "error - pot closed", amount: "5", repoErr: repo.ErrPotClosed, wantErr: repo.ErrPotClosed},
+		{name: "error - amount not positive", amount: "-5", wantErr: service.ErrInvalidPot},
+		{name: "error - too many decimal places", amount: "0.00001", wantErr: service.ErrInvalidPot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.PotRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				var pot *model.Pot
+				if tt.repoErr == nil {
+					pot = &model.Pot{Balance: dec(tt.amount)}
+				}
+				m.On("MovePotMoney", mock.Anything, testUser1UUIDString, testWallet1UUIDString, potID, model.PotMoveIn, dec(tt.amount), now).
+					Return(pot, tt.repoErr)
+			}
+
+			got, err := service.NewPotImpl(m, clock.NewFake(now)).MoveIntoPot(context.Background(), testUser1UUIDString, testWallet1UUIDString, potID, dec(tt.amount))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, got.Balance.Equal(dec(tt.amount)))
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWallet_Available(t *testing.T) {
+	w := model.Wallet{Balance: dec("150"), PotsBalance: dec("100.25")}
+	assert.Equal(t, "49.75", w.Available().String())
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+	"github.com/kylenguyen/wallet-app/pkg/schedule"
+)
+
+// ErrInvalidScheduledTransfer indicates that a schedule request was rejected.
+var ErrInvalidScheduledTransfer = errors.New("invalid scheduled transfer")
+
+type ScheduledTransferRepo interface {
+	CreateScheduledTransfer(ctx context.Context, s *model.ScheduledTransfer) error
+	ListScheduledTransfers(ctx context.Context, userIDStr string, walletIDStr string) ([]model.ScheduledTransfer, error)
+	GetScheduledTransfer(ctx context.Context, userIDStr string, walletIDStr string, scheduleIDStr string) (*model.ScheduledTransfer, error)
+	GetScheduledTransferByID(ctx context.Context, scheduleID uuid.UUID) (*model.ScheduledTransfer, error)
+	CancelScheduledTransfer(ctx context.Context, userIDStr string, walletIDStr string, scheduleIDStr string) error
+	ListDueScheduledTransfers(ctx context.Context, now time.Time, limit int) ([]model.ScheduledTransfer, error)
+	ClaimScheduledTransferRun(ctx context.Context, s *model.ScheduledTransfer, nextRunAt *time.Time, now time.Time) (*model.ScheduledTransferRun, error)
+	ListRetryableScheduledTransferRuns(ctx context.Context, now time.Time, limit int) ([]model.ScheduledTransferRun, error)
+	ClaimScheduledTransferRetry(ctx context.Context, run *model.ScheduledTransferRun, now time.Time) (bool, error)
+	FinishScheduledTransferRun(ctx context.Context, run *model.ScheduledTransferRun) error
+	ReleaseExpiredScheduledTransferRuns(ctx context.Context, claimedBefore time.Time, now time.Time) ([]model.ScheduledTransferRun, error)
+}
+
+// Transferer moves money between wallets. *WalletServiceImpl satisfies it.
+type Transferer interface {
+	Transfer(ctx context.Context, sourceUserId, sourceWalletId, destinationWalletId string, amount decimal.Decimal) (*model.Transaction, error)
+}
+
+type ScheduledTransferServiceImpl struct {
+	sRepo ScheduledTransferRepo
+	clock clock.Clock
+}
+
+func NewScheduledTransferImpl(sr ScheduledTransferRepo, clk clock.Clock) *ScheduledTransferServiceImpl {
+	return &ScheduledTransferServiceImpl{sRepo: sr, clock: clk}
+}
+
+// CreateScheduledTransfer validates the schedule expression, computes its first occurrence and stores it.
+func (ss *ScheduledTransferServiceImpl) CreateScheduledTransfer(ctx context.Context, userId, walletId string, req model.ScheduledTransferRequest) (*model.ScheduledTransfer, error) {
+	userID, err := uuid.Parse(userId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	sourceWalletID, err := uuid.Parse(walletId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	destinationWalletID, err := uuid.Parse(strings.TrimSpace(req.DestinationWalletID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid destination wallet ID", ErrInvalidScheduledTransfer)
+	}
+	if destinationWalletID == sourceWalletID {
+		return nil, fmt.Errorf("%w: source and destination wallets cannot be the same", ErrInvalidScheduledTransfer)
+	}
+	if req.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidScheduledTransfer)
+	}
+	if !req.Amount.Equal(req.Amount.Truncate(4)) {
+		return nil, fmt.Errorf("%w: amount has more than 4 decimal places", ErrInvalidScheduledTransfer)
+	}
+
+	now := ss.clock.Now().UTC()
+	s := &model.ScheduledTransfer{
+		ID:                  uuid.New(),
+		UserID:              userID,
+		SourceWalletID:      sourceWalletID,
+		DestinationWalletID: destinationWalletID,
+		Amount:              req.Amount,
+		Expression:          strings.TrimSpace(req.Schedule),
+		StartAt:             now,
+		EndAt:               req.EndAt,
+		Status:              model.ScheduledTransferStatusActive,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+	if req.StartAt != nil {
+		s.StartAt = req.StartAt.UTC()
+	}
+	if s.EndAt != nil && !s.EndAt.After(s.StartAt) {
+		return nil, fmt.Errorf("%w: end_at must be after start_at", ErrInvalidScheduledTransfer)
+	}
+
+	s.NextRunAt, err = nextOccurrence(s, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidScheduledTransfer, err)
+	}
+	if s.NextRunAt == nil {
+		return nil, fmt.Errorf("%w: schedule has no future occurrences", ErrInvalidScheduledTransfer)
+	}
+
+	if err = ss.sRepo.CreateScheduledTransfer(ctx, s); err != nil {
+		return nil, fmt.Errorf("service.CreateScheduledTransfer: %w", err)
+	}
+	return s, nil
+}
+
+// ListScheduledTransfers returns the schedules of a wallet.
+func (ss *ScheduledTransferServiceImpl) ListScheduledTransfers(ctx context.Context, userId, walletId string) ([]model.ScheduledTransfer, error) {
+	schedules, err := ss.sRepo.ListScheduledTransfers(ctx, userId, walletId)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListScheduledTransfers: %w", err)
+	}
+	return schedules, nil
+}
+
+// GetScheduledTransfer returns a schedule with the outcome of all of its runs.
+func (ss *ScheduledTransferServiceImpl) GetScheduledTransfer(ctx context.Context, userId, walletId, scheduleId string) (*model.ScheduledTransfer, error) {
+	s, err := ss.sRepo.GetScheduledTransfer(ctx, userId, walletId, scheduleId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetScheduledTransfer: %w", err)
+	}
+	return s, nil
+}
+
+// CancelScheduledTransfer stops a schedule from running again.
+func (ss *ScheduledTransferServiceImpl) CancelScheduledTransfer(ctx context.Context, userId, walletId, scheduleId string) error {
+	if err := ss.sRepo.CancelScheduledTransfer(ctx, userId, walletId, scheduleId); err != nil {
+		return fmt.Errorf("service.CancelScheduledTransfer: %w", err)
+	}
+	return nil
+}
+
+// nextOccurrence returns the first occurrence of the schedule strictly after t, or nil when the schedule
+// is exhausted or the occurrence falls after EndAt.
+func nextOccurrence(s *model.ScheduledTransfer, t time.Time) (*time.Time, error) {
+	sched, err := schedule.Parse(s.Expression, s.StartAt)
+	if err != nil {
+		return nil, err
+	}
+	next := sched.Next(t)
+	if next.IsZero() || (s.EndAt != nil && next.After(*s.EndAt)) {
+		return nil, nil
+	}
+	return &next, nil
+}
+
+// RetryPolicy controls how a run that failed for lack of funds is retried.
+// Attempt n+1 is made Backoff * 2^(n-1) after attempt n; after MaxAttempts the run fails.
+// A run still running Lease after it was claimed is taken to be abandoned by its worker and retried.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	Lease       time.Duration
+}
+
+// delay returns how long to wait after the given attempt before trying again.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && d < 24*time.Hour; i++ {
+		d *= 2
+	}
+	return d
+}
+
+// scheduledTransferBatchSize is how many due schedules or retries one pass of the worker picks up.
+const scheduledTransferBatchSize = 100
+
+// ScheduledTransferWorker executes due schedules.
+//
+// Every occurrence is claimed in the database before any money moves, and a claim can only succeed once,
+// so an occurrence is never transferred twice even with several workers running. If a worker dies between
+// the claim and recording the outcome, the run stays "running" until its lease (RetryPolicy.Lease) expires:
+// it is then marked succeeded if its transfer went through, and retried otherwise. The transfer is recorded
+// against its run and a run has at most one transfer, so a worker that was only slow cannot pay it again,
+// nor overwrite the outcome once its lease is gone.
+type ScheduledTransferWorker struct {
+	sRepo      ScheduledTransferRepo
+	transferer Transferer
+	clock      clock.Clock
+	policy     RetryPolicy
+}
+
+func NewScheduledTransferWorker(sr ScheduledTransferRepo, t Transferer, clk clock.Clock, policy RetryPolicy) *ScheduledTransferWorker {
+	return &ScheduledTransferWorker{sRepo: sr, transferer: t, clock: clk, policy: policy}
+}
+
+// Run calls RunOnce every interval until stop is closed or ctx is cancelled, as runEvery does.
+func (w *ScheduledTransferWorker) Run(ctx context.Context, stop <-chan struct{}, interval time.Duration) {
+	runEvery(ctx, stop, interval, "Scheduled transfer worker", w.RunOnce)
+}
+
+// RunOnce settles the runs whose lease expired, retries the runs whose backoff has elapsed, then starts a run
+// for every schedule that is due.
+// A schedule that missed several occurrences (e.g. while the worker was down) runs once to catch up
+// and then continues with its next future occurrence.
+func (w *ScheduledTransferWorker) RunOnce(ctx context.Context) error {
+	now := w.clock.Now().UTC()
+
+	released, err := w.sRepo.ReleaseExpiredScheduledTransferRuns(ctx, now.Add(-w.policy.Lease), now)
+	if err != nil {
+		return fmt.Errorf("service.RunOnce: %w", err)
+	}
+	for _, run := range released {
+		zerolog.Ctx(ctx).Warn().
+			Str("schedule-id", run.ScheduleID.String()).
+			Time("scheduled-for", run.ScheduledFor).
+			Str("status", string(run.Status)).
+			Msg("Scheduled transfer run lease expired")
+	}
+
+	retries, err := w.sRepo.ListRetryableScheduledTransferRuns(ctx, now, scheduledTransferBatchSize)
+	if err != nil {
+		return fmt.Errorf("service.RunOnce: %w", err)
+	}
+	for i := range retries {
+		run := &retries[i]
+		claimed, err := w.sRepo.ClaimScheduledTransferRetry(ctx, run, now)
+		if err != nil {
+			return fmt.Errorf("service.RunOnce: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+		s, err := w.sRepo.GetScheduledTransferByID(ctx, run.ScheduleID)
+		if err != nil {
+			return fmt.Errorf("service.RunOnce: %w", err)
+		}
+		if s.Status == model.ScheduledTransferStatusCancelled {
+			run.Status = model.ScheduledTransferRunStatusFailed
+			msg := "schedule was cancelled"
+			run.Error = &msg
+			if err = w.sRepo.FinishScheduledTransferRun(ctx, run); err != nil {
+				return fmt.Errorf("service.RunOnce: %w", err)
+			}
+			continue
+		}
+		if err = w.execute(ctx, s, run, now); err != nil {
+			return fmt.Errorf("service.RunOnce: %w", err)
+		}
+	}
+
+	due, err := w.sRepo.ListDueScheduledTransfers(ctx, now, scheduledTransferBatchSize)
+	if err != nil {
+		return fmt.Errorf("service.RunOnce: %w", err)
+	}
+	for i := range due {
+		s := &due[i]
+		after := now
+		if s.NextRunAt != nil && s.NextRunAt.After(after) {
+			after = *s.NextRunAt
+		}
+		next, err := nextOccurrence(s, after)
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("schedule-id", s.ID.String()).Msg("Invalid stored schedule expression")
+			continue
+		}
+		run, err := w.sRepo.ClaimScheduledTransferRun(ctx, s, next, now)
+		if err != nil {
+			return fmt.Errorf("service.RunOnce: %w", err)
+		}
+		if run == nil {
+			// Another worker got there first.
+			continue
+		}
+		if err = w.execute(ctx, s, run, now); err != nil {
+			return fmt.Errorf("service.RunOnce: %w", err)
+		}
+	}
+	return nil
+}
+
+// execute makes one attempt at the transfer of a claimed run and records the outcome.
+// The transfer is recorded against the run, and given until half the lease has passed since the claim, so it is
+// over before the run can be released; an attempt cut short is handed back for a retry, which finds out whether
+// it went through. An outcome the worker could not record because its claim lapsed anyway is dropped.
+func (w *ScheduledTransferWorker) execute(ctx context.Context, s *model.ScheduledTransfer, run *model.ScheduledTransferRun, now time.Time) error {
+	logger := zerolog.Ctx(ctx).With().
+		Str("schedule-id", s.ID.String()).
+		Time("scheduled-for", run.ScheduledFor).
+		Int("attempt", run.Attempts).
+		Logger()
+
+	transferCtx := repo.WithScheduledTransferRun(ctx, run.ID)
+	if w.policy.Lease > 0 && run.ClaimedAt != nil {
+		var cancel context.CancelFunc
+		transferCtx, cancel = context.WithTimeout(transferCtx, w.policy.Lease/2-w.clock.Now().Sub(*run.ClaimedAt))
+		defer cancel()
+	}
+	transaction, err := w.transferer.Transfer(transferCtx, s.UserID.String(), s.SourceWalletID.String(), s.DestinationWalletID.String(), s.Amount)
+	switch {
+	case err == nil:
+		run.Status = model.ScheduledTransferRunStatusSucceeded
+		run.TransactionID = &transaction.ID
+		run.Error = nil
+		run.NextAttemptAt = nil
+		logger.Info().Str("transaction-id", transaction.ID.String()).Msg("Scheduled transfer succeeded")
+	case errors.Is(err, repo.ErrScheduledTransferRunPaid):
+		// An earlier attempt paid the run; FinishScheduledTransferRun records its transaction.
+		run.Status = model.ScheduledTransferRunStatusSucceeded
+		run.TransactionID = nil
+		run.Error = nil
+		run.NextAttemptAt = nil
+		logger.Info().Msg("Scheduled transfer was already paid")
+	case errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil:
+		msg := err.Error()
+		run.Status = model.ScheduledTransferRunStatusRetrying
+		run.Error = &msg
+		run.NextAttemptAt = &now
+		logger.Warn().Err(err).Msg("Scheduled transfer timed out and will be retried")
+	case errors.Is(err, repo.ErrInsufficientFunds) && run.Attempts < w.policy.MaxAttempts:
+		msg := err.Error()
+		retryAt := now.Add(w.policy.delay(run.Attempts))
+		run.Status = model.ScheduledTransferRunStatusRetrying
+		run.Error = &msg
+		run.NextAttemptAt = &retryAt
+		logger.Warn().Err(err).Time("retry-at", retryAt).Msg("Scheduled transfer will be retried")
+	default:
+		msg := err.Error()
+		run.Status = model.ScheduledTransferRunStatusFailed
+		run.Error = &msg
+		run.NextAttemptAt = nil
+		logger.Error().Err(err).Msg("Scheduled transfer failed")
+	}
+	if err = w.sRepo.FinishScheduledTransferRun(ctx, run); err != nil {
+		if errors.Is(err, repo.ErrScheduledTransferRunNotClaimed) {
+			logger.Warn().Str("status", string(run.Status)).Msg("Scheduled transfer run lease expired before the outcome was recorded")
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,380 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+	"github.com/kylenguyen/wallet-app/pkg/schedule"
+)
+
+func mustTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestScheduledTransferServiceImpl_CreateScheduledTransfer(t *testing.T) {
+	now := mustTime("2025-01-15T09:30:00Z")
+	endAt := mustTime("2025-01-20T00:00:00Z")
+
+	tests := []struct {
+		name         string
+		req          model.ScheduledTransferRequest
+		repoErr      error
+		wantNextRun  *time.Time
+		wantErr      error
+		wantRepoCall bool
+	}{
+		{
+			name:         "success - first of the month",
+			req:          model.ScheduledTransferRequest{Amount: decimal.NewFromInt(100), DestinationWalletID: testWallet2UUID.String(), Schedule: "0 9 1 * *"},
+			wantNextRun:  ptr(mustTime("2025-02-01T09:00:00Z")),
+			wantRepoCall: true,
+		},
+		{
+			name:         "success - rrule anchored at start_at",
+			req:          model.ScheduledTransferRequest{Amount: decimal.NewFromInt(100), DestinationWalletID: testWallet2UUID.String(), Schedule: "FREQ=WEEKLY", StartAt: ptr(mustTime("2025-03-03T07:00:00Z"))},
+			wantNextRun:  ptr(mustTime("2025-03-03T07:00:00Z")),
+			wantRepoCall: true,
+		},
+		{
+			name:    "error - invalid expression",
+			req:     model.ScheduledTransferRequest{Amount: decimal.NewFromInt(100), DestinationWalletID: testWallet2UUID.String(), Schedule: "every monday"},
+			wantErr: schedule.ErrInvalidExpression,
+		},
+		{
+			name:    "error - no occurrence before end_at",
+			req:     model.ScheduledTransferRequest{Amount: decimal.NewFromInt(100), DestinationWalletID: testWallet2UUID.String(), Schedule: "@monthly", EndAt: &endAt},
+			wantErr: service.ErrInvalidScheduledTransfer,
+		},
+		{
+			name:    "error - same wallet",
+			req:     model.ScheduledTransferRequest{Amount: decimal.NewFromInt(100), DestinationWalletID: testWallet1UUIDString, Schedule: "@daily"},
+			wantErr: service.ErrInvalidScheduledTransfer,
+		},
+		{
+			name:    "error - amount not positive",
+			req:     model.ScheduledTransferRequest{Amount: decimal.Zero, DestinationWalletID: testWallet2UUID.String(), Schedule: "@daily"},
+			wantErr: service.ErrInvalidScheduledTransfer,
+		},
+		{
+			name:         "error - destination wallet not found",
+			req:          model.ScheduledTransferRequest{Amount: decimal.NewFromInt(100), DestinationWalletID: testWallet2UUID.String(), Schedule: "@daily"},
+			repoErr:      repo.ErrWalletNotFound,
+			wantErr:      repo.ErrWalletNotFound,
+			wantRepoCall: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.ScheduledTransferRepoMock)
+			if tt.wantRepoCall {
+				m.On("CreateScheduledTransfer", mock.Anything, mock.Anything).Return(tt.repoErr)
+			}
+			ss := service.NewScheduledTransferImpl(m, clock.NewFake(now))
+
+			got, err := ss.CreateScheduledTransfer(context.Background(), testUser1UUIDString, testWallet1UUIDString, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantNextRun, got.NextRunAt)
+				assert.Equal(t, model.ScheduledTransferStatusActive, got.Status)
+				assert.Equal(t, testUser1UUID, got.UserID)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScheduledTransferWorker_RunOnce(t *testing.T) {
+	now := mustTime("2025-02-01T09:00:30Z")
+	policy := service.RetryPolicy{MaxAttempts: 3, Backoff: time.Hour, Lease: 15 * time.Minute}
+	transactionID := uuid.New()
+
+	dueSchedule := func(nextRunAt time.Time) model.ScheduledTransfer {
+		return model.ScheduledTransfer{
+			ID:                  uuid.New(),
+			UserID:              testUser1UUID,
+			SourceWalletID:      testWallet1UUID,
+			DestinationWalletID: testWallet2UUID,
+			Amount:              decimal.NewFromInt(100),
+			Expression:          "0 9 1 * *",
+			StartAt:             mustTime("2025-01-01T00:00:00Z"),
+			NextRunAt:           &nextRunAt,
+			Status:              model.ScheduledTransferStatusActive,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		schedule    model.ScheduledTransfer
+		lostClaim   bool
+		transferErr error
+		finishErr   error
+		wantNext    *time.Time
+		wantStatus  model.ScheduledTransferRunStatus
+		wantRetryAt *time.Time
+	}{
+		{
+			name:       "success - transaction is recorded",
+			schedule:   dueSchedule(mustTime("2025-02-01T09:00:00Z")),
+			wantNext:   ptr(mustTime("2025-03-01T09:00:00Z")),
+			wantStatus: model.ScheduledTransferRunStatusSucceeded,
+		},
+		{
+			name:       "success - missed occurrences run once",
+			schedule:   dueSchedule(mustTime("2024-11-01T09:00:00Z")),
+			wantNext:   ptr(mustTime("2025-03-01T09:00:00Z")),
+			wantStatus: model.ScheduledTransferRunStatusSucceeded,
+		},
+		{
+			name:        "retry - insufficient funds",
+			schedule:    dueSchedule(mustTime("2025-02-01T09:00:00Z")),
+			transferErr: fmt.Errorf("service.Transfer: %w", repo.ErrInsufficientFunds),
+			wantNext:    ptr(mustTime("2025-03-01T09:00:00Z")),
+			wantStatus:  model.ScheduledTransferRunStatusRetrying,
+			wantRetryAt: ptr(now.Add(time.Hour)),
+		},
+		{
+			name:        "error - other failures are not retried",
+			schedule:    dueSchedule(mustTime("2025-02-01T09:00:00Z")),
+			transferErr: fmt.Errorf("service.Transfer: %w", repo.ErrWalletNotFound),
+			wantNext:    ptr(mustTime("2025-03-01T09:00:00Z")),
+			wantStatus:  model.ScheduledTransferRunStatusFailed,
+		},
+		{
+			name:        "success - run already paid by an earlier attempt",
+			schedule:    dueSchedule(mustTime("2025-02-01T09:00:00Z")),
+			transferErr: fmt.Errorf("service.Transfer: %w", repo.ErrScheduledTransferRunPaid),
+			wantNext:    ptr(mustTime("2025-03-01T09:00:00Z")),
+			wantStatus:  model.ScheduledTransferRunStatusSucceeded,
+		},
+		{
+			name:        "retry - transfer outlived half the lease",
+			schedule:    dueSchedule(mustTime("2025-02-01T09:00:00Z")),
+			transferErr: fmt.Errorf("service.Transfer: %w", context.DeadlineExceeded),
+			wantNext:    ptr(mustTime("2025-03-01T09:00:00Z")),
+			wantStatus:  model.ScheduledTransferRunStatusRetrying,
+			wantRetryAt: ptr(now),
+		},
+		{
+			name:       "lease lost - outcome is dropped",
+			schedule:   dueSchedule(mustTime("2025-02-01T09:00:00Z")),
+			finishErr:  repo.ErrScheduledTransferRunNotClaimed,
+			wantNext:   ptr(mustTime("2025-03-01T09:00:00Z")),
+			wantStatus: model.ScheduledTransferRunStatusSucceeded,
+		},
+		{
+			name:      "idempotent - occurrence already claimed",
+			schedule:  dueSchedule(mustTime("2025-02-01T09:00:00Z")),
+			lostClaim: true,
+			wantNext:  ptr(mustTime("2025-03-01T09:00:00Z")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.ScheduledTransferRepoMock)
+			tr := new(walletmocks.TransfererMock)
+
+			run := &model.ScheduledTransferRun{ID: uuid.New(), ScheduleID: tt.schedule.ID, ScheduledFor: *tt.schedule.NextRunAt,
+				Status: model.ScheduledTransferRunStatusRunning, Attempts: 1, ClaimedAt: ptr(now)}
+			if tt.lostClaim {
+				run = nil
+			}
+
+			m.On("ReleaseExpiredScheduledTransferRuns", mock.Anything, now.Add(-policy.Lease), now).Return([]model.ScheduledTransferRun{}, nil)
+			m.On("ListRetryableScheduledTransferRuns", mock.Anything, now, mock.Anything).Return([]model.ScheduledTransferRun{}, nil)
+			m.On("ListDueScheduledTransfers", mock.Anything, now, mock.Anything).Return([]model.ScheduledTransfer{tt.schedule}, nil)
+			m.On("ClaimScheduledTransferRun", mock.Anything, mock.Anything, tt.wantNext, now).Return(run, nil)
+			if !tt.lostClaim {
+				var transaction *model.Transaction
+				if tt.transferErr == nil {
+					transaction = &model.Transaction{ID: transactionID}
+				}
+				// The transfer must be over well before the lease runs out.
+				inLease := mock.MatchedBy(func(ctx context.Context) bool {
+					deadline, ok := ctx.Deadline()
+					return ok && time.Until(deadline) <= policy.Lease/2
+				})
+				tr.On("Transfer", inLease, testUser1UUIDString, testWallet1UUIDString, testWallet2UUID.String(), tt.schedule.Amount).
+					Return(transaction, tt.transferErr)
+				m.On("FinishScheduledTransferRun", mock.Anything, mock.Anything).Return(tt.finishErr)
+			}
+
+			w := service.NewScheduledTransferWorker(m, tr, clock.NewFake(now), policy)
+			require.NoError(t, w.RunOnce(context.Background()))
+
+			m.AssertExpectations(t)
+			tr.AssertExpectations(t)
+			if tt.lostClaim {
+				tr.AssertNotCalled(t, "Transfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.Equal(t, tt.wantStatus, run.Status)
+			assert.Equal(t, tt.wantRetryAt, run.NextAttemptAt)
+			if tt.wantStatus == model.ScheduledTransferRunStatusSucceeded {
+				// A run paid earlier is given that transaction when the outcome is recorded.
+				if tt.transferErr == nil {
+					assert.Equal(t, &transactionID, run.TransactionID)
+				} else {
+					assert.Nil(t, run.TransactionID)
+				}
+				assert.Nil(t, run.Error)
+			} else {
+				assert.Nil(t, run.TransactionID)
+				assert.NotNil(t, run.Error)
+			}
+		})
+	}
+}
+
+func TestScheduledTransferWorker_RunOnce_Retries(t *testing.T) {
+	start := mustTime("2025-02-01T09:00:00Z")
+	policy := service.RetryPolicy{MaxAttempts: 3, Backoff: time.Hour}
+	s := &model.ScheduledTransfer{
+		ID:                  uuid.New(),
+		UserID:              testUser1UUID,
+		SourceWalletID:      testWallet1UUID,
+		DestinationWalletID: testWallet2UUID,
+		Amount:              decimal.NewFromInt(100),
+		Status:              model.ScheduledTransferStatusActive,
+	}
+	insufficient := fmt.Errorf("service.Transfer: %w", repo.ErrInsufficientFunds)
+
+	clk := clock.NewFake(start)
+	m := new(walletmocks.ScheduledTransferRepoMock)
+	tr := new(walletmocks.TransfererMock)
+	w := service.NewScheduledTransferWorker(m, tr, clk, policy)
+
+	run := model.ScheduledTransferRun{ID: uuid.New(), ScheduleID: s.ID, ScheduledFor: start, Status: model.ScheduledTransferRunStatusRetrying, Attempts: 1}
+	m.On("ReleaseExpiredScheduledTransferRuns", mock.Anything, mock.Anything, mock.Anything).Return([]model.ScheduledTransferRun{}, nil)
+	m.On("ListDueScheduledTransfers", mock.Anything, mock.Anything, mock.Anything).Return([]model.ScheduledTransfer{}, nil)
+	m.On("ListRetryableScheduledTransferRuns", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(context.Context, time.Time, int) []model.ScheduledTransferRun {
+			return []model.ScheduledTransferRun{run}
+		}, nil)
+	m.On("ClaimScheduledTransferRetry", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { args.Get(1).(*model.ScheduledTransferRun).Attempts++ }).
+		Return(true, nil)
+	m.On("GetScheduledTransferByID", mock.Anything, s.ID).Return(s, nil)
+	m.On("FinishScheduledTransferRun", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { run = *args.Get(1).(*model.ScheduledTransferRun) }).
+		Return(nil)
+	tr.On("Transfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, insufficient)
+
+	// The second attempt backs off by one hour, the third by two.
+	clk.Advance(time.Hour)
+	require.NoError(t, w.RunOnce(context.Background()))
+	assert.Equal(t, 2, run.Attempts)
+	assert.Equal(t, model.ScheduledTransferRunStatusRetrying, run.Status)
+	assert.Equal(t, ptr(clk.Now().Add(2*time.Hour)), run.NextAttemptAt)
+
+	// The last attempt gives up.
+	clk.Advance(2 * time.Hour)
+	require.NoError(t, w.RunOnce(context.Background()))
+	assert.Equal(t, 3, run.Attempts)
+	assert.Equal(t, model.ScheduledTransferRunStatusFailed, run.Status)
+	assert.Nil(t, run.NextAttemptAt)
+	tr.AssertNumberOfCalls(t, "Transfer", 2)
+}
+
+func TestScheduledTransferWorker_RunOnce_LostRetryClaim(t *testing.T) {
+	now := mustTime("2025-02-01T10:00:00Z")
+	m := new(walletmocks.ScheduledTransferRepoMock)
+	tr := new(walletmocks.TransfererMock)
+
+	run := model.ScheduledTransferRun{ID: uuid.New(), ScheduleID: uuid.New(), Status: model.ScheduledTransferRunStatusRetrying, Attempts: 1}
+	m.On("ReleaseExpiredScheduledTransferRuns", mock.Anything, mock.Anything, now).Return([]model.ScheduledTransferRun{}, nil)
+	m.On("ListRetryableScheduledTransferRuns", mock.Anything, now, mock.Anything).Return([]model.ScheduledTransferRun{run}, nil)
+	m.On("ClaimScheduledTransferRetry", mock.Anything, mock.Anything, now).Return(false, nil)
+	m.On("ListDueScheduledTransfers", mock.Anything, now, mock.Anything).Return([]model.ScheduledTransfer{}, nil)
+
+	w := service.NewScheduledTransferWorker(m, tr, clock.NewFake(now), service.RetryPolicy{MaxAttempts: 3, Backoff: time.Hour})
+	require.NoError(t, w.RunOnce(context.Background()))
+
+	m.AssertExpectations(t)
+	m.AssertNotCalled(t, "FinishScheduledTransferRun", mock.Anything, mock.Anything)
+	tr.AssertNotCalled(t, "Transfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestScheduledTransferWorker_RunOnce_ExpiredLease(t *testing.T) {
+	now := mustTime("2025-02-01T10:00:00Z")
+	policy := service.RetryPolicy{MaxAttempts: 3, Backoff: time.Hour, Lease: 15 * time.Minute}
+	s := &model.ScheduledTransfer{
+		ID:                  uuid.New(),
+		UserID:              testUser1UUID,
+		SourceWalletID:      testWallet1UUID,
+		DestinationWalletID: testWallet2UUID,
+		Amount:              decimal.NewFromInt(100),
+		Status:              model.ScheduledTransferStatusActive,
+	}
+	transactionID := uuid.New()
+	m := new(walletmocks.ScheduledTransferRepoMock)
+	tr := new(walletmocks.TransfererMock)
+
+	// The run was claimed at 09:00 by a worker that never came back; released, it is due for a retry right away.
+	released := model.ScheduledTransferRun{ID: uuid.New(), ScheduleID: s.ID, ScheduledFor: mustTime("2025-02-01T09:00:00Z"),
+		Status: model.ScheduledTransferRunStatusRetrying, Attempts: 1, NextAttemptAt: &now, ClaimedAt: ptr(mustTime("2025-02-01T09:00:00Z"))}
+	var finished model.ScheduledTransferRun
+	m.On("ReleaseExpiredScheduledTransferRuns", mock.Anything, now.Add(-15*time.Minute), now).Return([]model.ScheduledTransferRun{released}, nil).Once()
+	m.On("ListRetryableScheduledTransferRuns", mock.Anything, now, mock.Anything).Return([]model.ScheduledTransferRun{released}, nil)
+	m.On("ClaimScheduledTransferRetry", mock.Anything, mock.Anything, now).
+		Run(func(args mock.Arguments) {
+			run := args.Get(1).(*model.ScheduledTransferRun)
+			run.Attempts++
+			run.ClaimedAt = &now
+		}).
+		Return(true, nil)
+	m.On("GetScheduledTransferByID", mock.Anything, s.ID).Return(s, nil)
+	m.On("FinishScheduledTransferRun", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { finished = *args.Get(1).(*model.ScheduledTransferRun) }).
+		Return(nil)
+	m.On("ListDueScheduledTransfers", mock.Anything, now, mock.Anything).Return([]model.ScheduledTransfer{}, nil)
+	tr.On("Transfer", mock.Anything, testUser1UUIDString, testWallet1UUIDString, testWallet2UUID.String(), s.Amount).
+		Return(&model.Transaction{ID: transactionID}, nil).Once()
+
+	w := service.NewScheduledTransferWorker(m, tr, clock.NewFake(now), policy)
+	require.NoError(t, w.RunOnce(context.Background()))
+
+	m.AssertExpectations(t)
+	tr.AssertExpectations(t)
+	assert.Equal(t, released.ID, finished.ID)
+	assert.Equal(t, 2, finished.Attempts)
+	assert.Equal(t, model.ScheduledTransferRunStatusSucceeded, finished.Status)
+	assert.Equal(t, &transactionID, finished.TransactionID)
+}
+
+func TestScheduledTransferWorker_RunOnce_ReleaseFails(t *testing.T) {
+	now := mustTime("2025-02-01T10:00:00Z")
+	m := new(walletmocks.ScheduledTransferRepoMock)
+	tr := new(walletmocks.TransfererMock)
+	m.On("ReleaseExpiredScheduledTransferRuns", mock.Anything, mock.Anything, now).Return(nil, errors.New("connection reset"))
+
+	w := service.NewScheduledTransferWorker(m, tr, clock.NewFake(now), service.RetryPolicy{MaxAttempts: 3, Backoff: time.Hour, Lease: time.Minute})
+	require.Error(t, w.RunOnce(context.Background()))
+
+	m.AssertNotCalled(t, "ListRetryableScheduledTransferRuns", mock.Anything, mock.Anything, mock.Anything)
+	m.AssertNotCalled(t, "ListDueScheduledTransfers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
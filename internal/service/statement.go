@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/pkg/camt053"
+)
+
+// ErrInvalidStatementPeriod indicates that the requested statement period is empty or reversed.
+var ErrInvalidStatementPeriod = errors.New("statement period is invalid")
+
+type StatementRepo interface {
+	GetWalletActivity(ctx context.Context, userIDStr string, walletIDStr string, since time.Time) (*model.WalletActivity, error)
+}
+
+type StatementServiceImpl struct {
+	sRepo    StatementRepo
+	currency string
+}
+
+func NewStatementImpl(sr StatementRepo, currency string) *StatementServiceImpl {
+	return &StatementServiceImpl{sRepo: sr, currency: currency}
+}
+
+// GenerateCamt053 renders an ISO 20022 camt.053.001.02 statement of the wallet for the half-open period [from, to).
+// The opening (OPBD) and closing (CLBD) booked balances are derived backwards from the current balance,
+// so they stay correct even when older history has been archived.
+func (ss *StatementServiceImpl) GenerateCamt053(ctx context.Context, userId, walletId string, from, to time.Time) ([]byte, error) {
+	from, to = from.UTC(), to.UTC()
+	if !from.Before(to) {
+		return nil, ErrInvalidStatementPeriod
+	}
+
+	activity, err := ss.sRepo.GetWalletActivity(ctx, userId, walletId, from)
+	if err != nil {
+		return nil, fmt.Errorf("service.GenerateCamt053: %w", err)
+	}
+
+	walletID := activity.Wallet.ID
+	var inPeriod []model.Transaction
+	movedAfter := decimal.Zero
+	for _, t := range activity.Transactions {
+		if t.CreatedAt.Before(to) {
+			inPeriod = append(inPeriod, t)
+		} else {
+			movedAfter = movedAfter.Add(t.NetAmountFor(walletID))
+		}
+	}
+
+	closing := activity.Wallet.Balance.Sub(movedAfter)
+	opening := closing
+	var entries []camt053.ReportEntry2
+	credits, debits := decimal.Zero, decimal.Zero
+	var creditCount, debitCount int
+	for _, t := range inPeriod {
+		net := t.NetAmountFor(walletID)
+		opening = opening.Sub(net)
+		if net.IsNegative() {
+			debits = debits.Add(net.Abs())
+			debitCount++
+		} else {
+			credits = credits.Add(net)
+			creditCount++
+		}
+		entries = append(entries, ss.entry(t, walletID, net))
+	}
+
+	now := time.Now().UTC()
+	net := credits.Sub(debits)
+	stmt := camt053.AccountStatement2{
+		Id:      fmt.Sprintf("%s-%s%s", reference(walletID)[:16], from.Format("20060102"), to.Format("20060102")),
+		CreDtTm: camt053.ISODateTime(now),
+		FrToDt: &camt053.DateTimePeriodDetails{
+			FrDtTm: camt053.ISODateTime(from),
+			ToDtTm: camt053.ISODateTime(to),
+		},
+		Acct: camt053.CashAccount20{
+			Id:  camt053.AccountIdentification4Choice{Othr: camt053.GenericAccountIdentification1{Id: reference(walletID)}},
+			Ccy: ss.currency,
+			Nm:  truncate(activity.Wallet.Name, 70),
+		},
+		Bal: []camt053.CashBalance3{
+			ss.balance(camt053.BalanceOpeningBooked, opening, from),
+			ss.balance(camt053.BalanceClosingBooked, closing, to.Add(-time.Nanosecond)),
+		},
+		TxsSummry: &camt053.TotalTransactions2{
+			TtlNtries: &camt053.NumberAndSumOfTransactions2{
+				NbOfNtries:    strconv.Itoa(len(entries)),
+				Sum:           camt053.FormatDecimal(credits.Add(debits)),
+				TtlNetNtryAmt: camt053.FormatDecimal(net.Abs()),
+				CdtDbtInd:     creditDebit(net),
+			},
+			TtlCdtNtries: &camt053.NumberAndSumOfTransactions1{
+				NbOfNtries: strconv.Itoa(creditCount),
+				Sum:        camt053.FormatDecimal(credits),
+			},
+			TtlDbtNtries: &camt053.NumberAndSumOfTransactions1{
+				NbOfNtries: strconv.Itoa(debitCount),
+				Sum:        camt053.FormatDecimal(debits),
+			},
+		},
+		Ntry: entries,
+	}
+	if activity.Owner.Name != "" {
+		stmt.Acct.Ownr = &camt053.PartyIdentification32{Nm: truncate(activity.Owner.Name, 140)}
+	}
+
+	doc := camt053.Document{
+		BkToCstmrStmt: camt053.BankToCustomerStatementV02{
+			GrpHdr: camt053.GroupHeader42{
+				MsgId:   reference(uuid.New()),
+				CreDtTm: camt053.ISODateTime(now),
+			},
+			Stmt: []camt053.AccountStatement2{stmt},
+		},
+	}
+
+	out, err := doc.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("service.GenerateCamt053: %w", err)
+	}
+	return out, nil
+}
+
+func (ss *StatementServiceImpl) balance(code string, amount decimal.Decimal, day time.Time) camt053.CashBalance3 {
+	return camt053.CashBalance3{
+		Tp:        camt053.BalanceType12{CdOrPrtry: camt053.BalanceType5Choice{Cd: code}},
+		Amt:       camt053.NewAmount(amount, ss.currency),
+		CdtDbtInd: creditDebit(amount),
+		Dt:        camt053.NewDate(day),
+	}
+}
+
+// entry maps one transaction to a booked statement entry, using the ISO bank transaction codes
+// PMNT/CNTR/CDPT (cash deposit), PMNT/CNTR/CWDL (cash withdrawal), PMNT/ICDT|RCDT/BOOK
+// (issued or received internal book transfer), ACMT/MDOP|MCOP/CHRG (fee charged or collected),
+// ACMT/MCOP/INTR (interest paid), ACMT/MDOP|MCOP/ADJT (manual adjustment) and PMNT/ICDT|RCDT/RRTN
+// (reversal of a disputed transfer).
+func (ss *StatementServiceImpl) entry(t model.Transaction, walletID uuid.UUID, net decimal.Decimal) camt053.ReportEntry2 {
+	ref := reference(t.ID)
+	details := camt053.EntryTransaction2{
+		Refs: &camt053.TransactionReferences2{AcctSvcrRef: ref, TxId: ref},
+	}
+
+	domain := "PMNT"
+	var family, subFamily, info string
+	switch t.Type {
+	case model.TransactionTypeDeposit:
+		family, subFamily, info = "CNTR", "CDPT", "Deposit"
+	case model.TransactionTypeWithdrawal:
+		family, subFamily, info = "CNTR", "CWDL", "Withdrawal"
+	case model.TransactionTypeTransfer:
+		subFamily = "BOOK"
+		if net.IsNegative() {
+			family = "ICDT"
+			if t.RelatedWalletID != nil {
+				info = "Transfer to wallet " + t.RelatedWalletID.String()
+				details.RltdPties = &camt053.TransactionParty2{CdtrAcct: account(*t.RelatedWalletID)}
+			}
+		} else {
+			family = "RCDT"
+			info = "Transfer from wallet " + t.WalletID.String()
+			details.RltdPties = &camt053.TransactionParty2{DbtrAcct: account(t.WalletID)}
+		}
+	case model.TransactionTypeFee:
+		domain, subFamily = "ACMT", "CHRG"
+		if net.IsNegative() {
+			family, info = "MDOP", "Fee"
+			if t.ParentTransactionID != nil {
+				info = "Fee for transaction " + reference(*t.ParentTransactionID)
+			}
+		} else {
+			family, info = "MCOP", "Fee from wallet "+t.WalletID.String()
+		}
+	case model.TransactionTypeInterest:
+		domain, family, subFamily, info = "ACMT", "MCOP", "INTR", "Interest"
+	case model.TransactionTypeAdjustment:
+		domain, subFamily, info = "ACMT", "ADJT", "Manual adjustment"
+		if net.IsNegative() {
+			family = "MDOP"
+		} else {
+			family = "MCOP"
+		}
+	case model.TransactionTypeReversal:
+		subFamily = "RRTN"
+		if t.ParentTransactionID != nil {
+			info = "Reversal of transaction " + reference(*t.ParentTransactionID)
+		}
+		if net.IsNegative() {
+			family = "ICDT"
+			if t.RelatedWalletID != nil {
+				details.RltdPties = &camt053.TransactionParty2{CdtrAcct: account(*t.RelatedWalletID)}
+			}
+		} else {
+			family = "RCDT"
+			details.RltdPties = &camt053.TransactionParty2{DbtrAcct: account(t.WalletID)}
+		}
+	}
+
+	entry := camt053.ReportEntry2{
+		NtryRef:      ref,
+		Amt:          camt053.NewAmount(net, ss.currency),
+		CdtDbtInd:    creditDebit(net),
+		Sts:          camt053.EntryStatusBooked,
+		BookgDt:      camt053.NewDateTime(t.CreatedAt.UTC()),
+		ValDt:        camt053.NewDate(t.CreatedAt.UTC()),
+		AcctSvcrRef:  ref,
+		BkTxCd:       camt053.BankTransactionCodeStructure4{Prtry: &camt053.ProprietaryBankTransactionCodeStructure1{Cd: string(t.Type)}},
+		NtryDtls:     []camt053.EntryDetails1{{TxDtls: []camt053.EntryTransaction2{details}}},
+		AddtlNtryInf: info,
+	}
+	if family != "" {
+		entry.BkTxCd.Domn = &camt053.BankTransactionCodeStructure5{
+			Cd:   domain,
+			Fmly: camt053.BankTransactionCodeStructure6{Cd: family, SubFmlyCd: subFamily},
+		}
+	}
+	return entry
+}
+
+func account(walletID uuid.UUID) *camt053.CashAccount16 {
+	return &camt053.CashAccount16{
+		Id: camt053.AccountIdentification4Choice{Othr: camt053.GenericAccountIdentification1{Id: reference(walletID)}},
+	}
+}
+
+func creditDebit(amount decimal.Decimal) string {
+	if amount.IsNegative() {
+		return camt053.Debit
+	}
+	return camt053.Credit
+}
+
+// reference renders a UUID without hyphens so it fits the 35 character ISO 20022 text fields.
+func reference(id uuid.UUID) string {
+	return strings.ReplaceAll(id.String(), "-", "")
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
@@ -0,0 +1,141 @@
+package service_test
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+)
+
+const camt053XSD = "../../pkg/camt053/testdata/camt.053.001.02.xsd"
+
+var testWallet2UUID = uuid.MustParse("0b7c5d33-5a3a-4c43-9c1e-0d4c1ea1a7e2")
+
+// statementXML is the part of a camt.053 document the assertions look at.
+type statementXML struct {
+	Stmt struct {
+		Bal []struct {
+			Cd        string `xml:"Tp>CdOrPrtry>Cd"`
+			Amt       string `xml:"Amt"`
+			CdtDbtInd string `xml:"CdtDbtInd"`
+			Dt        string `xml:"Dt>Dt"`
+		} `xml:"Bal"`
+		Ntry []struct {
+			Amt       string `xml:"Amt"`
+			CdtDbtInd string `xml:"CdtDbtInd"`
+			Fmly      string `xml:"BkTxCd>Domn>Fmly>Cd"`
+			SubFmly   string `xml:"BkTxCd>Domn>Fmly>SubFmlyCd"`
+		} `xml:"Ntry"`
+		TtlNetNtryAmt string `xml:"TxsSummry>TtlNtries>TtlNetNtryAmt"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+func TestStatementServiceImpl_GenerateCamt053(t *testing.T) {
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	activity := &model.WalletActivity{
+		Wallet: model.Wallet{ID: testWallet1UUID, UserID: testUser1UUID, Name: "Primary", Balance: decimal.NewFromInt(1000)},
+		Owner:  model.User{ID: testUser1UUID, Name: "Alice Smith"},
+		Since:  from,
+		Transactions: []model.Transaction{
+			{ID: uuid.New(), WalletID: testWallet1UUID, Type: model.TransactionTypeDeposit, Amount: decimal.NewFromInt(500), CreatedAt: from.Add(time.Hour)},
+			{ID: uuid.New(), WalletID: testWallet1UUID, Type: model.TransactionTypeWithdrawal, Amount: decimal.RequireFromString("20.5"), CreatedAt: from.Add(2 * time.Hour)},
+			{ID: uuid.New(), WalletID: testWallet1UUID, Type: model.TransactionTypeTransfer, Amount: decimal.NewFromInt(100), RelatedWalletID: &testWallet2UUID, CreatedAt: from.Add(3 * time.Hour)},
+			{ID: uuid.New(), WalletID: testWallet2UUID, Type: model.TransactionTypeTransfer, Amount: decimal.NewFromInt(40), RelatedWalletID: &testWallet1UUID, CreatedAt: from.Add(4 * time.Hour)},
+			// After the period: only used to roll the current balance back to the closing balance.
+			{ID: uuid.New(), WalletID: testWallet1UUID, Type: model.TransactionTypeDeposit, Amount: decimal.NewFromInt(300), CreatedAt: to.Add(time.Hour)},
+		},
+	}
+
+	m := new(walletmocks.StatementRepoMock)
+	m.On("GetWalletActivity", mock.Anything, testUser1UUIDString, testWallet1UUIDString, from).Return(activity, nil)
+	ss := service.NewStatementImpl(m, "USD")
+
+	out, err := ss.GenerateCamt053(context.Background(), testUser1UUIDString, testWallet1UUIDString, from, to)
+	require.NoError(t, err)
+	m.AssertExpectations(t)
+
+	var doc statementXML
+	require.NoError(t, xml.Unmarshal(out, &doc))
+
+	// closing = 1000 - 300 = 700, opening = 700 - (500 - 20.5 - 100 + 40) = 280.5
+	require.Len(t, doc.Stmt.Bal, 2)
+	assert.Equal(t, "OPBD", doc.Stmt.Bal[0].Cd)
+	assert.Equal(t, "280.50", doc.Stmt.Bal[0].Amt)
+	assert.Equal(t, "CRDT", doc.Stmt.Bal[0].CdtDbtInd)
+	assert.Equal(t, "2025-05-01", doc.Stmt.Bal[0].Dt)
+	assert.Equal(t, "CLBD", doc.Stmt.Bal[1].Cd)
+	assert.Equal(t, "700.00", doc.Stmt.Bal[1].Amt)
+	assert.Equal(t, "2025-05-31", doc.Stmt.Bal[1].Dt)
+	assert.Equal(t, "419.50", doc.Stmt.TtlNetNtryAmt)
+
+	require.Len(t, doc.Stmt.Ntry, 4)
+	wantEntries := [][4]string{
+		{"500.00", "CRDT", "CNTR", "CDPT"},
+		{"20.50", "DBIT", "CNTR", "CWDL"},
+		{"100.00", "DBIT", "ICDT", "BOOK"},
+		{"40.00", "CRDT", "RCDT", "BOOK"},
+	}
+	for i, want := range wantEntries {
+		got := doc.Stmt.Ntry[i]
+		assert.Equal(t, want, [4]string{got.Amt, got.CdtDbtInd, got.Fmly, got.SubFmly}, "entry %d", i)
+	}
+
+	validateAgainstXSD(t, out)
+}
+
+func TestStatementServiceImpl_GenerateCamt053_Errors(t *testing.T) {
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("error - empty period", func(t *testing.T) {
+		m := new(walletmocks.StatementRepoMock)
+		ss := service.NewStatementImpl(m, "USD")
+		_, err := ss.GenerateCamt053(context.Background(), testUser1UUIDString, testWallet1UUIDString, from, from)
+		assert.ErrorIs(t, err, service.ErrInvalidStatementPeriod)
+		m.AssertNotCalled(t, "GetWalletActivity")
+	})
+
+	t.Run("error - wallet not found", func(t *testing.T) {
+		m := new(walletmocks.StatementRepoMock)
+		m.On("GetWalletActivity", mock.Anything, testUser1UUIDString, testWallet1UUIDString, from).Return(nil, repo.ErrWalletNotFound)
+		ss := service.NewStatementImpl(m, "USD")
+		_, err := ss.GenerateCamt053(context.Background(), testUser1UUIDString, testWallet1UUIDString, from, from.AddDate(0, 1, 0))
+		assert.True(t, errors.Is(err, repo.ErrWalletNotFound))
+		m.AssertExpectations(t)
+	})
+}
+
+// validateAgainstXSD checks doc against the vendored camt.053.001.02 schema using xmllint,
+// which is the only XSD validator available without extra Go dependencies. It runs as a subtest that is
+// reported as skipped where xmllint is missing, and fails in CI, so a pass always means the document was validated.
+func validateAgainstXSD(t *testing.T, doc []byte) {
+	t.Helper()
+	t.Run("valid against camt.053.001.02 XSD", func(t *testing.T) {
+		xmllint, err := exec.LookPath("xmllint")
+		if err != nil {
+			if os.Getenv("CI") != "" {
+				t.Fatal("xmllint must be installed in CI to validate statements against the XSD")
+			}
+			t.Skip("xmllint not installed, skipping XSD validation")
+		}
+		path := filepath.Join(t.TempDir(), "statement.xml")
+		require.NoError(t, os.WriteFile(path, doc, 0o600))
+		out, err := exec.Command(xmllint, "--noout", "--schema", camt053XSD, path).CombinedOutput()
+		assert.NoError(t, err, string(out))
+	})
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+)
+
+// MaxTransferBatchRows is the largest number of rows accepted in one batch.
+const MaxTransferBatchRows = 1000
+
+// ErrInvalidTransferBatch indicates that a batch was rejected during up-front validation.
+var ErrInvalidTransferBatch = errors.New("invalid transfer batch")
+
+// TransferBatchValidationError lists every problem found while validating a batch.
+// It wraps ErrInvalidTransferBatch.
+type TransferBatchValidationError struct {
+	Problems []string
+}
+
+func (e *TransferBatchValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidTransferBatch, strings.Join(e.Problems, "; "))
+}
+
+func (e *TransferBatchValidationError) Unwrap() error {
+	return ErrInvalidTransferBatch
+}
+
+type TransferBatchRepo interface {
+	FindMissingWallets(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	CreateTransferBatch(ctx context.Context, batch *model.TransferBatch) error
+	GetTransferBatch(ctx context.Context, userIDStr string, walletIDStr string, batchIDStr string) (*model.TransferBatch, error)
+	SetTransferBatchStatus(ctx context.Context, batchID uuid.UUID, status model.TransferBatchStatus) error
+	ExecuteTransferBatchItem(ctx context.Context, batch *model.TransferBatch, item *model.TransferBatchItem) error
+	ExecuteTransferBatchAtomically(ctx context.Context, batch *model.TransferBatch) error
+}
+
+type TransferBatchServiceImpl struct {
+	bRepo TransferBatchRepo
+	fees  FeeCalculator
+	wg    sync.WaitGroup
+}
+
+// NewTransferBatchImpl creates the transfer batch service. With a nil FeeCalculator no fees are charged.
+func NewTransferBatchImpl(br TransferBatchRepo, fc FeeCalculator) *TransferBatchServiceImpl {
+	return &TransferBatchServiceImpl{bRepo: br, fees: fc}
+}
+
+// CreateTransferBatch validates every row up front, stores the batch and starts executing it in the background.
+// The returned batch is in the pending state; progress can be followed with GetTransferBatch.
+func (bs *TransferBatchServiceImpl) CreateTransferBatch(ctx context.Context, userId, walletId string, mode model.TransferBatchMode, rows []model.TransferBatchRow) (*model.TransferBatch, error) {
+	userID, err := uuid.Parse(userId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	sourceWalletID, err := uuid.Parse(walletId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+
+	now := time.Now()
+	batch := &model.TransferBatch{
+		ID:             uuid.New(),
+		UserID:         userID,
+		SourceWalletID: sourceWalletID,
+		Mode:           mode,
+		Status:         model.TransferBatchStatusPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err = bs.validate(ctx, batch, rows); err != nil {
+		return nil, err
+	}
+
+	if err = bs.bRepo.CreateTransferBatch(ctx, batch); err != nil {
+		return nil, fmt.Errorf("service.CreateTransferBatch: %w", err)
+	}
+	batch.Summarize()
+
+	// The batch outlives the request that created it, but keeps its logger.
+	// The caller gets its own copy so it can be serialized while the rows execute.
+	runCtx := context.WithoutCancel(ctx)
+	running := *batch
+	running.Items = slices.Clone(batch.Items)
+	bs.wg.Add(1)
+	go func() {
+		defer bs.wg.Done()
+		bs.run(runCtx, &running)
+	}()
+
+	return batch, nil
+}
+
+// GetTransferBatch returns a batch with the current outcome of every row.
+func (bs *TransferBatchServiceImpl) GetTransferBatch(ctx context.Context, userId, walletId, batchId string) (*model.TransferBatch, error) {
+	batch, err := bs.bRepo.GetTransferBatch(ctx, userId, walletId, batchId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetTransferBatch: %w", err)
+	}
+	return batch, nil
+}
+
+// Wait blocks until every batch started by the service has finished running.
+func (bs *TransferBatchServiceImpl) Wait() {
+	bs.wg.Wait()
+}
+
+// validate checks all rows before anything is stored, so a batch is either accepted whole or rejected
+// with the complete list of problems. Valid rows are appended to batch.Items with the transfer fee quoted for them.
+func (bs *TransferBatchServiceImpl) validate(ctx context.Context, batch *model.TransferBatch, rows []model.TransferBatchRow) error {
+	var problems []string
+	if !batch.Mode.IsValid() {
+		problems = append(problems, fmt.Sprintf("mode must be %q or %q", model.TransferBatchModeAllOrNothing, model.TransferBatchModeBestEffort))
+	}
+	if len(rows) == 0 {
+		problems = append(problems, "batch has no rows")
+	}
+	if len(rows) > MaxTransferBatchRows {
+		problems = append(problems, fmt.Sprintf("batch has %d rows, at most %d are allowed", len(rows), MaxTransferBatchRows))
+	}
+	if len(problems) > 0 {
+		return &TransferBatchValidationError{Problems: problems}
+	}
+
+	rowsByDestination := map[uuid.UUID][]int{}
+	var destinations []uuid.UUID
+	for i, row := range rows {
+		number := i + 1
+		destinationID, err := uuid.Parse(strings.TrimSpace(row.DestinationWalletID))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: invalid destination wallet ID", number))
+			continue
+		}
+		if destinationID == batch.SourceWalletID {
+			problems = append(problems, fmt.Sprintf("row %d: source and destination wallets cannot be the same", number))
+			continue
+		}
+		if row.Amount.LessThanOrEqual(decimal.Zero) {
+			problems = append(problems, fmt.Sprintf("row %d: amount must be positive", number))
+			continue
+		}
+		if !row.Amount.Equal(row.Amount.Truncate(4)) {
+			problems = append(problems, fmt.Sprintf("row %d: amount has more than 4 decimal places", number))
+			continue
+		}
+		if len([]rune(row.Reference)) > 140 {
+			problems = append(problems, fmt.Sprintf("row %d: reference is longer than 140 characters", number))
+			continue
+		}
+
+		if _, seen := rowsByDestination[destinationID]; !seen {
+			destinations = append(destinations, destinationID)
+		}
+		rowsByDestination[destinationID] = append(rowsByDestination[destinationID], number)
+		item := model.TransferBatchItem{
+			ID:                  uuid.New(),
+			BatchID:             batch.ID,
+			RowNumber:           number,
+			DestinationWalletID: destinationID,
+			Amount:              row.Amount,
+			Reference:           row.Reference,
+			Fee:                 decimal.Zero,
+			Status:              model.TransferBatchItemStatusPending,
+			UpdatedAt:           batch.CreatedAt,
+		}
+		if bs.fees != nil {
+			fee, err := bs.fees.Fee(ctx, model.FeeOperationTransfer, row.Amount)
+			if err != nil {
+				return fmt.Errorf("service.CreateTransferBatch: %w", err)
+			}
+			item.Fee = fee.Amount
+			item.FeeWalletID = &fee.WalletID
+			item.FeeScheduleID = fee.ScheduleID
+		}
+		batch.Items = append(batch.Items, item)
+	}
+
+	if len(destinations) > 0 {
+		missing, err := bs.bRepo.FindMissingWallets(ctx, destinations)
+		if err != nil {
+			return fmt.Errorf("service.CreateTransferBatch: %w", err)
+		}
+		for _, id := range missing {
+			for _, number := range rowsByDestination[id] {
+				problems = append(problems, fmt.Sprintf("row %d: destination wallet not found", number))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &TransferBatchValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// run executes a stored batch and records its final status.
+func (bs *TransferBatchServiceImpl) run(ctx context.Context, batch *model.TransferBatch) {
+	logger := zerolog.Ctx(ctx).With().Str("batch-id", batch.ID.String()).Logger()
+
+	if err := bs.bRepo.SetTransferBatchStatus(ctx, batch.ID, model.TransferBatchStatusRunning); err != nil {
+		logger.Error().Err(err).Msg("Failed to start transfer batch")
+		return
+	}
+
+	status := model.TransferBatchStatusCompleted
+	switch batch.Mode {
+	case model.TransferBatchModeAllOrNothing:
+		if err := bs.bRepo.ExecuteTransferBatchAtomically(ctx, batch); err != nil {
+			logger.Error().Err(err).Msg("Failed to execute transfer batch")
+			status = model.TransferBatchStatusFailed
+			break
+		}
+		for _, item := range batch.Items {
+			if item.Status != model.TransferBatchItemStatusSucceeded {
+				status = model.TransferBatchStatusFailed
+				break
+			}
+		}
+	case model.TransferBatchModeBestEffort:
+		for i := range batch.Items {
+			if err := bs.bRepo.ExecuteTransferBatchItem(ctx, batch, &batch.Items[i]); err != nil {
+				logger.Error().Err(err).Int("row", batch.Items[i].RowNumber).Msg("Failed to execute transfer batch row")
+				status = model.TransferBatchStatusFailed
+				break
+			}
+		}
+	}
+
+	if err := bs.bRepo.SetTransferBatchStatus(ctx, batch.ID, status); err != nil {
+		logger.Error().Err(err).Msg("Failed to finish transfer batch")
+		return
+	}
+	batch.Summarize()
+	logger.Info().
+		Str("status", string(status)).
+		Int("succeeded", batch.Summary.Succeeded).
+		Int("failed", batch.Summary.Failed).
+		Msg("Transfer batch finished")
+}
@@ -0,0 +1,156 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+)
+
+var testWallet3UUID = uuid.MustParse("6a1f0c3e-93a4-4d8e-a3f4-2f6c55b7e0a1")
+
+func TestTransferBatchServiceImpl_CreateTransferBatch_Validation(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         model.TransferBatchMode
+		rows         []model.TransferBatchRow
+		missing      []uuid.UUID
+		wantProblems []string
+	}{
+		{
+			name:         "error - unknown mode",
+			mode:         "sometimes",
+			rows:         []model.TransferBatchRow{{DestinationWalletID: testWallet2UUID.String(), Amount: decimal.NewFromInt(1)}},
+			wantProblems: []string{`mode must be "all_or_nothing" or "best_effort"`},
+		},
+		{
+			name:         "error - no rows",
+			mode:         model.TransferBatchModeBestEffort,
+			wantProblems: []string{"batch has no rows"},
+		},
+		{
+			name: "error - every invalid row is reported",
+			mode: model.TransferBatchModeAllOrNothing,
+			rows: []model.TransferBatchRow{
+				{DestinationWalletID: "not-a-uuid", Amount: decimal.NewFromInt(1)},
+				{DestinationWalletID: testWallet1UUIDString, Amount: decimal.NewFromInt(1)},
+				{DestinationWalletID: testWallet2UUID.String(), Amount: decimal.NewFromInt(-5)},
+				{DestinationWalletID: testWallet2UUID.String(), Amount: decimal.RequireFromString("0.00001")},
+				{DestinationWalletID: testWallet3UUID.String(), Amount: decimal.NewFromInt(10)},
+				{DestinationWalletID: testWallet2UUID.String(), Amount: decimal.NewFromInt(10)},
+			},
+			missing: []uuid.UUID{testWallet3UUID},
+			wantProblems: []string{
+				"row 1: invalid destination wallet ID",
+				"row 2: source and destination wallets cannot be the same",
+				"row 3: amount must be positive",
+				"row 4: amount has more than 4 decimal places",
+				"row 5: destination wallet not found",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.TransferBatchRepoMock)
+			if tt.missing != nil {
+				m.On("FindMissingWallets", mock.Anything, mock.Anything).Return(tt.missing, nil)
+			}
+			bs := service.NewTransferBatchImpl(m, nil)
+
+			got, err := bs.CreateTransferBatch(context.Background(), testUser1UUIDString, testWallet1UUIDString, tt.mode, tt.rows)
+			assert.Nil(t, got)
+			require.ErrorIs(t, err, service.ErrInvalidTransferBatch)
+			var validationErr *service.TransferBatchValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.wantProblems, validationErr.Problems)
+			m.AssertExpectations(t)
+			m.AssertNotCalled(t, "CreateTransferBatch", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTransferBatchServiceImpl_CreateTransferBatch_BestEffort(t *testing.T) {
+	rows := []model.TransferBatchRow{
+		{DestinationWalletID: testWallet2UUID.String(), Amount: decimal.NewFromInt(100), Reference: "May salary"},
+		{DestinationWalletID: testWallet3UUID.String(), Amount: decimal.NewFromInt(900), Reference: "May salary"},
+	}
+
+	m := new(walletmocks.TransferBatchRepoMock)
+	m.On("FindMissingWallets", mock.Anything, []uuid.UUID{testWallet2UUID, testWallet3UUID}).Return(nil, nil)
+	m.On("CreateTransferBatch", mock.Anything, mock.AnythingOfType("*model.TransferBatch")).Return(nil)
+	m.On("SetTransferBatchStatus", mock.Anything, mock.Anything, model.TransferBatchStatusRunning).Return(nil).Once()
+	var running *model.TransferBatch
+	m.On("ExecuteTransferBatchItem", mock.Anything, mock.Anything, mock.AnythingOfType("*model.TransferBatchItem")).
+		Run(func(args mock.Arguments) {
+			running = args.Get(1).(*model.TransferBatch)
+			item := args.Get(2).(*model.TransferBatchItem)
+			if item.RowNumber == 1 {
+				txID := uuid.New()
+				item.Status, item.TransactionID = model.TransferBatchItemStatusSucceeded, &txID
+			} else {
+				msg := "insufficient funds"
+				item.Status, item.Error = model.TransferBatchItemStatusFailed, &msg
+			}
+		}).Return(nil).Twice()
+	m.On("SetTransferBatchStatus", mock.Anything, mock.Anything, model.TransferBatchStatusCompleted).Return(nil).Once()
+
+	bs := service.NewTransferBatchImpl(m, nil)
+	batch, err := bs.CreateTransferBatch(context.Background(), testUser1UUIDString, testWallet1UUIDString, model.TransferBatchModeBestEffort, rows)
+	require.NoError(t, err)
+	assert.Equal(t, model.TransferBatchStatusPending, batch.Status)
+	assert.Equal(t, 2, batch.Summary.Total)
+	assert.True(t, decimal.NewFromInt(1000).Equal(batch.Summary.Amount))
+
+	bs.Wait()
+	m.AssertExpectations(t)
+	assert.Equal(t, model.TransferBatchItemStatusPending, batch.Items[0].Status, "caller's copy is not touched by the run")
+	require.NotNil(t, running)
+	assert.Equal(t, batch.ID, running.ID)
+	assert.Equal(t, 1, running.Summary.Succeeded)
+	assert.Equal(t, 1, running.Summary.Failed)
+	assert.True(t, decimal.NewFromInt(100).Equal(running.Summary.Moved))
+}
+
+func TestTransferBatchServiceImpl_CreateTransferBatch_AllOrNothingRolledBack(t *testing.T) {
+	rows := []model.TransferBatchRow{
+		{DestinationWalletID: testWallet2UUID.String(), Amount: decimal.NewFromInt(100)},
+		{DestinationWalletID: testWallet3UUID.String(), Amount: decimal.NewFromInt(900)},
+	}
+
+	m := new(walletmocks.TransferBatchRepoMock)
+	m.On("FindMissingWallets", mock.Anything, mock.Anything).Return(nil, nil)
+	m.On("CreateTransferBatch", mock.Anything, mock.Anything).Return(nil)
+	m.On("SetTransferBatchStatus", mock.Anything, mock.Anything, model.TransferBatchStatusRunning).Return(nil).Once()
+	var running *model.TransferBatch
+	m.On("ExecuteTransferBatchAtomically", mock.Anything, mock.AnythingOfType("*model.TransferBatch")).
+		Run(func(args mock.Arguments) {
+			running = args.Get(1).(*model.TransferBatch)
+			batch := running
+			msg := "insufficient funds"
+			batch.Items[0].Status = model.TransferBatchItemStatusSkipped
+			batch.Items[1].Status, batch.Items[1].Error = model.TransferBatchItemStatusFailed, &msg
+		}).Return(nil).Once()
+	m.On("SetTransferBatchStatus", mock.Anything, mock.Anything, model.TransferBatchStatusFailed).Return(nil).Once()
+
+	bs := service.NewTransferBatchImpl(m, nil)
+	batch, err := bs.CreateTransferBatch(context.Background(), testUser1UUIDString, testWallet1UUIDString, model.TransferBatchModeAllOrNothing, rows)
+	require.NoError(t, err)
+
+	bs.Wait()
+	m.AssertExpectations(t)
+	m.AssertNotCalled(t, "ExecuteTransferBatchItem", mock.Anything, mock.Anything, mock.Anything)
+	require.NotNil(t, running)
+	assert.Equal(t, batch.ID, running.ID)
+	assert.Equal(t, 1, running.Summary.Skipped)
+	assert.Equal(t, 1, running.Summary.Failed)
+	assert.True(t, decimal.Zero.Equal(running.Summary.Moved))
+}
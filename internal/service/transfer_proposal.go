@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+const (
+	// DefaultProposalTTLHours is how long transfer proposals wait for approvals when a policy does not say.
+	DefaultProposalTTLHours = 72
+	// MaxProposalTTLHours is the longest a policy may let transfer proposals wait for approvals.
+	MaxProposalTTLHours = 30 * 24
+)
+
+// ErrInvalidTransferProposal indicates that an approval policy or a transfer proposal was rejected during validation.
+var ErrInvalidTransferProposal = errors.New("invalid transfer proposal")
+
+type TransferProposalRepo interface {
+	SetApprovalPolicy(ctx context.Context, userID string, policy *model.ApprovalPolicy) error
+	GetApprovalPolicy(ctx context.Context, userID string, walletID string) (*model.ApprovalPolicy, error)
+	DeleteApprovalPolicy(ctx context.Context, userID string, walletID string) error
+	CreateTransferProposal(ctx context.Context, userID string, proposal *model.TransferProposal) error
+	ListTransferProposals(ctx context.Context, userID string, walletID string, status model.TransferProposalStatus) ([]model.TransferProposal, error)
+	GetTransferProposal(ctx context.Context, userID string, walletID string, proposalID string) (*model.TransferProposal, error)
+	ApproveTransferProposal(ctx context.Context, userID string, walletID string, proposalID string, now time.Time) (*model.TransferProposal, error)
+	CancelTransferProposal(ctx context.Context, userID string, walletID string, proposalID string, now time.Time) (*model.TransferProposal, error)
+	ExpireTransferProposals(ctx context.Context, now time.Time) (int, error)
+}
+
+type TransferProposalServiceImpl struct {
+	pRepo TransferProposalRepo
+	fees  FeeCalculator
+	clock clock.Clock
+}
+
+// NewTransferProposalImpl creates the transfer proposal service. With a nil FeeCalculator no fees are charged.
+func NewTransferProposalImpl(pr TransferProposalRepo, fc FeeCalculator, clk clock.Clock) *TransferProposalServiceImpl {
+	return &TransferProposalServiceImpl{pRepo: pr, fees: fc, clock: clk}
+}
+
+// SetApprovalPolicy makes transfers from the wallet above the threshold need approvals from its owners.
+func (ps *TransferProposalServiceImpl) SetApprovalPolicy(ctx context.Context, userId, walletId string, req model.ApprovalPolicyRequest) (*model.ApprovalPolicy, error) {
+	walletID, err := uuid.Parse(walletId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	if req.Threshold.IsNegative() {
+		return nil, fmt.Errorf("%w: threshold must not be negative", ErrInvalidTransferProposal)
+	}
+	if req.RequiredApprovals < 1 {
+		return nil, fmt.Errorf("%w: required_approvals must be at least 1", ErrInvalidTransferProposal)
+	}
+	ttl := req.ProposalTTLHours
+	if ttl == 0 {
+		ttl = DefaultProposalTTLHours
+	}
+	if ttl < 0 || ttl > MaxProposalTTLHours {
+		return nil, fmt.Errorf("%w: proposal_ttl_hours must be between 1 and %d", ErrInvalidTransferProposal, MaxProposalTTLHours)
+	}
+
+	policy := &model.ApprovalPolicy{
+		WalletID:          walletID,
+		Threshold:         req.Threshold,
+		RequiredApprovals: req.RequiredApprovals,
+		ProposalTTLHours:  ttl,
+		UpdatedAt:         ps.clock.Now().UTC(),
+	}
+	if err = ps.pRepo.SetApprovalPolicy(ctx, userId, policy); err != nil {
+		return nil, fmt.Errorf("service.SetApprovalPolicy: %w", err)
+	}
+	return policy, nil
+}
+
+func (ps *TransferProposalServiceImpl) GetApprovalPolicy(ctx context.Context, userId, walletId string) (*model.ApprovalPolicy, error) {
+	policy, err := ps.pRepo.GetApprovalPolicy(ctx, userId, walletId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetApprovalPolicy: %w", err)
+	}
+	return policy, nil
+}
+
+func (ps *TransferProposalServiceImpl) DeleteApprovalPolicy(ctx context.Context, userId, walletId string) error {
+	if err := ps.pRepo.DeleteApprovalPolicy(ctx, userId, walletId); err != nil {
+		return fmt.Errorf("service.DeleteApprovalPolicy: %w", err)
+	}
+	return nil
+}
+
+// ProposeTransfer turns a transfer that needs approval into a pending proposal. The fee is quoted now and its
+// amount held together with the transfer's until the proposal is executed, cancelled or expires.
+func (ps *TransferProposalServiceImpl) ProposeTransfer(ctx context.Context, userId, walletId, destinationWalletId string, amount decimal.Decimal) (*model.TransferProposal, error) {
+	walletID, err := uuid.Parse(walletId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	destinationWalletID, err := uuid.Parse(destinationWalletId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid destination wallet ID", ErrInvalidTransferProposal)
+	}
+	if walletID == destinationWalletID {
+		return nil, fmt.Errorf("%w: source and destination wallets cannot be the same", ErrInvalidTransferProposal)
+	}
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidTransferProposal)
+	}
+
+	proposal := &model.TransferProposal{
+		ID:                  uuid.New(),
+		WalletID:            walletID,
+		DestinationWalletID: destinationWalletID,
+		Amount:              amount,
+		Fee:                 decimal.Zero,
+		CreatedAt:           ps.clock.Now().UTC(),
+	}
+	if ps.fees != nil {
+		fee, err := ps.fees.Fee(ctx, model.FeeOperationTransfer, amount)
+		if err != nil {
+			return nil, fmt.Errorf("service.ProposeTransfer: %w", err)
+		}
+		proposal.Fee = fee.Amount
+		proposal.FeeWalletID = &fee.WalletID
+		proposal.FeeScheduleID = fee.ScheduleID
+	}
+	if err = ps.pRepo.CreateTransferProposal(ctx, userId, proposal); err != nil {
+		return nil, fmt.Errorf("service.ProposeTransfer: %w", err)
+	}
+	return proposal, nil
+}
+
+// ListTransferProposals returns the proposals of the wallet, optionally only those in status.
+func (ps *TransferProposalServiceImpl) ListTransferProposals(ctx context.Context, userId, walletId string, status model.TransferProposalStatus) ([]model.TransferProposal, error) {
+	proposals, err := ps.pRepo.ListTransferProposals(ctx, userId, walletId, status)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListTransferProposals: %w", err)
+	}
+	now := ps.clock.Now()
+	for i := range proposals {
+		reportExpiry(&proposals[i], now)
+	}
+	return proposals, nil
+}
+
+func (ps *TransferProposalServiceImpl) GetTransferProposal(ctx context.Context, userId, walletId, proposalId string) (*model.TransferProposal, error) {
+	proposal, err := ps.pRepo.GetTransferProposal(ctx, userId, walletId, proposalId)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetTransferProposal: %w", err)
+	}
+	reportExpiry(proposal, ps.clock.Now())
+	return proposal, nil
+}
+
+// ApproveTransferProposal signs off on the proposal; the transfer is executed by the approval that reaches the quorum.
+func (ps *TransferProposalServiceImpl) ApproveTransferProposal(ctx context.Context, userId, walletId, proposalId string) (*model.TransferProposal, error) {
+	proposal, err := ps.pRepo.ApproveTransferProposal(ctx, userId, walletId, proposalId, ps.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("service.ApproveTransferProposal: %w", err)
+	}
+	return proposal, nil
+}
+
+func (ps *TransferProposalServiceImpl) CancelTransferProposal(ctx context.Context, userId, walletId, proposalId string) (*model.TransferProposal, error) {
+	proposal, err := ps.pRepo.CancelTransferProposal(ctx, userId, walletId, proposalId, ps.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("service.CancelTransferProposal: %w", err)
+	}
+	return proposal, nil
+}
+
+// reportExpiry shows a pending proposal past its expiry as expired, whether or not the expiry job has released
+// its hold yet.
+func reportExpiry(proposal *model.TransferProposal, now time.Time) {
+	if proposal.Status == model.TransferProposalStatusPending && !proposal.ExpiresAt.After(now) {
+		proposal.Status = model.TransferProposalStatusExpired
+	}
+}
+
+// TransferProposalExpiryJob expires pending transfer proposals past their expiry and releases the funds they hold.
+type TransferProposalExpiryJob struct {
+	pRepo TransferProposalRepo
+	clock clock.Clock
+}
+
+func NewTransferProposalExpiryJob(pr TransferProposalRepo, clk clock.Clock) *TransferProposalExpiryJob {
+	return &TransferProposalExpiryJob{pRepo: pr, clock: clk}
+}
+
+// Run calls RunOnce every interval until stop is closed or ctx is cancelled, as runEvery does.
+func (j *TransferProposalExpiryJob) Run(ctx context.Context, stop <-chan struct{}, interval time.Duration) {
+	runEvery(ctx, stop, interval, "Transfer proposal expiry", j.RunOnce)
+}
+
+// RunOnce expires every pending proposal past its expiry.
+func (j *TransferProposalExpiryJob) RunOnce(ctx context.Context) error {
+	n, err := j.pRepo.ExpireTransferProposals(ctx, j.clock.Now())
+	if err != nil {
+		return fmt.Errorf("service.RunOnce: %w", err)
+	}
+	if n > 0 {
+		zerolog.Ctx(ctx).Info().Int("expired", n).Msg("Expired transfer proposals")
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+func TestApprovalPolicy_RequiresApproval(t *testing.T) {
+	policy := model.ApprovalPolicy{Threshold: dec("1000"), RequiredApprovals: 2}
+
+	assert.False(t, policy.RequiresApproval(dec("999.99")))
+	assert.False(t, policy.RequiresApproval(dec("1000")))
+	assert.True(t, policy.RequiresApproval(dec("1000.01")))
+}
+
+func TestTransferProposalServiceImpl_SetApprovalPolicy(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	tests := []struct {
+		name    string
+		req     model.ApprovalPolicyRequest
+		wantTTL int
+		repoErr error
+		wantErr error
+	}{
+		{name: "success - default ttl", req: model.ApprovalPolicyRequest{Threshold: dec("1000"), RequiredApprovals: 2}, wantTTL: service.DefaultProposalTTLHours},
+		{name: "success - zero threshold", req: model.ApprovalPolicyRequest{Threshold: dec("0"), RequiredApprovals: 1, ProposalTTLHours: 24}, wantTTL: 24},
+		{name: "error - too few owners", req: model.ApprovalPolicyRequest{Threshold: dec("1000"), RequiredApprovals: 3}, wantTTL: service.DefaultProposalTTLHours, repoErr: repo.ErrTooFewApprovers, wantErr: repo.ErrTooFewApprovers},
+		{name: "error - not an owner", req: model.ApprovalPolicyRequest{Threshold: dec("1000"), RequiredApprovals: 1}, wantTTL: service.DefaultProposalTTLHours, repoErr: repo.ErrWalletForbidden, wantErr: repo.ErrWalletForbidden},
+		{name: "error - negative threshold", req: model.ApprovalPolicyRequest{Threshold: dec("-1"), RequiredApprovals: 1}, wantErr: service.ErrInvalidTransferProposal},
+		{name: "error - no approvals", req: model.ApprovalPolicyRequest{Threshold: dec("1000"), RequiredApprovals: 0}, wantErr: service.ErrInvalidTransferProposal},
+		{name: "error - negative ttl", req: model.ApprovalPolicyRequest{Threshold: dec("1000"), RequiredApprovals: 1, ProposalTTLHours: -1}, wantErr: service.ErrInvalidTransferProposal},
+		{name: "error - ttl too long", req: model.ApprovalPolicyRequest{Threshold: dec("1000"), RequiredApprovals: 1, ProposalTTLHours: service.MaxProposalTTLHours + 1}, wantErr: service.ErrInvalidTransferProposal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.TransferProposalRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				m.On("SetApprovalPolicy", mock.Anything, testUser1UUIDString, mock.MatchedBy(func(p *model.ApprovalPolicy) bool {
+					return p.WalletID == testWallet1UUID && p.Threshold.Equal(tt.req.Threshold) &&
+						p.RequiredApprovals == tt.req.RequiredApprovals && p.ProposalTTLHours == tt.wantTTL
+				})).Return(tt.repoErr)
+			}
+			ps := service.NewTransferProposalImpl(m, nil, clock.NewFake(now))
+
+			got, err := ps.SetApprovalPolicy(context.Background(), testUser1UUIDString, testWallet1UUIDString, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantTTL, got.ProposalTTLHours)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransferProposalServiceImpl_ProposeTransfer(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	fee := model.Fee{Amount: dec("2.5"), WalletID: testFeeWalletUUID, ScheduleID: ptr(uuid.New())}
+
+	tests := []struct {
+		name        string
+		destination string
+		amount      string
+		fees        bool
+		repoErr     error
+		wantErr     error
+		wantFee     string
+	}{
+		{name: "success - no fees", destination: testWallet2UUID.String(), amount: "5000", wantFee: "0"},
+		{name: "success - fee quoted", destination: testWallet2UUID.String(), amount: "5000", fees: true, wantFee: "2.5"},
+		{name: "error - no policy", destination: testWallet2UUID.String(), amount: "5000", wantFee: "0", repoErr: repo.ErrApprovalPolicyNotFound, wantErr: repo.ErrApprovalPolicyNotFound},
+		{name: "error - funds cannot be held", destination: testWallet2UUID.String(), amount: "5000", wantFee: "0", repoErr: repo.ErrInsufficientFunds, wantErr: repo.ErrInsufficientFunds},
+		{name: "error - same wallet", destination: testWallet1UUIDString, amount: "5000", wantErr: service.ErrInvalidTransferProposal},
+		{name: "error - malformed destination", destination: "nope", amount: "5000", wantErr: service.ErrInvalidTransferProposal},
+		{name: "error - amount not positive", destination: testWallet2UUID.String(), amount: "0", wantErr: service.ErrInvalidTransferProposal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.TransferProposalRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				m.On("CreateTransferProposal", mock.Anything, testUser1UUIDString, mock.MatchedBy(func(p *model.TransferProposal) bool {
+					return p.WalletID == testWallet1UUID && p.DestinationWalletID == testWallet2UUID &&
+						p.Amount.Equal(dec(tt.amount)) && p.Fee.Equal(dec(tt.wantFee)) && p.CreatedAt.Equal(now)
+				})).Return(tt.repoErr)
+			}
+			var fc service.FeeCalculator
+			if tt.fees {
+				fcm := new(walletmocks.FeeCalculatorMock)
+				fcm.On("Fee", mock.Anything, model.FeeOperationTransfer, dec(tt.amount)).Return(fee, nil)
+				fc = fcm
+			}
+			ps := service.NewTransferProposalImpl(m, fc, clock.NewFake(now))
+
+			got, err := ps.ProposeTransfer(context.Background(), testUser1UUIDString, testWallet1UUIDString, tt.destination, dec(tt.amount))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				if tt.fees {
+					assert.Equal(t, &fee.WalletID, got.FeeWalletID)
+					assert.Equal(t, fee.ScheduleID, got.FeeScheduleID)
+				} else {
+					assert.Nil(t, got.FeeWalletID)
+				}
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransferProposalServiceImpl_ListTransferProposals_ReportsExpiry(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	m := new(walletmocks.TransferProposalRepoMock)
+	m.On("ListTransferProposals", mock.Anything, testUser1UUIDString, testWallet1UUIDString, model.TransferProposalStatus("")).
+		Return([]model.TransferProposal{
+			{ID: uuid.New(), Status: model.TransferProposalStatusPending, ExpiresAt: now.Add(time.Hour)},
+			{ID: uuid.New(), Status: model.TransferProposalStatusPending, ExpiresAt: now},
+			{ID: uuid.New(), Status: model.TransferProposalStatusExecuted, ExpiresAt: now.Add(-time.Hour)},
+		}, nil)
+	ps := service.NewTransferProposalImpl(m, nil, clock.NewFake(now))
+
+	got, err := ps.ListTransferProposals(context.Background(), testUser1UUIDString, testWallet1UUIDString, "")
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, model.TransferProposalStatusPending, got[0].Status)
+	assert.Equal(t, model.TransferProposalStatusExpired, got[1].Status)
+	assert.Equal(t, model.TransferProposalStatusExecuted, got[2].Status)
+}
+
+func TestTransferProposalServiceImpl_ApproveTransferProposal(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+	proposalID := uuid.New()
+
+	tests := []struct {
+		name    string
+		repoErr error
+	}{
+		{name: "success"},
+		{name: "error - own proposal", repoErr: repo.ErrSelfApproval},
+		{name: "error - approved twice", repoErr: repo.ErrAlreadyApproved},
+		{name: "error - expired", repoErr: repo.ErrTransferProposalExpired},
+		{name: "error - not an owner", repoErr: repo.ErrWalletForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.TransferProposalRepoMock)
+			var proposal *model.TransferProposal
+			if tt.repoErr == nil {
+				proposal = &model.TransferProposal{ID: proposalID, Status: model.TransferProposalStatusExecuted}
+			}
+			m.On("ApproveTransferProposal", mock.Anything, testUser2UUID.String(), testWallet1UUIDString, proposalID.String(), now).
+				Return(proposal, tt.repoErr)
+			ps := service.NewTransferProposalImpl(m, nil, clock.NewFake(now))
+
+			got, err := ps.ApproveTransferProposal(context.Background(), testUser2UUID.String(), testWallet1UUIDString, proposalID.String())
+			if tt.repoErr != nil {
+				assert.ErrorIs(t, err, tt.repoErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, model.TransferProposalStatusExecuted, got.Status)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransferProposalExpiryJob_RunOnce(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	m := new(walletmocks.TransferProposalRepoMock)
+	m.On("ExpireTransferProposals", mock.Anything, now).Return(2, nil).Once()
+	require.NoError(t, service.NewTransferProposalExpiryJob(m, clock.NewFake(now)).RunOnce(context.Background()))
+
+	dbErr := errors.New("connection reset")
+	m.On("ExpireTransferProposals", mock.Anything, now).Return(0, dbErr).Once()
+	assert.ErrorIs(t, service.NewTransferProposalExpiryJob(m, clock.NewFake(now)).RunOnce(context.Background()), dbErr)
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+)
+
+var (
+	// ErrInvalidHandle indicates that a handle does not follow the allowed format.
+	ErrInvalidHandle = errors.New("handle must be 3-30 characters of a-z, 0-9, '_' or '.', starting with a letter or digit")
+	// ErrInvalidRecipient indicates that a recipient was not identified by exactly one email or handle.
+	ErrInvalidRecipient = errors.New("exactly one of email or handle must be given")
+)
+
+var handlePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.]{2,29}$`)
+
+type UserRepo interface {
+	FindRecipientByEmail(ctx context.Context, email string) (*model.Recipient, error)
+	FindRecipientByHandle(ctx context.Context, handle string) (*model.Recipient, error)
+	SetHandle(ctx context.Context, userIDStr string, handle string) (*model.User, error)
+	SetDefaultWallet(ctx context.Context, userIDStr string, walletIDStr string) error
+}
+
+type UserServiceImpl struct {
+	uRepo UserRepo
+}
+
+func NewUserImpl(ur UserRepo) *UserServiceImpl {
+	return &UserServiceImpl{ur}
+}
+
+// LookupRecipient finds the user behind an email or handle and returns only their masked name,
+// so a sender can check who they are about to pay without learning who else uses the service.
+func (us *UserServiceImpl) LookupRecipient(ctx context.Context, email, handle string) (*model.RecipientPreview, error) {
+	recipient, err := us.findRecipient(ctx, email, handle)
+	if err != nil {
+		return nil, fmt.Errorf("service.LookupRecipient: %w", err)
+	}
+	return &model.RecipientPreview{DisplayName: MaskName(recipient.Name), Handle: recipient.Handle}, nil
+}
+
+// ResolveRecipientWallet returns the ID of the wallet that a transfer addressed to an email or handle is paid into.
+func (us *UserServiceImpl) ResolveRecipientWallet(ctx context.Context, email, handle string) (string, error) {
+	recipient, err := us.findRecipient(ctx, email, handle)
+	if err != nil {
+		return "", fmt.Errorf("service.ResolveRecipientWallet: %w", err)
+	}
+	if recipient.WalletID == nil {
+		return "", fmt.Errorf("service.ResolveRecipientWallet: recipient has no wallet: %w", repo.ErrWalletNotFound)
+	}
+	return recipient.WalletID.String(), nil
+}
+
+func (us *UserServiceImpl) findRecipient(ctx context.Context, email, handle string) (*model.Recipient, error) {
+	email = strings.TrimSpace(email)
+	handle = strings.TrimPrefix(strings.TrimSpace(handle), "@")
+	switch {
+	case email != "" && handle == "":
+		return us.uRepo.FindRecipientByEmail(ctx, email)
+	case handle != "" && email == "":
+		return us.uRepo.FindRecipientByHandle(ctx, handle)
+	}
+	return nil, ErrInvalidRecipient
+}
+
+// SetHandle claims a handle for the user. A leading "@" is ignored and the handle is stored in lower case.
+func (us *UserServiceImpl) SetHandle(ctx context.Context, userId, handle string) (*model.User, error) {
+	handle = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+	if !handlePattern.MatchString(handle) {
+		return nil, ErrInvalidHandle
+	}
+	user, err := us.uRepo.SetHandle(ctx, userId, handle)
+	if err != nil {
+		return nil, fmt.Errorf("service.SetHandle: %w", err)
+	}
+	return user, nil
+}
+
+// SetDefaultWallet chooses the wallet that transfers addressed to the user by email or handle are paid into.
+func (us *UserServiceImpl) SetDefaultWallet(ctx context.Context, userId, walletId string) error {
+	if err := us.uRepo.SetDefaultWallet(ctx, userId, walletId); err != nil {
+		return fmt.Errorf("service.SetDefaultWallet: %w", err)
+	}
+	return nil
+}
+
+// MaskName keeps the first letter of each word of a name and replaces the rest with asterisks,
+// e.g. "Alice Smith" becomes "A**** S****".
+func MaskName(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		first, size := utf8.DecodeRuneInString(word)
+		words[i] = string(first) + strings.Repeat("*", utf8.RuneCountInString(word[size:]))
+	}
+	return strings.Join(words, " ")
+}
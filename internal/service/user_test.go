@@ -0,0 +1,161 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+)
+
+func TestMaskName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Alice Smith", want: "A**** S****"},
+		{name: "  Bob  ", want: "B**"},
+		{name: "Zoë Ågren-Lind", want: "Z** Å*********"},
+		{name: "X", want: "X"},
+		{name: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, service.MaskName(tt.name))
+		})
+	}
+}
+
+func TestUserServiceImpl_LookupRecipient(t *testing.T) {
+	handle := "alice"
+	alice := &model.Recipient{UserID: testUser1UUID, Name: "Alice Smith", Handle: &handle, WalletID: &testWallet1UUID}
+
+	tests := []struct {
+		name    string
+		email   string
+		handle  string
+		setup   func(m *walletmocks.UserRepoMock)
+		want    *model.RecipientPreview
+		wantErr error
+	}{
+		{
+			name:  "success - by email",
+			email: " alice@example.com ",
+			setup: func(m *walletmocks.UserRepoMock) {
+				m.On("FindRecipientByEmail", mock.Anything, "alice@example.com").Return(alice, nil)
+			},
+			want: &model.RecipientPreview{DisplayName: "A**** S****", Handle: &handle},
+		},
+		{
+			name:   "success - by handle with @",
+			handle: "@alice",
+			setup: func(m *walletmocks.UserRepoMock) {
+				m.On("FindRecipientByHandle", mock.Anything, "alice").Return(alice, nil)
+			},
+			want: &model.RecipientPreview{DisplayName: "A**** S****", Handle: &handle},
+		},
+		{
+			name:   "error - not found",
+			handle: "nobody",
+			setup: func(m *walletmocks.UserRepoMock) {
+				m.On("FindRecipientByHandle", mock.Anything, "nobody").Return(nil, repo.ErrRecipientNotFound)
+			},
+			wantErr: repo.ErrRecipientNotFound,
+		},
+		{
+			name:    "error - both email and handle",
+			email:   "alice@example.com",
+			handle:  "alice",
+			wantErr: service.ErrInvalidRecipient,
+		},
+		{
+			name:    "error - neither email nor handle",
+			wantErr: service.ErrInvalidRecipient,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.UserRepoMock)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			us := service.NewUserImpl(m)
+
+			got, err := us.LookupRecipient(context.Background(), tt.email, tt.handle)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserServiceImpl_ResolveRecipientWallet(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := new(walletmocks.UserRepoMock)
+		m.On("FindRecipientByEmail", mock.Anything, "alice@example.com").
+			Return(&model.Recipient{UserID: testUser1UUID, Name: "Alice Smith", WalletID: &testWallet1UUID}, nil)
+
+		got, err := service.NewUserImpl(m).ResolveRecipientWallet(context.Background(), "alice@example.com", "")
+		require.NoError(t, err)
+		assert.Equal(t, testWallet1UUIDString, got)
+	})
+
+	t.Run("error - recipient has no wallet", func(t *testing.T) {
+		m := new(walletmocks.UserRepoMock)
+		m.On("FindRecipientByHandle", mock.Anything, "alice").
+			Return(&model.Recipient{UserID: testUser1UUID, Name: "Alice Smith"}, nil)
+
+		got, err := service.NewUserImpl(m).ResolveRecipientWallet(context.Background(), "", "alice")
+		assert.ErrorIs(t, err, repo.ErrWalletNotFound)
+		assert.Empty(t, got)
+	})
+}
+
+func TestUserServiceImpl_SetHandle(t *testing.T) {
+	tests := []struct {
+		name       string
+		handle     string
+		wantStored string
+		repoErr    error
+		wantErr    error
+	}{
+		{name: "success - normalised", handle: " @Alice.Smith ", wantStored: "alice.smith"},
+		{name: "error - taken", handle: "alice", wantStored: "alice", repoErr: repo.ErrHandleTaken, wantErr: repo.ErrHandleTaken},
+		{name: "error - too short", handle: "al", wantErr: service.ErrInvalidHandle},
+		{name: "error - bad characters", handle: "alice smith", wantErr: service.ErrInvalidHandle},
+		{name: "error - leading underscore", handle: "_alice", wantErr: service.ErrInvalidHandle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.UserRepoMock)
+			if tt.wantStored != "" {
+				var user *model.User
+				if tt.repoErr == nil {
+					user = &model.User{ID: testUser1UUID, Handle: &tt.wantStored}
+				}
+				m.On("SetHandle", mock.Anything, testUser1UUIDString, tt.wantStored).Return(user, tt.repoErr)
+			}
+
+			got, err := service.NewUserImpl(m).SetHandle(context.Background(), testUser1UUIDString, tt.handle)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantStored, *got.Handle)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
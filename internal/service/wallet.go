@@ -2,25 +2,36 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/kylenguyen/wallet-app/internal/model"
 	"github.com/shopspring/decimal"
 )
 
+// ErrInvalidAmount indicates a deposit, withdrawal or transfer of an amount that is not positive.
+var ErrInvalidAmount = errors.New("invalid amount")
+
 type WalletRepo interface {
 	GetWalletInfo(ctx context.Context, userId string, walletId string) (*model.Wallet, error)
 	GetTransactionsByWalletID(ctx context.Context, userIDStr string, walletIDStr string) ([]model.Transaction, error)
 	Deposit(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal) (*model.Transaction, error)
-	Withdraw(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal) (*model.Transaction, error)
-	Transfer(ctx context.Context, sourceUserIDStr string, sourceWalletIDStr string, destinationWalletIDStr string, amount decimal.Decimal) (*model.Transaction, error)
+	Withdraw(ctx context.Context, userIDStr string, walletIDStr string, amount decimal.Decimal, fee model.Fee) (*model.Transaction, error)
+	Transfer(ctx context.Context, sourceUserIDStr string, sourceWalletIDStr string, destinationWalletIDStr string, amount decimal.Decimal, fee model.Fee) (*model.Transaction, error)
 }
 
 type WalletServiceImpl struct {
 	wRepo WalletRepo
+	fees  FeeCalculator
+}
+
+// NewWalletImpl creates the wallet service. With a nil FeeCalculator no fees are charged.
+func NewWalletImpl(wr WalletRepo, fc FeeCalculator) *WalletServiceImpl {
+	return &WalletServiceImpl{wr, fc}
 }
 
-func NewWalletImpl(wr WalletRepo) *WalletServiceImpl {
-	return &WalletServiceImpl{wr}
+// fee works out the fee for an operation, or a zero fee when the service charges none.
+func (ws *WalletServiceImpl) fee(ctx context.Context, operation model.FeeOperation, amount decimal.Decimal) (model.Fee, error) {
+	return feeOrZero(ctx, ws.fees, operation, amount)
 }
 
 func (ws *WalletServiceImpl) GetWalletInfo(ctx context.Context, userId, walletId string) (*model.Wallet, error) {
@@ -37,7 +48,7 @@ func (ws *WalletServiceImpl) GetWalletTransactionsByWalletID(ctx context.Context
 
 func (ws *WalletServiceImpl) Deposit(ctx context.Context, userId, walletId string, amount decimal.Decimal) (*model.Transaction, error) {
 	if amount.LessThanOrEqual(decimal.Zero) {
-		return nil, fmt.Errorf("deposit amount must be positive")
+		return nil, fmt.Errorf("%w: deposit amount must be positive", ErrInvalidAmount)
 	}
 	transaction, err := ws.wRepo.Deposit(ctx, userId, walletId, amount)
 	if err != nil {
@@ -48,9 +59,13 @@ func (ws *WalletServiceImpl) Deposit(ctx context.Context, userId, walletId strin
 
 func (ws *WalletServiceImpl) Withdraw(ctx context.Context, userId, walletId string, amount decimal.Decimal) (*model.Transaction, error) {
 	if amount.LessThanOrEqual(decimal.Zero) {
-		return nil, fmt.Errorf("withdrawal amount must be positive")
+		return nil, fmt.Errorf("%w: withdrawal amount must be positive", ErrInvalidAmount)
 	}
-	transaction, err := ws.wRepo.Withdraw(ctx, userId, walletId, amount)
+	fee, err := ws.fee(ctx, model.FeeOperationWithdrawal, amount)
+	if err != nil {
+		return nil, fmt.Errorf("service.Withdraw: %w", err)
+	}
+	transaction, err := ws.wRepo.Withdraw(ctx, userId, walletId, amount, fee)
 	if err != nil {
 		return nil, fmt.Errorf("service.Withdraw: %w", err)
 	}
@@ -59,12 +74,16 @@ func (ws *WalletServiceImpl) Withdraw(ctx context.Context, userId, walletId stri
 
 func (ws *WalletServiceImpl) Transfer(ctx context.Context, sourceUserId, sourceWalletId, destinationWalletId string, amount decimal.Decimal) (*model.Transaction, error) {
 	if amount.LessThanOrEqual(decimal.Zero) {
-		return nil, fmt.Errorf("transfer amount must be positive")
+		return nil, fmt.Errorf("%w: transfer amount must be positive", ErrInvalidAmount)
 	}
 	if sourceWalletId == destinationWalletId {
 		return nil, fmt.Errorf("source and destination wallets cannot be the same")
 	}
-	transaction, err := ws.wRepo.Transfer(ctx, sourceUserId, sourceWalletId, destinationWalletId, amount)
+	fee, err := ws.fee(ctx, model.FeeOperationTransfer, amount)
+	if err != nil {
+		return nil, fmt.Errorf("service.Transfer: %w", err)
+	}
+	transaction, err := ws.wRepo.Transfer(ctx, sourceUserId, sourceWalletId, destinationWalletId, amount, fee)
 	if err != nil {
 		return nil, fmt.Errorf("service.Transfer: %w", err)
 	}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+// ErrInvalidWalletMember indicates that an invitation or a role change was rejected during validation.
+var ErrInvalidWalletMember = errors.New("invalid wallet member")
+
+type WalletMemberRepo interface {
+	ListWalletMembers(ctx context.Context, userID string, walletID string) ([]model.WalletMember, error)
+	InviteWalletMember(ctx context.Context, userID string, member *model.WalletMember) error
+	UpdateWalletMemberRole(ctx context.Context, userID string, walletID string, memberID string, role model.WalletRole, at time.Time) (*model.WalletMember, error)
+	RemoveWalletMember(ctx context.Context, userID string, walletID string, memberID string) error
+	ListWalletInvitations(ctx context.Context, userID string) ([]model.WalletMember, error)
+	AcceptWalletInvitation(ctx context.Context, userID string, walletID string, at time.Time) (*model.WalletMember, error)
+}
+
+type WalletMemberServiceImpl struct {
+	mRepo WalletMemberRepo
+	clock clock.Clock
+}
+
+func NewWalletMemberImpl(mr WalletMemberRepo, clk clock.Clock) *WalletMemberServiceImpl {
+	return &WalletMemberServiceImpl{mRepo: mr, clock: clk}
+}
+
+func (ms *WalletMemberServiceImpl) ListWalletMembers(ctx context.Context, userId, walletId string) ([]model.WalletMember, error) {
+	members, err := ms.mRepo.ListWalletMembers(ctx, userId, walletId)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListWalletMembers: %w", err)
+	}
+	return members, nil
+}
+
+// InviteWalletMember invites another user to the wallet. The invitation grants nothing until the user accepts it.
+func (ms *WalletMemberServiceImpl) InviteWalletMember(ctx context.Context, userId, walletId string, req model.WalletMemberRequest) (*model.WalletMember, error) {
+	walletID, err := uuid.Parse(walletId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet ID format: %w", err)
+	}
+	memberID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: user_id must be a valid UUID", ErrInvalidWalletMember)
+	}
+	if memberID.String() == userId {
+		return nil, fmt.Errorf("%w: cannot invite yourself", ErrInvalidWalletMember)
+	}
+	if !req.Role.IsValid() {
+		return nil, fmt.Errorf("%w: role must be one of owner, spender or viewer", ErrInvalidWalletMember)
+	}
+
+	member := &model.WalletMember{
+		WalletID:  walletID,
+		UserID:    memberID,
+		Role:      req.Role,
+		CreatedAt: ms.clock.Now().UTC(),
+	}
+	if err = ms.mRepo.InviteWalletMember(ctx, userId, member); err != nil {
+		return nil, fmt.Errorf("service.InviteWalletMember: %w", err)
+	}
+	return member, nil
+}
+
+func (ms *WalletMemberServiceImpl) UpdateWalletMemberRole(ctx context.Context, userId, walletId, memberId string, req model.WalletRoleRequest) (*model.WalletMember, error) {
+	if !req.Role.IsValid() {
+		return nil, fmt.Errorf("%w: role must be one of owner, spender or viewer", ErrInvalidWalletMember)
+	}
+	member, err := ms.mRepo.UpdateWalletMemberRole(ctx, userId, walletId, memberId, req.Role, ms.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("service.UpdateWalletMemberRole: %w", err)
+	}
+	return member, nil
+}
+
+func (ms *WalletMemberServiceImpl) RemoveWalletMember(ctx context.Context, userId, walletId, memberId string) error {
+	if err := ms.mRepo.RemoveWalletMember(ctx, userId, walletId, memberId); err != nil {
+		return fmt.Errorf("service.RemoveWalletMember: %w", err)
+	}
+	return nil
+}
+
+func (ms *WalletMemberServiceImpl) ListWalletInvitations(ctx context.Context, userId string) ([]model.WalletMember, error) {
+	invitations, err := ms.mRepo.ListWalletInvitations(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListWalletInvitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (ms *WalletMemberServiceImpl) AcceptWalletInvitation(ctx context.Context, userId, walletId string) (*model.WalletMember, error) {
+	member, err := ms.mRepo.AcceptWalletInvitation(ctx, userId, walletId, ms.clock.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("service.AcceptWalletInvitation: %w", err)
+	}
+	return member, nil
+}
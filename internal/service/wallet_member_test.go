@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/internal/model"
+	"github.com/kylenguyen/wallet-app/internal/repo"
+	"github.com/kylenguyen/wallet-app/internal/service"
+	walletmocks "github.com/kylenguyen/wallet-app/internal/service/mocks"
+	"github.com/kylenguyen/wallet-app/pkg/clock"
+)
+
+func TestWalletRole_Allows(t *testing.T) {
+	tests := []struct {
+		role model.WalletRole
+		min  model.WalletRole
+		want bool
+	}{
+		{role: model.WalletRoleOwner, min: model.WalletRoleOwner, want: true},
+		{role: model.WalletRoleOwner, min: model.WalletRoleViewer, want: true},
+		{role: model.WalletRoleSpender, min: model.WalletRoleSpender, want: true},
+		{role: model.WalletRoleSpender, min: model.WalletRoleOwner, want: false},
+		{role: model.WalletRoleViewer, min: model.WalletRoleViewer, want: true},
+		{role: model.WalletRoleViewer, min: model.WalletRoleSpender, want: false},
+		{role: model.WalletRole("admin"), min: model.WalletRoleViewer, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"/"+string(tt.min), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.role.Allows(tt.min))
+		})
+	}
+}
+
+func TestWalletMemberServiceImpl_InviteWalletMember(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	tests := []struct {
+		name    string
+		req     model.WalletMemberRequest
+		repoErr error
+		wantErr error
+	}{
+		{name: "success", req: model.WalletMemberRequest{UserID: testUser2UUID.String(), Role: model.WalletRoleSpender}},
+		{name: "error - already a member", req: model.WalletMemberRequest{UserID: testUser2UUID.String(), Role: model.WalletRoleViewer}, repoErr: repo.ErrWalletMemberExists, wantErr: repo.ErrWalletMemberExists},
+		{name: "error - not an owner", req: model.WalletMemberRequest{UserID: testUser2UUID.String(), Role: model.WalletRoleViewer}, repoErr: repo.ErrWalletForbidden, wantErr: repo.ErrWalletForbidden},
+		{name: "error - unknown user", req: model.WalletMemberRequest{UserID: testUser2UUID.String(), Role: model.WalletRoleOwner}, repoErr: repo.ErrUserNotFound, wantErr: repo.ErrUserNotFound},
+		{name: "error - invalid role", req: model.WalletMemberRequest{UserID: testUser2UUID.String(), Role: "admin"}, wantErr: service.ErrInvalidWalletMember},
+		{name: "error - malformed user id", req: model.WalletMemberRequest{UserID: "bob", Role: model.WalletRoleViewer}, wantErr: service.ErrInvalidWalletMember},
+		{name: "error - inviting yourself", req: model.WalletMemberRequest{UserID: testUser1UUIDString, Role: model.WalletRoleViewer}, wantErr: service.ErrInvalidWalletMember},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.WalletMemberRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				m.On("InviteWalletMember", mock.Anything, testUser1UUIDString, mock.MatchedBy(func(wm *model.WalletMember) bool {
+					return wm.WalletID == testWallet1UUID && wm.UserID == testUser2UUID && wm.Role == tt.req.Role && wm.CreatedAt.Equal(now)
+				})).Return(tt.repoErr)
+			}
+			ms := service.NewWalletMemberImpl(m, clock.NewFake(now))
+
+			got, err := ms.InviteWalletMember(context.Background(), testUser1UUIDString, testWallet1UUIDString, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testUser2UUID, got.UserID)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWalletMemberServiceImpl_UpdateWalletMemberRole(t *testing.T) {
+	now := mustTime("2025-06-15T12:00:00Z")
+
+	tests := []struct {
+		name    string
+		role    model.WalletRole
+		repoErr error
+		wantErr error
+	}{
+		{name: "success", role: model.WalletRoleViewer},
+		{name: "error - last owner", role: model.WalletRoleSpender, repoErr: repo.ErrLastWalletOwner, wantErr: repo.ErrLastWalletOwner},
+		{name: "error - not a member", role: model.WalletRoleOwner, repoErr: repo.ErrWalletMemberNotFound, wantErr: repo.ErrWalletMemberNotFound},
+		{name: "error - invalid role", role: "", wantErr: service.ErrInvalidWalletMember},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := new(walletmocks.WalletMemberRepoMock)
+			if tt.wantErr == nil || tt.repoErr != nil {
+				var member *model.WalletMember
+				if tt.repoErr == nil {
+					member = &model.WalletMember{WalletID: testWallet1UUID, UserID: testUser2UUID, Role: tt.role, Status: model.WalletMemberStatusActive}
+				}
+				m.On("UpdateWalletMemberRole", mock.Anything, testUser1UUIDString, testWallet1UUIDString, testUser2UUID.String(), tt.role, now).
+					Return(member, tt.repoErr)
+			}
+			ms := service.NewWalletMemberImpl(m, clock.NewFake(now))
+
+			got, err := ms.UpdateWalletMemberRole(context.Background(), testUser1UUIDString, testWallet1UUIDString, testUser2UUID.String(), model.WalletRoleRequest{Role: tt.role})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.role, got.Role)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -76,7 +76,7 @@ func TestWalletServiceImpl_GetWalletInfo(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			wRepo := tt.fields.wRepo().(*walletmocks.WalletRepoMock)
-			ws := service.NewWalletImpl(wRepo)
+			ws := service.NewWalletImpl(wRepo, nil)
 			got, err := ws.GetWalletInfo(tt.args.ctx, tt.args.userId, tt.args.walletId)
 			tt.wantErr(t, err)
 			assert.Equal(t, tt.want, got)
@@ -0,0 +1,7 @@
+DROP TABLE transactions;
+DROP TABLE wallets;
+DROP TABLE users;
+
+DROP FUNCTION trigger_set_timestamp();
+
+DROP TYPE transaction_type;
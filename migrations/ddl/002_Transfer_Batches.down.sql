@@ -0,0 +1,6 @@
+DROP TABLE transfer_batch_items;
+DROP TABLE transfer_batches;
+
+DROP TYPE transfer_batch_item_status;
+DROP TYPE transfer_batch_status;
+DROP TYPE transfer_batch_mode;
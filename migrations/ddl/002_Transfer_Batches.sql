@@ -0,0 +1,60 @@
+-- =================================================================
+--  Bulk transfer batches
+-- =================================================================
+
+CREATE TYPE transfer_batch_mode AS ENUM (
+    'all_or_nothing',
+    'best_effort'
+);
+
+CREATE TYPE transfer_batch_status AS ENUM (
+    'pending',
+    'running',
+    'completed',
+    'failed'
+);
+
+CREATE TYPE transfer_batch_item_status AS ENUM (
+    'pending',
+    'succeeded',
+    'failed',
+    'skipped'
+);
+
+-- A batch pays many destination wallets from a single source wallet.
+CREATE TABLE transfer_batches (
+                                  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                  user_id UUID NOT NULL REFERENCES users(id),
+                                  source_wallet_id UUID NOT NULL REFERENCES wallets(id),
+                                  mode transfer_batch_mode NOT NULL,
+                                  status transfer_batch_status NOT NULL DEFAULT 'pending',
+                                  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One row of a batch. transaction_id is set once the transfer has been committed.
+CREATE TABLE transfer_batch_items (
+                                      id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                      batch_id UUID NOT NULL REFERENCES transfer_batches(id) ON DELETE CASCADE,
+                                      row_number INT NOT NULL,
+                                      destination_wallet_id UUID NOT NULL REFERENCES wallets(id),
+                                      amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+                                      reference VARCHAR(140) NOT NULL DEFAULT '',
+                                      status transfer_batch_item_status NOT NULL DEFAULT 'pending',
+                                      error TEXT NULL,
+                                      transaction_id UUID NULL REFERENCES transactions(id),
+                                      updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                      UNIQUE (batch_id, row_number)
+);
+
+CREATE INDEX idx_transfer_batches_source_wallet_id ON transfer_batches(source_wallet_id);
+
+CREATE TRIGGER set_transfer_batches_updated_at
+    BEFORE UPDATE ON transfer_batches
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_transfer_batch_items_updated_at
+    BEFORE UPDATE ON transfer_batch_items
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,5 @@
+DROP TABLE scheduled_transfer_runs;
+DROP TABLE scheduled_transfers;
+
+DROP TYPE scheduled_transfer_run_status;
+DROP TYPE scheduled_transfer_status;
@@ -0,0 +1,64 @@
+-- =================================================================
+--  Scheduled and recurring transfers (standing orders)
+-- =================================================================
+
+CREATE TYPE scheduled_transfer_status AS ENUM (
+    'active',
+    'cancelled',
+    'completed'
+);
+
+CREATE TYPE scheduled_transfer_run_status AS ENUM (
+    'running',
+    'retrying',
+    'succeeded',
+    'failed'
+);
+
+-- A standing order from a wallet owned by user_id to any destination wallet.
+-- expression is a 5-field cron expression or an RRULE anchored at start_at;
+-- next_run_at is NULL once the schedule has no further occurrences.
+CREATE TABLE scheduled_transfers (
+                                     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                     user_id UUID NOT NULL REFERENCES users(id),
+                                     source_wallet_id UUID NOT NULL REFERENCES wallets(id),
+                                     destination_wallet_id UUID NOT NULL REFERENCES wallets(id),
+                                     amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+                                     expression VARCHAR(255) NOT NULL,
+                                     start_at TIMESTAMPTZ NOT NULL,
+                                     end_at TIMESTAMPTZ NULL,
+                                     next_run_at TIMESTAMPTZ NULL,
+                                     status scheduled_transfer_status NOT NULL DEFAULT 'active',
+                                     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                     CHECK (source_wallet_id <> destination_wallet_id)
+);
+
+-- One row per occurrence of a schedule. The unique key is what makes a run idempotent:
+-- an occurrence can only be claimed once, however many workers are polling.
+CREATE TABLE scheduled_transfer_runs (
+                                         id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                         schedule_id UUID NOT NULL REFERENCES scheduled_transfers(id) ON DELETE CASCADE,
+                                         scheduled_for TIMESTAMPTZ NOT NULL,
+                                         status scheduled_transfer_run_status NOT NULL,
+                                         attempts INT NOT NULL DEFAULT 0,
+                                         next_attempt_at TIMESTAMPTZ NULL,
+                                         transaction_id UUID NULL REFERENCES transactions(id),
+                                         error TEXT NULL,
+                                         created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                         updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                         UNIQUE (schedule_id, scheduled_for)
+);
+
+CREATE INDEX idx_scheduled_transfers_due ON scheduled_transfers(next_run_at) WHERE status = 'active';
+CREATE INDEX idx_scheduled_transfer_runs_retry ON scheduled_transfer_runs(next_attempt_at) WHERE status = 'retrying';
+
+CREATE TRIGGER set_scheduled_transfers_updated_at
+    BEFORE UPDATE ON scheduled_transfers
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TRIGGER set_scheduled_transfer_runs_updated_at
+    BEFORE UPDATE ON scheduled_transfer_runs
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
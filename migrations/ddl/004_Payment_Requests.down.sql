@@ -0,0 +1,3 @@
+DROP TABLE payment_requests;
+
+DROP TYPE payment_request_status;
@@ -0,0 +1,38 @@
+-- =================================================================
+--  Payment requests (asking another user for money)
+-- =================================================================
+
+CREATE TYPE payment_request_status AS ENUM (
+    'pending',
+    'accepted',
+    'declined',
+    'cancelled',
+    'expired'
+);
+
+-- A request from requester_user_id, to be paid into requester_wallet_id, addressed to payer_user_id.
+-- The payer chooses which of their wallets to pay from when accepting; payer_wallet_id and
+-- transaction_id are filled in at that point.
+CREATE TABLE payment_requests (
+                                  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                  requester_user_id UUID NOT NULL REFERENCES users(id),
+                                  requester_wallet_id UUID NOT NULL REFERENCES wallets(id),
+                                  payer_user_id UUID NOT NULL REFERENCES users(id),
+                                  payer_wallet_id UUID NULL REFERENCES wallets(id),
+                                  amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+                                  memo VARCHAR(140) NOT NULL DEFAULT '',
+                                  status payment_request_status NOT NULL DEFAULT 'pending',
+                                  expires_at TIMESTAMPTZ NOT NULL,
+                                  transaction_id UUID NULL REFERENCES transactions(id),
+                                  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                  CHECK (requester_user_id <> payer_user_id)
+);
+
+CREATE INDEX idx_payment_requests_payer ON payment_requests(payer_user_id, status);
+CREATE INDEX idx_payment_requests_requester ON payment_requests(requester_user_id, status);
+
+CREATE TRIGGER set_payment_requests_updated_at
+    BEFORE UPDATE ON payment_requests
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,6 @@
+DROP INDEX idx_users_email_lower;
+DROP INDEX idx_users_handle;
+
+ALTER TABLE users
+    DROP COLUMN default_wallet_id,
+    DROP COLUMN handle;
@@ -0,0 +1,18 @@
+-- =================================================================
+--  Addressing transfers by email or handle
+-- =================================================================
+
+-- handle is an optional public name users can be paid by. It is stored in lower case.
+-- default_wallet_id is the wallet transfers addressed to the user (rather than to a wallet) are paid into.
+ALTER TABLE users
+    ADD COLUMN handle VARCHAR(30) NULL,
+    ADD COLUMN default_wallet_id UUID NULL REFERENCES wallets(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX idx_users_handle ON users(lower(handle));
+
+-- Email lookups are case-insensitive.
+CREATE INDEX idx_users_email_lower ON users(lower(email));
+
+-- Existing users default to their oldest wallet.
+UPDATE users u
+SET default_wallet_id = (SELECT w.id FROM wallets w WHERE w.user_id = u.id ORDER BY w.created_at, w.id LIMIT 1);
@@ -0,0 +1,13 @@
+-- The 'fee' transaction type stays: Postgres cannot remove a value from an enum. Removing the fee wallet fails
+-- while transactions still reference it.
+DELETE FROM wallets WHERE id = '00000000-0000-0000-0000-0000000000fe';
+DELETE FROM users WHERE id = '00000000-0000-0000-0000-000000000001';
+
+ALTER TABLE transactions
+    DROP COLUMN parent_transaction_id,
+    DROP COLUMN fee_schedule_id;
+
+DROP TABLE fee_schedules;
+DROP FUNCTION trigger_fee_schedules_immutable();
+
+DROP TYPE fee_operation;
@@ -0,0 +1,53 @@
+-- =================================================================
+--  Fees on withdrawals and transfers
+-- =================================================================
+
+-- A fee is recorded like a transfer: one row on the paying wallet with related_wallet_id set to the fee wallet.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'fee';
+
+CREATE TYPE fee_operation AS ENUM (
+    'withdrawal',
+    'transfer'
+);
+
+-- Fee schedules are versioned and never changed in place: a new version takes over from effective_from on.
+-- rule is a model.FeeRule: {"flat", "percent", "tiers": [{"up_to", "flat", "percent"}], "min", "max"}.
+CREATE TABLE fee_schedules (
+                               id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                               operation fee_operation NOT NULL,
+                               currency CHAR(3) NOT NULL,
+                               version INT NOT NULL CHECK (version > 0),
+                               rule JSONB NOT NULL,
+                               effective_from TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                               created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                               UNIQUE (operation, currency, version)
+);
+
+CREATE OR REPLACE FUNCTION trigger_fee_schedules_immutable()
+    RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'fee schedules are immutable; insert a new version instead';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER fee_schedules_immutable
+    BEFORE UPDATE OR DELETE ON fee_schedules
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_fee_schedules_immutable();
+
+-- Explains every fee: which schedule computed it and which operation it was charged on.
+ALTER TABLE transactions
+    ADD COLUMN fee_schedule_id UUID NULL REFERENCES fee_schedules(id),
+    ADD COLUMN parent_transaction_id UUID NULL REFERENCES transactions(id);
+
+-- The house account fees are paid into. Its wallet ID is the FEE_WALLET_ID setting.
+INSERT INTO users (id, name, email)
+VALUES ('00000000-0000-0000-0000-000000000001', 'Wallet App', 'house@wallet-app.local');
+
+INSERT INTO wallets (id, user_id, name)
+VALUES ('00000000-0000-0000-0000-0000000000fe', '00000000-0000-0000-0000-000000000001', 'Fees');
+
+-- Version 1: withdrawals cost 1% (at least 0.50, at most 10.00); transfers are free up to 1,000 and 0.25% above.
+INSERT INTO fee_schedules (operation, currency, version, rule, effective_from)
+VALUES ('withdrawal', 'USD', 1, '{"flat": "0", "percent": "1", "min": "0.50", "max": "10.00"}', '2025-01-01T00:00:00Z'),
+       ('transfer', 'USD', 1, '{"flat": "0", "percent": "0", "tiers": [{"up_to": "1000", "flat": "0", "percent": "0"}, {"flat": "0", "percent": "0.25"}]}', '2025-01-01T00:00:00Z');
@@ -0,0 +1,8 @@
+-- The 'interest' transaction type stays: Postgres cannot remove a value from an enum.
+DROP TABLE interest_accruals;
+
+ALTER TABLE wallets
+    DROP COLUMN product_since,
+    DROP COLUMN product_id;
+
+DROP TABLE wallet_products;
@@ -0,0 +1,47 @@
+-- =================================================================
+--  Savings products and interest
+-- =================================================================
+
+-- An interest payout is a credit to the savings wallet, recorded like a deposit.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'interest';
+
+-- Products a wallet can be put on. A wallet with a product is a savings wallet earning apr percent a year.
+CREATE TABLE wallet_products (
+                                 id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                 code VARCHAR(50) UNIQUE NOT NULL,
+                                 name VARCHAR(255) NOT NULL,
+                                 apr DECIMAL(7, 4) NOT NULL CHECK (apr >= 0),
+                                 created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                 updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER set_wallet_products_updated_at
+    BEFORE UPDATE ON wallet_products
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+-- product_since is when the wallet was put on its product; interest accrues from that day on.
+ALTER TABLE wallets
+    ADD COLUMN product_id UUID NULL REFERENCES wallet_products(id),
+    ADD COLUMN product_since TIMESTAMPTZ NULL;
+
+-- Interest earned by a wallet each day, at full precision. The unique key makes accrual idempotent per day;
+-- transaction_id is set when the accrual is paid out in the monthly interest transaction.
+CREATE TABLE interest_accruals (
+                                   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                   wallet_id UUID NOT NULL REFERENCES wallets(id),
+                                   accrual_date DATE NOT NULL,
+                                   balance DECIMAL(19, 4) NOT NULL,
+                                   apr DECIMAL(7, 4) NOT NULL,
+                                   day_count VARCHAR(7) NOT NULL,
+                                   amount DECIMAL(28, 10) NOT NULL CHECK (amount >= 0),
+                                   transaction_id UUID NULL REFERENCES transactions(id),
+                                   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                   UNIQUE (wallet_id, accrual_date)
+);
+
+-- Index for the monthly payout, which only looks at accruals not paid yet.
+CREATE INDEX idx_interest_accruals_unpaid ON interest_accruals(wallet_id, accrual_date) WHERE transaction_id IS NULL;
+
+INSERT INTO wallet_products (code, name, apr)
+VALUES ('easy-saver', 'Easy Saver', 2.5000);
@@ -0,0 +1,9 @@
+DROP TABLE pot_movements;
+DROP TABLE pots;
+
+ALTER TABLE wallets
+    DROP CONSTRAINT wallets_pots_balance_check,
+    DROP COLUMN pots_balance;
+
+DROP TYPE pot_move_direction;
+DROP TYPE pot_status;
@@ -0,0 +1,50 @@
+-- =================================================================
+--  Pots: money ring-fenced inside a wallet
+-- =================================================================
+
+CREATE TYPE pot_status AS ENUM (
+    'open',
+    'closed'
+);
+
+CREATE TYPE pot_move_direction AS ENUM (
+    'in',
+    'out'
+);
+
+-- pots_balance is the part of balance held in the wallet's pots; only the rest can be spent.
+ALTER TABLE wallets
+    ADD COLUMN pots_balance DECIMAL(19, 4) NOT NULL DEFAULT 0.00,
+    ADD CONSTRAINT wallets_pots_balance_check CHECK (pots_balance >= 0 AND pots_balance <= balance);
+
+CREATE TABLE pots (
+                      id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                      wallet_id UUID NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+                      name VARCHAR(100) NOT NULL,
+                      balance DECIMAL(19, 4) NOT NULL DEFAULT 0.00 CHECK (balance >= 0),
+                      target_amount DECIMAL(19, 4) NULL CHECK (target_amount > 0),
+                      target_date DATE NULL,
+                      status pot_status NOT NULL DEFAULT 'open',
+                      created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                      updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                      CHECK (status = 'open' OR balance = 0)
+);
+
+-- Open pots of a wallet have distinct names.
+CREATE UNIQUE INDEX idx_pots_wallet_id_name ON pots(wallet_id, lower(name)) WHERE status = 'open';
+
+CREATE TRIGGER set_pots_updated_at
+    BEFORE UPDATE ON pots
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+-- History of moves between a wallet and its pots. They stay within the wallet, so they are not transactions.
+CREATE TABLE pot_movements (
+                               id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                               pot_id UUID NOT NULL REFERENCES pots(id) ON DELETE CASCADE,
+                               direction pot_move_direction NOT NULL,
+                               amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+                               created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_pot_movements_pot_id ON pot_movements(pot_id);
@@ -0,0 +1,10 @@
+ALTER TABLE transactions
+    DROP COLUMN initiated_by;
+
+DROP TRIGGER add_wallet_owner ON wallets;
+DROP FUNCTION trigger_add_wallet_owner();
+
+DROP TABLE wallet_members;
+
+DROP TYPE wallet_member_status;
+DROP TYPE wallet_role;
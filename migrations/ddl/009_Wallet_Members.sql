@@ -0,0 +1,62 @@
+-- =================================================================
+--  Shared wallets: members with roles
+-- =================================================================
+
+CREATE TYPE wallet_role AS ENUM (
+    'owner',
+    'spender',
+    'viewer'
+);
+
+CREATE TYPE wallet_member_status AS ENUM (
+    'invited',
+    'active'
+);
+
+-- Who may use a wallet and how. wallets.user_id stays as the user who created the wallet;
+-- access is decided by an active membership only.
+CREATE TABLE wallet_members (
+                                wallet_id UUID NOT NULL REFERENCES wallets(id) ON DELETE CASCADE,
+                                user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                role wallet_role NOT NULL,
+                                status wallet_member_status NOT NULL DEFAULT 'active',
+                                invited_by UUID NULL REFERENCES users(id),
+                                created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                PRIMARY KEY (wallet_id, user_id)
+);
+
+-- Index for listing the wallets and invitations of a user.
+CREATE INDEX idx_wallet_members_user_id ON wallet_members(user_id);
+
+CREATE TRIGGER set_wallet_members_updated_at
+    BEFORE UPDATE ON wallet_members
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Every existing wallet is owned by the user it belongs to.
+INSERT INTO wallet_members (wallet_id, user_id, role, status, created_at)
+SELECT id, user_id, 'owner', 'active', created_at FROM wallets;
+
+-- New wallets start with their creator as owner.
+CREATE OR REPLACE FUNCTION trigger_add_wallet_owner()
+    RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO wallet_members (wallet_id, user_id, role, status) VALUES (NEW.id, NEW.user_id, 'owner', 'active');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER add_wallet_owner
+    AFTER INSERT ON wallets
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_add_wallet_owner();
+
+-- The member who initiated each transaction; NULL for transactions the system books itself, such as interest.
+ALTER TABLE transactions
+    ADD COLUMN initiated_by UUID NULL REFERENCES users(id);
+
+UPDATE transactions t
+SET initiated_by = w.user_id
+FROM wallets w
+WHERE w.id = t.wallet_id AND t.type <> 'interest';
@@ -0,0 +1,10 @@
+DROP TABLE transfer_approvals;
+DROP TABLE transfer_proposals;
+DROP TYPE transfer_proposal_status;
+
+DROP TABLE wallet_approval_policies;
+
+ALTER TABLE wallets
+    DROP CONSTRAINT wallets_reserved_balance_check,
+    DROP COLUMN held_balance,
+    ADD CONSTRAINT wallets_pots_balance_check CHECK (pots_balance >= 0 AND pots_balance <= balance);
@@ -0,0 +1,71 @@
+-- =================================================================
+--  Maker-checker transfers: approval policies, proposals and holds
+-- =================================================================
+
+-- held_balance is the part of balance held for pending transfer proposals. Like money in pots, it cannot be
+-- spent; together they can never exceed the balance.
+ALTER TABLE wallets
+    ADD COLUMN held_balance DECIMAL(19, 4) NOT NULL DEFAULT 0.00,
+    DROP CONSTRAINT wallets_pots_balance_check,
+    ADD CONSTRAINT wallets_reserved_balance_check
+        CHECK (pots_balance >= 0 AND held_balance >= 0 AND pots_balance + held_balance <= balance);
+
+-- Transfers from the wallet above threshold need required_approvals approvals from the wallet's owners.
+CREATE TABLE wallet_approval_policies (
+                                          wallet_id UUID PRIMARY KEY REFERENCES wallets(id) ON DELETE CASCADE,
+                                          threshold DECIMAL(19, 4) NOT NULL CHECK (threshold >= 0),
+                                          required_approvals INT NOT NULL CHECK (required_approvals > 0),
+                                          proposal_ttl_hours INT NOT NULL CHECK (proposal_ttl_hours > 0),
+                                          created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                          updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER set_wallet_approval_policies_updated_at
+    BEFORE UPDATE ON wallet_approval_policies
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+CREATE TYPE transfer_proposal_status AS ENUM (
+    'pending',
+    'executed',
+    'cancelled',
+    'expired'
+);
+
+-- A transfer waiting for approval. held_amount (amount plus the fee quoted when it was proposed) is added to
+-- the wallet's held_balance while the proposal is pending and released when it is executed, cancelled or expires.
+CREATE TABLE transfer_proposals (
+                                    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                    wallet_id UUID NOT NULL REFERENCES wallets(id),
+                                    destination_wallet_id UUID NOT NULL REFERENCES wallets(id),
+                                    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+                                    fee DECIMAL(19, 4) NOT NULL DEFAULT 0.00 CHECK (fee >= 0),
+                                    fee_wallet_id UUID NULL,
+                                    fee_schedule_id UUID NULL REFERENCES fee_schedules(id),
+                                    held_amount DECIMAL(19, 4) NOT NULL CHECK (held_amount > 0),
+                                    proposed_by UUID NOT NULL REFERENCES users(id),
+                                    required_approvals INT NOT NULL CHECK (required_approvals > 0),
+                                    status transfer_proposal_status NOT NULL DEFAULT 'pending',
+                                    expires_at TIMESTAMPTZ NOT NULL,
+                                    transaction_id UUID NULL REFERENCES transactions(id),
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                    CHECK (wallet_id <> destination_wallet_id)
+);
+
+CREATE INDEX idx_transfer_proposals_wallet_id ON transfer_proposals(wallet_id, status);
+-- Index for the expiry job.
+CREATE INDEX idx_transfer_proposals_pending_expires_at ON transfer_proposals(expires_at) WHERE status = 'pending';
+
+CREATE TRIGGER set_transfer_proposals_updated_at
+    BEFORE UPDATE ON transfer_proposals
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+-- One sign-off per approver per proposal.
+CREATE TABLE transfer_approvals (
+                                    proposal_id UUID NOT NULL REFERENCES transfer_proposals(id) ON DELETE CASCADE,
+                                    user_id UUID NOT NULL REFERENCES users(id),
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                    PRIMARY KEY (proposal_id, user_id)
+);
@@ -0,0 +1,7 @@
+DROP TABLE escrows;
+
+DROP TYPE escrow_resolution;
+DROP TYPE escrow_status;
+
+-- Fails while transactions still reference the escrow wallet.
+DELETE FROM wallets WHERE id = '00000000-0000-0000-0000-0000000000e5';
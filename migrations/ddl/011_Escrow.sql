@@ -0,0 +1,52 @@
+-- =================================================================
+--  Escrow between a buyer wallet and a seller wallet
+-- =================================================================
+
+-- The system account escrowed funds are held in. Its wallet ID is the ESCROW_WALLET_ID setting.
+INSERT INTO wallets (id, user_id, name)
+VALUES ('00000000-0000-0000-0000-0000000000e5', '00000000-0000-0000-0000-000000000001', 'Escrow');
+
+CREATE TYPE escrow_status AS ENUM (
+    'held',
+    'released',
+    'refunded'
+);
+
+-- Why an escrow left the held state.
+CREATE TYPE escrow_resolution AS ENUM (
+    'buyer_confirmed',
+    'timeout',
+    'admin'
+);
+
+-- Funds moved from buyer_wallet_id into escrow_wallet_id by funding_transaction_id. Releasing pays them to
+-- seller_wallet_id and refunding pays them back to buyer_wallet_id, both by settlement_transaction_id.
+CREATE TABLE escrows (
+                         id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                         buyer_wallet_id UUID NOT NULL REFERENCES wallets(id),
+                         seller_wallet_id UUID NOT NULL REFERENCES wallets(id),
+                         escrow_wallet_id UUID NOT NULL REFERENCES wallets(id),
+                         amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+                         memo VARCHAR(140) NOT NULL DEFAULT '',
+                         status escrow_status NOT NULL DEFAULT 'held',
+                         release_after TIMESTAMPTZ NOT NULL,
+                         created_by UUID NOT NULL REFERENCES users(id),
+                         funding_transaction_id UUID NOT NULL REFERENCES transactions(id),
+                         settlement_transaction_id UUID NULL REFERENCES transactions(id),
+                         resolution escrow_resolution NULL,
+                         resolved_by VARCHAR(100) NULL,
+                         created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                         updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                         CHECK (buyer_wallet_id <> seller_wallet_id),
+                         CHECK ((status = 'held') = (settlement_transaction_id IS NULL))
+);
+
+CREATE INDEX idx_escrows_buyer_wallet_id ON escrows(buyer_wallet_id);
+CREATE INDEX idx_escrows_seller_wallet_id ON escrows(seller_wallet_id);
+-- Index for the release job.
+CREATE INDEX idx_escrows_held_release_after ON escrows(release_after) WHERE status = 'held';
+
+CREATE TRIGGER set_escrows_updated_at
+    BEFORE UPDATE ON escrows
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
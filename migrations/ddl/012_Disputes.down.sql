@@ -0,0 +1,6 @@
+-- The 'reversal' transaction type stays: Postgres cannot remove a value from an enum.
+DROP TABLE dispute_notes;
+DROP TABLE disputes;
+
+DROP TYPE dispute_party;
+DROP TYPE dispute_status;
@@ -0,0 +1,62 @@
+-- =================================================================
+--  Disputes and chargebacks of transfers
+-- =================================================================
+
+-- A reversal pays a disputed transfer back: it is recorded on the recipient's wallet, with related_wallet_id
+-- pointing at the original payer and parent_transaction_id at the disputed transfer.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'reversal';
+
+CREATE TYPE dispute_status AS ENUM (
+    'open',
+    'resolved',
+    'withdrawn'
+);
+
+-- The side of a disputed transfer a dispute was resolved in favour of.
+CREATE TYPE dispute_party AS ENUM (
+    'payer',
+    'recipient'
+);
+
+-- A dispute raised by the payer of the transfer transaction_id. held_amount is held on recipient_wallet_id
+-- while the dispute is open. Resolving it for the payer posts reversal_transaction_id.
+CREATE TABLE disputes (
+                          id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                          transaction_id UUID UNIQUE NOT NULL REFERENCES transactions(id),
+                          payer_wallet_id UUID NOT NULL REFERENCES wallets(id),
+                          recipient_wallet_id UUID NOT NULL REFERENCES wallets(id),
+                          amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+                          held_amount DECIMAL(19, 4) NOT NULL DEFAULT 0.00 CHECK (held_amount >= 0 AND held_amount <= amount),
+                          reason VARCHAR(500) NOT NULL,
+                          status dispute_status NOT NULL DEFAULT 'open',
+                          opened_by UUID NOT NULL REFERENCES users(id),
+                          resolved_in_favor_of dispute_party NULL,
+                          reversal_transaction_id UUID NULL REFERENCES transactions(id),
+                          resolved_by VARCHAR(100) NULL,
+                          created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                          updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                          CHECK ((status = 'resolved') = (resolved_in_favor_of IS NOT NULL)),
+                          CHECK ((resolved_in_favor_of = 'payer') = (reversal_transaction_id IS NOT NULL))
+);
+
+CREATE INDEX idx_disputes_payer_wallet_id ON disputes(payer_wallet_id);
+CREATE INDEX idx_disputes_recipient_wallet_id ON disputes(recipient_wallet_id);
+CREATE INDEX idx_disputes_status_created_at ON disputes(status, created_at);
+
+CREATE TRIGGER set_disputes_updated_at
+    BEFORE UPDATE ON disputes
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Evidence attached to a dispute, written either by a member of one of its wallets or by an administrator.
+CREATE TABLE dispute_notes (
+                               id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                               dispute_id UUID NOT NULL REFERENCES disputes(id) ON DELETE CASCADE,
+                               author_user_id UUID NULL REFERENCES users(id),
+                               author_admin VARCHAR(100) NULL,
+                               body VARCHAR(2000) NOT NULL,
+                               created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                               CHECK ((author_user_id IS NULL) <> (author_admin IS NULL))
+);
+
+CREATE INDEX idx_dispute_notes_dispute_id ON dispute_notes(dispute_id, created_at);
@@ -0,0 +1,10 @@
+-- The 'adjustment' transaction type stays: Postgres cannot remove a value from an enum.
+DROP INDEX idx_users_name_lower;
+
+DROP TABLE admin_audit_log;
+DROP FUNCTION trigger_reject_change();
+
+ALTER TABLE wallets
+    DROP COLUMN status;
+
+DROP TYPE wallet_status;
@@ -0,0 +1,46 @@
+-- =================================================================
+--  Admin back-office: wallet freezes, manual adjustments and audit log
+-- =================================================================
+
+-- An adjustment is a manual credit or debit posted by an administrator. Unlike the other types its amount is
+-- signed: positive for a credit and negative for a debit.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'adjustment';
+
+CREATE TYPE wallet_status AS ENUM (
+    'active',
+    'frozen'
+);
+
+-- A frozen wallet can neither pay nor be paid.
+ALTER TABLE wallets
+    ADD COLUMN status wallet_status NOT NULL DEFAULT 'active';
+
+-- Every back-office action, including searches, in the order it was taken. Rows are never updated or deleted.
+CREATE TABLE admin_audit_log (
+                                 id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                 admin VARCHAR(100) NOT NULL,
+                                 action VARCHAR(50) NOT NULL,
+                                 wallet_id UUID NULL REFERENCES wallets(id),
+                                 transaction_id UUID NULL REFERENCES transactions(id),
+                                 reason_code VARCHAR(50) NULL,
+                                 details TEXT NOT NULL DEFAULT '',
+                                 created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_admin_audit_log_created_at ON admin_audit_log(created_at);
+CREATE INDEX idx_admin_audit_log_wallet_id ON admin_audit_log(wallet_id, created_at);
+
+CREATE OR REPLACE FUNCTION trigger_reject_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reject_admin_audit_log_change
+    BEFORE UPDATE OR DELETE ON admin_audit_log
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_reject_change();
+
+-- Searches for users by name, email or handle.
+CREATE INDEX idx_users_name_lower ON users(lower(name));
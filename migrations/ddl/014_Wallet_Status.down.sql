@@ -0,0 +1,11 @@
+-- The 'debit_blocked' and 'credit_blocked' values stay in wallet_status: Postgres cannot remove a value from
+-- an enum. Wallets in either are frozen instead, so no restriction is lost.
+UPDATE wallets SET status = 'frozen' WHERE status IN ('debit_blocked', 'credit_blocked');
+
+DROP INDEX idx_wallets_status_expires_at;
+
+ALTER TABLE wallets
+    DROP COLUMN status_expires_at,
+    DROP COLUMN status_changed_at,
+    DROP COLUMN status_changed_by,
+    DROP COLUMN status_reason;
@@ -0,0 +1,19 @@
+-- =================================================================
+--  Wallet restriction states
+-- =================================================================
+
+-- debit_blocked wallets can be paid but cannot pay; credit_blocked wallets can pay but cannot be paid.
+ALTER TYPE wallet_status ADD VALUE IF NOT EXISTS 'debit_blocked';
+ALTER TYPE wallet_status ADD VALUE IF NOT EXISTS 'credit_blocked';
+
+-- Who put the wallet in its current status, why and when, and when the restriction lifts by itself.
+-- status_changed_by is NULL when the system lifted an expired restriction, and all four are NULL for a wallet
+-- whose status was never changed.
+ALTER TABLE wallets
+    ADD COLUMN status_reason VARCHAR(500) NULL,
+    ADD COLUMN status_changed_by VARCHAR(100) NULL,
+    ADD COLUMN status_changed_at TIMESTAMPTZ NULL,
+    ADD COLUMN status_expires_at TIMESTAMPTZ NULL;
+
+-- Index for the job lifting expired restrictions.
+CREATE INDEX idx_wallets_status_expires_at ON wallets(status_expires_at) WHERE status_expires_at IS NOT NULL;
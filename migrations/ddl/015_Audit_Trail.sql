@@ -0,0 +1,29 @@
+-- =================================================================
+--  Audit trail: a hash-chained record of every state-changing API call
+-- =================================================================
+
+-- One row per POST, PUT, PATCH or DELETE request, numbered without gaps by seq. hash is the SHA-256 of the entry's
+-- fields and prev_hash, the hash of the entry before it (empty for the first), so changing, inserting or deleting
+-- an entry breaks the chain from that entry on. Rows are never updated or deleted.
+CREATE TABLE audit_trail (
+                             seq BIGINT PRIMARY KEY CHECK (seq > 0),
+                             request_id VARCHAR(100) NOT NULL,
+                             actor VARCHAR(150) NOT NULL,
+                             method VARCHAR(10) NOT NULL,
+                             route VARCHAR(255) NOT NULL,
+                             params JSONB NOT NULL,
+                             status INTEGER NOT NULL,
+                             wallet_id UUID NULL,
+                             balance_before DECIMAL(19, 4) NULL,
+                             balance_after DECIMAL(19, 4) NULL,
+                             created_at TIMESTAMPTZ NOT NULL,
+                             prev_hash VARCHAR(64) NOT NULL DEFAULT '' CHECK (prev_hash = '' OR length(prev_hash) = 64),
+                             hash VARCHAR(64) NOT NULL CHECK (length(hash) = 64)
+);
+
+CREATE INDEX idx_audit_trail_wallet_id ON audit_trail(wallet_id, seq);
+
+CREATE TRIGGER reject_audit_trail_change
+    BEFORE UPDATE OR DELETE ON audit_trail
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_reject_change();
@@ -0,0 +1,3 @@
+DROP INDEX idx_scheduled_transfer_runs_lease;
+
+ALTER TABLE scheduled_transfer_runs DROP COLUMN claimed_at;
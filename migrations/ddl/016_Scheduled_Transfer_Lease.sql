@@ -0,0 +1,11 @@
+-- =================================================================
+--  Scheduled transfers: lease on running runs
+-- =================================================================
+
+-- claimed_at is when a worker last claimed the run. A run still 'running' long after that belongs to a worker
+-- that died before recording the outcome, and is handed back to the retry path.
+ALTER TABLE scheduled_transfer_runs ADD COLUMN claimed_at TIMESTAMPTZ NULL;
+
+UPDATE scheduled_transfer_runs SET claimed_at = updated_at WHERE status = 'running';
+
+CREATE INDEX idx_scheduled_transfer_runs_lease ON scheduled_transfer_runs(claimed_at) WHERE status = 'running';
@@ -0,0 +1,21 @@
+-- =================================================================
+--  Audit trail head: the checkpoint the verifier compares the chain against
+-- =================================================================
+
+-- The seq and hash of the last audit trail entry, moved on in the transaction that appends an entry. The hash
+-- chain cannot tell on its own that its newest entries were deleted; a chain that ends before the head can.
+-- The single row is kept by the CHECK on id and cannot be deleted.
+CREATE TABLE audit_trail_head (
+                                  id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+                                  seq BIGINT NOT NULL CHECK (seq > 0),
+                                  hash VARCHAR(64) NOT NULL CHECK (length(hash) = 64),
+                                  updated_at TIMESTAMPTZ NOT NULL
+);
+
+INSERT INTO audit_trail_head (seq, hash, updated_at)
+SELECT seq, hash, created_at FROM audit_trail ORDER BY seq DESC LIMIT 1;
+
+CREATE TRIGGER reject_audit_trail_head_delete
+    BEFORE DELETE ON audit_trail_head
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_reject_change();
@@ -0,0 +1,3 @@
+DROP INDEX idx_transactions_scheduled_transfer_run;
+
+ALTER TABLE transactions DROP COLUMN scheduled_transfer_run_id;
@@ -0,0 +1,11 @@
+-- =================================================================
+--  Scheduled transfers: the transaction each run paid
+-- =================================================================
+
+-- scheduled_transfer_run_id is the run a scheduled transfer was made for. It is written in the transaction that
+-- moves the money, and unique, so a run whose worker outlived its lease cannot be paid a second time by the
+-- retry, and a released run is matched to its transfer exactly.
+ALTER TABLE transactions ADD COLUMN scheduled_transfer_run_id UUID NULL REFERENCES scheduled_transfer_runs(id);
+
+CREATE UNIQUE INDEX idx_transactions_scheduled_transfer_run ON transactions(scheduled_transfer_run_id)
+    WHERE scheduled_transfer_run_id IS NOT NULL;
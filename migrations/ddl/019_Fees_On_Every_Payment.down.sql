@@ -0,0 +1,5 @@
+-- The 'escrow' fee operation stays: Postgres cannot remove a value from an enum.
+ALTER TABLE transfer_batch_items
+    DROP COLUMN fee_schedule_id,
+    DROP COLUMN fee_wallet_id,
+    DROP COLUMN fee;
@@ -0,0 +1,14 @@
+-- =================================================================
+--  Fees on batch rows, payment requests and escrows
+-- =================================================================
+
+-- Batch rows and accepted payment requests are charged the transfer fee. Funding an escrow has a fee of its own,
+-- so it can be priced apart from transfers; its first schedule is in the next migration, since a new enum value
+-- cannot be used in the transaction that adds it.
+ALTER TYPE fee_operation ADD VALUE IF NOT EXISTS 'escrow';
+
+-- The fee of a batch row is quoted when the batch is created and charged with the row, as for transfer proposals.
+ALTER TABLE transfer_batch_items
+    ADD COLUMN fee DECIMAL(19, 4) NOT NULL DEFAULT 0.00 CHECK (fee >= 0),
+    ADD COLUMN fee_wallet_id UUID NULL,
+    ADD COLUMN fee_schedule_id UUID NULL REFERENCES fee_schedules(id);
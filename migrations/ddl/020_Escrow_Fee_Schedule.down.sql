@@ -0,0 +1,5 @@
+-- Fee schedules are immutable, so the trigger guarding them is lifted to remove the escrow schedules.
+-- This fails while fees charged on escrows still reference them.
+ALTER TABLE fee_schedules DISABLE TRIGGER fee_schedules_immutable;
+DELETE FROM fee_schedules WHERE operation = 'escrow';
+ALTER TABLE fee_schedules ENABLE TRIGGER fee_schedules_immutable;
@@ -0,0 +1,7 @@
+-- =================================================================
+--  Escrow fee schedule
+-- =================================================================
+
+-- Version 1: funding an escrow is free. A later version from the fee schedules file prices it.
+INSERT INTO fee_schedules (operation, currency, version, rule, effective_from)
+VALUES ('escrow', 'USD', 1, '{"flat": "0", "percent": "0"}', '2025-01-01T00:00:00Z');
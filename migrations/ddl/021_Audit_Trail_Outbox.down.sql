@@ -0,0 +1,5 @@
+DROP TRIGGER stage_audit_trail_entry ON wallets;
+
+DROP FUNCTION trigger_stage_audit_trail_entry();
+
+DROP TABLE audit_trail_outbox;
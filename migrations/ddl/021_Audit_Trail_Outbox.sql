@@ -0,0 +1,50 @@
+-- =================================================================
+--  Audit trail outbox: entries waiting to be chained onto the audit trail
+-- =================================================================
+
+-- One row per audited request, keyed by entry_id, which the server picks for the request; the client's request_id
+-- need not be unique. The transactions of the request write the row themselves, with the balance of the route's
+-- wallet before their first change to it and after their last, and the server fills in status and actor once it
+-- has responded. The audit trail chainer appends the rows to the audit trail in id order and deletes them, so
+-- requests never wait on the lock that keeps the chain in order. status stays NULL for a request the server
+-- stopped before answering.
+CREATE TABLE audit_trail_outbox (
+                                    id BIGSERIAL PRIMARY KEY,
+                                    entry_id UUID NOT NULL UNIQUE,
+                                    request_id VARCHAR(100) NOT NULL,
+                                    actor VARCHAR(150) NOT NULL,
+                                    method VARCHAR(10) NOT NULL,
+                                    route VARCHAR(255) NOT NULL,
+                                    params JSONB NOT NULL,
+                                    status INTEGER NULL,
+                                    wallet_id UUID NULL,
+                                    balance_before DECIMAL(19, 4) NULL,
+                                    balance_after DECIMAL(19, 4) NULL,
+                                    created_at TIMESTAMPTZ NOT NULL
+);
+
+-- Stages the audit trail entry of the transaction's request, which the server passes in the transaction-local
+-- setting wallet_app.audit_trail_entry as JSON, when the balance of the entry's wallet changes.
+CREATE OR REPLACE FUNCTION trigger_stage_audit_trail_entry()
+RETURNS TRIGGER AS $$
+DECLARE
+  entry JSONB := NULLIF(current_setting('wallet_app.audit_trail_entry', TRUE), '')::JSONB;
+BEGIN
+  IF entry IS NULL OR (entry->>'wallet_id')::UUID IS DISTINCT FROM NEW.id THEN
+    RETURN NULL;
+  END IF;
+
+  INSERT INTO audit_trail_outbox (entry_id, request_id, actor, method, route, params, wallet_id, balance_before,
+                                  balance_after, created_at)
+  VALUES ((entry->>'entry_id')::UUID, entry->>'request_id', entry->>'actor', entry->>'method', entry->>'route',
+          entry->'params', NEW.id, OLD.balance, NEW.balance, (entry->>'created_at')::TIMESTAMPTZ)
+  ON CONFLICT (entry_id) DO UPDATE SET balance_after = EXCLUDED.balance_after;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stage_audit_trail_entry
+    AFTER UPDATE OF balance ON wallets
+    FOR EACH ROW
+    WHEN (OLD.balance IS DISTINCT FROM NEW.balance)
+    EXECUTE FUNCTION trigger_stage_audit_trail_entry();
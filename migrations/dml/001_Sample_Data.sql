@@ -50,6 +50,8 @@ BEGIN
     -- Simulate a series of deposits, withdrawals, and transfers.
     -- For each transaction, we insert a record into the transactions table
     -- and update the corresponding wallet balance(s).
+    -- Like WalletRepoImpl.Transfer, a transfer is a single record on the source
+    -- wallet with a positive amount and related_wallet_id set to the destination.
 
     -- 1. Alice deposits $1000 into her Primary wallet.
     UPDATE wallets SET balance = balance + 1000.00 WHERE id = wallet_id_alice_primary;
@@ -74,14 +76,12 @@ BEGIN
     -- 6. Alice transfers $200 from her Primary wallet to Bob's Main Account.
     UPDATE wallets SET balance = balance - 200.00 WHERE id = wallet_id_alice_primary;
     UPDATE wallets SET balance = balance + 200.00 WHERE id = wallet_id_bob_main;
-    INSERT INTO transactions (wallet_id, type, amount, related_wallet_id) VALUES (wallet_id_alice_primary, 'transfer', -200.00, wallet_id_bob_main);
-    INSERT INTO transactions (wallet_id, type, amount, related_wallet_id) VALUES (wallet_id_bob_main, 'transfer', 200.00, wallet_id_alice_primary);
+    INSERT INTO transactions (wallet_id, type, amount, related_wallet_id) VALUES (wallet_id_alice_primary, 'transfer', 200.00, wallet_id_bob_main);
 
     -- 7. Bob transfers $75 from his Main Account to Charlie's Spending wallet.
     UPDATE wallets SET balance = balance - 75.00 WHERE id = wallet_id_bob_main;
     UPDATE wallets SET balance = balance + 75.00 WHERE id = wallet_id_charlie_spending;
-    INSERT INTO transactions (wallet_id, type, amount, related_wallet_id) VALUES (wallet_id_bob_main, 'transfer', -75.00, wallet_id_charlie_spending);
-    INSERT INTO transactions (wallet_id, type, amount, related_wallet_id) VALUES (wallet_id_charlie_spending, 'transfer', 75.00, wallet_id_bob_main);
+    INSERT INTO transactions (wallet_id, type, amount, related_wallet_id) VALUES (wallet_id_bob_main, 'transfer', 75.00, wallet_id_charlie_spending);
 
     -- 8. Charlie withdraws $20 from his Spending wallet.
     UPDATE wallets SET balance = balance - 20.00 WHERE id = wallet_id_charlie_spending;
@@ -90,8 +90,7 @@ BEGIN
     -- 9. Alice transfers $1000 from Savings to Primary.
     UPDATE wallets SET balance = balance - 1000.00 WHERE id = wallet_id_alice_savings;
     UPDATE wallets SET balance = balance + 1000.00 WHERE id = wallet_id_alice_primary;
-    INSERT INTO transactions (wallet_id, type, amount, related_wallet_id) VALUES (wallet_id_alice_savings, 'transfer', -1000.00, wallet_id_alice_primary);
-    INSERT INTO transactions (wallet_id, type, amount, related_wallet_id) VALUES (wallet_id_alice_primary, 'transfer', 1000.00, wallet_id_alice_savings);
+    INSERT INTO transactions (wallet_id, type, amount, related_wallet_id) VALUES (wallet_id_alice_savings, 'transfer', 1000.00, wallet_id_alice_primary);
 
     -- 10. Bob deposits another $150 into his Main Account.
     UPDATE wallets SET balance = balance + 150.00 WHERE id = wallet_id_bob_main;
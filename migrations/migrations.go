@@ -0,0 +1,17 @@
+// Package migrations embeds the database schema migrations in migrations/ddl, so the binaries can apply them
+// with pkg/migrate. The sample data in migrations/dml is not part of the schema and is not embedded.
+package migrations
+
+import (
+	"embed"
+
+	"github.com/kylenguyen/wallet-app/pkg/migrate"
+)
+
+//go:embed ddl/*.sql
+var ddl embed.FS
+
+// Load returns the schema migrations in version order.
+func Load() ([]migrate.Migration, error) {
+	return migrate.Load(ddl, "ddl")
+}
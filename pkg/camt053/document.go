@@ -0,0 +1,235 @@
+// Package camt053 models the subset of the ISO 20022 camt.053.001.02 (BankToCustomerStatement)
+// message that is needed to report the activity of a single account over a period.
+package camt053
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Namespace is the XML namespace of camt.053.001.02 documents.
+const Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+// Balance type codes (BalanceType12Code).
+const (
+	BalanceOpeningBooked = "OPBD"
+	BalanceClosingBooked = "CLBD"
+)
+
+// Credit/debit indicators (CreditDebitCode).
+const (
+	Credit = "CRDT"
+	Debit  = "DBIT"
+)
+
+// EntryStatusBooked is the EntryStatus2Code of an entry that has been posted to the account.
+const EntryStatusBooked = "BOOK"
+
+const (
+	isoDateTime = "2006-01-02T15:04:05.000Z07:00"
+	isoDate     = "2006-01-02"
+)
+
+// Document is the root element of a camt.053 message.
+type Document struct {
+	XMLName       xml.Name                   `xml:"Document"`
+	Xmlns         string                     `xml:"xmlns,attr"`
+	BkToCstmrStmt BankToCustomerStatementV02 `xml:"BkToCstmrStmt"`
+}
+
+type BankToCustomerStatementV02 struct {
+	GrpHdr GroupHeader42       `xml:"GrpHdr"`
+	Stmt   []AccountStatement2 `xml:"Stmt"`
+}
+
+type GroupHeader42 struct {
+	MsgId   string      `xml:"MsgId"`
+	CreDtTm ISODateTime `xml:"CreDtTm"`
+}
+
+type AccountStatement2 struct {
+	Id        string                 `xml:"Id"`
+	CreDtTm   ISODateTime            `xml:"CreDtTm"`
+	FrToDt    *DateTimePeriodDetails `xml:"FrToDt,omitempty"`
+	Acct      CashAccount20          `xml:"Acct"`
+	Bal       []CashBalance3         `xml:"Bal"`
+	TxsSummry *TotalTransactions2    `xml:"TxsSummry,omitempty"`
+	Ntry      []ReportEntry2         `xml:"Ntry"`
+}
+
+type DateTimePeriodDetails struct {
+	FrDtTm ISODateTime `xml:"FrDtTm"`
+	ToDtTm ISODateTime `xml:"ToDtTm"`
+}
+
+type CashAccount20 struct {
+	Id   AccountIdentification4Choice `xml:"Id"`
+	Ccy  string                       `xml:"Ccy,omitempty"`
+	Nm   string                       `xml:"Nm,omitempty"`
+	Ownr *PartyIdentification32       `xml:"Ownr,omitempty"`
+}
+
+type CashAccount16 struct {
+	Id AccountIdentification4Choice `xml:"Id"`
+}
+
+type AccountIdentification4Choice struct {
+	Othr GenericAccountIdentification1 `xml:"Othr"`
+}
+
+type GenericAccountIdentification1 struct {
+	Id string `xml:"Id"`
+}
+
+type PartyIdentification32 struct {
+	Nm string `xml:"Nm,omitempty"`
+}
+
+type CashBalance3 struct {
+	Tp        BalanceType12                     `xml:"Tp"`
+	Amt       ActiveOrHistoricCurrencyAndAmount `xml:"Amt"`
+	CdtDbtInd string                            `xml:"CdtDbtInd"`
+	Dt        DateAndDateTimeChoice             `xml:"Dt"`
+}
+
+type BalanceType12 struct {
+	CdOrPrtry BalanceType5Choice `xml:"CdOrPrtry"`
+}
+
+type BalanceType5Choice struct {
+	Cd string `xml:"Cd"`
+}
+
+type DateAndDateTimeChoice struct {
+	Dt   *ISODate     `xml:"Dt,omitempty"`
+	DtTm *ISODateTime `xml:"DtTm,omitempty"`
+}
+
+type TotalTransactions2 struct {
+	TtlNtries    *NumberAndSumOfTransactions2 `xml:"TtlNtries,omitempty"`
+	TtlCdtNtries *NumberAndSumOfTransactions1 `xml:"TtlCdtNtries,omitempty"`
+	TtlDbtNtries *NumberAndSumOfTransactions1 `xml:"TtlDbtNtries,omitempty"`
+}
+
+type NumberAndSumOfTransactions2 struct {
+	NbOfNtries    string `xml:"NbOfNtries"`
+	Sum           string `xml:"Sum"`
+	TtlNetNtryAmt string `xml:"TtlNetNtryAmt"`
+	CdtDbtInd     string `xml:"CdtDbtInd"`
+}
+
+type NumberAndSumOfTransactions1 struct {
+	NbOfNtries string `xml:"NbOfNtries"`
+	Sum        string `xml:"Sum"`
+}
+
+type ReportEntry2 struct {
+	NtryRef      string                            `xml:"NtryRef,omitempty"`
+	Amt          ActiveOrHistoricCurrencyAndAmount `xml:"Amt"`
+	CdtDbtInd    string                            `xml:"CdtDbtInd"`
+	Sts          string                            `xml:"Sts"`
+	BookgDt      DateAndDateTimeChoice             `xml:"BookgDt"`
+	ValDt        DateAndDateTimeChoice             `xml:"ValDt"`
+	AcctSvcrRef  string                            `xml:"AcctSvcrRef,omitempty"`
+	BkTxCd       BankTransactionCodeStructure4     `xml:"BkTxCd"`
+	NtryDtls     []EntryDetails1                   `xml:"NtryDtls,omitempty"`
+	AddtlNtryInf string                            `xml:"AddtlNtryInf,omitempty"`
+}
+
+type BankTransactionCodeStructure4 struct {
+	Domn  *BankTransactionCodeStructure5            `xml:"Domn,omitempty"`
+	Prtry *ProprietaryBankTransactionCodeStructure1 `xml:"Prtry,omitempty"`
+}
+
+type BankTransactionCodeStructure5 struct {
+	Cd   string                        `xml:"Cd"`
+	Fmly BankTransactionCodeStructure6 `xml:"Fmly"`
+}
+
+type BankTransactionCodeStructure6 struct {
+	Cd        string `xml:"Cd"`
+	SubFmlyCd string `xml:"SubFmlyCd"`
+}
+
+type ProprietaryBankTransactionCodeStructure1 struct {
+	Cd string `xml:"Cd"`
+}
+
+type EntryDetails1 struct {
+	TxDtls []EntryTransaction2 `xml:"TxDtls"`
+}
+
+type EntryTransaction2 struct {
+	Refs      *TransactionReferences2 `xml:"Refs,omitempty"`
+	RltdPties *TransactionParty2      `xml:"RltdPties,omitempty"`
+}
+
+type TransactionReferences2 struct {
+	AcctSvcrRef string `xml:"AcctSvcrRef,omitempty"`
+	EndToEndId  string `xml:"EndToEndId,omitempty"`
+	TxId        string `xml:"TxId,omitempty"`
+}
+
+type TransactionParty2 struct {
+	DbtrAcct *CashAccount16 `xml:"DbtrAcct,omitempty"`
+	CdtrAcct *CashAccount16 `xml:"CdtrAcct,omitempty"`
+}
+
+// ActiveOrHistoricCurrencyAndAmount is a non-negative amount qualified by its ISO 4217 currency code.
+type ActiveOrHistoricCurrencyAndAmount struct {
+	Value string `xml:",chardata"`
+	Ccy   string `xml:"Ccy,attr"`
+}
+
+// NewAmount formats the absolute value of amount for the given currency.
+// At least two fraction digits are kept, and never more than the five the schema allows.
+func NewAmount(amount decimal.Decimal, ccy string) ActiveOrHistoricCurrencyAndAmount {
+	return ActiveOrHistoricCurrencyAndAmount{Value: FormatDecimal(amount.Abs()), Ccy: ccy}
+}
+
+// FormatDecimal renders d with between two and five fraction digits.
+func FormatDecimal(d decimal.Decimal) string {
+	d = d.Round(5)
+	if d.Equal(d.Round(2)) {
+		return d.StringFixed(2)
+	}
+	return d.String()
+}
+
+// ISODateTime marshals a time as an ISO 8601 date-time in UTC.
+type ISODateTime time.Time
+
+func (t ISODateTime) MarshalText() ([]byte, error) {
+	return []byte(time.Time(t).UTC().Format(isoDateTime)), nil
+}
+
+// ISODate marshals a time as an ISO 8601 calendar date.
+type ISODate time.Time
+
+func (d ISODate) MarshalText() ([]byte, error) {
+	return []byte(time.Time(d).Format(isoDate)), nil
+}
+
+// NewDate returns a date choice holding only the calendar date of t.
+func NewDate(t time.Time) DateAndDateTimeChoice {
+	d := ISODate(t)
+	return DateAndDateTimeChoice{Dt: &d}
+}
+
+// NewDateTime returns a date choice holding the full timestamp t.
+func NewDateTime(t time.Time) DateAndDateTimeChoice {
+	dt := ISODateTime(t)
+	return DateAndDateTimeChoice{DtTm: &dt}
+}
+
+// Marshal renders the document as indented XML, including the XML declaration.
+func (d Document) Marshal() ([]byte, error) {
+	d.Xmlns = Namespace
+	out, err := xml.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,131 @@
+package metrics_test
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/pkg/metrics"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	reg := metrics.NewRegistry()
+	ops := reg.NewCounterVec("ops_total", "Operations by outcome.", "op", "outcome")
+	latency := reg.NewHistogramVec("latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+	reg.NewGaugeFunc("in_use", "Connections in use.", func() float64 { return 3 })
+	reg.NewCounterFunc("waits_total", "Waits\nfor a connection, in \\ units.", func() float64 { return 1.5 })
+	plain := reg.NewCounterVec("plain_total", "No labels.")
+
+	ops.Inc("withdraw", "insufficient_funds")
+	ops.Inc("deposit", "success")
+	ops.Add(2, "deposit", "success")
+	ops.Inc("transfer", `quoted "odd"`+"\n\\")
+	latency.Observe(0.05, "/a")
+	latency.Observe(0.1, "/a")
+	latency.Observe(0.5, "/a")
+	latency.Observe(7, "/a")
+	latency.Observe(math.Inf(1), "/b")
+	plain.Inc()
+
+	var out strings.Builder
+	require.NoError(t, reg.WriteText(&out))
+	assert.Equal(t, `# HELP in_use Connections in use.
+# TYPE in_use gauge
+in_use 3
+# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/a",le="0.1"} 2
+latency_seconds_bucket{route="/a",le="1"} 3
+latency_seconds_bucket{route="/a",le="+Inf"} 4
+latency_seconds_sum{route="/a"} 7.65
+latency_seconds_count{route="/a"} 4
+latency_seconds_bucket{route="/b",le="0.1"} 0
+latency_seconds_bucket{route="/b",le="1"} 0
+latency_seconds_bucket{route="/b",le="+Inf"} 1
+latency_seconds_sum{route="/b"} +Inf
+latency_seconds_count{route="/b"} 1
+# HELP ops_total Operations by outcome.
+# TYPE ops_total counter
+ops_total{op="deposit",outcome="success"} 3
+ops_total{op="transfer",outcome="quoted \"odd\"\n\\"} 1
+ops_total{op="withdraw",outcome="insufficient_funds"} 1
+# HELP plain_total No labels.
+# TYPE plain_total counter
+plain_total 1
+# HELP waits_total Waits\nfor a connection, in \\ units.
+# TYPE waits_total counter
+waits_total 1.5
+`, out.String())
+}
+
+func TestRegistry_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(reg *metrics.Registry)
+	}{
+		{"invalid name", func(reg *metrics.Registry) { reg.NewCounterVec("ops-total", "") }},
+		{"invalid label", func(reg *metrics.Registry) { reg.NewCounterVec("ops_total", "", "1op") }},
+		{"reserved le label", func(reg *metrics.Registry) { reg.NewHistogramVec("h", "", metrics.DefBuckets, "le") }},
+		{"registered twice", func(reg *metrics.Registry) {
+			reg.NewCounterVec("ops_total", "")
+			reg.NewGaugeFunc("ops_total", "", func() float64 { return 0 })
+		}},
+		{"unsorted buckets", func(reg *metrics.Registry) { reg.NewHistogramVec("h", "", []float64{1, 0.5}) }},
+		{"missing label value", func(reg *metrics.Registry) { reg.NewCounterVec("ops_total", "", "op", "outcome").Inc("deposit") }},
+		{"counter decreasing", func(reg *metrics.Registry) { reg.NewCounterVec("ops_total", "").Add(-1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Panics(t, func() { tt.fn(metrics.NewRegistry()) })
+		})
+	}
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	reg := metrics.NewRegistry()
+	ops := reg.NewCounterVec("ops_total", "", "op")
+	latency := reg.NewHistogramVec("latency_seconds", "", metrics.DefBuckets, "op")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				ops.Inc("deposit")
+				latency.Observe(0.01, "deposit")
+			}
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				require.NoError(t, reg.WriteText(&strings.Builder{}))
+			}
+		}()
+	}
+	wg.Wait()
+
+	var out strings.Builder
+	require.NoError(t, reg.WriteText(&out))
+	assert.Contains(t, out.String(), `ops_total{op="deposit"} 8000`)
+	assert.Contains(t, out.String(), `latency_seconds_count{op="deposit"} 8000`)
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	reg := metrics.NewRegistry()
+	reg.NewCounterVec("ops_total", "Operations.", "op").Inc("deposit")
+
+	rec := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `ops_total{op="deposit"} 1`)
+}
@@ -0,0 +1,86 @@
+package migrate_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/migrations"
+	"github.com/kylenguyen/wallet-app/pkg/migrate"
+)
+
+func Test_Load(t *testing.T) {
+	file := func(sql string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(sql)} }
+
+	testCases := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []migrate.Migration
+		wantErr error
+	}{
+		{
+			name: "sorted by version with optional down",
+			files: fstest.MapFS{
+				"ddl/010_Pots.sql":        file("CREATE TABLE pots ();"),
+				"ddl/002_Users.sql":       file("CREATE TABLE users ();"),
+				"ddl/002_Users.down.sql":  file("DROP TABLE users;"),
+				"ddl/README.md":           file("not a migration"),
+				"ddl/001_Extensions.sql":  file("CREATE EXTENSION pgcrypto;"),
+				"other/003_Elsewhere.sql": file("SELECT 1;"),
+			},
+			want: []migrate.Migration{
+				{Version: 1, Name: "Extensions", Up: "CREATE EXTENSION pgcrypto;"},
+				{Version: 2, Name: "Users", Up: "CREATE TABLE users ();", Down: "DROP TABLE users;"},
+				{Version: 10, Name: "Pots", Up: "CREATE TABLE pots ();"},
+			},
+		},
+		{
+			name:    "error - misnamed file",
+			files:   fstest.MapFS{"ddl/Users.sql": file("CREATE TABLE users ();")},
+			wantErr: migrate.ErrInvalidMigration,
+		},
+		{
+			name: "error - version used twice",
+			files: fstest.MapFS{
+				"ddl/001_Users.sql":   file("CREATE TABLE users ();"),
+				"ddl/001_Wallets.sql": file("CREATE TABLE wallets ();"),
+			},
+			wantErr: migrate.ErrInvalidMigration,
+		},
+		{
+			name:    "error - down without up",
+			files:   fstest.MapFS{"ddl/001_Users.down.sql": file("DROP TABLE users;")},
+			wantErr: migrate.ErrInvalidMigration,
+		},
+		{
+			name:    "error - version zero",
+			files:   fstest.MapFS{"ddl/000_Users.sql": file("CREATE TABLE users ();")},
+			wantErr: migrate.ErrInvalidMigration,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := migrate.Load(tc.files, "ddl")
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func Test_Load_Embedded(t *testing.T) {
+	all, err := migrations.Load()
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+
+	for i, m := range all {
+		assert.Equal(t, int64(i+1), m.Version, "versions must have no gaps")
+		assert.NotEmpty(t, m.Down, "migration %d_%s has no down migration", m.Version, m.Name)
+	}
+}
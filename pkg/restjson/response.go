@@ -34,8 +34,14 @@ func ResponseError(c *gin.Context, code int, err error) {
 
 // ResponseData formats a successful response with the default HTTP code (200 OK) and the provided data.
 func ResponseData(c *gin.Context, data interface{}) {
+	ResponseDataWithCode(c, http.StatusOK, data)
+}
+
+// ResponseDataWithCode formats a response carrying data with the specified HTTP status code, for responses that
+// have a body worth reading whatever their status, such as health reports.
+func ResponseDataWithCode(c *gin.Context, code int, data interface{}) {
 	resp := Response{
-		Code: http.StatusOK,
+		Code: code,
 		Data: data,
 	}
 
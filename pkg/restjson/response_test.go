@@ -120,3 +120,44 @@ func Test_ResponseData(t *testing.T) {
 		})
 	}
 }
+
+func Test_ResponseDataWithCode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		code         int
+		data         interface{}
+		expectedResp restjson.Response
+	}{
+		{
+			name:         "Success With OK",
+			code:         http.StatusOK,
+			data:         map[string]string{"status": "ok"},
+			expectedResp: restjson.Response{Code: http.StatusOK, Data: map[string]interface{}{"status": "ok"}},
+		},
+		{
+			name:         "Data With Service Unavailable",
+			code:         http.StatusServiceUnavailable,
+			data:         map[string]string{"status": "fail"},
+			expectedResp: restjson.Response{Code: http.StatusServiceUnavailable, Data: map[string]interface{}{"status": "fail"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			restjson.ResponseDataWithCode(c, tc.code, tc.data)
+
+			assert.Equal(t, tc.code, w.Code)
+			assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+			var resp restjson.Response
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResp, resp)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the RRULE FREQ part.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// RRule is the supported subset of an RFC 5545 recurrence rule:
+// FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY (weekly only), BYMONTHDAY (monthly only,
+// negative values count from the end of the month), BYHOUR, BYMINUTE, COUNT and UNTIL.
+// Months without the requested day are skipped, as the RFC prescribes.
+type RRule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Hour       int
+	Minute     int
+	Count      int
+	Until      time.Time
+
+	start time.Time
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// ParseRRule parses a rule such as "FREQ=MONTHLY;BYMONTHDAY=1;BYHOUR=9" anchored at start.
+func ParseRRule(expr string, start time.Time) (*RRule, error) {
+	start = start.UTC().Truncate(time.Minute)
+	r := &RRule{Interval: 1, Hour: start.Hour(), Minute: start.Minute(), start: start}
+
+	expr = strings.TrimSpace(expr)
+	if len(expr) >= 6 && strings.EqualFold(expr[:6], "RRULE:") {
+		expr = expr[6:]
+	}
+	for _, part := range strings.Split(expr, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not KEY=VALUE", ErrInvalidExpression, part)
+		}
+		value = strings.ToUpper(strings.TrimSpace(value))
+		var err error
+		switch strings.ToUpper(strings.TrimSpace(key)) {
+		case "FREQ":
+			r.Freq = Frequency(value)
+		case "INTERVAL":
+			r.Interval, err = positiveInt(value)
+		case "COUNT":
+			r.Count, err = positiveInt(value)
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "BYHOUR":
+			r.Hour, err = boundedInt(value, 0, 23)
+		case "BYMINUTE":
+			r.Minute, err = boundedInt(value, 0, 59)
+		case "BYDAY":
+			for _, d := range strings.Split(value, ",") {
+				wd, ok := weekdays[d]
+				if !ok {
+					return nil, fmt.Errorf("%w: unknown BYDAY %q", ErrInvalidExpression, d)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(value, ",") {
+				n, convErr := strconv.Atoi(d)
+				if convErr != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("%w: bad BYMONTHDAY %q", ErrInvalidExpression, d)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, n)
+			}
+		default:
+			return nil, fmt.Errorf("%w: unsupported RRULE part %q", ErrInvalidExpression, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidExpression, key, err)
+		}
+	}
+
+	switch r.Freq {
+	case Daily:
+		if len(r.ByDay) > 0 || len(r.ByMonthDay) > 0 {
+			return nil, fmt.Errorf("%w: BYDAY and BYMONTHDAY are not supported with FREQ=DAILY", ErrInvalidExpression)
+		}
+	case Weekly:
+		if len(r.ByMonthDay) > 0 {
+			return nil, fmt.Errorf("%w: BYMONTHDAY is not supported with FREQ=WEEKLY", ErrInvalidExpression)
+		}
+		if len(r.ByDay) == 0 {
+			r.ByDay = []time.Weekday{start.Weekday()}
+		}
+	case Monthly:
+		if len(r.ByDay) > 0 {
+			return nil, fmt.Errorf("%w: BYDAY is not supported with FREQ=MONTHLY", ErrInvalidExpression)
+		}
+		if len(r.ByMonthDay) == 0 {
+			r.ByMonthDay = []int{start.Day()}
+		}
+	default:
+		return nil, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY or MONTHLY", ErrInvalidExpression)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot both be set", ErrInvalidExpression)
+	}
+	return r, nil
+}
+
+// Next returns the first occurrence strictly after t.
+func (r *RRule) Next(t time.Time) time.Time {
+	t = t.UTC()
+	n := 0
+	for period := 0; period < 10000; period++ {
+		for _, occurrence := range r.occurrencesIn(period) {
+			if occurrence.Before(r.start) {
+				continue
+			}
+			n++
+			if r.Count > 0 && n > r.Count {
+				return time.Time{}
+			}
+			if !r.Until.IsZero() && occurrence.After(r.Until) {
+				return time.Time{}
+			}
+			if occurrence.After(t) {
+				return occurrence
+			}
+		}
+		// Without COUNT every occurrence need not be enumerated: jump close to t.
+		if r.Count == 0 && period == 0 {
+			if skip := r.periodsBefore(t) - 1; skip > 0 {
+				period = skip
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// occurrencesIn lists, in order, the candidate occurrences of the period-th interval after start.
+func (r *RRule) occurrencesIn(period int) []time.Time {
+	y, m, d := r.start.Date()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, r.Hour, r.Minute, 0, 0, time.UTC)
+	}
+
+	switch r.Freq {
+	case Daily:
+		return []time.Time{at(y, m, d+period*r.Interval)}
+	case Weekly:
+		// Weeks start on Monday, as RFC 5545's default WKST.
+		offset := (int(r.start.Weekday()) + 6) % 7
+		monday := at(y, m, d-offset+7*period*r.Interval)
+		var out []time.Time
+		for _, wd := range r.ByDay {
+			out = append(out, monday.AddDate(0, 0, (int(wd)+6)%7))
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+		return out
+	case Monthly:
+		first := time.Date(y, m+time.Month(period*r.Interval), 1, 0, 0, 0, 0, time.UTC)
+		last := first.AddDate(0, 1, -1).Day()
+		var out []time.Time
+		for _, md := range r.ByMonthDay {
+			day := md
+			if md < 0 {
+				day = last + md + 1
+			}
+			if day < 1 || day > last {
+				continue
+			}
+			out = append(out, at(first.Year(), first.Month(), day))
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+		return out
+	}
+	return nil
+}
+
+// periodsBefore estimates how many whole intervals separate start from t.
+func (r *RRule) periodsBefore(t time.Time) int {
+	if !t.After(r.start) {
+		return 0
+	}
+	switch r.Freq {
+	case Daily:
+		return int(t.Sub(r.start).Hours()/24) / r.Interval
+	case Weekly:
+		return int(t.Sub(r.start).Hours()/(24*7)) / r.Interval
+	case Monthly:
+		months := (t.Year()-r.start.Year())*12 + int(t.Month()) - int(r.start.Month())
+		return months / r.Interval
+	}
+	return 0
+}
+
+func positiveInt(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%q is not a positive integer", s)
+	}
+	return n, nil
+}
+
+func boundedInt(s string, min, max int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%q is not between %d and %d", s, min, max)
+	}
+	return n, nil
+}
+
+func parseUntil(s string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			if layout == "20060102" {
+				t = t.Add(24*time.Hour - time.Nanosecond)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not YYYYMMDD or YYYYMMDDTHHMMSSZ", s)
+}
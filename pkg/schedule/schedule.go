@@ -0,0 +1,48 @@
+// Package schedule computes the run times of recurring jobs described either by a
+// five-field cron expression or by a subset of the iCalendar RRULE syntax.
+//
+// All calculations are done in UTC.
+package schedule
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalidExpression indicates that a schedule expression could not be parsed.
+var ErrInvalidExpression = errors.New("invalid schedule expression")
+
+// Schedule yields the occurrences of a recurring job.
+type Schedule interface {
+	// Next returns the first occurrence strictly after t, or the zero time if there is none.
+	Next(t time.Time) time.Time
+}
+
+// Parse parses expr as an RRULE when it starts with "RRULE:" or contains "FREQ=", and as a
+// cron expression otherwise. start anchors RRULE intervals and provides the default time of
+// day, weekday and day of month; no occurrence is ever returned before start.
+func Parse(expr string, start time.Time) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(strings.ToUpper(expr), "RRULE:") || strings.Contains(strings.ToUpper(expr), "FREQ=") {
+		return ParseRRule(expr, start)
+	}
+	c, err := ParseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	return notBefore{Schedule: c, start: start.UTC()}, nil
+}
+
+// notBefore suppresses occurrences earlier than start.
+type notBefore struct {
+	Schedule
+	start time.Time
+}
+
+func (n notBefore) Next(t time.Time) time.Time {
+	if t.Before(n.start) {
+		t = n.start.Add(-time.Nanosecond)
+	}
+	return n.Schedule.Next(t)
+}
@@ -0,0 +1,146 @@
+package schedule_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kylenguyen/wallet-app/pkg/schedule"
+)
+
+func utc(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func Test_Next(t *testing.T) {
+	start := utc("2025-01-15T09:30:00Z")
+
+	testCases := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "cron - first of the month at 09:00",
+			expr:  "0 9 1 * *",
+			after: start,
+			want:  []time.Time{utc("2025-02-01T09:00:00Z"), utc("2025-03-01T09:00:00Z"), utc("2025-04-01T09:00:00Z")},
+		},
+		{
+			name:  "cron - every 15 minutes on weekdays",
+			expr:  "*/15 * * * 1-5",
+			after: utc("2025-01-17T23:50:00Z"), // Friday
+			want:  []time.Time{utc("2025-01-20T00:00:00Z"), utc("2025-01-20T00:15:00Z")},
+		},
+		{
+			name:  "cron - day of month or day of week",
+			expr:  "0 0 13 * 5",
+			after: utc("2025-06-01T00:00:00Z"),
+			want:  []time.Time{utc("2025-06-06T00:00:00Z"), utc("2025-06-13T00:00:00Z"), utc("2025-06-20T00:00:00Z")},
+		},
+		{
+			name:  "cron - descriptor",
+			expr:  "@monthly",
+			after: utc("2025-12-31T23:59:00Z"),
+			want:  []time.Time{utc("2026-01-01T00:00:00Z")},
+		},
+		{
+			name:  "cron - never before start",
+			expr:  "@daily",
+			after: utc("2024-01-01T00:00:00Z"),
+			want:  []time.Time{utc("2025-01-16T00:00:00Z")},
+		},
+		{
+			name:  "rrule - monthly on the 1st",
+			expr:  "FREQ=MONTHLY;BYMONTHDAY=1;BYHOUR=8;BYMINUTE=0",
+			after: start,
+			want:  []time.Time{utc("2025-02-01T08:00:00Z"), utc("2025-03-01T08:00:00Z")},
+		},
+		{
+			name:  "rrule - last day of every other month",
+			expr:  "RRULE:FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=-1",
+			after: start,
+			want:  []time.Time{utc("2025-01-31T09:30:00Z"), utc("2025-03-31T09:30:00Z"), utc("2025-05-31T09:30:00Z")},
+		},
+		{
+			name:  "rrule - months without the day are skipped",
+			expr:  "FREQ=MONTHLY;BYMONTHDAY=30",
+			after: utc("2025-01-30T10:00:00Z"),
+			want:  []time.Time{utc("2025-03-30T09:30:00Z")},
+		},
+		{
+			name:  "rrule - weekly defaults to the start weekday",
+			expr:  "FREQ=WEEKLY;INTERVAL=2",
+			after: start,
+			want:  []time.Time{utc("2025-01-29T09:30:00Z"), utc("2025-02-12T09:30:00Z")},
+		},
+		{
+			name:  "rrule - weekly on several days",
+			expr:  "FREQ=WEEKLY;BYDAY=MO,FR",
+			after: start,
+			want:  []time.Time{utc("2025-01-17T09:30:00Z"), utc("2025-01-20T09:30:00Z"), utc("2025-01-24T09:30:00Z")},
+		},
+		{
+			name:  "rrule - daily far in the future",
+			expr:  "FREQ=DAILY;INTERVAL=3",
+			after: utc("2035-01-01T00:00:00Z"),
+			want:  []time.Time{utc("2035-01-02T09:30:00Z"), utc("2035-01-05T09:30:00Z")},
+		},
+		{
+			name:  "rrule - count exhausted",
+			expr:  "FREQ=DAILY;COUNT=2",
+			after: start,
+			want:  []time.Time{utc("2025-01-16T09:30:00Z"), {}},
+		},
+		{
+			name:  "rrule - until reached",
+			expr:  "FREQ=DAILY;UNTIL=20250116",
+			after: start,
+			want:  []time.Time{utc("2025-01-16T09:30:00Z"), {}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := schedule.Parse(tc.expr, start)
+			require.NoError(t, err)
+
+			after := tc.after
+			for _, want := range tc.want {
+				got := s.Next(after)
+				assert.Equal(t, want, got)
+				after = got
+			}
+		})
+	}
+}
+
+func Test_Parse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* * 0 * *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=DAILY;COUNT=1;UNTIL=20250101",
+		"FREQ=DAILY;WKST=MO",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := schedule.Parse(expr, utc("2025-01-01T00:00:00Z"))
+			assert.True(t, errors.Is(err, schedule.ErrInvalidExpression), "got %v", err)
+		})
+	}
+}